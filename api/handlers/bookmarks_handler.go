@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	openapi "x-clone-backend/gen"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type BookmarksHandler struct {
+	bookmarksUsecase usecases.BookmarksUsecase
+}
+
+func NewBookmarksHandler(db *sql.DB) BookmarksHandler {
+	bookmarksRepository := infrastructure.NewBookmarksRepository(db)
+	postsRepository := infrastructure.NewPostsRepository(db)
+	usersRepository := infrastructure.NewUsersRepository(db)
+	bookmarksUsecase := usecases.NewBookmarksUsecase(bookmarksRepository, postsRepository, usersRepository)
+	return BookmarksHandler{
+		bookmarksUsecase,
+	}
+}
+
+// CreateBookmark bookmarks the post as the authenticated user, into the folder if specified.
+// It returns 201 for a new bookmark and 200 if the post was already bookmarked,
+// in which case the bookmark is moved into the folder.
+func (h *BookmarksHandler) CreateBookmark(w http.ResponseWriter, r *http.Request) {
+	slog.Info("POST /api/bookmarks was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var body createBookmarkRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+	if body.PostID == uuid.Nil {
+		http.Error(w, fmt.Sprintln("post_id is required."), http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.bookmarksUsecase.AddBookmark(userID, body.PostID, body.FolderID)
+	if err != nil {
+		writeBookmarkError(w, err, "Could not create a bookmark.")
+		return
+	}
+
+	writeCreatedStatus(w, created)
+}
+
+// DeleteBookmark removes the authenticated user's bookmark of the post.
+func (h *BookmarksHandler) DeleteBookmark(w http.ResponseWriter, r *http.Request, postIDStr string) {
+	slog.Info("DELETE /api/bookmarks/{postID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a postID (ID: %s)\n", postIDStr), http.StatusBadRequest)
+		return
+	}
+
+	err = h.bookmarksUsecase.RemoveBookmark(userID, postID)
+	if err != nil {
+		writeBookmarkError(w, err, "Could not delete a bookmark.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBookmarks gets the posts bookmarked by the authenticated user in reverse chronological
+// order of the bookmarks, only the ones in the folder if folder_id is specified.
+func (h *BookmarksHandler) GetBookmarks(w http.ResponseWriter, r *http.Request, params openapi.GetBookmarksParams) {
+	slog.Info("GET /api/bookmarks was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var folderID *uuid.UUID
+	if params.FolderId != nil {
+		id, err := uuid.Parse(*params.FolderId)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not parse a folderID (ID: %s)\n", *params.FolderId), http.StatusBadRequest)
+			return
+		}
+		folderID = &id
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.bookmarksUsecase.GetBookmarks(userID, folderID, cursor, limit)
+	if err != nil {
+		writeBookmarkError(w, err, "Could not get bookmarks.")
+		return
+	}
+
+	writeBookmarkResponse(w, http.StatusOK, postPageResponseBody{
+		Posts:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	})
+}
+
+// GetBookmarkFolders gets the bookmark folders of the authenticated user.
+func (h *BookmarksHandler) GetBookmarkFolders(w http.ResponseWriter, r *http.Request) {
+	slog.Info("GET /api/bookmarks/folders was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	folders, err := h.bookmarksUsecase.GetFolders(userID)
+	if err != nil {
+		writeBookmarkError(w, err, "Could not get bookmark folders.")
+		return
+	}
+
+	writeBookmarkResponse(w, http.StatusOK, bookmarkFoldersResponseBody{Folders: folders})
+}
+
+// CreateBookmarkFolder creates a bookmark folder of the authenticated user.
+func (h *BookmarksHandler) CreateBookmarkFolder(w http.ResponseWriter, r *http.Request) {
+	slog.Info("POST /api/bookmarks/folders was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var body bookmarkFolderRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+
+	folder, err := h.bookmarksUsecase.CreateFolder(userID, body.Name)
+	if err != nil {
+		writeBookmarkError(w, err, "Could not create a bookmark folder.")
+		return
+	}
+
+	writeBookmarkResponse(w, http.StatusCreated, folder)
+}
+
+// UpdateBookmarkFolder renames a bookmark folder of the authenticated user.
+func (h *BookmarksHandler) UpdateBookmarkFolder(w http.ResponseWriter, r *http.Request, folderIDStr string) {
+	slog.Info("PATCH /api/bookmarks/folders/{folderID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	folderID, err := uuid.Parse(folderIDStr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a folderID (ID: %s)\n", folderIDStr), http.StatusBadRequest)
+		return
+	}
+
+	var body bookmarkFolderRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err = decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+
+	folder, err := h.bookmarksUsecase.RenameFolder(userID, folderID, body.Name)
+	if err != nil {
+		writeBookmarkError(w, err, "Could not update the bookmark folder.")
+		return
+	}
+
+	writeBookmarkResponse(w, http.StatusOK, folder)
+}
+
+// DeleteBookmarkFolder deletes a bookmark folder of the authenticated user
+// along with the bookmarks in it.
+func (h *BookmarksHandler) DeleteBookmarkFolder(w http.ResponseWriter, r *http.Request, folderIDStr string) {
+	slog.Info("DELETE /api/bookmarks/folders/{folderID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	folderID, err := uuid.Parse(folderIDStr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a folderID (ID: %s)\n", folderIDStr), http.StatusBadRequest)
+		return
+	}
+
+	err = h.bookmarksUsecase.DeleteFolder(userID, folderID)
+	if err != nil {
+		writeBookmarkError(w, err, "Could not delete the bookmark folder.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeBookmarkError writes the status code corresponding to an error
+// returned by the bookmarks usecase.
+func writeBookmarkError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrPostNotFound),
+		errors.Is(err, domainerrors.ErrBookmarkNotFound),
+		errors.Is(err, domainerrors.ErrBookmarkFolderNotFound):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrBlocked), errors.Is(err, domainerrors.ErrPrivateAccount):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusForbidden)
+	case errors.Is(err, domainerrors.ErrInvalidBookmarkFolder):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusBadRequest)
+	case errors.Is(err, domainerrors.ErrBookmarkFolderExists):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusConflict)
+	default:
+		http.Error(w, fmt.Sprintln(message), http.StatusInternalServerError)
+	}
+}
+
+func writeBookmarkResponse(w http.ResponseWriter, code int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	encoder := json.NewEncoder(w)
+	err := encoder.Encode(response)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
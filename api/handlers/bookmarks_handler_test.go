@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func (s *HandlersTestSuite) TestBookmarks() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	readerID := s.newTestUser(`{ "username": "reader", "display_name": "reader", "password": "securepassword" }`)
+	blockerID := s.newTestUser(`{ "username": "blocker", "display_name": "blocker", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "first post" }`, authorID))
+	secondPostID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "second post" }`, authorID))
+	blockedPostID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "blocker's post" }`, blockerID))
+	s.newTestBlock(blockerID, readerID)
+
+	bookmarksHandler := NewBookmarksHandler(s.db)
+	folder := s.newTestBookmarkFolder(readerID, "  reading  ")
+	if folder.Name != "reading" {
+		s.T().Errorf("expected the folder name to be trimmed, but got %q", folder.Name)
+	}
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "anonymous viewer",
+			body:         fmt.Sprintf(`{ "post_id": "%s" }`, postID),
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "missing post id",
+			viewerID:     readerID,
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "non-existent post",
+			viewerID:     readerID,
+			body:         fmt.Sprintf(`{ "post_id": "%s" }`, uuid.New()),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "post of a user blocking the viewer",
+			viewerID:     readerID,
+			body:         fmt.Sprintf(`{ "post_id": "%s" }`, blockedPostID),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "another user's folder",
+			viewerID:     authorID,
+			body:         fmt.Sprintf(`{ "post_id": "%s", "folder_id": "%s" }`, postID, folder.ID),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "new bookmark",
+			viewerID:     readerID,
+			body:         fmt.Sprintf(`{ "post_id": "%s" }`, postID),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "new bookmark in a folder",
+			viewerID:     readerID,
+			body:         fmt.Sprintf(`{ "post_id": "%s", "folder_id": "%s" }`, secondPostID, folder.ID),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "move an existing bookmark into a folder",
+			viewerID:     readerID,
+			body:         fmt.Sprintf(`{ "post_id": "%s", "folder_id": "%s" }`, postID, folder.ID),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "own post",
+			viewerID:     authorID,
+			body:         fmt.Sprintf(`{ "post_id": "%s" }`, postID),
+			expectedCode: http.StatusCreated,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("POST", "/api/bookmarks", strings.NewReader(test.body))
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		bookmarksHandler.CreateBookmark(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	// Moving a bookmark into a folder keeps its position.
+	folderID := folder.ID.String()
+	if posts := s.getTestBookmarks(readerID, nil); len(posts) != 2 || posts[0].ID.String() != secondPostID || posts[1].ID.String() != postID {
+		s.T().Errorf("wrong bookmarks returned; got %+v", posts)
+	}
+	if posts := s.getTestBookmarks(readerID, &folderID); len(posts) != 2 {
+		s.T().Errorf("expected 2 bookmarks in the folder, but got %d", len(posts))
+	}
+	if posts := s.getTestBookmarks(authorID, nil); len(posts) != 1 || !posts[0].BookmarkedByMe {
+		s.T().Errorf("wrong bookmarks returned to the author; got %+v", posts)
+	}
+
+	// The bookmark count is only returned to the author.
+	post := s.getTestPost(authorID, postID)
+	if !post.BookmarkedByMe || post.BookmarkCount == nil || *post.BookmarkCount != 2 {
+		s.T().Errorf("wrong bookmarks of the post returned to the author; got %t and %v", post.BookmarkedByMe, post.BookmarkCount)
+	}
+	post = s.getTestPost(readerID, postID)
+	if !post.BookmarkedByMe || post.BookmarkCount != nil {
+		s.T().Errorf("wrong bookmarks of the post returned to the reader; got %t and %v", post.BookmarkedByMe, post.BookmarkCount)
+	}
+
+	req := withViewer(httptest.NewRequest("DELETE", fmt.Sprintf("/api/bookmarks/%s", postID), nil), readerID)
+	rr := httptest.NewRecorder()
+	bookmarksHandler.DeleteBookmark(rr, req, postID)
+	if rr.Code != http.StatusNoContent {
+		s.T().Errorf("delete a bookmark: expected %d, but got %d", http.StatusNoContent, rr.Code)
+	}
+	rr = httptest.NewRecorder()
+	bookmarksHandler.DeleteBookmark(rr, req, postID)
+	if rr.Code != http.StatusNotFound {
+		s.T().Errorf("delete a deleted bookmark: expected %d, but got %d", http.StatusNotFound, rr.Code)
+	}
+
+	// Deleting a post deletes its bookmarks.
+	s.newTestDeletePost(authorID, secondPostID)
+	if posts := s.getTestBookmarks(readerID, nil); len(posts) != 0 {
+		s.T().Errorf("expected no bookmarks, but got %d", len(posts))
+	}
+}
+
+func (s *HandlersTestSuite) TestBookmarkFolders() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	readerID := s.newTestUser(`{ "username": "reader", "display_name": "reader", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, authorID))
+
+	bookmarksHandler := NewBookmarksHandler(s.db)
+	folder := s.newTestBookmarkFolder(readerID, "reading")
+	_ = s.newTestBookmarkFolder(authorID, "reading")
+
+	createTests := []struct {
+		name         string
+		viewerID     string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "anonymous viewer",
+			body:         `{ "name": "later" }`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "blank name",
+			viewerID:     readerID,
+			body:         `{ "name": "  " }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "too long name",
+			viewerID:     readerID,
+			body:         fmt.Sprintf(`{ "name": "%s" }`, strings.Repeat("a", 26)),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "duplicate name",
+			viewerID:     readerID,
+			body:         `{ "name": "reading" }`,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "valid name",
+			viewerID:     readerID,
+			body:         `{ "name": "later" }`,
+			expectedCode: http.StatusCreated,
+		},
+	}
+
+	for _, test := range createTests {
+		req := httptest.NewRequest("POST", "/api/bookmarks/folders", strings.NewReader(test.body))
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		bookmarksHandler.CreateBookmarkFolder(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	folders := s.getTestBookmarkFolders(readerID)
+	if len(folders) != 2 || folders[0].Name != "reading" || folders[1].Name != "later" {
+		s.T().Errorf("wrong folders returned; got %+v", folders)
+	}
+
+	updateTests := []struct {
+		name         string
+		viewerID     string
+		folderID     string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "another user's folder",
+			viewerID:     authorID,
+			folderID:     folder.ID.String(),
+			body:         `{ "name": "mine" }`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid folder id",
+			viewerID:     readerID,
+			folderID:     "invalid",
+			body:         `{ "name": "mine" }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "name of another folder",
+			viewerID:     readerID,
+			folderID:     folder.ID.String(),
+			body:         `{ "name": "later" }`,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "valid name",
+			viewerID:     readerID,
+			folderID:     folder.ID.String(),
+			body:         `{ "name": "read soon" }`,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range updateTests {
+		req := withViewer(httptest.NewRequest("PATCH", fmt.Sprintf("/api/bookmarks/folders/%s", test.folderID), strings.NewReader(test.body)), test.viewerID)
+		rr := httptest.NewRecorder()
+
+		bookmarksHandler.UpdateBookmarkFolder(rr, req, test.folderID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+	if folders := s.getTestBookmarkFolders(readerID); folders[0].Name != "read soon" {
+		s.T().Errorf("expected the folder to be renamed, but got %q", folders[0].Name)
+	}
+
+	req := withViewer(httptest.NewRequest("POST", "/api/bookmarks", strings.NewReader(fmt.Sprintf(
+		`{ "post_id": "%s", "folder_id": "%s" }`, postID, folder.ID,
+	))), readerID)
+	rr := httptest.NewRecorder()
+	bookmarksHandler.CreateBookmark(rr, req)
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("failed to create a bookmark; got %d", rr.Code)
+	}
+
+	// Deleting a folder deletes the bookmarks in it.
+	req = withViewer(httptest.NewRequest("DELETE", fmt.Sprintf("/api/bookmarks/folders/%s", folder.ID), nil), authorID)
+	rr = httptest.NewRecorder()
+	bookmarksHandler.DeleteBookmarkFolder(rr, req, folder.ID.String())
+	if rr.Code != http.StatusNotFound {
+		s.T().Errorf("delete another user's folder: expected %d, but got %d", http.StatusNotFound, rr.Code)
+	}
+
+	req = withViewer(httptest.NewRequest("DELETE", fmt.Sprintf("/api/bookmarks/folders/%s", folder.ID), nil), readerID)
+	rr = httptest.NewRecorder()
+	bookmarksHandler.DeleteBookmarkFolder(rr, req, folder.ID.String())
+	if rr.Code != http.StatusNoContent {
+		s.T().Errorf("delete a folder: expected %d, but got %d", http.StatusNoContent, rr.Code)
+	}
+	if posts := s.getTestBookmarks(readerID, nil); len(posts) != 0 {
+		s.T().Errorf("expected the bookmarks in the folder to be deleted, but got %d", len(posts))
+	}
+
+	folderID := folder.ID.String()
+	req = withViewer(httptest.NewRequest("GET", "/api/bookmarks", nil), readerID)
+	rr = httptest.NewRecorder()
+	bookmarksHandler.GetBookmarks(rr, req, openapi.GetBookmarksParams{FolderId: &folderID})
+	if rr.Code != http.StatusNotFound {
+		s.T().Errorf("get bookmarks in a deleted folder: expected %d, but got %d", http.StatusNotFound, rr.Code)
+	}
+}
+
+func (s *HandlersTestSuite) newTestBookmarkFolder(userID, name string) entities.BookmarkFolder {
+	req := withViewer(httptest.NewRequest("POST", "/api/bookmarks/folders", strings.NewReader(fmt.Sprintf(`{ "name": "%s" }`, name))), userID)
+	rr := httptest.NewRecorder()
+
+	bookmarksHandler := NewBookmarksHandler(s.db)
+	bookmarksHandler.CreateBookmarkFolder(rr, req)
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("Failed to create a bookmark folder: %d", rr.Code)
+	}
+
+	var folder entities.BookmarkFolder
+	if err := json.NewDecoder(rr.Body).Decode(&folder); err != nil {
+		s.T().Fatalf("Failed to decode a bookmark folder: %v", err)
+	}
+	return folder
+}
+
+func (s *HandlersTestSuite) getTestBookmarkFolders(userID string) []*entities.BookmarkFolder {
+	req := withViewer(httptest.NewRequest("GET", "/api/bookmarks/folders", nil), userID)
+	rr := httptest.NewRecorder()
+
+	bookmarksHandler := NewBookmarksHandler(s.db)
+	bookmarksHandler.GetBookmarkFolders(rr, req)
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get bookmark folders: %d", rr.Code)
+	}
+
+	var res bookmarkFoldersResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		s.T().Fatalf("Failed to decode bookmark folders: %v", err)
+	}
+	return res.Folders
+}
+
+func (s *HandlersTestSuite) getTestBookmarks(userID string, folderID *string) []*entities.Post {
+	req := withViewer(httptest.NewRequest("GET", "/api/bookmarks", nil), userID)
+	rr := httptest.NewRecorder()
+
+	bookmarksHandler := NewBookmarksHandler(s.db)
+	bookmarksHandler.GetBookmarks(rr, req, openapi.GetBookmarksParams{FolderId: folderID})
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get bookmarks: %d", rr.Code)
+	}
+
+	var res postPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		s.T().Fatalf("Failed to decode bookmarks: %v", err)
+	}
+	return res.Posts
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"x-clone-backend/api/middlewares"
+	"x-clone-backend/internal/app/services"
+
+	"github.com/google/uuid"
+)
+
+// viewerIDFromContext returns the ID of the user who sends the request,
+// which is stored in the context by the JWT middlewares.
+// It returns an empty string for anonymous requests.
+func viewerIDFromContext(r *http.Request) string {
+	claims, ok := r.Context().Value(middlewares.UserContextKey).(*services.UserClaims)
+	if !ok {
+		return ""
+	}
+
+	return claims.Subject
+}
+
+// authenticatedUserID writes an error and returns false unless the request is authenticated,
+// and returns the ID of the authenticated user otherwise.
+func authenticatedUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	viewerID := viewerIDFromContext(r)
+	if viewerID == "" {
+		http.Error(w, "Authentication required.", http.StatusUnauthorized)
+		return uuid.Nil, false
+	}
+	userID, err := uuid.Parse(viewerID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a userID (ID: %s)\n", viewerID), http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// authorizeOwner writes an error and returns false unless the request
+// is authenticated as the user with the specified ID.
+func authorizeOwner(w http.ResponseWriter, r *http.Request, id string) bool {
+	if _, err := uuid.Parse(id); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a userID (ID: %s)\n", id), http.StatusBadRequest)
+		return false
+	}
+
+	viewerID := viewerIDFromContext(r)
+	if viewerID == "" {
+		http.Error(w, "Authentication required.", http.StatusUnauthorized)
+		return false
+	}
+	if viewerID != id {
+		http.Error(w, "Not allowed to access the resources of another user.", http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
@@ -3,32 +3,63 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"sync"
 	"time"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/app/usecases"
 	"x-clone-backend/internal/domain/entities"
-
-	"github.com/google/uuid"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
 )
 
 type CreatePostHandler struct {
-	db        *sql.DB
-	mu        *sync.Mutex
-	usersChan *map[string]chan entities.TimelineEvent
+	db                          *sql.DB
+	createPostEntitiesUsecase   usecases.CreatePostEntitiesUsecase
+	attachMediaUsecase          usecases.AttachMediaUsecase
+	createPollUsecase           usecases.CreatePollUsecase
+	scheduledPostsUsecase       usecases.ScheduledPostsUsecase
+	publishTimelineEventUsecase usecases.PublishTimelineEventUsecase
+	textConfig                  text.Config
 }
 
-func NewCreatePostHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent) CreatePostHandler {
+func NewCreatePostHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}, notificationChans *map[string]chan *entities.Notification, textConfig text.Config) CreatePostHandler {
+	notificationsRepository := infrastructure.NewNotificationsRepository(db)
+	postsRepository := infrastructure.NewPostsRepository(db)
+	usersRepository := infrastructure.NewUsersRepository(db)
+	notifyUsecase := usecases.NewNotifyUsecase(notificationsRepository, postsRepository, mu, notificationChans)
+	createPostEntitiesUsecase := usecases.NewCreatePostEntitiesUsecase(postsRepository, usersRepository, notifyUsecase)
+	attachMediaUsecase := usecases.NewAttachMediaUsecase(infrastructure.NewMediaRepository(db))
+	createPollUsecase := usecases.NewCreatePollUsecase(infrastructure.NewPollsRepository(db))
 	return CreatePostHandler{
-		db:        db,
-		mu:        mu,
-		usersChan: usersChan,
+		db:                          db,
+		createPostEntitiesUsecase:   createPostEntitiesUsecase,
+		attachMediaUsecase:          attachMediaUsecase,
+		createPollUsecase:           createPollUsecase,
+		scheduledPostsUsecase:       newScheduledPostsUsecase(db, mu, usersChan, listChans, notificationChans),
+		publishTimelineEventUsecase: newPublishTimelineEventUsecase(db, mu, usersChan, listChans),
+		textConfig:                  textConfig,
 	}
 }
 
+// newPublishTimelineEventUsecase builds the usecase pushing the posts
+// to the streams of the timelines they appear on.
+func newPublishTimelineEventUsecase(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}) usecases.PublishTimelineEventUsecase {
+	return usecases.NewPublishTimelineEventUsecase(infrastructure.NewUsersRepository(db), infrastructure.NewListsRepository(db), mu, usersChan, listChans)
+}
+
 // CreatePost creates a new post with the specified user_id and text,
 // then, inserts it into posts table.
+// The users mentioned in the text are recorded and notified, and the hashtags are recorded.
+// Up to four media uploaded by the user can be attached by media_ids,
+// or a poll by poll instead.
+// If scheduled_at is specified, the post is queued to be published at the time instead,
+// and 202 is returned with the scheduled post.
+// The text is normalized into NFC and must not be longer than the configured weighted
+// length, and can be empty only if media are attached.
 //
 // TODO: https://github.com/okuda-seminar/X-Clone-Backend/issues/174
 // - [Posts] Separate the logic of CreatePost into usecase and repository layers.
@@ -42,64 +73,167 @@ func (h *CreatePostHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	query := `INSERT INTO posts (user_id, text) VALUES ($1, $2) RETURNING id, created_at`
-
-	var (
-		id        uuid.UUID
-		createdAt time.Time
-	)
+	h.createPost(w, body, nil)
+}
 
-	err = h.db.QueryRow(query, body.UserID, body.Text).Scan(&id, &createdAt)
+// createPost validates the post in the request body, creates or schedules it,
+// and writes the response. If beforeCommit is not nil, it is called in the transaction
+// creating the post, which is rolled back if it returns an error; it is not called
+// for scheduled posts.
+func (h *CreatePostHandler) createPost(w http.ResponseWriter, body createPostRequestBody, beforeCommit func(tx *sql.Tx) error) {
+	var err error
+	body.Text, err = validatePostText(h.textConfig, body.Text, len(body.MediaIDs) > 0)
 	if err != nil {
-		http.Error(w, fmt.Sprintln("Could not create a post."), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Text was invalid: %v\n", err), http.StatusBadRequest)
 		return
 	}
 
-	post := entities.Post{
-		ID:        id,
-		UserID:    body.UserID,
-		Text:      body.Text,
-		CreatedAt: createdAt,
+	media, err := h.attachMediaUsecase.GetAttachableMedia(body.UserID, body.MediaIDs)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidMediaAttachment) {
+			http.Error(w, fmt.Sprintf("Could not attach the media: %v\n", err), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, fmt.Sprintln("Could not create a post."), http.StatusInternalServerError)
+		return
 	}
 
-	go func(userID uuid.UUID, userChan *map[string]chan entities.TimelineEvent) {
-		var posts []*entities.Post
-		posts = append(posts, &post)
-		query = `SELECT source_user_id FROM followships WHERE target_user_id=$1`
-		rows, err := h.db.Query(query, userID.String())
+	var poll *entities.Poll
+	if body.Poll != nil {
+		if len(body.MediaIDs) > 0 {
+			http.Error(w, fmt.Sprintln("Could not create the poll: a poll cannot be attached with media"), http.StatusBadRequest)
+			return
+		}
+		duration := time.Duration(body.Poll.DurationMinutes) * time.Minute
+		poll, err = h.createPollUsecase.NewPoll(body.Poll.Choices, duration, time.Now())
 		if err != nil {
-			log.Fatalln(err)
+			http.Error(w, fmt.Sprintf("Could not create the poll: %v\n", err), http.StatusBadRequest)
 			return
 		}
+	}
+
+	if body.ScheduledAt != nil {
+		h.schedulePost(w, body, poll)
+		return
+	}
 
-		var ids []uuid.UUID
-		for rows.Next() {
-			var id uuid.UUID
-			if err := rows.Scan(&id); err != nil {
-				log.Fatalln(err)
-				return
-			}
+	post, err := h.insertPost(body, media, poll, beforeCommit)
+	if errors.Is(err, domainerrors.ErrDraftNotFound) || errors.Is(err, domainerrors.ErrDraftChanged) {
+		writeDraftError(w, err, "Could not publish the draft.")
+		return
+	} else if err != nil {
+		slog.Error("Could not create a post.", "user_id", body.UserID, "error", err)
+		http.Error(w, fmt.Sprintln("Could not create a post."), http.StatusInternalServerError)
+		return
+	}
 
-			ids = append(ids, id)
-		}
-		ids = append(ids, userID)
-		for _, id := range ids {
-			h.mu.Lock()
-			if userChan, ok := (*h.usersChan)[id.String()]; ok {
-				userChan <- entities.TimelineEvent{EventType: entities.PostCreated, Posts: posts}
-			}
-			h.mu.Unlock()
-		}
+	h.createPostEntitiesUsecase.NotifyMentions(post.UserID, post.ID, post.Entities.Mentions)
 
-	}(body.UserID, h.usersChan)
+	go h.publishTimelineEventUsecase.PublishToTimelines(post.UserID, entities.TimelineEvent{EventType: entities.PostCreated, Posts: []*entities.Post{post}})
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 
 	encoder := json.NewEncoder(w)
-	err = encoder.Encode(&post)
+	err = encoder.Encode(post)
 	if err != nil {
 		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+	}
+}
+
+// insertPost creates the validated post with its entities, media and poll
+// in a single transaction, so that a post is never created with some of them missing.
+func (h *CreatePostHandler) insertPost(body createPostRequestBody, media []*entities.Media, poll *entities.Poll, beforeCommit func(tx *sql.Tx) error) (*entities.Post, error) {
+	tx, err := h.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	post := &entities.Post{
+		UserID: body.UserID,
+		Text:   body.Text,
+	}
+	query := `INSERT INTO posts (user_id, text) VALUES ($1, $2) RETURNING id, created_at`
+	if err := tx.QueryRow(query, body.UserID, body.Text).Scan(&post.ID, &post.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	post.Entities, err = h.createPostEntitiesUsecase.CreatePostEntities(tx, post.UserID, post.ID, false, post.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.attachMediaUsecase.AttachMedia(tx, post.ID, false, media); err != nil {
+		return nil, err
+	}
+	if len(media) > 0 {
+		post.Media = media
+	}
+
+	if poll != nil {
+		if err := h.createPollUsecase.CreatePoll(tx, post.ID, poll); err != nil {
+			return nil, err
+		}
+		post.Poll = poll
+	}
+
+	if beforeCommit != nil {
+		if err := beforeCommit(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// schedulePost queues the validated post to be published at body.ScheduledAt.
+// The poll is saved with its normalized choices, and opened when the post is published.
+func (h *CreatePostHandler) schedulePost(w http.ResponseWriter, body createPostRequestBody, poll *entities.Poll) {
+	post := &entities.ScheduledPost{
+		UserID:      body.UserID,
+		Text:        body.Text,
+		MediaIDs:    body.MediaIDs,
+		ScheduledAt: *body.ScheduledAt,
+	}
+	if poll != nil {
+		post.Poll = &entities.PendingPoll{DurationMinutes: body.Poll.DurationMinutes}
+		for _, choice := range poll.Choices {
+			post.Poll.Choices = append(post.Poll.Choices, choice.Label)
+		}
+	}
+
+	err := h.scheduledPostsUsecase.SchedulePost(post, time.Now())
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidScheduledTime) {
+			http.Error(w, fmt.Sprintf("Scheduled time was invalid: %v\n", err), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, fmt.Sprintln("Could not schedule a post."), http.StatusInternalServerError)
 		return
 	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(post)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+	}
+}
+
+// validatePostText validates the text of a post or a quote repost and returns it
+// normalized. An empty text is allowed if media are attached, as in a photo-only post.
+func validatePostText(config text.Config, s string, hasMedia bool) (string, error) {
+	result, err := config.Validate(s)
+	if errors.Is(err, domainerrors.ErrEmptyPostText) && hasMedia {
+		return result.Text, nil
+	}
+
+	return result.Text, err
 }
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/domain/entities"
+)
+
+func (s *HandlersTestSuite) TestCreatePostTextValidation() {
+	userID := s.newTestUser(`{ "username": "writer", "display_name": "writer", "password": "securepassword" }`)
+	mediaID := s.newTestMedia(userID)
+
+	tests := []struct {
+		name         string
+		text         string
+		mediaIDs     string
+		expectedCode int
+		expectedText string
+	}{
+		{
+			name:         "280 latin characters",
+			text:         strings.Repeat("a", 280),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "281 latin characters",
+			text:         strings.Repeat("a", 281),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "140 japanese characters",
+			text:         strings.Repeat("あ", 140),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "141 japanese characters",
+			text:         strings.Repeat("あ", 141),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "140 emojis with skin tones",
+			text:         strings.Repeat("👍🏽", 140),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "long url counted as 23 characters",
+			text:         strings.Repeat("a", 256) + " https://example.com/" + strings.Repeat("b", 300),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "decomposed text normalized into nfc",
+			text:         "cafe\u0301",
+			expectedCode: http.StatusCreated,
+			expectedText: "caf\u00e9",
+		},
+		{
+			name:         "empty text",
+			text:         "",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "whitespace-only text",
+			text:         " \n\u3000",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "empty text with media",
+			text:         "",
+			mediaIDs:     fmt.Sprintf(`"%s"`, mediaID),
+			expectedCode: http.StatusCreated,
+		},
+	}
+
+	createPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	for _, test := range tests {
+		body, _ := json.Marshal(test.text)
+		req := httptest.NewRequest(
+			"POST",
+			"/api/posts",
+			strings.NewReader(fmt.Sprintf(`{ "user_id": "%s", "text": %s, "media_ids": [%s] }`, userID, body, test.mediaIDs)),
+		)
+		rr := httptest.NewRecorder()
+		createPostHandler.CreatePost(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusCreated {
+			continue
+		}
+
+		var post entities.Post
+		if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+		expectedText := test.expectedText
+		if expectedText == "" {
+			expectedText = test.text
+		}
+		if post.Text != expectedText {
+			s.T().Errorf("%s: wrong text stored; expected %q, but got %q", test.name, expectedText, post.Text)
+		}
+	}
+
+	// The error tells the computed weighted length.
+	req := httptest.NewRequest(
+		"POST",
+		"/api/posts",
+		strings.NewReader(fmt.Sprintf(`{ "user_id": "%s", "text": "%s" }`, userID, strings.Repeat("あ", 141))),
+	)
+	rr := httptest.NewRecorder()
+	createPostHandler.CreatePost(rr, req)
+	if !strings.Contains(rr.Body.String(), "282") {
+		s.T().Errorf("expected the error to tell the weighted length 282, but got %q", rr.Body.String())
+	}
+
+	// The limit is configurable.
+	req = httptest.NewRequest(
+		"POST",
+		"/api/posts",
+		strings.NewReader(fmt.Sprintf(`{ "user_id": "%s", "text": "too long" }`, userID)),
+	)
+	rr = httptest.NewRecorder()
+	shortPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.Config{MaxWeightedLength: 5})
+	shortPostHandler.CreatePost(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		s.T().Errorf("expected %d for a text longer than the configured limit, but got %d", http.StatusBadRequest, rr.Code)
+	}
+}
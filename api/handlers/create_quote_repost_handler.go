@@ -3,32 +3,52 @@ package handlers
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"sync"
-	"time"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/app/usecases"
 	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
 
 	"github.com/google/uuid"
 )
 
 type CreateQuoteRepostHandler struct {
-	db        *sql.DB
-	mu        *sync.Mutex
-	usersChan *map[string]chan entities.TimelineEvent
+	db                          *sql.DB
+	notifyUsecase               usecases.NotifyUsecase
+	createPostEntitiesUsecase   usecases.CreatePostEntitiesUsecase
+	attachMediaUsecase          usecases.AttachMediaUsecase
+	publishTimelineEventUsecase usecases.PublishTimelineEventUsecase
+	textConfig                  text.Config
 }
 
-func NewCreateQuoteRepostHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent) CreateQuoteRepostHandler {
+func NewCreateQuoteRepostHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}, notificationChans *map[string]chan *entities.Notification, textConfig text.Config) CreateQuoteRepostHandler {
+	notificationsRepository := infrastructure.NewNotificationsRepository(db)
+	postsRepository := infrastructure.NewPostsRepository(db)
+	usersRepository := infrastructure.NewUsersRepository(db)
+	notifyUsecase := usecases.NewNotifyUsecase(notificationsRepository, postsRepository, mu, notificationChans)
+	createPostEntitiesUsecase := usecases.NewCreatePostEntitiesUsecase(postsRepository, usersRepository, notifyUsecase)
+	attachMediaUsecase := usecases.NewAttachMediaUsecase(infrastructure.NewMediaRepository(db))
 	return CreateQuoteRepostHandler{
-		db:        db,
-		mu:        mu,
-		usersChan: usersChan,
+		db:                          db,
+		notifyUsecase:               notifyUsecase,
+		createPostEntitiesUsecase:   createPostEntitiesUsecase,
+		attachMediaUsecase:          attachMediaUsecase,
+		publishTimelineEventUsecase: newPublishTimelineEventUsecase(db, mu, usersChan, listChans),
+		textConfig:                  textConfig,
 	}
 }
 
 // CreateQuoteRepost creates a new quote repost with the specified post_id and user_id,
 // then, inserts it into reposts table.
+// The users mentioned in the text are recorded and notified.
+// Up to four media uploaded by the user can be attached by media_ids.
+// The text is validated in the same way as that of a post.
+// If the quoted post does not exist, it returns 404.
 func (h *CreateQuoteRepostHandler) CreateQuoteRepost(w http.ResponseWriter, r *http.Request, userIDStr string) {
 	var body createQuoteRepostRequestBody
 
@@ -45,6 +65,20 @@ func (h *CreateQuoteRepostHandler) CreateQuoteRepost(w http.ResponseWriter, r *h
 		return
 	}
 
+	h.createQuoteRepost(w, userID, body, nil)
+}
+
+// createQuoteRepost validates the quote repost in the request body, creates it,
+// and writes the response. If beforeCommit is not nil, it is called in the transaction
+// creating the quote repost, which is rolled back if it returns an error.
+func (h *CreateQuoteRepostHandler) createQuoteRepost(w http.ResponseWriter, userID uuid.UUID, body createQuoteRepostRequestBody, beforeCommit func(tx *sql.Tx) error) {
+	var err error
+	body.Text, err = validatePostText(h.textConfig, body.Text, len(body.MediaIDs) > 0)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Text was invalid: %v\n", err), http.StatusBadRequest)
+		return
+	}
+
 	query := `
 		SELECT 
 			r.id IS NOT NULL AS is_parent_repost
@@ -62,71 +96,112 @@ func (h *CreateQuoteRepostHandler) CreateQuoteRepost(w http.ResponseWriter, r *h
 		return
 	}
 
-	if isParentRepost {
-		query = `INSERT INTO reposts (user_id, parent_repost_id, is_quote, text) VALUES ($1, $2, $3, $4) RETURNING id, created_at`
-	} else {
-		query = `INSERT INTO reposts (user_id, parent_post_id, is_quote, text) VALUES ($1, $2, $3, $4) RETURNING id, created_at`
-	}
-
-	var (
-		id        uuid.UUID
-		createdAt time.Time
-	)
-
-	isQuote := true
-
-	err = h.db.QueryRow(query, userID, body.PostID, isQuote, body.Text).Scan(&id, &createdAt)
+	media, err := h.attachMediaUsecase.GetAttachableMedia(userID, body.MediaIDs)
 	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidMediaAttachment) {
+			http.Error(w, fmt.Sprintf("Could not attach the media: %v\n", err), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, fmt.Sprintln("Could not create a quote repost."), http.StatusInternalServerError)
 		return
 	}
 
-	quoteRepost := entities.Repost{
-		ID:        id,
-		ParentID:  body.PostID,
-		UserID:    userID,
-		Text:      body.Text,
-		CreatedAt: createdAt,
+	quoteRepost, err := h.insertQuoteRepost(userID, body, isParentRepost, media, beforeCommit)
+	if errors.Is(err, domainerrors.ErrDraftNotFound) || errors.Is(err, domainerrors.ErrDraftChanged) {
+		writeDraftError(w, err, "Could not publish the draft.")
+		return
+	} else if errors.Is(err, domainerrors.ErrPostNotFound) {
+		http.Error(w, fmt.Sprintf("Could not find a post (ID: %s)\n", body.PostID), http.StatusNotFound)
+		return
+	} else if err != nil {
+		slog.Error("Could not create a quote repost.", "user_id", userID, "error", err)
+		http.Error(w, fmt.Sprintln("Could not create a quote repost."), http.StatusInternalServerError)
+		return
 	}
 
-	go func(userID uuid.UUID, userChan *map[string]chan entities.TimelineEvent) {
-		var quoteReposts []*entities.Repost
-		quoteReposts = append(quoteReposts, &quoteRepost)
-		query := `SELECT source_user_id FROM followships WHERE target_user_id = $1`
-		rows, err := h.db.Query(query, userID.String())
-		if err != nil {
-			log.Fatalln(err)
-			return
-		}
-
-		var ids []uuid.UUID
-		for rows.Next() {
-			var id uuid.UUID
-			if err := rows.Scan(&id); err != nil {
-				log.Fatalln(err)
-				return
-			}
-
-			ids = append(ids, id)
-		}
-		ids = append(ids, userID)
-		for _, id := range ids {
-			h.mu.Lock()
-			if userChan, ok := (*h.usersChan)[id.String()]; ok {
-				userChan <- entities.TimelineEvent{EventType: entities.QuoteRepostCreated, Reposts: quoteReposts}
-			}
-			h.mu.Unlock()
-		}
+	if !isParentRepost {
+		h.notifyUsecase.NotifyPostAuthor(body.PostID, userID, entities.NotificationQuote, &quoteRepost.ID)
+	}
+	h.createPostEntitiesUsecase.NotifyMentions(userID, quoteRepost.ID, quoteRepost.Entities.Mentions)
 
-	}(userID, h.usersChan)
+	go h.publishTimelineEventUsecase.PublishToTimelines(userID, entities.TimelineEvent{EventType: entities.QuoteRepostCreated, Reposts: []*entities.Repost{quoteRepost}})
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 
 	encoder := json.NewEncoder(w)
-	err = encoder.Encode(&quoteRepost)
+	err = encoder.Encode(quoteRepost)
 	if err != nil {
 		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
-		return
 	}
 }
+
+// insertQuoteRepost creates the validated quote repost with its entities and media
+// in a single transaction. A quoted post is locked in share mode until the quote
+// repost is committed, so that the version it quotes cannot be edited in between.
+func (h *CreateQuoteRepostHandler) insertQuoteRepost(userID uuid.UUID, body createQuoteRepostRequestBody, isParentRepost bool, media []*entities.Media, beforeCommit func(tx *sql.Tx) error) (*entities.Repost, error) {
+	tx, err := h.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var (
+		query         string
+		quotedVersion sql.NullInt64
+	)
+	if isParentRepost {
+		query = `INSERT INTO reposts (user_id, parent_repost_id, is_quote, text) VALUES ($1, $2, $3, $4) RETURNING id, created_at`
+	} else {
+		// The quote references the current version of the post, which it keeps when the post is edited.
+		err := tx.QueryRow(`SELECT edit_count FROM posts WHERE id = $1 FOR SHARE`, body.PostID).Scan(&quotedVersion)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerrors.ErrPostNotFound
+		} else if err != nil {
+			return nil, err
+		}
+		query = `INSERT INTO reposts (user_id, parent_post_id, is_quote, text, quoted_post_version) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+	}
+
+	quoteRepost := &entities.Repost{
+		ParentID: body.PostID,
+		UserID:   userID,
+		Text:     body.Text,
+	}
+	isQuote := true
+	args := []any{userID, body.PostID, isQuote, body.Text}
+	if !isParentRepost {
+		args = append(args, quotedVersion)
+	}
+	if err := tx.QueryRow(query, args...).Scan(&quoteRepost.ID, &quoteRepost.CreatedAt); err != nil {
+		return nil, err
+	}
+	if quotedVersion.Valid {
+		version := int(quotedVersion.Int64)
+		quoteRepost.QuotedVersion = &version
+	}
+
+	quoteRepost.Entities, err = h.createPostEntitiesUsecase.CreatePostEntities(tx, userID, quoteRepost.ID, true, quoteRepost.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.attachMediaUsecase.AttachMedia(tx, quoteRepost.ID, true, media); err != nil {
+		return nil, err
+	}
+	if len(media) > 0 {
+		quoteRepost.Media = media
+	}
+
+	if beforeCommit != nil {
+		if err := beforeCommit(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return quoteRepost, nil
+}
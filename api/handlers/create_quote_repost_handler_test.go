@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"x-clone-backend/internal/app/text"
 
 	"github.com/google/uuid"
 )
@@ -32,6 +33,18 @@ func (s *HandlersTestSuite) TestCreateQuoteRepost() {
 			body:         fmt.Sprintf(`{ "post_id": "%s", "text": "test" }`, repostID),
 			expectedCode: http.StatusCreated,
 		},
+		{
+			name:         "whitespace-only text",
+			userID:       userID,
+			body:         fmt.Sprintf(`{ "post_id": "%s", "text": "  " }`, postID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "text longer than 280 characters",
+			userID:       userID,
+			body:         fmt.Sprintf(`{ "post_id": "%s", "text": "%s" }`, postID, strings.Repeat("a", 281)),
+			expectedCode: http.StatusBadRequest,
+		},
 		{
 			name:         "invalid JSON body",
 			userID:       userID,
@@ -42,7 +55,7 @@ func (s *HandlersTestSuite) TestCreateQuoteRepost() {
 			name:         "invalid body",
 			userID:       userID,
 			body:         `{"text": "test"}`,
-			expectedCode: http.StatusInternalServerError,
+			expectedCode: http.StatusNotFound,
 		},
 		{
 			name:         "non-existent user id",
@@ -54,7 +67,7 @@ func (s *HandlersTestSuite) TestCreateQuoteRepost() {
 			name:         "non-existent post id",
 			userID:       userID,
 			body:         fmt.Sprintf(`{ "post_id": "%s", "text": "test" }`, uuid.New()),
-			expectedCode: http.StatusInternalServerError,
+			expectedCode: http.StatusNotFound,
 		},
 	}
 
@@ -66,7 +79,7 @@ func (s *HandlersTestSuite) TestCreateQuoteRepost() {
 		)
 		rr := httptest.NewRecorder()
 
-		createRepostHandler := NewCreateQuoteRepostHandler(s.db, &s.mu, &s.userChannels)
+		createRepostHandler := NewCreateQuoteRepostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
 		createRepostHandler.CreateQuoteRepost(rr, req, test.userID)
 
 		if rr.Code != test.expectedCode {
@@ -4,26 +4,30 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"sync"
 	"time"
+	"x-clone-backend/internal/app/usecases"
 	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
 
 	"github.com/google/uuid"
 )
 
 type CreateRepostHandler struct {
-	db        *sql.DB
-	mu        *sync.Mutex
-	usersChan *map[string]chan entities.TimelineEvent
+	db                          *sql.DB
+	notifyUsecase               usecases.NotifyUsecase
+	publishTimelineEventUsecase usecases.PublishTimelineEventUsecase
 }
 
-func NewCreateRepostHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent) CreateRepostHandler {
+func NewCreateRepostHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}, notificationChans *map[string]chan *entities.Notification) CreateRepostHandler {
+	notificationsRepository := infrastructure.NewNotificationsRepository(db)
+	postsRepository := infrastructure.NewPostsRepository(db)
+	notifyUsecase := usecases.NewNotifyUsecase(notificationsRepository, postsRepository, mu, notificationChans)
 	return CreateRepostHandler{
-		db:        db,
-		mu:        mu,
-		usersChan: usersChan,
+		db:                          db,
+		notifyUsecase:               notifyUsecase,
+		publishTimelineEventUsecase: newPublishTimelineEventUsecase(db, mu, usersChan, listChans),
 	}
 }
 
@@ -89,36 +93,11 @@ func (h *CreateRepostHandler) CreateRepost(w http.ResponseWriter, r *http.Reques
 		CreatedAt: createdAt,
 	}
 
-	go func(userID uuid.UUID, userChan *map[string]chan entities.TimelineEvent) {
-		var reposts []*entities.Repost
-		reposts = append(reposts, &repost)
-		query := `SELECT source_user_id FROM followships WHERE target_user_id = $1`
-		rows, err := h.db.Query(query, userID.String())
-		if err != nil {
-			log.Fatalln(err)
-			return
-		}
-
-		var ids []uuid.UUID
-		for rows.Next() {
-			var id uuid.UUID
-			if err := rows.Scan(&id); err != nil {
-				log.Fatalln(err)
-				return
-			}
-
-			ids = append(ids, id)
-		}
-		ids = append(ids, userID)
-		for _, id := range ids {
-			h.mu.Lock()
-			if userChan, ok := (*h.usersChan)[id.String()]; ok {
-				userChan <- entities.TimelineEvent{EventType: entities.RepostCreated, Reposts: reposts}
-			}
-			h.mu.Unlock()
-		}
-
-	}(userID, h.usersChan)
+	if !isParentRepost {
+		h.notifyUsecase.NotifyPostAuthor(body.PostID, userID, entities.NotificationRepost, &repost.ID)
+	}
+
+	go h.publishTimelineEventUsecase.PublishToTimelines(userID, entities.TimelineEvent{EventType: entities.RepostCreated, Reposts: []*entities.Repost{&repost}})
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -66,7 +66,7 @@ func (s *HandlersTestSuite) TestCreateRepost() {
 		)
 		rr := httptest.NewRecorder()
 
-		createRepostHandler := NewCreateRepostHandler(s.db, &s.mu, &s.userChannels)
+		createRepostHandler := NewCreateRepostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels)
 		createRepostHandler.CreateRepost(rr, req, test.userID)
 
 		if rr.Code != test.expectedCode {
@@ -4,26 +4,24 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"sync"
 	"time"
+	"x-clone-backend/internal/app/usecases"
 	"x-clone-backend/internal/domain/entities"
 
 	"github.com/google/uuid"
 )
 
 type DeleteRepostHandler struct {
-	db        *sql.DB
-	mu        *sync.Mutex
-	usersChan *map[string]chan entities.TimelineEvent
+	db                          *sql.DB
+	publishTimelineEventUsecase usecases.PublishTimelineEventUsecase
 }
 
-func NewDeleteRepostHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent) DeleteRepostHandler {
+func NewDeleteRepostHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}) DeleteRepostHandler {
 	return DeleteRepostHandler{
-		db:        db,
-		mu:        mu,
-		usersChan: usersChan,
+		db:                          db,
+		publishTimelineEventUsecase: newPublishTimelineEventUsecase(db, mu, usersChan, listChans),
 	}
 }
 
@@ -76,35 +74,7 @@ func (h *DeleteRepostHandler) DeleteRepost(w http.ResponseWriter, r *http.Reques
 		CreatedAt: createdAt,
 	}
 
-	go func(userID uuid.UUID, usersChan *map[string]chan entities.TimelineEvent) {
-		var reposts []*entities.Repost
-		reposts = append(reposts, &repost)
-		query = `SELECT source_user_id FROM followships WHERE target_user_id=$1`
-		rows, err := h.db.Query(query, userID.String())
-		if err != nil {
-			log.Fatalln(err)
-			return
-		}
-
-		var ids []uuid.UUID
-		for rows.Next() {
-			var id uuid.UUID
-			if err := rows.Scan(&id); err != nil {
-				log.Fatalln(err)
-				return
-			}
-
-			ids = append(ids, id)
-		}
-		ids = append(ids, userID)
-		for _, id := range ids {
-			h.mu.Lock()
-			if userChan, ok := (*usersChan)[id.String()]; ok {
-				userChan <- entities.TimelineEvent{EventType: entities.RepostDeleted, Reposts: reposts}
-			}
-			h.mu.Unlock()
-		}
-	}(userID, h.usersChan)
+	go h.publishTimelineEventUsecase.PublishToTimelines(userID, entities.TimelineEvent{EventType: entities.RepostDeleted, Reposts: []*entities.Repost{&repost}})
 
 	w.WriteHeader(http.StatusNoContent)
 }
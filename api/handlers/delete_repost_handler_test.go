@@ -49,7 +49,7 @@ func (s *HandlersTestSuite) TestDeleteRepost() {
 		req.SetPathValue("user_id", userID)
 		req.SetPathValue("post_id", test.parentID)
 
-		deleteRepostHandler := NewDeleteRepostHandler(s.db, &s.mu, &s.userChannels)
+		deleteRepostHandler := NewDeleteRepostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels)
 		deleteRepostHandler.DeleteRepost(rr, req, userID, test.parentID)
 
 		if rr.Code != test.expectedCode {
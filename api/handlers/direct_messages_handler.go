@@ -0,0 +1,540 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"sync"
+	openapi "x-clone-backend/gen"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type DirectMessagesHandler struct {
+	mu                    *sync.Mutex
+	messageChans          *map[string]chan *entities.DirectMessageEvent
+	directMessagesUsecase usecases.DirectMessagesUsecase
+}
+
+func NewDirectMessagesHandler(db *sql.DB, mu *sync.Mutex, messageChans *map[string]chan *entities.DirectMessageEvent) DirectMessagesHandler {
+	directMessagesRepository := infrastructure.NewDirectMessagesRepository(db)
+	usersRepository := infrastructure.NewUsersRepository(db)
+	directMessagesUsecase := usecases.NewDirectMessagesUsecase(directMessagesRepository, usersRepository, mu, messageChans)
+	return DirectMessagesHandler{
+		mu:                    mu,
+		messageChans:          messageChans,
+		directMessagesUsecase: directMessagesUsecase,
+	}
+}
+
+// StartConversation opens the one-to-one conversation of the authenticated user and the participant.
+// It returns 201 for a new conversation and 200 if they already had one.
+func (h *DirectMessagesHandler) StartConversation(w http.ResponseWriter, r *http.Request) {
+	slog.Info("POST /api/dm/conversations was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var body createConversationRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+	if body.ParticipantID == uuid.Nil {
+		http.Error(w, fmt.Sprintln("participant_id is required."), http.StatusBadRequest)
+		return
+	}
+
+	conversation, created, err := h.directMessagesUsecase.StartConversation(userID.String(), body.ParticipantID.String())
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not start a conversation.")
+		return
+	}
+
+	code := http.StatusOK
+	if created {
+		code = http.StatusCreated
+	}
+	writeDirectMessageResponse(w, code, conversation)
+}
+
+// CreateGroupConversation creates a group conversation of the authenticated user and the participants.
+func (h *DirectMessagesHandler) CreateGroupConversation(w http.ResponseWriter, r *http.Request) {
+	slog.Info("POST /api/dm/groups was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var body createGroupConversationRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+
+	var participantIDs []string
+	for _, participantID := range body.ParticipantIDs {
+		participantIDs = append(participantIDs, participantID.String())
+	}
+
+	conversation, err := h.directMessagesUsecase.CreateGroup(userID.String(), body.Name, participantIDs)
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not create a group conversation.")
+		return
+	}
+
+	writeDirectMessageResponse(w, http.StatusCreated, conversation)
+}
+
+// GetConversations gets the authenticated user's conversations, the most recently active first.
+func (h *DirectMessagesHandler) GetConversations(w http.ResponseWriter, r *http.Request, params openapi.GetConversationsParams) {
+	slog.Info("GET /api/dm/conversations was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.directMessagesUsecase.GetConversations(userID.String(), cursor, limit)
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not get conversations.")
+		return
+	}
+
+	writeDirectMessageResponse(w, http.StatusOK, conversationPageResponseBody{
+		Conversations: page.Items,
+		NextCursor:    encodeNextCursor(page.NextCursor),
+	})
+}
+
+// GetConversation gets a conversation the authenticated user participates in.
+func (h *DirectMessagesHandler) GetConversation(w http.ResponseWriter, r *http.Request, conversationID string) {
+	slog.Info("GET /api/dm/conversations/{conversationID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validConversationID(w, conversationID) {
+		return
+	}
+
+	conversation, err := h.directMessagesUsecase.GetConversation(conversationID, userID.String())
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not get the conversation.")
+		return
+	}
+
+	writeDirectMessageResponse(w, http.StatusOK, conversation)
+}
+
+// RenameConversation renames a group conversation of the authenticated user.
+func (h *DirectMessagesHandler) RenameConversation(w http.ResponseWriter, r *http.Request, conversationID string) {
+	slog.Info("PATCH /api/dm/conversations/{conversationID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validConversationID(w, conversationID) {
+		return
+	}
+
+	var body renameConversationRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+
+	conversation, err := h.directMessagesUsecase.RenameConversation(conversationID, userID.String(), body.Name)
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not rename the conversation.")
+		return
+	}
+
+	writeDirectMessageResponse(w, http.StatusOK, conversation)
+}
+
+// AddConversationParticipant adds a user to a group conversation of the authenticated user.
+// It returns 201 for a new participant and 200 if the user was already a participant.
+func (h *DirectMessagesHandler) AddConversationParticipant(w http.ResponseWriter, r *http.Request, conversationID string) {
+	slog.Info("POST /api/dm/conversations/{conversationID}/participants was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validConversationID(w, conversationID) {
+		return
+	}
+
+	var body addConversationParticipantRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+	if body.UserID == uuid.Nil {
+		http.Error(w, fmt.Sprintln("user_id is required."), http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.directMessagesUsecase.AddParticipant(conversationID, userID.String(), body.UserID.String())
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not add a participant to the conversation.")
+		return
+	}
+
+	writeCreatedStatus(w, created)
+}
+
+// LeaveConversation removes the authenticated user from a group conversation.
+func (h *DirectMessagesHandler) LeaveConversation(w http.ResponseWriter, r *http.Request, conversationID string) {
+	slog.Info("POST /api/dm/conversations/{conversationID}/leave was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validConversationID(w, conversationID) {
+		return
+	}
+
+	err := h.directMessagesUsecase.LeaveConversation(conversationID, userID.String())
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not leave the conversation.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDirectMessages gets the messages of the conversation, the latest first,
+// leaving out the ones the authenticated user has deleted.
+func (h *DirectMessagesHandler) GetDirectMessages(w http.ResponseWriter, r *http.Request, conversationID string, params openapi.GetDirectMessagesParams) {
+	slog.Info("GET /api/dm/conversations/{conversationID}/messages was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validConversationID(w, conversationID) {
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.directMessagesUsecase.GetMessages(conversationID, userID.String(), cursor, limit)
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not get messages.")
+		return
+	}
+
+	writeDirectMessageResponse(w, http.StatusOK, directMessagePageResponseBody{
+		Messages:   page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	})
+}
+
+// SendDirectMessage sends a message from the authenticated user to the conversation.
+func (h *DirectMessagesHandler) SendDirectMessage(w http.ResponseWriter, r *http.Request, conversationID string) {
+	slog.Info("POST /api/dm/conversations/{conversationID}/messages was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validConversationID(w, conversationID) {
+		return
+	}
+
+	var body sendDirectMessageRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+
+	message, err := h.directMessagesUsecase.SendMessage(conversationID, userID.String(), body.Text)
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not send the message.")
+		return
+	}
+
+	writeDirectMessageResponse(w, http.StatusCreated, message)
+}
+
+// DeleteDirectMessage deletes a message for the authenticated user only.
+func (h *DirectMessagesHandler) DeleteDirectMessage(w http.ResponseWriter, r *http.Request, conversationID string, messageID string) {
+	slog.Info("DELETE /api/dm/conversations/{conversationID}/messages/{messageID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validConversationID(w, conversationID) || !validMessageID(w, messageID) {
+		return
+	}
+
+	err := h.directMessagesUsecase.DeleteMessage(conversationID, messageID, userID.String())
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not delete the message.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddDirectMessageReaction reacts to a message with an emoji as the authenticated user.
+// It returns 201 for a new reaction and 200 if the user had already reacted with the emoji.
+func (h *DirectMessagesHandler) AddDirectMessageReaction(w http.ResponseWriter, r *http.Request, conversationID string, messageID string) {
+	slog.Info("POST /api/dm/conversations/{conversationID}/messages/{messageID}/reactions was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validConversationID(w, conversationID) || !validMessageID(w, messageID) {
+		return
+	}
+
+	var body addReactionRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+
+	message, created, err := h.directMessagesUsecase.React(conversationID, messageID, userID.String(), body.Emoji)
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not react to the message.")
+		return
+	}
+
+	code := http.StatusOK
+	if created {
+		code = http.StatusCreated
+	}
+	writeDirectMessageResponse(w, code, message)
+}
+
+// RemoveDirectMessageReaction removes a reaction of the authenticated user from a message.
+func (h *DirectMessagesHandler) RemoveDirectMessageReaction(w http.ResponseWriter, r *http.Request, conversationID string, messageID string, emoji string) {
+	slog.Info("DELETE /api/dm/conversations/{conversationID}/messages/{messageID}/reactions/{emoji} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validConversationID(w, conversationID) || !validMessageID(w, messageID) {
+		return
+	}
+
+	err := h.directMessagesUsecase.Unreact(conversationID, messageID, userID.String(), emoji)
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not remove the reaction.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkConversationAsRead marks the messages received so far in the conversation as read,
+// which the other participants see as the read receipt of the authenticated user.
+func (h *DirectMessagesHandler) MarkConversationAsRead(w http.ResponseWriter, r *http.Request, conversationID string) {
+	slog.Info("POST /api/dm/conversations/{conversationID}/read was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validConversationID(w, conversationID) {
+		return
+	}
+
+	_, err := h.directMessagesUsecase.MarkConversationAsRead(conversationID, userID.String())
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not mark the conversation as read.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// StreamDirectMessages streams the new messages, reactions and read receipts in the authenticated
+// user's conversations as server-sent events, in the same way as notifications are streamed.
+func (h *DirectMessagesHandler) StreamDirectMessages(w http.ResponseWriter, r *http.Request) {
+	userID := viewerIDFromContext(r)
+	if userID == "" {
+		http.Error(w, "Authentication required.", http.StatusUnauthorized)
+		return
+	}
+
+	h.mu.Lock()
+	if _, exists := (*h.messageChans)[userID]; !exists {
+		(*h.messageChans)[userID] = make(chan *entities.DirectMessageEvent, 1)
+	}
+	messageChan := (*h.messageChans)[userID]
+	h.mu.Unlock()
+
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case event := <-messageChan:
+			jsonData, err := json.Marshal(event)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+
+			fmt.Fprintf(w, "data: %s\n\n", jsonData)
+			flusher.Flush()
+		case <-r.Context().Done():
+			h.mu.Lock()
+			delete(*h.messageChans, userID)
+			h.mu.Unlock()
+			return
+		}
+	}
+}
+
+// GetDirectMessageSettings gets the direct message settings of the authenticated user.
+func (h *DirectMessagesHandler) GetDirectMessageSettings(w http.ResponseWriter, r *http.Request, id string) {
+	slog.Info("GET /api/users/{id}/settings/direct_messages was called.")
+
+	if !authorizeOwner(w, r, id) {
+		return
+	}
+
+	settings, err := h.directMessagesUsecase.GetSettings(id)
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not get direct message settings.")
+		return
+	}
+
+	writeDirectMessageResponse(w, http.StatusOK, settings)
+}
+
+// UpdateDirectMessageSettings changes the direct message settings of the authenticated user.
+// Settings missing from the request body are left as they are.
+func (h *DirectMessagesHandler) UpdateDirectMessageSettings(w http.ResponseWriter, r *http.Request, id string) {
+	slog.Info("PATCH /api/users/{id}/settings/direct_messages was called.")
+
+	if !authorizeOwner(w, r, id) {
+		return
+	}
+
+	var body entities.DirectMessageSettingsPatch
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Request body was invalid: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.directMessagesUsecase.UpdateSettings(id, body)
+	if err != nil {
+		writeDirectMessageError(w, err, "Could not update direct message settings.")
+		return
+	}
+
+	writeDirectMessageResponse(w, http.StatusOK, settings)
+}
+
+func validConversationID(w http.ResponseWriter, conversationID string) bool {
+	if _, err := uuid.Parse(conversationID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a conversationID (ID: %s)\n", conversationID), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+func validMessageID(w http.ResponseWriter, messageID string) bool {
+	if _, err := uuid.Parse(messageID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a messageID (ID: %s)\n", messageID), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// writeDirectMessageError writes the status code corresponding to an error
+// returned by the direct messages usecase.
+func writeDirectMessageError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrConversationNotFound),
+		errors.Is(err, domainerrors.ErrDirectMessageNotFound),
+		errors.Is(err, domainerrors.ErrReactionNotFound),
+		errors.Is(err, domainerrors.ErrUserNotFound):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrBlocked), errors.Is(err, domainerrors.ErrDirectMessageNotAllowed):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusForbidden)
+	case errors.Is(err, domainerrors.ErrSelfConversation),
+		errors.Is(err, domainerrors.ErrInvalidDirectMessage),
+		errors.Is(err, domainerrors.ErrInvalidDirectMessageSettings),
+		errors.Is(err, domainerrors.ErrInvalidConversation),
+		errors.Is(err, domainerrors.ErrNotGroupConversation),
+		errors.Is(err, domainerrors.ErrConversationFull),
+		errors.Is(err, domainerrors.ErrInvalidReaction):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusBadRequest)
+	default:
+		http.Error(w, fmt.Sprintln(message), http.StatusInternalServerError)
+	}
+}
+
+func writeDirectMessageResponse(w http.ResponseWriter, code int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	encoder := json.NewEncoder(w)
+	err := encoder.Encode(response)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
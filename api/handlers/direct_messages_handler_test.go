@@ -0,0 +1,643 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/domain/entities"
+)
+
+func (s *HandlersTestSuite) TestDirectMessages() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	blockerID := s.newTestUser(`{ "username": "blocker", "display_name": "blocker", "password": "securepassword" }`)
+	s.newTestBlock(blockerID, aliceID)
+
+	directMessagesHandler := NewDirectMessagesHandler(s.db, &s.mu, &s.messageChannels)
+
+	startTests := []struct {
+		name         string
+		viewerID     string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "anonymous viewer",
+			body:         fmt.Sprintf(`{ "participant_id": "%s" }`, bobID),
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "missing participant",
+			viewerID:     aliceID,
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "self conversation",
+			viewerID:     aliceID,
+			body:         fmt.Sprintf(`{ "participant_id": "%s" }`, aliceID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "nonexistent participant",
+			viewerID:     aliceID,
+			body:         `{ "participant_id": "00000000-0000-0000-0000-000000000001" }`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "blocked participant",
+			viewerID:     aliceID,
+			body:         fmt.Sprintf(`{ "participant_id": "%s" }`, blockerID),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "new conversation",
+			viewerID:     aliceID,
+			body:         fmt.Sprintf(`{ "participant_id": "%s" }`, bobID),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "existing conversation",
+			viewerID:     bobID,
+			body:         fmt.Sprintf(`{ "participant_id": "%s" }`, aliceID),
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range startTests {
+		req := httptest.NewRequest("POST", "/api/dm/conversations", strings.NewReader(test.body))
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+		directMessagesHandler.StartConversation(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	conversation := s.newTestConversation(aliceID, bobID)
+	if len(conversation.Participants) != 2 || conversation.LastMessage != nil || conversation.UnreadCount != 0 {
+		s.T().Errorf("wrong conversation returned; got %+v", conversation)
+	}
+	conversationID := conversation.ID.String()
+
+	sendTests := []struct {
+		name         string
+		viewerID     string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "blank text",
+			viewerID:     aliceID,
+			body:         `{ "text": "  " }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "too long text",
+			viewerID:     aliceID,
+			body:         fmt.Sprintf(`{ "text": "%s" }`, strings.Repeat("a", entities.MaxDirectMessageLength+1)),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "non-participant",
+			viewerID:     blockerID,
+			body:         `{ "text": "hello" }`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "valid message",
+			viewerID:     aliceID,
+			body:         `{ "text": " hello " }`,
+			expectedCode: http.StatusCreated,
+		},
+	}
+
+	for _, test := range sendTests {
+		req := withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/messages", strings.NewReader(test.body)), test.viewerID)
+		rr := httptest.NewRecorder()
+		directMessagesHandler.SendDirectMessage(rr, req, conversationID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	s.newTestDirectMessage(aliceID, conversationID, "how are you?")
+
+	conversations := s.getTestConversations(bobID)
+	if len(conversations) != 1 {
+		s.T().Fatalf("expected 1 conversation, but got %d", len(conversations))
+	}
+	if conversations[0].UnreadCount != 2 || conversations[0].LastMessage == nil || conversations[0].LastMessage.Text != "how are you?" {
+		s.T().Errorf("wrong conversation returned to the recipient; got %+v", conversations[0])
+	}
+	if unread := s.getTestConversations(aliceID)[0].UnreadCount; unread != 0 {
+		s.T().Errorf("expected no unread messages for the sender, but got %d", unread)
+	}
+
+	req := withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/read", nil), bobID)
+	rr := httptest.NewRecorder()
+	directMessagesHandler.MarkConversationAsRead(rr, req, conversationID)
+	if rr.Code != http.StatusNoContent {
+		s.T().Errorf("mark as read: expected %d, but got %d", http.StatusNoContent, rr.Code)
+	}
+	if unread := s.getTestConversations(bobID)[0].UnreadCount; unread != 0 {
+		s.T().Errorf("expected no unread messages after reading, but got %d", unread)
+	}
+
+	// Deleting a message hides it only from the user who deleted it.
+	messages := s.getTestDirectMessages(bobID, conversationID)
+	if len(messages) != 2 || messages[0].Text != "how are you?" || messages[1].Text != "hello" {
+		s.T().Fatalf("wrong messages returned; got %+v", messages)
+	}
+	req = withViewer(httptest.NewRequest("DELETE", "/api/dm/conversations/{conversationID}/messages/{messageID}", nil), bobID)
+	rr = httptest.NewRecorder()
+	directMessagesHandler.DeleteDirectMessage(rr, req, conversationID, messages[0].ID.String())
+	if rr.Code != http.StatusNoContent {
+		s.T().Errorf("delete message: expected %d, but got %d", http.StatusNoContent, rr.Code)
+	}
+	rr = httptest.NewRecorder()
+	directMessagesHandler.DeleteDirectMessage(rr, req, conversationID, messages[0].ID.String())
+	if rr.Code != http.StatusNotFound {
+		s.T().Errorf("delete deleted message: expected %d, but got %d", http.StatusNotFound, rr.Code)
+	}
+
+	if messages := s.getTestDirectMessages(bobID, conversationID); len(messages) != 1 || messages[0].Text != "hello" {
+		s.T().Errorf("expected only the remaining message, but got %+v", messages)
+	}
+	if lastMessage := s.getTestConversations(bobID)[0].LastMessage; lastMessage == nil || lastMessage.Text != "hello" {
+		s.T().Errorf("expected the remaining message as the last message, but got %+v", lastMessage)
+	}
+	if messages := s.getTestDirectMessages(aliceID, conversationID); len(messages) != 2 {
+		s.T().Errorf("expected the sender to still see 2 messages, but got %d", len(messages))
+	}
+
+	req = withViewer(httptest.NewRequest("GET", "/api/dm/conversations/{conversationID}/messages", nil), blockerID)
+	rr = httptest.NewRecorder()
+	directMessagesHandler.GetDirectMessages(rr, req, conversationID, openapi.GetDirectMessagesParams{})
+	if rr.Code != http.StatusNotFound {
+		s.T().Errorf("non-participant: expected %d, but got %d", http.StatusNotFound, rr.Code)
+	}
+
+	// Blocking refuses new messages in the existing conversation.
+	s.newTestBlock(bobID, aliceID)
+	req = withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/messages", strings.NewReader(`{ "text": "hello?" }`)), aliceID)
+	rr = httptest.NewRecorder()
+	directMessagesHandler.SendDirectMessage(rr, req, conversationID)
+	if rr.Code != http.StatusForbidden {
+		s.T().Errorf("blocked sender: expected %d, but got %d", http.StatusForbidden, rr.Code)
+	}
+}
+
+func (s *HandlersTestSuite) TestDirectMessageSettings() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	carolID := s.newTestUser(`{ "username": "carol", "display_name": "carol", "password": "securepassword" }`)
+
+	directMessagesHandler := NewDirectMessagesHandler(s.db, &s.mu, &s.messageChannels)
+
+	req := withViewer(httptest.NewRequest("GET", "/api/users/{id}/settings/direct_messages", nil), aliceID)
+	rr := httptest.NewRecorder()
+	directMessagesHandler.GetDirectMessageSettings(rr, req, aliceID)
+	var settings entities.DirectMessageSettings
+	if err := json.NewDecoder(rr.Body).Decode(&settings); err != nil || settings.AllowFrom != entities.DirectMessagesFromEveryone {
+		s.T().Errorf("expected the default settings, but got %+v (err: %v)", settings, err)
+	}
+
+	updateTests := []struct {
+		name         string
+		viewerID     string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "another user",
+			viewerID:     bobID,
+			body:         `{ "allow_from": "following" }`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "unknown permission",
+			viewerID:     aliceID,
+			body:         `{ "allow_from": "nobody" }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unknown field",
+			viewerID:     aliceID,
+			body:         `{ "allow": "following" }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "valid settings",
+			viewerID:     aliceID,
+			body:         `{ "allow_from": "following" }`,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range updateTests {
+		req := withViewer(httptest.NewRequest("PATCH", "/api/users/{id}/settings/direct_messages", strings.NewReader(test.body)), test.viewerID)
+		rr := httptest.NewRecorder()
+		directMessagesHandler.UpdateDirectMessageSettings(rr, req, aliceID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	// Alice follows Bob but not Carol, so only Bob can start a conversation with her.
+	s.newTestFollow(aliceID, bobID)
+	s.newTestConversation(bobID, aliceID)
+
+	req = withViewer(httptest.NewRequest("POST", "/api/dm/conversations", strings.NewReader(fmt.Sprintf(`{ "participant_id": "%s" }`, aliceID))), carolID)
+	rr = httptest.NewRecorder()
+	directMessagesHandler.StartConversation(rr, req)
+	if rr.Code != http.StatusForbidden {
+		s.T().Errorf("unfollowed sender: expected %d, but got %d", http.StatusForbidden, rr.Code)
+	}
+
+	// Carol can reply once Alice has written to her.
+	conversation := s.newTestConversation(aliceID, carolID)
+	conversationID := conversation.ID.String()
+
+	req = withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/messages", strings.NewReader(`{ "text": "hi alice" }`)), carolID)
+	rr = httptest.NewRecorder()
+	directMessagesHandler.SendDirectMessage(rr, req, conversationID)
+	if rr.Code != http.StatusForbidden {
+		s.T().Errorf("unfollowed sender before a reply: expected %d, but got %d", http.StatusForbidden, rr.Code)
+	}
+
+	s.newTestDirectMessage(aliceID, conversationID, "hi carol")
+	s.newTestDirectMessage(carolID, conversationID, "hi alice")
+}
+
+func (s *HandlersTestSuite) TestDirectMessagesAreDelivered() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	conversation := s.newTestConversation(aliceID, bobID)
+	conversationID := conversation.ID.String()
+
+	directMessagesHandler := NewDirectMessagesHandler(s.db, &s.mu, &s.messageChannels)
+
+	// Subscribe to Bob's events as the stream handler does.
+	messageChan := make(chan *entities.DirectMessageEvent, 1)
+	s.mu.Lock()
+	s.messageChannels[bobID] = messageChan
+	s.mu.Unlock()
+
+	s.newTestDirectMessage(aliceID, conversationID, "hello")
+
+	event := s.receiveTestDirectMessageEvent(messageChan)
+	if event.EventType != entities.DirectMessageCreated || event.Message == nil ||
+		event.Message.Text != "hello" || event.Message.SenderID.String() != aliceID || event.Message.ConversationID != conversation.ID {
+		s.T().Errorf("unexpected event %+v", event)
+	}
+	messageID := event.Message.ID.String()
+
+	req := withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/messages/{messageID}/reactions", strings.NewReader(`{ "emoji": "🔥" }`)), aliceID)
+	rr := httptest.NewRecorder()
+	directMessagesHandler.AddDirectMessageReaction(rr, req, conversationID, messageID)
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("react: expected %d, but got %d", http.StatusCreated, rr.Code)
+	}
+
+	event = s.receiveTestDirectMessageEvent(messageChan)
+	if event.EventType != entities.DirectMessageReacted || event.Message == nil || len(event.Message.Reactions) != 1 {
+		s.T().Errorf("unexpected event %+v", event)
+	}
+
+	// Alice's stream receives Bob's read receipt.
+	aliceChan := make(chan *entities.DirectMessageEvent, 1)
+	s.mu.Lock()
+	s.messageChannels[aliceID] = aliceChan
+	s.mu.Unlock()
+
+	req = withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/read", nil), bobID)
+	rr = httptest.NewRecorder()
+	directMessagesHandler.MarkConversationAsRead(rr, req, conversationID)
+
+	event = s.receiveTestDirectMessageEvent(aliceChan)
+	if event.EventType != entities.ConversationRead || event.ReadReceipt == nil || event.ReadReceipt.UserID.String() != bobID {
+		s.T().Errorf("unexpected event %+v", event)
+	}
+}
+
+func (s *HandlersTestSuite) TestGroupConversations() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	carolID := s.newTestUser(`{ "username": "carol", "display_name": "carol", "password": "securepassword" }`)
+	daveID := s.newTestUser(`{ "username": "dave", "display_name": "dave", "password": "securepassword" }`)
+	blockerID := s.newTestUser(`{ "username": "blocker", "display_name": "blocker", "password": "securepassword" }`)
+	s.newTestBlock(blockerID, aliceID)
+
+	directMessagesHandler := NewDirectMessagesHandler(s.db, &s.mu, &s.messageChannels)
+
+	createTests := []struct {
+		name         string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "no other participants",
+			body:         fmt.Sprintf(`{ "name": "solo", "participant_ids": ["%s"] }`, aliceID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "too long name",
+			body:         fmt.Sprintf(`{ "name": "%s", "participant_ids": ["%s"] }`, strings.Repeat("a", entities.MaxConversationNameLength+1), bobID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "blocked participant",
+			body:         fmt.Sprintf(`{ "name": "friends", "participant_ids": ["%s", "%s"] }`, bobID, blockerID),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "nonexistent participant",
+			body:         fmt.Sprintf(`{ "name": "friends", "participant_ids": ["%s", "00000000-0000-0000-0000-000000000001"] }`, bobID),
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range createTests {
+		req := withViewer(httptest.NewRequest("POST", "/api/dm/groups", strings.NewReader(test.body)), aliceID)
+		rr := httptest.NewRecorder()
+		directMessagesHandler.CreateGroupConversation(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	group := s.newTestGroupConversation(aliceID, fmt.Sprintf(`{ "name": " friends ", "participant_ids": ["%s", "%s"] }`, bobID, carolID))
+	if !group.IsGroup || group.Name != "friends" || len(group.Participants) != 3 || len(group.ReadReceipts) != 3 {
+		s.T().Errorf("wrong group returned; got %+v", group)
+	}
+	groupID := group.ID.String()
+
+	// Once a participant blocks another, neither can send messages to the group.
+	s.newTestDirectMessage(bobID, groupID, "hi all")
+	s.newTestBlock(carolID, bobID)
+	req := withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/messages", strings.NewReader(`{ "text": "hi again" }`)), bobID)
+	rr := httptest.NewRecorder()
+	directMessagesHandler.SendDirectMessage(rr, req, groupID)
+	if rr.Code != http.StatusForbidden {
+		s.T().Errorf("message to a group with a blocker: expected %d, but got %d", http.StatusForbidden, rr.Code)
+	}
+
+	req = withViewer(httptest.NewRequest("PATCH", "/api/dm/conversations/{conversationID}", strings.NewReader(`{ "name": "best friends" }`)), bobID)
+	rr = httptest.NewRecorder()
+	directMessagesHandler.RenameConversation(rr, req, groupID)
+	if rr.Code != http.StatusOK {
+		s.T().Errorf("rename: expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+
+	addTests := []struct {
+		name         string
+		viewerID     string
+		userID       string
+		expectedCode int
+	}{
+		{name: "non-participant", viewerID: daveID, userID: daveID, expectedCode: http.StatusNotFound},
+		{name: "blocked user", viewerID: aliceID, userID: blockerID, expectedCode: http.StatusForbidden},
+		{name: "new participant", viewerID: carolID, userID: daveID, expectedCode: http.StatusCreated},
+		{name: "existing participant", viewerID: aliceID, userID: daveID, expectedCode: http.StatusOK},
+	}
+
+	for _, test := range addTests {
+		req := withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/participants", strings.NewReader(fmt.Sprintf(`{ "user_id": "%s" }`, test.userID))), test.viewerID)
+		rr := httptest.NewRecorder()
+		directMessagesHandler.AddConversationParticipant(rr, req, groupID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	req = withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/leave", nil), bobID)
+	rr = httptest.NewRecorder()
+	directMessagesHandler.LeaveConversation(rr, req, groupID)
+	if rr.Code != http.StatusNoContent {
+		s.T().Errorf("leave: expected %d, but got %d", http.StatusNoContent, rr.Code)
+	}
+
+	messages := s.getTestDirectMessages(aliceID, groupID)
+	expectedTypes := []entities.DirectMessageType{
+		entities.DirectMessageParticipantLeft,
+		entities.DirectMessageParticipantAdded,
+		entities.DirectMessageConversationRenamed,
+		entities.DirectMessageText,
+	}
+	if len(messages) != len(expectedTypes) {
+		s.T().Fatalf("expected %d messages, but got %d", len(expectedTypes), len(messages))
+	}
+	for i, message := range messages {
+		if message.Type != expectedTypes[i] {
+			s.T().Errorf("message %d: expected %s, but got %s", i, expectedTypes[i], message.Type)
+		}
+	}
+	if messages[1].TargetUserID == nil || messages[1].TargetUserID.String() != daveID || messages[2].Text != "best friends" {
+		s.T().Errorf("wrong system messages returned; got %+v and %+v", messages[1], messages[2])
+	}
+
+	conversation := s.getTestConversations(aliceID)[0]
+	if conversation.Name != "best friends" || len(conversation.Participants) != 3 {
+		s.T().Errorf("wrong group returned after the changes; got %+v", conversation)
+	}
+	if conversations := s.getTestConversations(bobID); len(conversations) != 0 {
+		s.T().Errorf("expected no conversations for the user who left, but got %d", len(conversations))
+	}
+
+	// A full group still accepts adding one of its participants again.
+	for i := len(conversation.Participants); i < entities.MaxGroupParticipants; i++ {
+		user, err := s.usersRepository.CreateUser(nil, fmt.Sprintf("member%d", i), "member", "securepassword")
+		if err != nil {
+			s.T().Fatalf("Failed to create a user: %v", err)
+		}
+		_, err = s.db.Exec(`INSERT INTO conversation_participants (conversation_id, user_id) VALUES ($1, $2)`, groupID, user.ID)
+		if err != nil {
+			s.T().Fatalf("Failed to add a participant: %v", err)
+		}
+	}
+	fullTests := []struct {
+		name         string
+		userID       string
+		expectedCode int
+	}{
+		{name: "existing participant of a full group", userID: daveID, expectedCode: http.StatusOK},
+		{name: "new participant of a full group", userID: bobID, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, test := range fullTests {
+		req := withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/participants", strings.NewReader(fmt.Sprintf(`{ "user_id": "%s" }`, test.userID))), aliceID)
+		rr := httptest.NewRecorder()
+		directMessagesHandler.AddConversationParticipant(rr, req, groupID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	direct := s.newTestConversation(aliceID, bobID)
+	req = withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/leave", nil), aliceID)
+	rr = httptest.NewRecorder()
+	directMessagesHandler.LeaveConversation(rr, req, direct.ID.String())
+	if rr.Code != http.StatusBadRequest {
+		s.T().Errorf("leave one-to-one conversation: expected %d, but got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func (s *HandlersTestSuite) TestDirectMessageReactions() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	carolID := s.newTestUser(`{ "username": "carol", "display_name": "carol", "password": "securepassword" }`)
+	conversationID := s.newTestConversation(aliceID, bobID).ID.String()
+	s.newTestDirectMessage(aliceID, conversationID, "hello")
+	messageID := s.getTestDirectMessages(aliceID, conversationID)[0].ID.String()
+
+	directMessagesHandler := NewDirectMessagesHandler(s.db, &s.mu, &s.messageChannels)
+
+	reactTests := []struct {
+		name         string
+		viewerID     string
+		emoji        string
+		expectedCode int
+	}{
+		{name: "invalid emoji", viewerID: bobID, emoji: "ok", expectedCode: http.StatusBadRequest},
+		{name: "non-participant", viewerID: carolID, emoji: "🔥", expectedCode: http.StatusNotFound},
+		{name: "new reaction", viewerID: bobID, emoji: "🔥", expectedCode: http.StatusCreated},
+		{name: "existing reaction", viewerID: bobID, emoji: "🔥", expectedCode: http.StatusOK},
+		{name: "same emoji by another user", viewerID: aliceID, emoji: "🔥", expectedCode: http.StatusCreated},
+		{name: "another emoji", viewerID: aliceID, emoji: "😂", expectedCode: http.StatusCreated},
+	}
+
+	for _, test := range reactTests {
+		req := withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/messages/{messageID}/reactions", strings.NewReader(fmt.Sprintf(`{ "emoji": "%s" }`, test.emoji))), test.viewerID)
+		rr := httptest.NewRecorder()
+		directMessagesHandler.AddDirectMessageReaction(rr, req, conversationID, messageID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	reactions := s.getTestDirectMessages(bobID, conversationID)[0].Reactions
+	if len(reactions) != 2 || reactions[0].Emoji != "🔥" || len(reactions[0].UserIDs) != 2 ||
+		reactions[0].UserIDs[0].String() != bobID || reactions[1].Emoji != "😂" {
+		s.T().Errorf("wrong reactions returned; got %+v", reactions)
+	}
+
+	req := withViewer(httptest.NewRequest("DELETE", "/api/dm/conversations/{conversationID}/messages/{messageID}/reactions/{emoji}", nil), bobID)
+	rr := httptest.NewRecorder()
+	directMessagesHandler.RemoveDirectMessageReaction(rr, req, conversationID, messageID, "🔥")
+	if rr.Code != http.StatusNoContent {
+		s.T().Errorf("unreact: expected %d, but got %d", http.StatusNoContent, rr.Code)
+	}
+	rr = httptest.NewRecorder()
+	directMessagesHandler.RemoveDirectMessageReaction(rr, req, conversationID, messageID, "🔥")
+	if rr.Code != http.StatusNotFound {
+		s.T().Errorf("unreact again: expected %d, but got %d", http.StatusNotFound, rr.Code)
+	}
+
+	reactions = s.getTestDirectMessages(bobID, conversationID)[0].Reactions
+	if len(reactions) != 2 || len(reactions[0].UserIDs) != 1 || reactions[0].UserIDs[0].String() != aliceID {
+		s.T().Errorf("wrong reactions returned after removal; got %+v", reactions)
+	}
+}
+
+// receiveTestDirectMessageEvent receives the event which must have been delivered to the channel.
+func (s *HandlersTestSuite) receiveTestDirectMessageEvent(messageChan chan *entities.DirectMessageEvent) *entities.DirectMessageEvent {
+	select {
+	case event := <-messageChan:
+		return event
+	default:
+		s.T().Fatalf("expected a direct message event to be delivered")
+		return nil
+	}
+}
+
+func (s *HandlersTestSuite) newTestConversation(userID, participantID string) entities.Conversation {
+	req := withViewer(httptest.NewRequest("POST", "/api/dm/conversations", strings.NewReader(fmt.Sprintf(`{ "participant_id": "%s" }`, participantID))), userID)
+	rr := httptest.NewRecorder()
+
+	directMessagesHandler := NewDirectMessagesHandler(s.db, &s.mu, &s.messageChannels)
+	directMessagesHandler.StartConversation(rr, req)
+	if rr.Code != http.StatusCreated && rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to start a conversation: %d", rr.Code)
+	}
+
+	var conversation entities.Conversation
+	if err := json.NewDecoder(rr.Body).Decode(&conversation); err != nil {
+		s.T().Fatalf("Failed to decode a conversation: %v", err)
+	}
+	return conversation
+}
+
+func (s *HandlersTestSuite) newTestGroupConversation(userID, body string) entities.Conversation {
+	req := withViewer(httptest.NewRequest("POST", "/api/dm/groups", strings.NewReader(body)), userID)
+	rr := httptest.NewRecorder()
+
+	directMessagesHandler := NewDirectMessagesHandler(s.db, &s.mu, &s.messageChannels)
+	directMessagesHandler.CreateGroupConversation(rr, req)
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("Failed to create a group conversation: %d", rr.Code)
+	}
+
+	var conversation entities.Conversation
+	if err := json.NewDecoder(rr.Body).Decode(&conversation); err != nil {
+		s.T().Fatalf("Failed to decode a conversation: %v", err)
+	}
+	return conversation
+}
+
+func (s *HandlersTestSuite) newTestDirectMessage(senderID, conversationID, text string) {
+	req := withViewer(httptest.NewRequest("POST", "/api/dm/conversations/{conversationID}/messages", strings.NewReader(fmt.Sprintf(`{ "text": "%s" }`, text))), senderID)
+	rr := httptest.NewRecorder()
+
+	directMessagesHandler := NewDirectMessagesHandler(s.db, &s.mu, &s.messageChannels)
+	directMessagesHandler.SendDirectMessage(rr, req, conversationID)
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("Failed to send a message: %d", rr.Code)
+	}
+}
+
+func (s *HandlersTestSuite) getTestConversations(userID string) []*entities.Conversation {
+	req := withViewer(httptest.NewRequest("GET", "/api/dm/conversations", nil), userID)
+	rr := httptest.NewRecorder()
+
+	directMessagesHandler := NewDirectMessagesHandler(s.db, &s.mu, &s.messageChannels)
+	directMessagesHandler.GetConversations(rr, req, openapi.GetConversationsParams{})
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get conversations: %d", rr.Code)
+	}
+
+	var res conversationPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		s.T().Fatalf("Failed to decode conversations: %v", err)
+	}
+	return res.Conversations
+}
+
+func (s *HandlersTestSuite) getTestDirectMessages(userID, conversationID string) []*entities.DirectMessage {
+	req := withViewer(httptest.NewRequest("GET", "/api/dm/conversations/{conversationID}/messages", nil), userID)
+	rr := httptest.NewRecorder()
+
+	directMessagesHandler := NewDirectMessagesHandler(s.db, &s.mu, &s.messageChannels)
+	directMessagesHandler.GetDirectMessages(rr, req, conversationID, openapi.GetDirectMessagesParams{})
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get messages: %d", rr.Code)
+	}
+
+	var res directMessagePageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		s.T().Fatalf("Failed to decode messages: %v", err)
+	}
+	return res.Messages
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	openapi "x-clone-backend/gen"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type DraftsHandler struct {
+	draftsUsecase            usecases.DraftsUsecase
+	createPostHandler        CreatePostHandler
+	createQuoteRepostHandler CreateQuoteRepostHandler
+	textConfig               text.Config
+}
+
+func NewDraftsHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}, notificationChans *map[string]chan *entities.Notification, textConfig text.Config) DraftsHandler {
+	return DraftsHandler{
+		draftsUsecase:            usecases.NewDraftsUsecase(infrastructure.NewDraftsRepository(db)),
+		createPostHandler:        NewCreatePostHandler(db, mu, usersChan, listChans, notificationChans, textConfig),
+		createQuoteRepostHandler: NewCreateQuoteRepostHandler(db, mu, usersChan, listChans, notificationChans, textConfig),
+		textConfig:               textConfig,
+	}
+}
+
+// CreateDraft saves a draft of a post or a quote repost for the authenticated user.
+// The text is normalized and must not be longer than that of a post, but can be empty;
+// the rest of the content is validated when the draft is published.
+func (h *DraftsHandler) CreateDraft(w http.ResponseWriter, r *http.Request) {
+	slog.Info("POST /api/drafts was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	draft, ok := h.decodeDraft(w, r, userID)
+	if !ok {
+		return
+	}
+
+	if err := h.draftsUsecase.CreateDraft(draft); err != nil {
+		writeDraftError(w, err, "Could not save a draft.")
+		return
+	}
+
+	writeDraftResponse(w, http.StatusCreated, draft)
+}
+
+// GetDrafts gets the drafts of the authenticated user, the most recently updated first.
+func (h *DraftsHandler) GetDrafts(w http.ResponseWriter, r *http.Request, params openapi.GetDraftsParams) {
+	slog.Info("GET /api/drafts was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.draftsUsecase.GetDrafts(userID, cursor, limit)
+	if err != nil {
+		writeDraftError(w, err, "Could not get the drafts.")
+		return
+	}
+
+	writeDraftResponse(w, http.StatusOK, draftPageResponseBody{
+		Drafts:     page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	})
+}
+
+// GetDraft gets a draft of the authenticated user.
+func (h *DraftsHandler) GetDraft(w http.ResponseWriter, r *http.Request, draftIDStr string) {
+	slog.Info("GET /api/drafts/{draftID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	draftID, ok := parseDraftID(w, draftIDStr)
+	if !ok {
+		return
+	}
+
+	draft, err := h.draftsUsecase.GetDraft(userID, draftID)
+	if err != nil {
+		writeDraftError(w, err, "Could not get the draft.")
+		return
+	}
+
+	writeDraftResponse(w, http.StatusOK, draft)
+}
+
+// UpdateDraft replaces the content of a draft of the authenticated user,
+// which is validated in the same way as when the draft is created.
+func (h *DraftsHandler) UpdateDraft(w http.ResponseWriter, r *http.Request, draftIDStr string) {
+	slog.Info("PUT /api/drafts/{draftID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	draftID, ok := parseDraftID(w, draftIDStr)
+	if !ok {
+		return
+	}
+
+	draft, ok := h.decodeDraft(w, r, userID)
+	if !ok {
+		return
+	}
+	draft.ID = draftID
+
+	if err := h.draftsUsecase.UpdateDraft(draft); err != nil {
+		writeDraftError(w, err, "Could not update the draft.")
+		return
+	}
+
+	writeDraftResponse(w, http.StatusOK, draft)
+}
+
+// DeleteDraft deletes a draft of the authenticated user.
+func (h *DraftsHandler) DeleteDraft(w http.ResponseWriter, r *http.Request, draftIDStr string) {
+	slog.Info("DELETE /api/drafts/{draftID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	draftID, ok := parseDraftID(w, draftIDStr)
+	if !ok {
+		return
+	}
+
+	if err := h.draftsUsecase.DeleteDraft(userID, draftID); err != nil {
+		writeDraftError(w, err, "Could not delete the draft.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PublishDraft publishes a draft of the authenticated user as a quote repost if it quotes a post,
+// and as a post otherwise, in the same way as CreateQuoteRepost and CreatePost respectively,
+// whose responses are returned. The draft is deleted in the transaction publishing it,
+// so that it is kept if it cannot be published, and published only once by concurrent requests;
+// the ones which lose the race get 404, and 409 is returned if the draft is updated meanwhile.
+func (h *DraftsHandler) PublishDraft(w http.ResponseWriter, r *http.Request, draftIDStr string) {
+	slog.Info("POST /api/drafts/{draftID}/publish was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	draftID, ok := parseDraftID(w, draftIDStr)
+	if !ok {
+		return
+	}
+
+	draft, err := h.draftsUsecase.GetDraft(userID, draftID)
+	if err != nil {
+		writeDraftError(w, err, "Could not get the draft.")
+		return
+	}
+
+	deleteDraft := func(tx *sql.Tx) error {
+		return h.draftsUsecase.DeletePublishedDraft(tx, draft)
+	}
+	if draft.QuotedPostID != nil {
+		h.createQuoteRepostHandler.createQuoteRepost(w, userID, createQuoteRepostRequestBody{
+			PostID:   *draft.QuotedPostID,
+			Text:     draft.Text,
+			MediaIDs: draft.MediaIDs,
+		}, deleteDraft)
+	} else {
+		body := createPostRequestBody{UserID: userID, Text: draft.Text, MediaIDs: draft.MediaIDs}
+		if draft.Poll != nil {
+			body.Poll = &createPollRequestBody{Choices: draft.Poll.Choices, DurationMinutes: draft.Poll.DurationMinutes}
+		}
+		h.createPostHandler.createPost(w, body, deleteDraft)
+	}
+}
+
+// decodeDraft decodes the draft of the user in the request body,
+// and writes 400 unless it is valid.
+func (h *DraftsHandler) decodeDraft(w http.ResponseWriter, r *http.Request, userID uuid.UUID) (*entities.Draft, bool) {
+	var body draftRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return nil, false
+	}
+
+	// The text can be empty as the draft is being written.
+	body.Text, err = validatePostText(h.textConfig, body.Text, true)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Text was invalid: %v\n", err), http.StatusBadRequest)
+		return nil, false
+	}
+
+	draft := &entities.Draft{
+		UserID:       userID,
+		Text:         body.Text,
+		MediaIDs:     body.MediaIDs,
+		QuotedPostID: body.QuotedPostID,
+	}
+	if body.Poll != nil {
+		draft.Poll = &entities.PendingPoll{Choices: body.Poll.Choices, DurationMinutes: body.Poll.DurationMinutes}
+	}
+
+	return draft, true
+}
+
+func parseDraftID(w http.ResponseWriter, draftIDStr string) (uuid.UUID, bool) {
+	draftID, err := uuid.Parse(draftIDStr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a draftID (ID: %s)\n", draftIDStr), http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+
+	return draftID, true
+}
+
+// writeDraftError maps the errors of the drafts usecase to the status codes.
+func writeDraftError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrDraftNotFound):
+		http.Error(w, fmt.Sprintf("%s: %v\n", message, err), http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrInvalidDraft):
+		http.Error(w, fmt.Sprintf("%s: %v\n", message, err), http.StatusBadRequest)
+	case errors.Is(err, domainerrors.ErrDraftChanged):
+		http.Error(w, fmt.Sprintf("%s: %v\n", message, err), http.StatusConflict)
+	default:
+		http.Error(w, fmt.Sprintln(message), http.StatusInternalServerError)
+	}
+}
+
+func writeDraftResponse(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	encoder := json.NewEncoder(w)
+	err := encoder.Encode(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	openapi "x-clone-backend/gen"
+
+	"github.com/google/uuid"
+)
+
+func (s *HandlersTestSuite) TestCreateDraft() {
+	userID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "hello" }`, userID))
+
+	draftsHandler := NewDraftsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "unauthenticated",
+			body:         `{ "text": "hello" }`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "too long text",
+			viewerID:     userID,
+			body:         fmt.Sprintf(`{ "text": "%s" }`, strings.Repeat("a", 281)),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "quote with a poll",
+			viewerID:     userID,
+			body:         fmt.Sprintf(`{ "text": "hello", "quoted_post_id": "%s", "poll": { "choices": ["yes", "no"], "duration_minutes": 60 } }`, postID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "empty text",
+			viewerID:     userID,
+			body:         `{ "text": "" }`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "quote",
+			viewerID:     userID,
+			body:         fmt.Sprintf(`{ "text": "nice", "quoted_post_id": "%s" }`, postID),
+			expectedCode: http.StatusCreated,
+		},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("POST", "/api/drafts", strings.NewReader(test.body)), test.viewerID)
+		rr := httptest.NewRecorder()
+		draftsHandler.CreateDraft(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	drafts := s.getTestDrafts(userID)
+	if len(drafts) != 2 || drafts[0].QuotedPostID == nil || drafts[0].QuotedPostID.String() != postID || drafts[1].Text != "" {
+		s.T().Errorf("wrong drafts returned; got %+v", drafts)
+	}
+}
+
+func (s *HandlersTestSuite) TestUpdateDraft() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	draftID := s.newTestDraft(aliceID, `{ "text": "helo" }`)
+
+	draftsHandler := NewDraftsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		draftID      string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "invalid draft ID",
+			viewerID:     aliceID,
+			draftID:      "invalid",
+			body:         `{ "text": "hello" }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "another user's draft",
+			viewerID:     bobID,
+			draftID:      draftID,
+			body:         `{ "text": "hello" }`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "valid update",
+			viewerID:     aliceID,
+			draftID:      draftID,
+			body:         `{ "text": "hello", "poll": { "choices": ["yes", "no"], "duration_minutes": 60 } }`,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("PUT", "/api/drafts/{draftID}", strings.NewReader(test.body)), test.viewerID)
+		rr := httptest.NewRecorder()
+		draftsHandler.UpdateDraft(rr, req, test.draftID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	req := withViewer(httptest.NewRequest("GET", "/api/drafts/{draftID}", nil), aliceID)
+	rr := httptest.NewRecorder()
+	draftsHandler.GetDraft(rr, req, draftID)
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+
+	var draft entities.Draft
+	if err := json.NewDecoder(rr.Body).Decode(&draft); err != nil {
+		s.T().Fatalf("failed to decode the draft: %v", err)
+	}
+	if draft.Text != "hello" || draft.Poll == nil || len(draft.Poll.Choices) != 2 || !draft.UpdatedAt.After(draft.CreatedAt) {
+		s.T().Errorf("wrong draft returned; got %+v", draft)
+	}
+
+	// Getting the draft does not delete it.
+	if drafts := s.getTestDrafts(aliceID); len(drafts) != 1 || drafts[0].ID.String() != draftID {
+		s.T().Errorf("wrong drafts returned; got %+v", drafts)
+	}
+}
+
+func (s *HandlersTestSuite) TestDeleteDraft() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	draftID := s.newTestDraft(aliceID, `{ "text": "hello" }`)
+
+	draftsHandler := NewDraftsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		expectedCode int
+	}{
+		{name: "another user's draft", viewerID: bobID, expectedCode: http.StatusNotFound},
+		{name: "own draft", viewerID: aliceID, expectedCode: http.StatusNoContent},
+		{name: "already deleted", viewerID: aliceID, expectedCode: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("DELETE", "/api/drafts/{draftID}", nil), test.viewerID)
+		rr := httptest.NewRecorder()
+		draftsHandler.DeleteDraft(rr, req, draftID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestPublishDraft() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "hello" }`, bobID))
+	emptyDraftID := s.newTestDraft(aliceID, `{ "text": "" }`)
+	postDraftID := s.newTestDraft(aliceID, `{ "text": "hello @bob", "poll": { "choices": ["yes", "no"], "duration_minutes": 60 } }`)
+	quoteDraftID := s.newTestDraft(aliceID, fmt.Sprintf(`{ "text": "nice", "quoted_post_id": "%s" }`, postID))
+
+	draftsHandler := NewDraftsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		draftID      string
+		expectedCode int
+	}{
+		{name: "another user's draft", viewerID: bobID, draftID: postDraftID, expectedCode: http.StatusNotFound},
+		{name: "empty text", viewerID: aliceID, draftID: emptyDraftID, expectedCode: http.StatusBadRequest},
+		{name: "post", viewerID: aliceID, draftID: postDraftID, expectedCode: http.StatusCreated},
+		{name: "quote repost", viewerID: aliceID, draftID: quoteDraftID, expectedCode: http.StatusCreated},
+		{name: "already published", viewerID: aliceID, draftID: postDraftID, expectedCode: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("POST", "/api/drafts/{draftID}/publish", nil), test.viewerID)
+		rr := httptest.NewRecorder()
+		draftsHandler.PublishDraft(rr, req, test.draftID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code == http.StatusBadRequest {
+			if _, err := draftsHandler.draftsUsecase.GetDraft(uuid.MustParse(test.viewerID), uuid.MustParse(test.draftID)); err != nil {
+				s.T().Errorf("%s: expected the draft to be kept, but got %v", test.name, err)
+			}
+		}
+		if rr.Code != http.StatusCreated {
+			continue
+		}
+
+		switch test.draftID {
+		case postDraftID:
+			var post entities.Post
+			if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+				s.T().Fatalf("%s: failed to decode the post: %v", test.name, err)
+			}
+			if post.Text != "hello @bob" || post.Poll == nil || len(post.Entities.Mentions) != 1 {
+				s.T().Errorf("%s: wrong post returned; got %+v", test.name, post)
+			}
+		case quoteDraftID:
+			var quote entities.Repost
+			if err := json.NewDecoder(rr.Body).Decode(&quote); err != nil {
+				s.T().Fatalf("%s: failed to decode the quote repost: %v", test.name, err)
+			}
+			if quote.Text != "nice" || quote.ParentID.String() != postID {
+				s.T().Errorf("%s: wrong quote repost returned; got %+v", test.name, quote)
+			}
+		}
+	}
+
+	// Only the draft which could not be published is left.
+	if drafts := s.getTestDrafts(aliceID); len(drafts) != 1 || drafts[0].ID.String() != emptyDraftID {
+		s.T().Errorf("wrong drafts returned; got %+v", drafts)
+	}
+}
+
+func (s *HandlersTestSuite) TestPurgeDrafts() {
+	userID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	oldDraftID := s.newTestDraft(userID, `{ "text": "old" }`)
+	newDraftID := s.newTestDraft(userID, `{ "text": "new" }`)
+
+	// The old draft is moved back in time, as if it had not been updated for long.
+	if _, err := s.db.Exec(`UPDATE drafts SET updated_at = NOW() - INTERVAL '31 days' WHERE id = $1`, oldDraftID); err != nil {
+		s.T().Fatalf("failed to update the draft: %v", err)
+	}
+
+	draftsUsecase := usecases.NewDraftsUsecase(infrastructure.NewDraftsRepository(s.db))
+	n, err := draftsUsecase.PurgeDrafts(time.Now().Add(-entities.DefaultDraftMaxAge))
+	if err != nil || n != 1 {
+		s.T().Fatalf("expected 1 draft to be purged, but got %d: %v", n, err)
+	}
+
+	if drafts := s.getTestDrafts(userID); len(drafts) != 1 || drafts[0].ID.String() != newDraftID {
+		s.T().Errorf("wrong drafts returned; got %+v", drafts)
+	}
+}
+
+func (s *HandlersTestSuite) newTestDraft(userID, body string) string {
+	req := withViewer(httptest.NewRequest("POST", "/api/drafts", strings.NewReader(body)), userID)
+	rr := httptest.NewRecorder()
+
+	draftsHandler := NewDraftsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	draftsHandler.CreateDraft(rr, req)
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("Failed to create a draft: %d", rr.Code)
+	}
+
+	var draft entities.Draft
+	if err := json.NewDecoder(rr.Body).Decode(&draft); err != nil {
+		s.T().Fatalf("Failed to decode the draft: %v", err)
+	}
+	return draft.ID.String()
+}
+
+func (s *HandlersTestSuite) getTestDrafts(userID string) []*entities.Draft {
+	req := withViewer(httptest.NewRequest("GET", "/api/drafts", nil), userID)
+	rr := httptest.NewRecorder()
+
+	draftsHandler := NewDraftsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	draftsHandler.GetDrafts(rr, req, openapi.GetDraftsParams{})
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get the drafts: %d", rr.Code)
+	}
+
+	var body draftPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		s.T().Fatalf("Failed to decode the drafts: %v", err)
+	}
+	return body.Drafts
+}
+
+func (s *HandlersTestSuite) TestPublishDraftConcurrently() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	draftID := s.newTestDraft(aliceID, `{ "text": "hello" }`)
+
+	draftsHandler := NewDraftsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+
+	const requests = 5
+	codes := make(chan int, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := withViewer(httptest.NewRequest("POST", "/api/drafts/{draftID}/publish", nil), aliceID)
+			rr := httptest.NewRecorder()
+			draftsHandler.PublishDraft(rr, req, draftID)
+			codes <- rr.Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	counts := make(map[int]int)
+	for code := range codes {
+		counts[code]++
+	}
+	if counts[http.StatusCreated] != 1 || counts[http.StatusNotFound] != requests-1 {
+		s.T().Errorf("expected the draft to be published once, but got %v", counts)
+	}
+
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM posts WHERE user_id = $1`, aliceID).Scan(&count); err != nil || count != 1 {
+		s.T().Errorf("expected 1 post, but got %d (%v)", count, err)
+	}
+}
+
+func (s *HandlersTestSuite) TestPublishUpdatedDraft() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	draftID := s.newTestDraft(aliceID, `{ "text": "helo" }`)
+
+	draftsHandler := NewDraftsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	draft, err := draftsHandler.draftsUsecase.GetDraft(uuid.MustParse(aliceID), uuid.MustParse(draftID))
+	if err != nil {
+		s.T().Fatalf("failed to get the draft: %v", err)
+	}
+
+	// The draft is updated after it has been read to be published.
+	if _, err := s.db.Exec(`UPDATE drafts SET text = 'hello', updated_at = NOW() + INTERVAL '1 second' WHERE id = $1`, draftID); err != nil {
+		s.T().Fatalf("failed to update the draft: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	draftsHandler.createPostHandler.createPost(rr, createPostRequestBody{UserID: draft.UserID, Text: draft.Text}, func(tx *sql.Tx) error {
+		return draftsHandler.draftsUsecase.DeletePublishedDraft(tx, draft)
+	})
+	if rr.Code != http.StatusConflict {
+		s.T().Errorf("wrong code returned; expected %d, but got %d", http.StatusConflict, rr.Code)
+	}
+
+	// Neither the old version is published nor the updated draft deleted.
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM posts WHERE user_id = $1`, aliceID).Scan(&count); err != nil || count != 0 {
+		s.T().Errorf("expected no posts, but got %d (%v)", count, err)
+	}
+	if drafts := s.getTestDrafts(aliceID); len(drafts) != 1 || drafts[0].Text != "hello" {
+		s.T().Errorf("wrong drafts returned; got %+v", drafts)
+	}
+}
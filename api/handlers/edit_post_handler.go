@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type EditPostHandler struct {
+	editPostUsecase             usecases.EditPostUsecase
+	publishTimelineEventUsecase usecases.PublishTimelineEventUsecase
+	textConfig                  text.Config
+}
+
+func NewEditPostHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}, notificationChans *map[string]chan *entities.Notification, textConfig text.Config, editPolicy entities.PostEditPolicy) EditPostHandler {
+	notificationsRepository := infrastructure.NewNotificationsRepository(db)
+	postsRepository := infrastructure.NewPostsRepository(db)
+	usersRepository := infrastructure.NewUsersRepository(db)
+	notifyUsecase := usecases.NewNotifyUsecase(notificationsRepository, postsRepository, mu, notificationChans)
+	createPostEntitiesUsecase := usecases.NewCreatePostEntitiesUsecase(postsRepository, usersRepository, notifyUsecase)
+	editPostUsecase := usecases.NewEditPostUsecase(postsRepository, usersRepository, createPostEntitiesUsecase, editPolicy)
+	return EditPostHandler{
+		editPostUsecase:             editPostUsecase,
+		publishTimelineEventUsecase: newPublishTimelineEventUsecase(db, mu, usersChan, listChans),
+		textConfig:                  textConfig,
+	}
+}
+
+// EditPost replaces the text of the specified post written by the authenticated user,
+// and pushes the edited post to the timelines of the author, the followers and the lists
+// the author is a member of. The text is validated in the same way as when the post is created.
+func (h *EditPostHandler) EditPost(w http.ResponseWriter, r *http.Request, postIDStr string) {
+	slog.Info("PATCH /api/posts/{postID} was called.")
+
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a postID (ID: %s)\n", postIDStr), http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var body editPostRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err = decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+
+	// Whether the text can be empty depends on the media of the post, which the usecase checks.
+	body.Text, err = validatePostText(h.textConfig, body.Text, true)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Text was invalid: %v\n", err), http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.editPostUsecase.EditPost(userID, postID, body.Text)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrPostNotFound):
+			http.Error(w, fmt.Sprintf("Could not find a post (ID: %s)\n", postID), http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrEmptyPostText):
+			http.Error(w, fmt.Sprintf("Text was invalid: %v\n", err), http.StatusBadRequest)
+		case errors.Is(err, domainerrors.ErrNotPostAuthor),
+			errors.Is(err, domainerrors.ErrPostEditWindowClosed),
+			errors.Is(err, domainerrors.ErrPostEditLimitReached):
+			http.Error(w, fmt.Sprintf("Could not edit the post: %v\n", err), http.StatusForbidden)
+		default:
+			http.Error(w, fmt.Sprintf("Could not edit a post (ID: %s)\n", postID), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	go h.publishTimelineEventUsecase.PublishToTimelines(post.UserID, entities.TimelineEvent{EventType: entities.PostEdited, Posts: []*entities.Post{post}})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(post)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetPostHistory gets the versions of the specified post, the latest first.
+func (h *EditPostHandler) GetPostHistory(w http.ResponseWriter, r *http.Request, postID string) {
+	slog.Info("GET /api/posts/{postID}/history was called.")
+
+	if _, err := uuid.Parse(postID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a postID (ID: %s)\n", postID), http.StatusBadRequest)
+		return
+	}
+
+	versions, err := h.editPostUsecase.GetPostHistory(postID, viewerIDFromContext(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrPostNotFound):
+			http.Error(w, fmt.Sprintf("Could not find a post (ID: %s)\n", postID), http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrBlocked), errors.Is(err, domainerrors.ErrPrivateAccount):
+			http.Error(w, fmt.Sprintf("Not allowed to see the post: %v\n", err), http.StatusForbidden)
+		default:
+			http.Error(w, fmt.Sprintf("Could not get the history of a post (ID: %s)\n", postID), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(postHistoryResponseBody{Versions: versions})
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
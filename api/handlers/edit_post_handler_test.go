@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/domain/entities"
+
+	openapi "x-clone-backend/gen"
+)
+
+func (s *HandlersTestSuite) TestEditPost() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "helo" }`, aliceID))
+	quoteID := s.newTestQuoteRepost(bobID, postID)
+
+	editPostHandler := NewEditPostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig(), entities.DefaultPostEditPolicy())
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		postID       string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "invalid post ID",
+			viewerID:     aliceID,
+			postID:       "invalid",
+			body:         `{ "text": "hello" }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "nonexistent post",
+			viewerID:     aliceID,
+			postID:       "00000000-0000-0000-0000-000000000001",
+			body:         `{ "text": "hello" }`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "another user's post",
+			viewerID:     bobID,
+			postID:       postID,
+			body:         `{ "text": "hello" }`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "empty text without media",
+			viewerID:     aliceID,
+			postID:       postID,
+			body:         `{ "text": "  " }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invisible characters only without media",
+			viewerID:     aliceID,
+			postID:       postID,
+			body:         `{ "text": "\u200b\u3000" }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "too long text",
+			viewerID:     aliceID,
+			postID:       postID,
+			body:         fmt.Sprintf(`{ "text": "%s" }`, strings.Repeat("a", 281)),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "valid edit",
+			viewerID:     aliceID,
+			postID:       postID,
+			body:         `{ "text": "hello @bob" }`,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("PATCH", "/api/posts/{postID}", strings.NewReader(test.body)), test.viewerID)
+		rr := httptest.NewRecorder()
+		editPostHandler.EditPost(rr, req, test.postID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var post entities.Post
+		if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+			s.T().Fatalf("%s: failed to decode the post: %v", test.name, err)
+		}
+		if post.Text != "hello @bob" || post.EditCount != 1 || post.EditedAt == nil || len(post.Entities.Mentions) != 1 {
+			s.T().Errorf("%s: wrong post returned; got %+v", test.name, post)
+		}
+	}
+
+	versions := s.getTestPostHistory(postID)
+	if len(versions) != 2 || versions[0].Version != 1 || versions[0].Text != "hello @bob" || versions[1].Version != 0 || versions[1].Text != "helo" {
+		s.T().Errorf("wrong history returned; got %+v", versions)
+	}
+
+	// The quote made before the edit keeps referencing the original version.
+	newQuoteID := s.newTestQuoteRepost(bobID, postID)
+	quotedVersions := make(map[string]int)
+	for _, quote := range s.getTestPostQuotes(postID) {
+		if quote.QuotedVersion != nil {
+			quotedVersions[quote.ID.String()] = *quote.QuotedVersion
+		}
+	}
+	if quotedVersions[quoteID] != 0 || quotedVersions[newQuoteID] != 1 {
+		s.T().Errorf("wrong quoted versions returned; got %v", quotedVersions)
+	}
+}
+
+func (s *HandlersTestSuite) TestEditPostPolicy() {
+	userID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "hello" }`, userID))
+
+	tests := []struct {
+		name          string
+		policy        entities.PostEditPolicy
+		expectedCodes []int
+	}{
+		{
+			name:          "edits within the limit",
+			policy:        entities.PostEditPolicy{MaxEdits: 2, Window: time.Hour},
+			expectedCodes: []int{http.StatusOK, http.StatusOK, http.StatusForbidden},
+		},
+		{
+			name:          "closed window",
+			policy:        entities.PostEditPolicy{MaxEdits: 5, Window: 0},
+			expectedCodes: []int{http.StatusForbidden},
+		},
+	}
+
+	for _, test := range tests {
+		editPostHandler := NewEditPostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig(), test.policy)
+		for i, expectedCode := range test.expectedCodes {
+			req := withViewer(httptest.NewRequest("PATCH", "/api/posts/{postID}", strings.NewReader(fmt.Sprintf(`{ "text": "edit %d" }`, i))), userID)
+			rr := httptest.NewRecorder()
+			editPostHandler.EditPost(rr, req, postID)
+
+			if rr.Code != expectedCode {
+				s.T().Errorf("%s: edit %d: wrong code returned; expected %d, but got %d", test.name, i, expectedCode, rr.Code)
+			}
+		}
+	}
+
+	if versions := s.getTestPostHistory(postID); len(versions) != 3 {
+		s.T().Errorf("expected 3 versions, but got %d", len(versions))
+	}
+}
+
+func (s *HandlersTestSuite) TestPostEditedIsPublished() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	s.newTestFollow(bobID, aliceID)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "hello" }`, aliceID))
+
+	userChan := make(chan entities.TimelineEvent, 1)
+	s.mu.Lock()
+	s.userChannels[bobID] = userChan
+	s.mu.Unlock()
+
+	editPostHandler := NewEditPostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig(), entities.DefaultPostEditPolicy())
+	req := withViewer(httptest.NewRequest("PATCH", "/api/posts/{postID}", strings.NewReader(`{ "text": "hello, world" }`)), aliceID)
+	rr := httptest.NewRecorder()
+	editPostHandler.EditPost(rr, req, postID)
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+
+	// The event of the new post may still be on its way, so it is skipped.
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case event := <-userChan:
+			if event.EventType != entities.PostEdited {
+				continue
+			}
+			if len(event.Posts) != 1 || event.Posts[0].Text != "hello, world" {
+				s.T().Errorf("unexpected event %+v", event)
+			}
+			return
+		case <-timeout:
+			s.T().Fatalf("expected the edited post to be published")
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestGetPostHistory() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "hello" }`, aliceID))
+	s.newTestBlock(aliceID, bobID)
+
+	versions := s.getTestPostHistory(postID)
+	if len(versions) != 1 || versions[0].Version != 0 || versions[0].Text != "hello" {
+		s.T().Errorf("wrong history of a post never edited; got %+v", versions)
+	}
+
+	editPostHandler := NewEditPostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig(), entities.DefaultPostEditPolicy())
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		postID       string
+		expectedCode int
+	}{
+		{name: "invalid post ID", postID: "invalid", expectedCode: http.StatusBadRequest},
+		{name: "nonexistent post", postID: "00000000-0000-0000-0000-000000000001", expectedCode: http.StatusNotFound},
+		{name: "blocked viewer", viewerID: bobID, postID: postID, expectedCode: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("GET", "/api/posts/{postID}/history", nil), test.viewerID)
+		rr := httptest.NewRecorder()
+		editPostHandler.GetPostHistory(rr, req, test.postID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) getTestPostHistory(postID string) []*entities.PostEdit {
+	req := httptest.NewRequest("GET", "/api/posts/{postID}/history", nil)
+	rr := httptest.NewRecorder()
+
+	editPostHandler := NewEditPostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig(), entities.DefaultPostEditPolicy())
+	editPostHandler.GetPostHistory(rr, req, postID)
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get the history of a post: %d", rr.Code)
+	}
+
+	var body postHistoryResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		s.T().Fatalf("Failed to decode the history of a post: %v", err)
+	}
+	return body.Versions
+}
+
+func (s *HandlersTestSuite) getTestPostQuotes(postID string) []*entities.Repost {
+	req := httptest.NewRequest("GET", "/api/posts/{postID}/quotes", nil)
+	rr := httptest.NewRecorder()
+
+	getPostQuotesHandler := NewGetPostQuotesHandler(s.db)
+	getPostQuotesHandler.GetPostQuotes(rr, req, postID, openapi.GetPostQuotesParams{})
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get the quotes of a post: %d", rr.Code)
+	}
+
+	var body quotePageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		s.T().Fatalf("Failed to decode the quotes of a post: %v", err)
+	}
+	return body.Quotes
+}
@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -15,3 +18,29 @@ func isUniqueViolationError(err error) bool {
 
 	return false
 }
+
+// writeGraphMutationError writes the status code corresponding to an error
+// returned by following, muting or blocking a user.
+func writeGraphMutationError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrUserNotFound):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrSelfAction):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusBadRequest)
+	case errors.Is(err, domainerrors.ErrBlocked):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusForbidden)
+	default:
+		http.Error(w, fmt.Sprintln(message), http.StatusInternalServerError)
+	}
+}
+
+// writeCreatedStatus writes 201 if an idempotent request created a resource,
+// and 200 if the resource already existed.
+func writeCreatedStatus(w http.ResponseWriter, created bool) {
+	if created {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
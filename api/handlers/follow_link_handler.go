@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+type FollowLinkHandler struct {
+	followLinkUsecase usecases.FollowLinkUsecase
+}
+
+func NewFollowLinkHandler(db *sql.DB) FollowLinkHandler {
+	linksRepository := infrastructure.NewLinksRepository(db)
+	followLinkUsecase := usecases.NewFollowLinkUsecase(linksRepository)
+	return FollowLinkHandler{
+		followLinkUsecase,
+	}
+}
+
+// FollowLink redirects to the URL of the short link with the specified code.
+func (h *FollowLinkHandler) FollowLink(w http.ResponseWriter, r *http.Request, code string) {
+	slog.Info("GET /l/{code} was called.")
+
+	url, err := h.followLinkUsecase.FollowLink(code)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrLinkNotFound):
+			http.Error(w, fmt.Sprintf("Could not find link (code: %s)\n", code), http.StatusNotFound)
+		default:
+			http.Error(w, fmt.Sprintf("Could not follow link (code: %s)\n", code), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// The redirect is not cached, so that every click is counted.
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, url, http.StatusFound)
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+// stubUnfurlFetcher returns the cards of the URLs in place of fetching their pages,
+// and fails for the other URLs.
+type stubUnfurlFetcher map[string]*entities.LinkCard
+
+func (f stubUnfurlFetcher) Fetch(_ context.Context, url string) (*entities.LinkCard, error) {
+	card, ok := f[url]
+	if !ok {
+		return nil, errors.New("page not found")
+	}
+	return card, nil
+}
+
+func (s *HandlersTestSuite) TestFollowLink() {
+	userID := s.newTestUser(`{ "username": "linker", "display_name": "linker", "password": "securepassword" }`)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/posts", strings.NewReader(fmt.Sprintf(
+		`{ "user_id": "%s", "text": "read https://example.com/a and https://broken.example.com." }`, userID,
+	)))
+	createPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	createPostHandler.CreatePost(rr, req)
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("failed to create a post; got %d", rr.Code)
+	}
+
+	var post entities.Post
+	if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+		s.T().Fatalf("failed to decode the post")
+	}
+	if len(post.Entities.URLs) != 2 {
+		s.T().Fatalf("expected 2 urls, but got %d", len(post.Entities.URLs))
+	}
+	url := post.Entities.URLs[0]
+	if url.ExpandedURL != "https://example.com/a" || url.DisplayURL != "example.com/a" || url.Start != 5 || url.End != 26 {
+		s.T().Errorf("wrong url returned; got %+v", *url)
+	}
+
+	// Quoting the same URL reuses its short link.
+	rr = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", fmt.Sprintf("/api/users/%s/quote_reposts", userID), strings.NewReader(fmt.Sprintf(
+		`{ "post_id": "%s", "text": "again https://example.com/a" }`, post.ID,
+	)))
+	createQuoteRepostHandler := NewCreateQuoteRepostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	createQuoteRepostHandler.CreateQuoteRepost(rr, req, userID)
+
+	var quote entities.Repost
+	if err := json.NewDecoder(rr.Body).Decode(&quote); err != nil {
+		s.T().Fatalf("failed to decode the quote repost")
+	}
+	if len(quote.Entities.URLs) != 1 || quote.Entities.URLs[0].URL != url.URL {
+		s.T().Errorf("expected the quote repost to have the short link %s, but got %+v", url.URL, quote.Entities.URLs)
+	}
+
+	code := url.URL[len(entities.LinkPathPrefix):]
+	tests := []struct {
+		name             string
+		code             string
+		expectedCode     int
+		expectedLocation string
+	}{
+		{
+			name:             "follow a short link",
+			code:             code,
+			expectedCode:     http.StatusFound,
+			expectedLocation: "https://example.com/a",
+		},
+		{
+			name:             "follow the short link again",
+			code:             code,
+			expectedCode:     http.StatusFound,
+			expectedLocation: "https://example.com/a",
+		},
+		{
+			name:         "unknown code",
+			code:         "unknown",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	followLinkHandler := NewFollowLinkHandler(s.db)
+	for _, test := range tests {
+		rr := httptest.NewRecorder()
+		followLinkHandler.FollowLink(rr, httptest.NewRequest("GET", entities.ShortLinkURL(test.code), nil), test.code)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if location := rr.Header().Get("Location"); location != test.expectedLocation {
+			s.T().Errorf("%s: wrong location; expected %q, but got %q", test.name, test.expectedLocation, location)
+		}
+	}
+
+	var clickCount int
+	if err := s.db.QueryRow(`SELECT click_count FROM links WHERE code = $1`, code).Scan(&clickCount); err != nil || clickCount != 2 {
+		s.T().Errorf("expected 2 clicks, but got %d (%v)", clickCount, err)
+	}
+
+	card := &entities.LinkCard{Title: "Example", Description: "An  example\npage", ImageURL: "https://example.com/a.png"}
+	unfurlLinksUsecase := usecases.NewUnfurlLinksUsecase(
+		infrastructure.NewLinksRepository(s.db),
+		stubUnfurlFetcher{"https://example.com/a": card},
+	)
+	if n, err := unfurlLinksUsecase.UnfurlPendingLinks(); err != nil || n != 2 {
+		s.T().Fatalf("expected 2 links to be unfurled, but got %d (%v)", n, err)
+	}
+
+	rr = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", fmt.Sprintf("/api/posts/%s", post.ID), nil)
+	getPostByIDHandler := NewGetPostByIDHandler(s.db)
+	getPostByIDHandler.GetPostByID(rr, req, post.ID.String())
+
+	var got entities.Post
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		s.T().Fatalf("failed to decode the post")
+	}
+	if len(got.Entities.URLs) != 2 {
+		s.T().Fatalf("expected 2 urls, but got %d", len(got.Entities.URLs))
+	}
+	if got.Entities.URLs[0].URL != url.URL {
+		s.T().Errorf("expected the short link %s, but got %s", url.URL, got.Entities.URLs[0].URL)
+	}
+	expected := entities.LinkCard{Title: "Example", Description: "An example page", ImageURL: "https://example.com/a.png"}
+	if c := got.Entities.URLs[0].Card; c == nil || *c != expected {
+		s.T().Errorf("expected the card %+v, but got %+v", expected, c)
+	}
+	if c := got.Entities.URLs[1].Card; c != nil {
+		s.T().Errorf("expected no card for the broken link, but got %+v", c)
+	}
+
+	var linkCount int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM links`).Scan(&linkCount); err != nil || linkCount != 2 {
+		s.T().Errorf("expected the quote to reuse the short link, but got %d links (%v)", linkCount, err)
+	}
+}
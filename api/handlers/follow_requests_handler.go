@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type FollowRequestsHandler struct {
+	acceptFollowRequestUsecase  usecases.AcceptFollowRequestUsecase
+	declineFollowRequestUsecase usecases.DeclineFollowRequestUsecase
+}
+
+func NewFollowRequestsHandler(db *sql.DB) FollowRequestsHandler {
+	usersRepository := infrastructure.NewUsersRepository(db)
+	return FollowRequestsHandler{
+		acceptFollowRequestUsecase:  usecases.NewAcceptFollowRequestUsecase(usersRepository),
+		declineFollowRequestUsecase: usecases.NewDeclineFollowRequestUsecase(usersRepository),
+	}
+}
+
+// AcceptFollowRequest lets the authenticated user approve a follow request sent to them,
+// which turns it into a followship.
+func (h *FollowRequestsHandler) AcceptFollowRequest(w http.ResponseWriter, r *http.Request, id string, sourceUserID string) {
+	slog.Info("POST /api/users/{id}/follow_requests/{sourceUserID}/accept was called.")
+
+	if !authorizeOwner(w, r, id) {
+		return
+	}
+	if _, err := uuid.Parse(sourceUserID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a sourceUserID (ID: %s)\n", sourceUserID), http.StatusBadRequest)
+		return
+	}
+
+	err := h.acceptFollowRequestUsecase.AcceptFollowRequest(id, sourceUserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrFollowRequestNotFound), errors.Is(err, domainerrors.ErrUserNotFound):
+			http.Error(w, "No follow request found to accept", http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrBlocked):
+			http.Error(w, "Could not accept a follow request between blocked users.", http.StatusForbidden)
+		default:
+			http.Error(w, "Could not accept follow request.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// DeclineFollowRequest lets the authenticated user delete a follow request sent to them
+// without creating a followship.
+func (h *FollowRequestsHandler) DeclineFollowRequest(w http.ResponseWriter, r *http.Request, id string, sourceUserID string) {
+	slog.Info("DELETE /api/users/{id}/follow_requests/{sourceUserID} was called.")
+
+	if !authorizeOwner(w, r, id) {
+		return
+	}
+	if _, err := uuid.Parse(sourceUserID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a sourceUserID (ID: %s)\n", sourceUserID), http.StatusBadRequest)
+		return
+	}
+
+	err := h.declineFollowRequestUsecase.DeclineFollowRequest(id, sourceUserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrFollowRequestNotFound):
+			http.Error(w, "No follow request found to decline", http.StatusNotFound)
+		default:
+			http.Error(w, "Could not delete follow request.", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+type GetBlockedUsersHandler struct {
+	getBlockedUsersUsecase usecases.GetBlockedUsersUsecase
+}
+
+func NewGetBlockedUsersHandler(db *sql.DB) GetBlockedUsersHandler {
+	usersRepository := infrastructure.NewUsersRepository(db)
+	getBlockedUsersUsecase := usecases.NewGetBlockedUsersUsecase(usersRepository)
+	return GetBlockedUsersHandler{
+		getBlockedUsersUsecase,
+	}
+}
+
+// GetBlockedUsers gets users blocked by the specified user, most recent blocks first.
+// Only the user themself is allowed to see the list.
+func (h *GetBlockedUsersHandler) GetBlockedUsers(w http.ResponseWriter, r *http.Request, id string, params openapi.GetBlockedUsersParams) {
+	slog.Info("GET /api/users/{id}/blocking was called.")
+
+	if !authorizeOwner(w, r, id) {
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.getBlockedUsersUsecase.GetBlockedUsers(id, cursor, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not get blocked users."), http.StatusInternalServerError)
+		return
+	}
+
+	res := timestampedUserPageResponseBody{
+		Users:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(res)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
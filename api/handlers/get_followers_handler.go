@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	openapi "x-clone-backend/gen"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type GetFollowersHandler struct {
+	getFollowersUsecase usecases.GetFollowersUsecase
+}
+
+func NewGetFollowersHandler(db *sql.DB) GetFollowersHandler {
+	usersRepository := infrastructure.NewUsersRepository(db)
+	getFollowersUsecase := usecases.NewGetFollowersUsecase(usersRepository)
+	return GetFollowersHandler{
+		getFollowersUsecase,
+	}
+}
+
+// GetFollowers gets users following the specified user, most recent followers first,
+// each annotated with the viewer's relationship to them.
+func (h *GetFollowersHandler) GetFollowers(w http.ResponseWriter, r *http.Request, id string, params openapi.GetFollowersParams) {
+	slog.Info("GET /api/users/{id}/followers was called.")
+
+	if _, err := uuid.Parse(id); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a userID (ID: %s)\n", id), http.StatusBadRequest)
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.getFollowersUsecase.GetFollowers(id, viewerIDFromContext(r), cursor, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrUserNotFound):
+			http.Error(w, fmt.Sprintf("Could not find a user (ID: %s)\n", id), http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrBlocked), errors.Is(err, domainerrors.ErrPrivateAccount):
+			http.Error(w, fmt.Sprintf("Not allowed to see the activities: %v", err), http.StatusForbidden)
+		default:
+			http.Error(w, fmt.Sprintln("Could not get followers."), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := relatedUserPageResponseBody{
+		Users:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(res)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func (s *HandlersTestSuite) TestGetFollowers() {
+	targetID := s.newTestUser(`{ "username": "target", "display_name": "target", "password": "securepassword" }`)
+	followerID := s.newTestUser(`{ "username": "follower", "display_name": "follower", "password": "securepassword" }`)
+	mutualID := s.newTestUser(`{ "username": "mutual", "display_name": "mutual", "password": "securepassword" }`)
+	blockerID := s.newTestUser(`{ "username": "blocker", "display_name": "blocker", "password": "securepassword" }`)
+	viewerID := s.newTestUser(`{ "username": "viewer", "display_name": "viewer", "password": "securepassword" }`)
+	s.newTestFollow(followerID, targetID)
+	s.newTestFollow(mutualID, targetID)
+	s.newTestFollow(blockerID, targetID)
+	s.newTestFollow(viewerID, mutualID)
+	s.newTestBlock(blockerID, viewerID)
+
+	limit := 1
+
+	tests := []struct {
+		name          string
+		userID        string
+		viewerID      string
+		limit         *int
+		expectedCode  int
+		expectedCount int
+		expectNext    bool
+	}{
+		{
+			name:          "anonymous viewer sees all followers",
+			userID:        targetID,
+			expectedCode:  http.StatusOK,
+			expectedCount: 3,
+		},
+		{
+			name:          "blocked viewer does not see the blocker",
+			userID:        targetID,
+			viewerID:      viewerID,
+			expectedCode:  http.StatusOK,
+			expectedCount: 2,
+		},
+		{
+			name:          "paginate followers",
+			userID:        targetID,
+			limit:         &limit,
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+			expectNext:    true,
+		},
+		{
+			name:         "non-existent user id",
+			userID:       uuid.New().String(),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid user id",
+			userID:       "invalid",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/users/%s/followers", test.userID), nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		getFollowersHandler := NewGetFollowersHandler(s.db)
+		getFollowersHandler.GetFollowers(rr, req, test.userID, openapi.GetFollowersParams{Limit: test.limit})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res relatedUserPageResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		if len(res.Users) != test.expectedCount {
+			s.T().Errorf("%s: wrong number of users returned; expected %d, but got %d", test.name, test.expectedCount, len(res.Users))
+		}
+		if (res.NextCursor != "") != test.expectNext {
+			s.T().Errorf("%s: unexpected next cursor %q", test.name, res.NextCursor)
+		}
+		for _, user := range res.Users {
+			if (user.Relationship != nil) != (test.viewerID != "") {
+				s.T().Errorf("%s: unexpected relationship for %s", test.name, user.Username)
+			}
+			if user.Relationship != nil && user.ID.String() == mutualID && !user.Relationship.Following {
+				s.T().Errorf("%s: expected the viewer to follow %s", test.name, user.Username)
+			}
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestGetFollowing() {
+	sourceID := s.newTestUser(`{ "username": "source", "display_name": "source", "password": "securepassword" }`)
+	followeeID := s.newTestUser(`{ "username": "followee", "display_name": "followee", "password": "securepassword" }`)
+	s.newTestFollow(sourceID, followeeID)
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/users/%s/following", sourceID), nil)
+	req = withViewer(req, followeeID)
+	rr := httptest.NewRecorder()
+
+	getFollowingHandler := NewGetFollowingHandler(s.db)
+	getFollowingHandler.GetFollowing(rr, req, sourceID, openapi.GetFollowingParams{})
+
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("wrong code returned; expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+
+	var res relatedUserPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		s.T().Fatalf("failed to decode response")
+	}
+	if len(res.Users) != 1 || res.Users[0].ID.String() != followeeID {
+		s.T().Fatalf("expected only the followee, but got %v", res.Users)
+	}
+	// The viewer is the followee itself, so every flag is false.
+	if r := res.Users[0].Relationship; r == nil || *r != (entities.Relationship{}) {
+		s.T().Errorf("unexpected relationship %+v", res.Users[0].Relationship)
+	}
+}
+
+func (s *HandlersTestSuite) TestGetRelationships() {
+	viewerID := s.newTestUser(`{ "username": "viewer", "display_name": "viewer", "password": "securepassword" }`)
+	followeeID := s.newTestUser(`{ "username": "followee", "display_name": "followee", "password": "securepassword" }`)
+	followerID := s.newTestUser(`{ "username": "follower", "display_name": "follower", "password": "securepassword" }`)
+	privateID := s.newTestUser(`{ "username": "private", "display_name": "private", "password": "securepassword" }`)
+	s.makeTestUserPrivate(privateID)
+	s.newTestFollow(viewerID, followeeID)
+	s.newTestFollow(followerID, viewerID)
+	s.newTestFollow(viewerID, privateID)
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		ids          []string
+		expectedCode int
+	}{
+		{
+			name:         "get relationships",
+			viewerID:     viewerID,
+			ids:          []string{followeeID, followerID, privateID},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "anonymous viewer",
+			ids:          []string{followeeID},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "no ids",
+			viewerID:     viewerID,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid id",
+			viewerID:     viewerID,
+			ids:          []string{"invalid"},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/api/users/relationships?ids="+strings.Join(test.ids, ","), nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		getRelationshipsHandler := NewGetRelationshipsHandler(s.db)
+		getRelationshipsHandler.GetRelationships(rr, req, openapi.GetRelationshipsParams{Ids: test.ids})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res relationshipsResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		if r := res.Relationships[uuid.MustParse(followeeID)]; r == nil || !r.Following || r.FollowedBy {
+			s.T().Errorf("%s: unexpected relationship to the followee %+v", test.name, r)
+		}
+		if r := res.Relationships[uuid.MustParse(followerID)]; r == nil || r.Following || !r.FollowedBy {
+			s.T().Errorf("%s: unexpected relationship to the follower %+v", test.name, r)
+		}
+		if r := res.Relationships[uuid.MustParse(privateID)]; r == nil || r.Following || !r.FollowRequestSent {
+			s.T().Errorf("%s: unexpected relationship to the private user %+v", test.name, r)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestFollowRequest() {
+	requesterID := s.newTestUser(`{ "username": "requester", "display_name": "requester", "password": "securepassword" }`)
+	privateID := s.newTestUser(`{ "username": "private", "display_name": "private", "password": "securepassword" }`)
+	s.makeTestUserPrivate(privateID)
+
+	req := httptest.NewRequest(
+		"POST",
+		"/api/users/{id}/following",
+		strings.NewReader(fmt.Sprintf(`{ "target_user_id": "%s" }`, privateID)),
+	)
+	req.SetPathValue("id", requesterID)
+	req = withViewer(req, requesterID)
+	rr := httptest.NewRecorder()
+	CreateFollowship(rr, req, s.followUserUsecase)
+
+	if rr.Code != http.StatusAccepted {
+		s.T().Fatalf("following a private user: expected %d, but got %d", http.StatusAccepted, rr.Code)
+	}
+
+	followRequestsHandler := NewFollowRequestsHandler(s.db)
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		expectedCode int
+	}{
+		{
+			name:         "unauthenticated",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "accepted by the requester",
+			viewerID:     requesterID,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "accept follow request",
+			viewerID:     privateID,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "accept already accepted request",
+			viewerID:     privateID,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("POST", "/api/users/{id}/follow_requests/{sourceUserID}/accept", nil), test.viewerID)
+		rr := httptest.NewRecorder()
+
+		followRequestsHandler.AcceptFollowRequest(rr, req, privateID, requesterID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	relationships, err := s.usersRepository.GetRelationships(nil, requesterID, []string{privateID})
+	if err != nil {
+		s.T().Fatalf("Failed to get relationships: %v", err)
+	}
+	if r := relationships[uuid.MustParse(privateID)]; r == nil || !r.Following || r.FollowRequestSent {
+		s.T().Errorf("expected the request to turn into a followship, but got %+v", r)
+	}
+}
+
+func (s *HandlersTestSuite) TestDeclineFollowRequest() {
+	requesterID := s.newTestUser(`{ "username": "requester", "display_name": "requester", "password": "securepassword" }`)
+	privateID := s.newTestUser(`{ "username": "private", "display_name": "private", "password": "securepassword" }`)
+	s.makeTestUserPrivate(privateID)
+
+	req := httptest.NewRequest(
+		"POST",
+		"/api/users/{id}/following",
+		strings.NewReader(fmt.Sprintf(`{ "target_user_id": "%s" }`, privateID)),
+	)
+	req.SetPathValue("id", requesterID)
+	req = withViewer(req, requesterID)
+	rr := httptest.NewRecorder()
+	CreateFollowship(rr, req, s.followUserUsecase)
+
+	if rr.Code != http.StatusAccepted {
+		s.T().Fatalf("following a private user: expected %d, but got %d", http.StatusAccepted, rr.Code)
+	}
+
+	followRequestsHandler := NewFollowRequestsHandler(s.db)
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		sourceUserID string
+		expectedCode int
+	}{
+		{name: "declined by the requester", viewerID: requesterID, sourceUserID: requesterID, expectedCode: http.StatusForbidden},
+		{name: "invalid source user ID", viewerID: privateID, sourceUserID: "invalid", expectedCode: http.StatusBadRequest},
+		{name: "decline follow request", viewerID: privateID, sourceUserID: requesterID, expectedCode: http.StatusNoContent},
+		{name: "decline already declined request", viewerID: privateID, sourceUserID: requesterID, expectedCode: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("DELETE", "/api/users/{id}/follow_requests/{sourceUserID}", nil), test.viewerID)
+		rr := httptest.NewRecorder()
+
+		followRequestsHandler.DeclineFollowRequest(rr, req, privateID, test.sourceUserID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	relationships, err := s.usersRepository.GetRelationships(nil, requesterID, []string{privateID})
+	if err != nil {
+		s.T().Fatalf("Failed to get relationships: %v", err)
+	}
+	if r := relationships[uuid.MustParse(privateID)]; r == nil || r.Following || r.FollowRequestSent {
+		s.T().Errorf("expected the request to be deleted without a followship, but got %+v", r)
+	}
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+type GetHashtagPostsHandler struct {
+	getHashtagPostsUsecase usecases.GetHashtagPostsUsecase
+}
+
+func NewGetHashtagPostsHandler(db *sql.DB) GetHashtagPostsHandler {
+	postsRepository := infrastructure.NewPostsRepository(db)
+	getHashtagPostsUsecase := usecases.NewGetHashtagPostsUsecase(postsRepository)
+	return GetHashtagPostsHandler{
+		getHashtagPostsUsecase,
+	}
+}
+
+// GetHashtagPosts gets posts with the specified hashtag, most recent first.
+// The hashtag is matched case-insensitively, and may be given with or without the leading "#".
+func (h *GetHashtagPostsHandler) GetHashtagPosts(w http.ResponseWriter, r *http.Request, tag string, params openapi.GetHashtagPostsParams) {
+	slog.Info("GET /api/hashtags/{tag}/posts was called.")
+
+	tag = strings.TrimLeft(tag, "#＃")
+	if hashtags := entities.ExtractHashtags("#" + tag); len(hashtags) != 1 || hashtags[0].Text != tag {
+		http.Error(w, fmt.Sprintf("Invalid hashtag (tag: %s)\n", tag), http.StatusBadRequest)
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.getHashtagPostsUsecase.GetHashtagPosts(tag, viewerIDFromContext(r), cursor, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not get posts."), http.StatusInternalServerError)
+		return
+	}
+
+	res := postPageResponseBody{
+		Posts:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(res)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/domain/entities"
+)
+
+func (s *HandlersTestSuite) TestCreatePostWithTags() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+
+	req := httptest.NewRequest(
+		"POST",
+		"/api/posts",
+		strings.NewReader(fmt.Sprintf(`{ "user_id": "%s", "text": "#Go is up $GOOG" }`, authorID)),
+	)
+	rr := httptest.NewRecorder()
+	createPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	createPostHandler.CreatePost(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("wrong code returned; expected %d, but got %d", http.StatusCreated, rr.Code)
+	}
+
+	var post entities.Post
+	if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+		s.T().Fatalf("failed to decode response")
+	}
+
+	if hashtags := post.Entities.Hashtags; len(hashtags) != 1 || *hashtags[0] != (entities.Tag{Text: "Go", Start: 0, End: 3}) {
+		s.T().Errorf("unexpected hashtags %v", hashtags)
+	}
+	if cashtags := post.Entities.Cashtags; len(cashtags) != 1 || *cashtags[0] != (entities.Tag{Text: "GOOG", Start: 10, End: 15}) {
+		s.T().Errorf("unexpected cashtags %v", cashtags)
+	}
+}
+
+func (s *HandlersTestSuite) TestGetHashtagPosts() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	privateID := s.newTestUser(`{ "username": "private", "display_name": "private", "password": "securepassword" }`)
+	s.makeTestUserPrivate(privateID)
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "learning #go" }`, authorID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "#GO #go again" }`, authorID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "#go privately" }`, privateID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "#golang" }`, authorID))
+
+	getHashtagPostsHandler := NewGetHashtagPostsHandler(s.db)
+
+	limit := 1
+
+	tests := []struct {
+		name          string
+		tag           string
+		viewerID      string
+		limit         *int
+		expectedCode  int
+		expectedCount int
+		expectNext    bool
+	}{
+		{
+			name:          "anonymous viewer",
+			tag:           "go",
+			expectedCode:  http.StatusOK,
+			expectedCount: 2,
+		},
+		{
+			name:          "private author themself",
+			tag:           "Go",
+			viewerID:      privateID,
+			expectedCode:  http.StatusOK,
+			expectedCount: 3,
+		},
+		{
+			name:          "with the hash sign",
+			tag:           "#go",
+			limit:         &limit,
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+			expectNext:    true,
+		},
+		{
+			name:          "unused hashtag",
+			tag:           "rust",
+			expectedCode:  http.StatusOK,
+			expectedCount: 0,
+		},
+		{
+			name:         "invalid hashtag",
+			tag:          "123",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/api/hashtags/{tag}/posts", nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		getHashtagPostsHandler.GetHashtagPosts(rr, req, test.tag, openapi.GetHashtagPostsParams{Limit: test.limit})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res postPageResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		if len(res.Posts) != test.expectedCount {
+			s.T().Errorf("%s: wrong number of posts returned; expected %d, but got %d", test.name, test.expectedCount, len(res.Posts))
+		}
+		if (res.NextCursor != "") != test.expectNext {
+			s.T().Errorf("%s: unexpected next cursor %q", test.name, res.NextCursor)
+		}
+		for _, post := range res.Posts {
+			if len(post.Entities.Hashtags) == 0 {
+				s.T().Errorf("%s: expected the hashtags of %q", test.name, post.Text)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	openapi "x-clone-backend/gen"
+
+	"github.com/google/uuid"
+)
+
+func (s *HandlersTestSuite) TestGetLikedPosts() {
+	likerID := s.newTestUser(`{ "username": "liker", "display_name": "liker", "password": "securepassword" }`)
+	privateLikerID := s.newTestUser(`{ "username": "private", "display_name": "private", "password": "securepassword" }`)
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	olderPostID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "older" }`, authorID))
+	newerPostID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "newer" }`, authorID))
+	// Like the newer post first so that the order follows the likes rather than the posts.
+	s.newTestLike(likerID, newerPostID)
+	s.newTestLike(likerID, olderPostID)
+	s.newTestLike(privateLikerID, olderPostID)
+	s.makeTestUserPrivate(privateLikerID)
+
+	tests := []struct {
+		name          string
+		userID        string
+		viewerID      string
+		expectedCode  int
+		expectedPosts []string
+	}{
+		{
+			name:          "get liked posts in reverse chronological order of the likes",
+			userID:        likerID,
+			expectedCode:  http.StatusOK,
+			expectedPosts: []string{olderPostID, newerPostID},
+		},
+		{
+			name:          "private user sees their own likes",
+			userID:        privateLikerID,
+			viewerID:      privateLikerID,
+			expectedCode:  http.StatusOK,
+			expectedPosts: []string{olderPostID},
+		},
+		{
+			name:         "other users cannot see likes of a private user",
+			userID:       privateLikerID,
+			viewerID:     likerID,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "non-existent user id",
+			userID:       uuid.New().String(),
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/users/%s/likes", test.userID), nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		getLikedPostsHandler := NewGetLikedPostsHandler(s.db)
+		getLikedPostsHandler.GetLikedPosts(rr, req, test.userID, openapi.GetLikedPostsParams{})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res postPageResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		if len(res.Posts) != len(test.expectedPosts) {
+			s.T().Errorf("%s: wrong number of posts returned; expected %d, but got %d", test.name, len(test.expectedPosts), len(res.Posts))
+			continue
+		}
+		for i, post := range res.Posts {
+			if post.ID.String() != test.expectedPosts[i] {
+				s.T().Errorf("%s: wrong post at %d; expected %s, but got %s", test.name, i, test.expectedPosts[i], post.ID)
+			}
+		}
+	}
+}
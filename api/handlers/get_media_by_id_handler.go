@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type GetMediaByIDHandler struct {
+	getMediaUsecase usecases.GetMediaUsecase
+}
+
+func NewGetMediaByIDHandler(db *sql.DB) GetMediaByIDHandler {
+	mediaRepository := infrastructure.NewMediaRepository(db)
+	getMediaUsecase := usecases.NewGetMediaUsecase(mediaRepository)
+	return GetMediaByIDHandler{
+		getMediaUsecase,
+	}
+}
+
+// GetMediaByID gets the media with the specified ID.
+func (h *GetMediaByIDHandler) GetMediaByID(w http.ResponseWriter, r *http.Request, mediaID string) {
+	slog.Info("GET /api/media/{mediaID} was called.")
+
+	if _, err := uuid.Parse(mediaID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a mediaID (ID: %s)\n", mediaID), http.StatusBadRequest)
+		return
+	}
+
+	media, err := h.getMediaUsecase.GetMedia(mediaID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrMediaNotFound):
+			http.Error(w, fmt.Sprintf("Could not find media (ID: %s)\n", mediaID), http.StatusNotFound)
+		default:
+			http.Error(w, fmt.Sprintf("Could not get media (ID: %s)\n", mediaID), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(media)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
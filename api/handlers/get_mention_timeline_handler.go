@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+type GetMentionTimelineHandler struct {
+	getMentionTimelineUsecase usecases.GetMentionTimelineUsecase
+}
+
+func NewGetMentionTimelineHandler(db *sql.DB) GetMentionTimelineHandler {
+	postsRepository := infrastructure.NewPostsRepository(db)
+	getMentionTimelineUsecase := usecases.NewGetMentionTimelineUsecase(postsRepository)
+	return GetMentionTimelineHandler{
+		getMentionTimelineUsecase,
+	}
+}
+
+// GetMentionTimeline gets posts and quote reposts mentioning the specified user,
+// most recent mentions first. Only the user themself is allowed to see the timeline.
+func (h *GetMentionTimelineHandler) GetMentionTimeline(w http.ResponseWriter, r *http.Request, id string, params openapi.GetMentionTimelineParams) {
+	slog.Info("GET /api/users/{id}/mentions was called.")
+
+	if !authorizeOwner(w, r, id) {
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.getMentionTimelineUsecase.GetMentionTimeline(id, cursor, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not get mentions."), http.StatusInternalServerError)
+		return
+	}
+
+	res := timelineItemPageResponseBody{
+		Items:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(res)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/domain/entities"
+)
+
+func (s *HandlersTestSuite) TestCreatePostWithMentions() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	mentionedID := s.newTestUser(`{ "username": "mentioned", "display_name": "mentioned", "password": "securepassword" }`)
+
+	req := httptest.NewRequest(
+		"POST",
+		"/api/posts",
+		strings.NewReader(fmt.Sprintf(`{ "user_id": "%s", "text": "hi @Mentioned, @nobody and mail@mentioned" }`, authorID)),
+	)
+	rr := httptest.NewRecorder()
+	createPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	createPostHandler.CreatePost(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("wrong code returned; expected %d, but got %d", http.StatusCreated, rr.Code)
+	}
+
+	var post entities.Post
+	if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+		s.T().Fatalf("failed to decode response")
+	}
+
+	expected := entities.Mention{Username: "mentioned", Start: 3, End: 13}
+	if len(post.Entities.Mentions) != 1 {
+		s.T().Fatalf("expected only the existing user to be mentioned, but got %d mentions", len(post.Entities.Mentions))
+	}
+	mention := post.Entities.Mentions[0]
+	if mention.UserID.String() != mentionedID || mention.Username != expected.Username || mention.Start != expected.Start || mention.End != expected.End {
+		s.T().Errorf("expected a mention like %+v, but got %+v", expected, *mention)
+	}
+
+	res := s.getTestNotifications(mentionedID, nil)
+	if len(res.Notifications) != 1 || res.Notifications[0].Type != entities.NotificationMention {
+		s.T().Fatalf("expected a mention notification, but got %d notifications", len(res.Notifications))
+	}
+	if source := res.Notifications[0].SourceID; source == nil || *source != post.ID {
+		s.T().Errorf("expected the notification to point at the post, but got %v", source)
+	}
+}
+
+func (s *HandlersTestSuite) TestGetMentionTimeline() {
+	mentionedID := s.newTestUser(`{ "username": "mentioned", "display_name": "mentioned", "password": "securepassword" }`)
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	quoterID := s.newTestUser(`{ "username": "quoter", "display_name": "quoter", "password": "securepassword" }`)
+	blockerID := s.newTestUser(`{ "username": "blocker", "display_name": "blocker", "password": "securepassword" }`)
+	s.newTestBlock(blockerID, mentionedID)
+
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "hello @mentioned" }`, authorID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "hello @mentioned" }`, blockerID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "hello @author" }`, authorID))
+
+	req := httptest.NewRequest(
+		"POST",
+		fmt.Sprintf("/api/users/%s/quote_reposts", quoterID),
+		strings.NewReader(fmt.Sprintf(`{ "post_id": "%s", "text": "@mentioned look" }`, postID)),
+	)
+	createQuoteRepostHandler := NewCreateQuoteRepostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	createQuoteRepostHandler.CreateQuoteRepost(httptest.NewRecorder(), req, quoterID)
+
+	getMentionTimelineHandler := NewGetMentionTimelineHandler(s.db)
+
+	limit := 1
+
+	tests := []struct {
+		name          string
+		viewerID      string
+		limit         *int
+		expectedCode  int
+		expectedCount int
+		expectNext    bool
+	}{
+		{
+			name:          "get mentions",
+			viewerID:      mentionedID,
+			expectedCode:  http.StatusOK,
+			expectedCount: 2,
+		},
+		{
+			name:          "paginate mentions",
+			viewerID:      mentionedID,
+			limit:         &limit,
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+			expectNext:    true,
+		},
+		{
+			name:         "another user",
+			viewerID:     authorID,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "anonymous viewer",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/users/%s/mentions", mentionedID), nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		getMentionTimelineHandler.GetMentionTimeline(rr, req, mentionedID, openapi.GetMentionTimelineParams{Limit: test.limit})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res timelineItemPageResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		if len(res.Items) != test.expectedCount {
+			s.T().Errorf("%s: wrong number of items returned; expected %d, but got %d", test.name, test.expectedCount, len(res.Items))
+			continue
+		}
+		if (res.NextCursor != "") != test.expectNext {
+			s.T().Errorf("%s: unexpected next cursor %q", test.name, res.NextCursor)
+		}
+
+		// The quote repost is the most recent mention.
+		quote := res.Items[0].Quote
+		if quote == nil || quote.UserID.String() != quoterID || quote.Author == nil {
+			s.T().Errorf("%s: expected the quote repost first, but got %+v", test.name, res.Items[0])
+		} else if len(quote.Entities.Mentions) != 1 || quote.Entities.Mentions[0].UserID.String() != mentionedID {
+			s.T().Errorf("%s: unexpected entities of the quote repost %+v", test.name, quote.Entities)
+		}
+		if test.expectedCount == 2 {
+			post := res.Items[1].Post
+			if post == nil || post.ID.String() != postID || len(post.Entities.Mentions) != 1 {
+				s.T().Errorf("%s: expected the author's post second, but got %+v", test.name, res.Items[1])
+			}
+		}
+	}
+}
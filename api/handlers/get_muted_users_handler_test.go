@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	openapi "x-clone-backend/gen"
+)
+
+func (s *HandlersTestSuite) TestGetMutedUsers() {
+	ownerID := s.newTestUser(`{ "username": "owner", "display_name": "owner", "password": "securepassword" }`)
+	otherID := s.newTestUser(`{ "username": "other", "display_name": "other", "password": "securepassword" }`)
+	for i := 0; i < 2; i++ {
+		targetID := s.newTestUser(fmt.Sprintf(`{ "username": "muted%d", "display_name": "muted%d", "password": "securepassword" }`, i, i))
+		req := httptest.NewRequest(
+			"POST",
+			"/api/users/{id}/muting",
+			strings.NewReader(fmt.Sprintf(`{ "target_user_id": "%s" }`, targetID)),
+		)
+		req.SetPathValue("id", ownerID)
+		req = withViewer(req, ownerID)
+		CreateMuting(httptest.NewRecorder(), req, s.muteUserUsecase)
+	}
+
+	limit := 1
+
+	tests := []struct {
+		name          string
+		viewerID      string
+		limit         *int
+		expectedCode  int
+		expectedCount int
+		expectNext    bool
+	}{
+		{
+			name:          "owner sees muted users",
+			viewerID:      ownerID,
+			expectedCode:  http.StatusOK,
+			expectedCount: 2,
+		},
+		{
+			name:          "paginate muted users",
+			viewerID:      ownerID,
+			limit:         &limit,
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+			expectNext:    true,
+		},
+		{
+			name:         "another user",
+			viewerID:     otherID,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "anonymous viewer",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/users/%s/muting", ownerID), nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		getMutedUsersHandler := NewGetMutedUsersHandler(s.db)
+		getMutedUsersHandler.GetMutedUsers(rr, req, ownerID, openapi.GetMutedUsersParams{Limit: test.limit})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res timestampedUserPageResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		if len(res.Users) != test.expectedCount {
+			s.T().Errorf("%s: wrong number of users returned; expected %d, but got %d", test.name, test.expectedCount, len(res.Users))
+		}
+		if (res.NextCursor != "") != test.expectNext {
+			s.T().Errorf("%s: unexpected next cursor %q", test.name, res.NextCursor)
+		}
+		for _, user := range res.Users {
+			if user.CreatedAt.IsZero() {
+				s.T().Errorf("%s: missing created_at for %s", test.name, user.Username)
+			}
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestGetBlockedUsers() {
+	ownerID := s.newTestUser(`{ "username": "owner", "display_name": "owner", "password": "securepassword" }`)
+	blockedID := s.newTestUser(`{ "username": "blocked", "display_name": "blocked", "password": "securepassword" }`)
+	s.newTestBlock(ownerID, blockedID)
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/users/%s/blocking", ownerID), nil)
+	req = withViewer(req, ownerID)
+	rr := httptest.NewRecorder()
+
+	getBlockedUsersHandler := NewGetBlockedUsersHandler(s.db)
+	getBlockedUsersHandler.GetBlockedUsers(rr, req, ownerID, openapi.GetBlockedUsersParams{})
+
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("wrong code returned; expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+
+	var res timestampedUserPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		s.T().Fatalf("failed to decode response")
+	}
+	if len(res.Users) != 1 || res.Users[0].ID.String() != blockedID {
+		s.T().Errorf("expected only the blocked user, but got %v", res.Users)
+	}
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+type GetNotificationsHandler struct {
+	getNotificationsUsecase usecases.GetNotificationsUsecase
+}
+
+func NewGetNotificationsHandler(db *sql.DB) GetNotificationsHandler {
+	notificationsRepository := infrastructure.NewNotificationsRepository(db)
+	getNotificationsUsecase := usecases.NewGetNotificationsUsecase(notificationsRepository)
+	return GetNotificationsHandler{
+		getNotificationsUsecase,
+	}
+}
+
+// GetNotifications gets the authenticated user's grouped notifications, most recent first,
+// with the number of unread ones.
+func (h *GetNotificationsHandler) GetNotifications(w http.ResponseWriter, r *http.Request, params openapi.GetNotificationsParams) {
+	slog.Info("GET /api/notifications was called.")
+
+	viewerID := viewerIDFromContext(r)
+	if viewerID == "" {
+		http.Error(w, "Authentication required.", http.StatusUnauthorized)
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, unreadCount, err := h.getNotificationsUsecase.GetNotifications(viewerID, cursor, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not get notifications."), http.StatusInternalServerError)
+		return
+	}
+
+	res := notificationPageResponseBody{
+		Notifications: page.Items,
+		UnreadCount:   unreadCount,
+		NextCursor:    encodeNextCursor(page.NextCursor),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(res)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func (s *HandlersTestSuite) TestGetNotifications() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	likerID := s.newTestUser(`{ "username": "liker", "display_name": "liker", "password": "securepassword" }`)
+	anotherLikerID := s.newTestUser(`{ "username": "another", "display_name": "another", "password": "securepassword" }`)
+	mutedID := s.newTestUser(`{ "username": "muted", "display_name": "muted", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, authorID))
+
+	req := httptest.NewRequest(
+		"POST",
+		"/api/users/{id}/muting",
+		strings.NewReader(fmt.Sprintf(`{ "target_user_id": "%s" }`, mutedID)),
+	)
+	req.SetPathValue("id", authorID)
+	req = withViewer(req, authorID)
+	CreateMuting(httptest.NewRecorder(), req, s.muteUserUsecase)
+
+	s.newTestLike(authorID, postID)
+	s.newTestLike(likerID, postID)
+	s.newTestLike(anotherLikerID, postID)
+	s.newTestLike(mutedID, postID)
+	s.newTestFollow(likerID, authorID)
+
+	res := s.getTestNotifications(authorID, nil)
+	if len(res.Notifications) != 2 || res.UnreadCount != 2 {
+		s.T().Fatalf("expected 2 unread notifications, but got %d (%d unread)", len(res.Notifications), res.UnreadCount)
+	}
+
+	follow, like := res.Notifications[0], res.Notifications[1]
+	if follow.Type != entities.NotificationFollow || follow.ActorCount != 1 || follow.Actors[0].ID.String() != likerID {
+		s.T().Errorf("unexpected follow notification %+v", follow)
+	}
+	// The author's own like and the muted user's like are left out.
+	if like.Type != entities.NotificationLike || like.ActorCount != 2 || len(like.Actors) != 2 {
+		s.T().Errorf("unexpected like notification %+v", like)
+	}
+	if like.PostID == nil || like.PostID.String() != postID {
+		s.T().Errorf("expected the like notification to point at the post, but got %v", like.PostID)
+	}
+	if like.Actors[0].ID.String() != anotherLikerID {
+		s.T().Errorf("expected the most recent liker first, but got %s", like.Actors[0].Username)
+	}
+
+	limit := 1
+	res = s.getTestNotifications(authorID, &limit)
+	if len(res.Notifications) != 1 || res.NextCursor == "" {
+		s.T().Errorf("expected a page of 1 notification with a next cursor, but got %d and %q", len(res.Notifications), res.NextCursor)
+	}
+
+	// The older likes of the group are not returned again on the next page.
+	req = withViewer(httptest.NewRequest("GET", "/api/notifications", nil), authorID)
+	rr := httptest.NewRecorder()
+	getNotificationsHandler := NewGetNotificationsHandler(s.db)
+	getNotificationsHandler.GetNotifications(rr, req, openapi.GetNotificationsParams{Cursor: &res.NextCursor})
+	var next notificationPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&next); err != nil {
+		s.T().Fatalf("Failed to decode notifications: %v", err)
+	}
+	if len(next.Notifications) != 1 || next.Notifications[0].ID != like.ID || next.Notifications[0].ActorCount != 2 {
+		s.T().Errorf("expected the like group alone on the next page, but got %+v", next.Notifications)
+	}
+
+	markNotificationsAsReadHandler := NewMarkNotificationsAsReadHandler(s.db)
+
+	tests := []struct {
+		name           string
+		viewerID       string
+		notificationID string
+		expectedCode   int
+		expectedUnread int
+	}{
+		{
+			name:           "mark a grouped notification as read",
+			viewerID:       authorID,
+			notificationID: like.ID.String(),
+			expectedCode:   http.StatusNoContent,
+			expectedUnread: 1,
+		},
+		{
+			name:           "mark another user's notification as read",
+			viewerID:       likerID,
+			notificationID: follow.ID.String(),
+			expectedCode:   http.StatusNotFound,
+			expectedUnread: 1,
+		},
+		{
+			name:           "non-existent notification id",
+			viewerID:       authorID,
+			notificationID: uuid.New().String(),
+			expectedCode:   http.StatusNotFound,
+			expectedUnread: 1,
+		},
+		{
+			name:           "invalid notification id",
+			viewerID:       authorID,
+			notificationID: "invalid",
+			expectedCode:   http.StatusBadRequest,
+			expectedUnread: 1,
+		},
+		{
+			name:           "anonymous viewer",
+			notificationID: follow.ID.String(),
+			expectedCode:   http.StatusUnauthorized,
+			expectedUnread: 1,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/api/notifications/%s/read", test.notificationID), nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		markNotificationsAsReadHandler.MarkNotificationAsRead(rr, req, test.notificationID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+		if unread := s.getTestNotifications(authorID, nil).UnreadCount; unread != test.expectedUnread {
+			s.T().Errorf("%s: wrong unread count; expected %d, but got %d", test.name, test.expectedUnread, unread)
+		}
+	}
+
+	req = withViewer(httptest.NewRequest("POST", "/api/notifications/read", nil), authorID)
+	rr = httptest.NewRecorder()
+	markNotificationsAsReadHandler.MarkAllNotificationsAsRead(rr, req)
+	if rr.Code != http.StatusNoContent {
+		s.T().Errorf("mark all notifications as read: expected %d, but got %d", http.StatusNoContent, rr.Code)
+	}
+	if unread := s.getTestNotifications(authorID, nil).UnreadCount; unread != 0 {
+		s.T().Errorf("expected no unread notifications, but got %d", unread)
+	}
+
+	req = httptest.NewRequest("GET", "/api/notifications", nil)
+	rr = httptest.NewRecorder()
+	getNotificationsHandler.GetNotifications(rr, req, openapi.GetNotificationsParams{})
+	if rr.Code != http.StatusUnauthorized {
+		s.T().Errorf("anonymous viewer: expected %d, but got %d", http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func (s *HandlersTestSuite) TestNotificationsAreDelivered() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	reposterID := s.newTestUser(`{ "username": "reposter", "display_name": "reposter", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, authorID))
+
+	// Subscribe to the author's notifications as the stream handler does.
+	notificationChan := make(chan *entities.Notification, 1)
+	s.mu.Lock()
+	s.notificationChannels[authorID] = notificationChan
+	s.mu.Unlock()
+
+	repostID := s.newTestRepost(reposterID, postID)
+
+	select {
+	case notification := <-notificationChan:
+		if notification.Type != entities.NotificationRepost || notification.SourceID == nil || notification.SourceID.String() != repostID {
+			s.T().Errorf("unexpected notification %+v", notification)
+		}
+		if len(notification.Actors) != 1 || notification.Actors[0].ID.String() != reposterID {
+			s.T().Errorf("expected the reposter as the actor, but got %v", notification.Actors)
+		}
+	default:
+		s.T().Errorf("expected a notification to be delivered")
+	}
+}
+
+// getTestNotifications gets the notifications of the specified user.
+func (s *HandlersTestSuite) getTestNotifications(userID string, limit *int) notificationPageResponseBody {
+	req := withViewer(httptest.NewRequest("GET", "/api/notifications", nil), userID)
+	rr := httptest.NewRecorder()
+
+	getNotificationsHandler := NewGetNotificationsHandler(s.db)
+	getNotificationsHandler.GetNotifications(rr, req, openapi.GetNotificationsParams{Limit: limit})
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get notifications: %d", rr.Code)
+	}
+
+	var res notificationPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		s.T().Fatalf("Failed to decode notifications: %v", err)
+	}
+	return res
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type GetPostByIDHandler struct {
+	getSpecificPostUsecase usecases.GetSpecificPostUsecase
+}
+
+func NewGetPostByIDHandler(db *sql.DB) GetPostByIDHandler {
+	postsRepository := infrastructure.NewPostsRepository(db)
+	usersRepository := infrastructure.NewUsersRepository(db)
+	getSpecificPostUsecase := usecases.NewGetSpecificPostUsecase(postsRepository, usersRepository)
+	return GetPostByIDHandler{
+		getSpecificPostUsecase,
+	}
+}
+
+// GetPostByID gets a post with the specified ID, together with its author,
+// engagement counts and whether the viewer has liked or reposted it.
+// If the author is private or blocks the viewer, it returns 403.
+func (h *GetPostByIDHandler) GetPostByID(w http.ResponseWriter, r *http.Request, postID string) {
+	slog.Info("GET /api/posts/{postID} was called.")
+
+	if _, err := uuid.Parse(postID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a postID (ID: %s)\n", postID), http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.getSpecificPostUsecase.GetSpecificPost(postID, viewerIDFromContext(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrPostNotFound):
+			http.Error(w, fmt.Sprintf("Could not find a post (ID: %s)\n", postID), http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrBlocked), errors.Is(err, domainerrors.ErrPrivateAccount):
+			http.Error(w, fmt.Sprintf("Not allowed to see the post: %v\n", err), http.StatusForbidden)
+		default:
+			http.Error(w, fmt.Sprintf("Could not get a post (ID: %s)\n", postID), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(post)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
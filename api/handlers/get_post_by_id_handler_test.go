@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func (s *HandlersTestSuite) TestGetPostByID() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	viewerID := s.newTestUser(`{ "username": "viewer", "display_name": "viewer", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, authorID))
+	s.newTestLike(viewerID, postID)
+	_ = s.newTestRepost(viewerID, postID)
+	_ = s.newTestQuoteRepost(authorID, postID)
+
+	blockedID := s.newTestUser(`{ "username": "blocked", "display_name": "blocked", "password": "securepassword" }`)
+	s.newTestBlock(authorID, blockedID)
+	privateAuthorID := s.newTestUser(`{ "username": "private", "display_name": "private", "password": "securepassword" }`)
+	privatePostID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "private post" }`, privateAuthorID))
+	s.makeTestUserPrivate(privateAuthorID)
+
+	tests := []struct {
+		name                 string
+		postID               string
+		viewerID             string
+		expectedCode         int
+		expectedLikeCount    int
+		expectedRepostCount  int
+		expectedQuoteCount   int
+		expectedLikedByMe    bool
+		expectedRepostedByMe bool
+	}{
+		{
+			name:                 "get a post as a viewer who liked and reposted it",
+			postID:               postID,
+			viewerID:             viewerID,
+			expectedCode:         http.StatusOK,
+			expectedLikeCount:    1,
+			expectedRepostCount:  1,
+			expectedQuoteCount:   1,
+			expectedLikedByMe:    true,
+			expectedRepostedByMe: true,
+		},
+		{
+			name:                 "get a post as the author who only quoted it",
+			postID:               postID,
+			viewerID:             authorID,
+			expectedCode:         http.StatusOK,
+			expectedLikeCount:    1,
+			expectedRepostCount:  1,
+			expectedQuoteCount:   1,
+			expectedLikedByMe:    false,
+			expectedRepostedByMe: false,
+		},
+		{
+			name:                "get a post anonymously",
+			postID:              postID,
+			expectedCode:        http.StatusOK,
+			expectedLikeCount:   1,
+			expectedRepostCount: 1,
+			expectedQuoteCount:  1,
+		},
+		{
+			name:         "get a post as a viewer whom the author blocks",
+			postID:       postID,
+			viewerID:     blockedID,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "get a post of a private author as a non-follower",
+			postID:       privatePostID,
+			viewerID:     viewerID,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "get a post of a private author anonymously",
+			postID:       privatePostID,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "non-existent post id",
+			postID:       uuid.New().String(),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid post id",
+			postID:       "invalid",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/posts/%s", test.postID), nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		getPostByIDHandler := NewGetPostByIDHandler(s.db)
+		getPostByIDHandler.GetPostByID(rr, req, test.postID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var post entities.Post
+		if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		if post.Author == nil || post.Author.Username != "author" {
+			s.T().Errorf("%s: wrong author returned; got %+v", test.name, post.Author)
+		}
+		if post.LikeCount != test.expectedLikeCount {
+			s.T().Errorf("%s: wrong like count; expected %d, but got %d", test.name, test.expectedLikeCount, post.LikeCount)
+		}
+		if post.RepostCount != test.expectedRepostCount {
+			s.T().Errorf("%s: wrong repost count; expected %d, but got %d", test.name, test.expectedRepostCount, post.RepostCount)
+		}
+		if post.QuoteCount != test.expectedQuoteCount {
+			s.T().Errorf("%s: wrong quote count; expected %d, but got %d", test.name, test.expectedQuoteCount, post.QuoteCount)
+		}
+		if post.LikedByMe != test.expectedLikedByMe {
+			s.T().Errorf("%s: wrong liked_by_me; expected %t, but got %t", test.name, test.expectedLikedByMe, post.LikedByMe)
+		}
+		if post.RepostedByMe != test.expectedRepostedByMe {
+			s.T().Errorf("%s: wrong reposted_by_me; expected %t, but got %t", test.name, test.expectedRepostedByMe, post.RepostedByMe)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) getTestPost(viewerID, postID string) entities.Post {
+	req := withViewer(httptest.NewRequest("GET", fmt.Sprintf("/api/posts/%s", postID), nil), viewerID)
+	rr := httptest.NewRecorder()
+
+	getPostByIDHandler := NewGetPostByIDHandler(s.db)
+	getPostByIDHandler.GetPostByID(rr, req, postID)
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get a post: %d", rr.Code)
+	}
+
+	var post entities.Post
+	if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+		s.T().Fatalf("Failed to decode a post: %v", err)
+	}
+	return post
+}
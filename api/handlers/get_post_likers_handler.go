@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	openapi "x-clone-backend/gen"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type GetPostLikersHandler struct {
+	getPostLikersUsecase usecases.GetPostLikersUsecase
+}
+
+func NewGetPostLikersHandler(db *sql.DB) GetPostLikersHandler {
+	postsRepository := infrastructure.NewPostsRepository(db)
+	usersRepository := infrastructure.NewUsersRepository(db)
+	getPostLikersUsecase := usecases.NewGetPostLikersUsecase(postsRepository, usersRepository)
+	return GetPostLikersHandler{
+		getPostLikersUsecase,
+	}
+}
+
+// GetPostLikers gets users who liked the specified post, visible to the viewer.
+func (h *GetPostLikersHandler) GetPostLikers(w http.ResponseWriter, r *http.Request, postID string, params openapi.GetPostLikersParams) {
+	slog.Info("GET /api/posts/{postID}/likes was called.")
+
+	if _, err := uuid.Parse(postID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a postID (ID: %s)\n", postID), http.StatusBadRequest)
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.getPostLikersUsecase.GetPostLikers(postID, viewerIDFromContext(r), cursor, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrPostNotFound):
+			http.Error(w, fmt.Sprintf("Could not find a post (ID: %s)\n", postID), http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrBlocked), errors.Is(err, domainerrors.ErrPrivateAccount):
+			http.Error(w, fmt.Sprintf("Not allowed to see the activities: %v", err), http.StatusForbidden)
+		default:
+			http.Error(w, fmt.Sprintln("Could not get users."), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	res := userSummaryPageResponseBody{
+		Users:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(res)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	openapi "x-clone-backend/gen"
+
+	"github.com/google/uuid"
+)
+
+func (s *HandlersTestSuite) TestGetPostLikers() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	likerID := s.newTestUser(`{ "username": "liker", "display_name": "liker", "password": "securepassword" }`)
+	privateLikerID := s.newTestUser(`{ "username": "private", "display_name": "private", "password": "securepassword" }`)
+	blockerID := s.newTestUser(`{ "username": "blocker", "display_name": "blocker", "password": "securepassword" }`)
+	viewerID := s.newTestUser(`{ "username": "viewer", "display_name": "viewer", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, authorID))
+	s.newTestLike(likerID, postID)
+	s.newTestLike(privateLikerID, postID)
+	s.newTestLike(blockerID, postID)
+	s.makeTestUserPrivate(privateLikerID)
+	s.newTestBlock(blockerID, viewerID)
+
+	limit := 1
+
+	tests := []struct {
+		name          string
+		postID        string
+		viewerID      string
+		limit         *int
+		cursor        *string
+		expectedCode  int
+		expectedCount int
+		expectNext    bool
+	}{
+		{
+			name:          "anonymous viewer sees public likers only",
+			postID:        postID,
+			expectedCode:  http.StatusOK,
+			expectedCount: 2,
+		},
+		{
+			name:          "private liker sees themself",
+			postID:        postID,
+			viewerID:      privateLikerID,
+			expectedCode:  http.StatusOK,
+			expectedCount: 3,
+		},
+		{
+			name:          "blocked viewer does not see the blocker",
+			postID:        postID,
+			viewerID:      viewerID,
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+		},
+		{
+			name:          "paginate likers",
+			postID:        postID,
+			limit:         &limit,
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+			expectNext:    true,
+		},
+		{
+			name:         "invalid limit",
+			postID:       postID,
+			limit:        new(int),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "non-existent post id",
+			postID:       uuid.New().String(),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid post id",
+			postID:       "invalid",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/posts/%s/likes", test.postID), nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		getPostLikersHandler := NewGetPostLikersHandler(s.db)
+		getPostLikersHandler.GetPostLikers(rr, req, test.postID, openapi.GetPostLikersParams{Cursor: test.cursor, Limit: test.limit})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res userSummaryPageResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		if len(res.Users) != test.expectedCount {
+			s.T().Errorf("%s: wrong number of users returned; expected %d, but got %d", test.name, test.expectedCount, len(res.Users))
+		}
+		if (res.NextCursor != "") != test.expectNext {
+			s.T().Errorf("%s: unexpected next cursor %q", test.name, res.NextCursor)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestGetPostLikersFollowingCursor() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, authorID))
+	for i := 0; i < 3; i++ {
+		likerID := s.newTestUser(fmt.Sprintf(`{ "username": "liker%d", "display_name": "liker", "password": "securepassword" }`, i))
+		s.newTestLike(likerID, postID)
+	}
+
+	limit := 2
+	seen := make(map[uuid.UUID]bool)
+	var cursor *string
+	for page := 0; page < 2; page++ {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/posts/%s/likes", postID), nil)
+		rr := httptest.NewRecorder()
+
+		getPostLikersHandler := NewGetPostLikersHandler(s.db)
+		getPostLikersHandler.GetPostLikers(rr, req, postID, openapi.GetPostLikersParams{Cursor: cursor, Limit: &limit})
+
+		var res userSummaryPageResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Fatalf("failed to decode response: %v", err)
+		}
+		for _, user := range res.Users {
+			if seen[user.ID] {
+				s.T().Errorf("user %s returned twice", user.ID)
+			}
+			seen[user.ID] = true
+		}
+		cursor = &res.NextCursor
+	}
+
+	if len(seen) != 3 {
+		s.T().Errorf("wrong number of users returned over pages; expected 3, but got %d", len(seen))
+	}
+}
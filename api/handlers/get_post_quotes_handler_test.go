@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	openapi "x-clone-backend/gen"
+)
+
+func (s *HandlersTestSuite) TestGetPostRepostersAndQuotes() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	reposterID := s.newTestUser(`{ "username": "reposter", "display_name": "reposter", "password": "securepassword" }`)
+	quoterID := s.newTestUser(`{ "username": "quoter", "display_name": "quoter", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, authorID))
+	_ = s.newTestRepost(reposterID, postID)
+	_ = s.newTestQuoteRepost(quoterID, postID)
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/api/posts/%s/reposts", postID), nil)
+	rr := httptest.NewRecorder()
+	getPostRepostersHandler := NewGetPostRepostersHandler(s.db)
+	getPostRepostersHandler.GetPostReposters(rr, req, postID, openapi.GetPostRepostersParams{})
+
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("wrong code returned for reposters; expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+	var reposters userSummaryPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&reposters); err != nil {
+		s.T().Fatalf("failed to decode reposters: %v", err)
+	}
+	if len(reposters.Users) != 1 || reposters.Users[0].ID.String() != reposterID {
+		s.T().Errorf("expected only the reposter, but got %+v", reposters.Users)
+	}
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/api/posts/%s/quotes", postID), nil)
+	rr = httptest.NewRecorder()
+	getPostQuotesHandler := NewGetPostQuotesHandler(s.db)
+	getPostQuotesHandler.GetPostQuotes(rr, req, postID, openapi.GetPostQuotesParams{})
+
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("wrong code returned for quotes; expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+	var quotes quotePageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&quotes); err != nil {
+		s.T().Fatalf("failed to decode quotes: %v", err)
+	}
+	if len(quotes.Quotes) != 1 || quotes.Quotes[0].Author == nil || quotes.Quotes[0].Author.ID.String() != quoterID {
+		s.T().Errorf("expected only the quote by the quoter, but got %+v", quotes.Quotes)
+	}
+}
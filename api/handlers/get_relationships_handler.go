@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+// maxRelationshipIDs is the maximum number of users
+// whose relationships can be looked up at once.
+const maxRelationshipIDs = 100
+
+type GetRelationshipsHandler struct {
+	getRelationshipsUsecase usecases.GetRelationshipsUsecase
+}
+
+func NewGetRelationshipsHandler(db *sql.DB) GetRelationshipsHandler {
+	usersRepository := infrastructure.NewUsersRepository(db)
+	getRelationshipsUsecase := usecases.NewGetRelationshipsUsecase(usersRepository)
+	return GetRelationshipsHandler{
+		getRelationshipsUsecase,
+	}
+}
+
+// GetRelationships gets the authenticated user's relationships to the specified users.
+func (h *GetRelationshipsHandler) GetRelationships(w http.ResponseWriter, r *http.Request, params openapi.GetRelationshipsParams) {
+	slog.Info("GET /api/users/relationships was called.")
+
+	viewerID := viewerIDFromContext(r)
+	if viewerID == "" {
+		http.Error(w, "Authentication required.", http.StatusUnauthorized)
+		return
+	}
+
+	if len(params.Ids) == 0 || len(params.Ids) > maxRelationshipIDs {
+		http.Error(w, fmt.Sprintf("ids must contain between 1 and %d user IDs\n", maxRelationshipIDs), http.StatusBadRequest)
+		return
+	}
+	for _, id := range params.Ids {
+		if _, err := uuid.Parse(id); err != nil {
+			http.Error(w, fmt.Sprintf("Could not parse a userID (ID: %s)\n", id), http.StatusBadRequest)
+			return
+		}
+	}
+
+	relationships, err := h.getRelationshipsUsecase.GetRelationships(viewerID, params.Ids)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not get relationships."), http.StatusInternalServerError)
+		return
+	}
+
+	res := relationshipsResponseBody{
+		Relationships: relationships,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(res)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
@@ -108,7 +108,7 @@ func (s *HandlersTestSuite) TestGetReverseChronologicalHomeTimeline() {
 		}
 		if test.name == "get posts and posts deleted during timeline access" {
 			time.Sleep(100 * time.Millisecond)
-			s.newTestDeletePost(post1ID)
+			s.newTestDeletePost(user1ID, post1ID)
 		}
 		if test.name == "get a target user post and a repost notification during timeline access" {
 			_ = s.newTestRepost(user5ID, post2ID)
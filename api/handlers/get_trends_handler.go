@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+const defaultTrendsLimit = 10
+
+type GetTrendsHandler struct {
+	getTrendsUsecase usecases.GetTrendsUsecase
+}
+
+func NewGetTrendsHandler(db *sql.DB) GetTrendsHandler {
+	trendsRepository := infrastructure.NewTrendsRepository(db)
+	getTrendsUsecase := usecases.NewGetTrendsUsecase(trendsRepository)
+	return GetTrendsHandler{
+		getTrendsUsecase,
+	}
+}
+
+// GetTrends gets the top trending hashtags as seen by the viewer,
+// leaving out the activities of users the viewer mutes or blocks.
+func (h *GetTrendsHandler) GetTrends(w http.ResponseWriter, r *http.Request, params openapi.GetTrendsParams) {
+	slog.Info("GET /api/trends was called.")
+
+	limit := defaultTrendsLimit
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > entities.MaxTrendCandidates {
+			http.Error(w, fmt.Sprintf("limit must be between 1 and %d\n", entities.MaxTrendCandidates), http.StatusBadRequest)
+			return
+		}
+		limit = *params.Limit
+	}
+
+	trends, err := h.getTrendsUsecase.GetTrends(viewerIDFromContext(r), limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not get trends."), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(trendsResponseBody{Trends: trends})
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+func (s *HandlersTestSuite) TestGetTrends() {
+	viewerID := s.newTestUser(`{ "username": "viewer", "display_name": "viewer", "password": "securepassword" }`)
+	var authorIDs, mutedIDs []string
+	for i := 0; i < 3; i++ {
+		authorIDs = append(authorIDs, s.newTestUser(fmt.Sprintf(`{ "username": "author%d", "display_name": "author", "password": "securepassword" }`, i)))
+	}
+	for i := 0; i < 2; i++ {
+		mutedIDs = append(mutedIDs, s.newTestUser(fmt.Sprintf(`{ "username": "muted%d", "display_name": "muted", "password": "securepassword" }`, i)))
+	}
+
+	for _, authorID := range authorIDs {
+		s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "#Rising topic" }`, authorID))
+		s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "#rising_topic" }`, authorID))
+	}
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "#lonely" }`, authorIDs[0]))
+	for _, mutedID := range mutedIDs {
+		s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "#spam" }`, mutedID))
+
+		req := httptest.NewRequest(
+			"POST",
+			"/api/users/{id}/muting",
+			strings.NewReader(fmt.Sprintf(`{ "target_user_id": "%s" }`, mutedID)),
+		)
+		req.SetPathValue("id", viewerID)
+		req = withViewer(req, viewerID)
+		CreateMuting(httptest.NewRecorder(), req, s.muteUserUsecase)
+	}
+
+	computeTrendsUsecase := usecases.NewComputeTrendsUsecase(infrastructure.NewTrendsRepository(s.db))
+	if err := computeTrendsUsecase.ComputeTrends(time.Now().Add(time.Minute)); err != nil {
+		s.T().Fatalf("Failed to compute trends: %v", err)
+	}
+
+	getTrendsHandler := NewGetTrendsHandler(s.db)
+
+	invalidLimit := 0
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		limit        *int
+		expectedCode int
+		expectedTags []string
+	}{
+		{
+			// "#rising_topic" is a near duplicate of "#rising" and "#lonely" has a single author.
+			name:         "anonymous viewer",
+			expectedCode: http.StatusOK,
+			expectedTags: []string{"rising", "spam"},
+		},
+		{
+			name:         "viewer muting the authors of a trend",
+			viewerID:     viewerID,
+			expectedCode: http.StatusOK,
+			expectedTags: []string{"rising"},
+		},
+		{
+			name:         "invalid limit",
+			limit:        &invalidLimit,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/api/trends", nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		getTrendsHandler.GetTrends(rr, req, openapi.GetTrendsParams{Limit: test.limit})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res trendsResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		var tags []string
+		for _, trend := range res.Trends {
+			tags = append(tags, trend.Tag)
+		}
+		if strings.Join(tags, ",") != strings.Join(test.expectedTags, ",") {
+			s.T().Errorf("%s: expected trends %v, but got %v", test.name, test.expectedTags, tags)
+		}
+		if len(res.Trends) > 0 && res.Trends[0].Posts != 3 {
+			s.T().Errorf("%s: expected 3 posts with #rising, but got %d", test.name, res.Trends[0].Posts)
+		}
+	}
+}
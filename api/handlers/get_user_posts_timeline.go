@@ -22,9 +22,10 @@ func NewGetUserPostsTimelineHandler(db *sql.DB) GetUserPostsTimelineHandler {
 
 // GetUserPostsTimeline gets posts by a single user, specified by the requested user ID.
 func (h *GetUserPostsTimelineHandler) GetUserPostsTimeline(w http.ResponseWriter, r *http.Request, id string) {
-	posts, err := h.getSpecificUserPostsUsecase.GetSpecificUserPosts(id)
+	posts, err := h.getSpecificUserPostsUsecase.GetSpecificUserPosts(id, viewerIDFromContext(r))
 	if err != nil {
 		http.Error(w, "Failed to get posts", http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"log/slog"
 	"net/http"
 	"sync"
@@ -17,13 +16,17 @@ import (
 	"github.com/google/uuid"
 )
 
-// DeleteUser deletes a user with the specified user ID.
+// DeleteUser deletes a user with the specified user ID, which must be the authenticated user.
 // If a target user does not exist, it returns 404.
 func DeleteUserByID(w http.ResponseWriter, r *http.Request, u usecases.DeleteUserUsecase) {
 	userID := r.PathValue("userID")
 
 	slog.Info(fmt.Sprintf("DELETE /api/users was called with %s.", userID))
 
+	if !authorizeOwner(w, r, userID) {
+		return
+	}
+
 	err := u.DeleteUser(userID)
 	if err != nil {
 		switch {
@@ -38,18 +41,32 @@ func DeleteUserByID(w http.ResponseWriter, r *http.Request, u usecases.DeleteUse
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// DeletePost deletes a post with the specified post ID.
-// If the post doesn't exist, it returns 404 error.
-func DeletePost(w http.ResponseWriter, r *http.Request, db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent) {
+// DeletePost deletes a post with the specified post ID written by the authenticated user.
+// If the post doesn't exist, it returns 404 error, and 403 if it was written by another user.
+func DeletePost(w http.ResponseWriter, r *http.Request, db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}) {
 	postID := r.PathValue("postID")
 	slog.Info(fmt.Sprintf("DELETE /api/posts was called with %s.", postID))
 
-	query := `DELETE FROM posts WHERE id = $1 RETURNING user_id, text, created_at`
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	query := `DELETE FROM posts WHERE id = $1 AND user_id = $2 RETURNING user_id, text, created_at`
 	var post entities.Post
 
-	err := db.QueryRow(query, postID).Scan(&post.UserID, &post.Text, &post.CreatedAt)
+	err := db.QueryRow(query, postID, userID).Scan(&post.UserID, &post.Text, &post.CreatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			var exists bool
+			if err := db.QueryRow(`SELECT EXISTS (SELECT 1 FROM posts WHERE id = $1)`, postID).Scan(&exists); err != nil {
+				http.Error(w, fmt.Sprintf("Could not delete a post (ID: %s)\n", postID), http.StatusInternalServerError)
+				return
+			}
+			if exists {
+				http.Error(w, fmt.Sprintf("Could not delete a post: %v\n", domainerrors.ErrNotPostAuthor), http.StatusForbidden)
+				return
+			}
 			http.Error(w, fmt.Sprintf("No row found to delete (ID: %s)\n", postID), http.StatusNotFound)
 			return
 		}
@@ -64,42 +81,15 @@ func DeletePost(w http.ResponseWriter, r *http.Request, db *sql.DB, mu *sync.Mut
 		return
 	}
 
-	go func(userID uuid.UUID, userChan *map[string]chan entities.TimelineEvent) {
-		var posts []*entities.Post
-		posts = append(posts, &post)
-		query = `SELECT source_user_id FROM followships WHERE target_user_id=$1`
-		rows, err := db.Query(query, userID.String())
-		if err != nil {
-			log.Fatalln(err)
-			return
-		}
-
-		var ids []uuid.UUID
-		for rows.Next() {
-			var id uuid.UUID
-			if err := rows.Scan(&id); err != nil {
-				log.Fatalln(err)
-				return
-			}
-
-			ids = append(ids, id)
-		}
-		ids = append(ids, userID)
-		for _, id := range ids {
-			mu.Lock()
-			if userChan, ok := (*usersChan)[id.String()]; ok {
-				userChan <- entities.TimelineEvent{EventType: entities.PostDeleted, Posts: posts}
-			}
-			mu.Unlock()
-		}
-
-	}(post.UserID, usersChan)
+	publishTimelineEventUsecase := newPublishTimelineEventUsecase(db, mu, usersChan, listChans)
+	go publishTimelineEventUsecase.PublishToTimelines(post.UserID, entities.TimelineEvent{EventType: entities.PostDeleted, Posts: []*entities.Post{&post}})
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
 // LikePost creates a like with the specified user_id and post_id,
 // then, inserts it into likes table.
+// It returns 201 for a new like and 200 if the post was already liked.
 func LikePost(w http.ResponseWriter, r *http.Request, u usecases.LikePostUsecase) {
 	var body likePostRequestBody
 
@@ -114,13 +104,29 @@ func LikePost(w http.ResponseWriter, r *http.Request, u usecases.LikePostUsecase
 
 	slog.Info(fmt.Sprintf("POST /api/users/{id}/likes was called with %s.", userID))
 
-	err = u.LikePost(userID, body.PostID)
+	if !authorizeOwner(w, r, userID) {
+		return
+	}
+
+	if body.PostID == uuid.Nil {
+		http.Error(w, fmt.Sprintln("post_id is required."), http.StatusBadRequest)
+		return
+	}
+
+	created, err := u.LikePost(userID, body.PostID)
 	if err != nil {
-		http.Error(w, fmt.Sprintln("Could not create a like."), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, domainerrors.ErrUserNotFound), errors.Is(err, domainerrors.ErrPostNotFound):
+			http.Error(w, fmt.Sprintf("Could not create a like: %v", err), http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrBlocked), errors.Is(err, domainerrors.ErrPrivateAccount):
+			http.Error(w, fmt.Sprintf("Could not create a like: %v", err), http.StatusForbidden)
+		default:
+			http.Error(w, fmt.Sprintln("Could not create a like."), http.StatusInternalServerError)
+		}
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	writeCreatedStatus(w, created)
 }
 
 func UnlikePost(w http.ResponseWriter, r *http.Request, u usecases.UnlikePostUsecase) {
@@ -129,6 +135,10 @@ func UnlikePost(w http.ResponseWriter, r *http.Request, u usecases.UnlikePostUse
 
 	slog.Info(fmt.Sprintf("DELETE /api/users/{id}/likes/{post_id} was called with %s and %s.", userID, postID))
 
+	if !authorizeOwner(w, r, userID) {
+		return
+	}
+
 	err := u.UnlikePost(userID, postID)
 	if err != nil {
 		switch {
@@ -143,6 +153,9 @@ func UnlikePost(w http.ResponseWriter, r *http.Request, u usecases.UnlikePostUse
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// CreateFollowship makes the user follow the target user.
+// It returns 201 for a new followship, 200 if the user already follows the target,
+// and 202 if a follow request was sent to a private account.
 func CreateFollowship(w http.ResponseWriter, r *http.Request, u usecases.FollowUserUsecase) {
 	var body createFollowshipRequestBody
 
@@ -154,19 +167,37 @@ func CreateFollowship(w http.ResponseWriter, r *http.Request, u usecases.FollowU
 	}
 
 	sourceUserID := r.PathValue("id")
+	if !authorizeOwner(w, r, sourceUserID) {
+		return
+	}
+	if _, err := uuid.Parse(body.TargetUserID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a target_user_id (ID: %s)\n", body.TargetUserID), http.StatusBadRequest)
+		return
+	}
 
-	err = u.FollowUser(sourceUserID, body.TargetUserID)
+	result, err := u.FollowUser(sourceUserID, body.TargetUserID)
 	if err != nil {
-		http.Error(w, fmt.Sprintln("Could not create followship."), http.StatusInternalServerError)
+		writeGraphMutationError(w, err, "Could not create followship.")
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	switch result {
+	case usecases.FollowRequested:
+		// A follow request to a private account is accepted but not applied yet.
+		w.WriteHeader(http.StatusAccepted)
+	case usecases.AlreadyFollowing:
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusCreated)
+	}
 }
 
 func DeleteFollowship(w http.ResponseWriter, r *http.Request, u usecases.UnfollowUserUsecase) {
 	sourceUserID := r.PathValue("source_user_id")
 	targetUserID := r.PathValue("target_user_id")
+	if !authorizeOwner(w, r, sourceUserID) {
+		return
+	}
 
 	err := u.UnfollowUser(sourceUserID, targetUserID)
 	if err != nil {
@@ -182,6 +213,8 @@ func DeleteFollowship(w http.ResponseWriter, r *http.Request, u usecases.Unfollo
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// CreateMuting makes the user mute the target user.
+// It returns 201 for a new mute and 200 if the target was already muted.
 func CreateMuting(w http.ResponseWriter, r *http.Request, u usecases.MuteUserUsecase) {
 	var body createMutingRequestBody
 
@@ -193,19 +226,29 @@ func CreateMuting(w http.ResponseWriter, r *http.Request, u usecases.MuteUserUse
 	}
 
 	sourceUserID := r.PathValue("id")
+	if !authorizeOwner(w, r, sourceUserID) {
+		return
+	}
+	if _, err := uuid.Parse(body.TargetUserID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a target_user_id (ID: %s)\n", body.TargetUserID), http.StatusBadRequest)
+		return
+	}
 
-	err = u.MuteUser(sourceUserID, body.TargetUserID)
+	created, err := u.MuteUser(sourceUserID, body.TargetUserID)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Could not create muting: %v", err), http.StatusInternalServerError)
+		writeGraphMutationError(w, err, "Could not create muting.")
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	writeCreatedStatus(w, created)
 }
 
 func DeleteMuting(w http.ResponseWriter, r *http.Request, u usecases.UnmuteUserUsecase) {
 	sourceUserID := r.PathValue("source_user_id")
 	targetUserID := r.PathValue("target_user_id")
+	if !authorizeOwner(w, r, sourceUserID) {
+		return
+	}
 
 	err := u.UnmuteUser(sourceUserID, targetUserID)
 	if err != nil {
@@ -221,6 +264,8 @@ func DeleteMuting(w http.ResponseWriter, r *http.Request, u usecases.UnmuteUserU
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// CreateBlocking makes the user block the target user.
+// It returns 201 for a new block and 200 if the target was already blocked.
 func CreateBlocking(w http.ResponseWriter, r *http.Request, u usecases.BlockUserUsecase) {
 	var body createBlockingRequestBody
 
@@ -232,20 +277,30 @@ func CreateBlocking(w http.ResponseWriter, r *http.Request, u usecases.BlockUser
 	}
 
 	sourceUserID := r.PathValue("id")
+	if !authorizeOwner(w, r, sourceUserID) {
+		return
+	}
 	targetUserID := body.TargetUserID
+	if _, err := uuid.Parse(targetUserID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a target_user_id (ID: %s)\n", targetUserID), http.StatusBadRequest)
+		return
+	}
 
-	err = u.BlockUser(sourceUserID, targetUserID)
+	created, err := u.BlockUser(sourceUserID, targetUserID)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Could not create block: %v", err), http.StatusInternalServerError)
+		writeGraphMutationError(w, err, "Could not create block.")
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	writeCreatedStatus(w, created)
 }
 
 func DeleteBlocking(w http.ResponseWriter, r *http.Request, u usecases.UnblockUserUsecase) {
 	sourceUserID := r.PathValue("source_user_id")
 	targetUserID := r.PathValue("target_user_id")
+	if !authorizeOwner(w, r, sourceUserID) {
+		return
+	}
 
 	err := u.UnblockUser(sourceUserID, targetUserID)
 	if err != nil {
@@ -1,39 +1,61 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"x-clone-backend/api/middlewares"
+	"x-clone-backend/internal/app/services"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/app/usecases"
 	"x-clone-backend/internal/domain/entities"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
 
 func (s *HandlersTestSuite) TestDeletePost() {
 	userID := s.newTestUser(`{ "username": "test user", "display_name": "test user", "password": "securepassword" }`)
+	otherUserID := s.newTestUser(`{ "username": "other user", "display_name": "other user", "password": "securepassword" }`)
 	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, userID))
 
 	tests := []struct {
 		name         string
+		userID       string
 		postID       string
 		expectedCode int
 	}{
+		{
+			name:         "fail to delete a post without authentication.",
+			postID:       postID,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "fail to delete a post written by another user.",
+			userID:       otherUserID,
+			postID:       postID,
+			expectedCode: http.StatusForbidden,
+		},
 		{
 			name:         "delete a post successfully with a proper post ID.",
+			userID:       userID,
 			postID:       postID,
 			expectedCode: http.StatusNoContent,
 		},
 		{
 			name:         "fail to delete a post that was already deleted .",
+			userID:       userID,
 			postID:       postID,
 			expectedCode: http.StatusNotFound,
 		},
 		{
 			name:         "fail to delete a post with a non-existent post ID.",
+			userID:       userID,
 			postID:       uuid.New().String(),
 			expectedCode: http.StatusNotFound,
 		},
@@ -43,9 +65,12 @@ func (s *HandlersTestSuite) TestDeletePost() {
 		req := httptest.NewRequest("DELETE", "/api/posts{postID}",
 			nil)
 		req.SetPathValue("postID", test.postID)
+		if test.userID != "" {
+			req = withViewer(req, test.userID)
+		}
 
 		rr := httptest.NewRecorder()
-		DeletePost(rr, req, s.db, &s.mu, &s.userChannels)
+		DeletePost(rr, req, s.db, &s.mu, &s.userChannels, &s.listChannels)
 
 		if rr.Code != test.expectedCode {
 			s.T().Errorf(
@@ -65,7 +90,9 @@ func (s *HandlersTestSuite) TestLikePost() {
 	// for testing purposes to obtain these IDs.
 	authorUserID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
 	likerUserID := s.newTestUser(`{ "username": "liker", "display_name": "liker", "password": "securepassword" }`)
+	blockedUserID := s.newTestUser(`{ "username": "blocked", "display_name": "blocked", "password": "securepassword" }`)
 	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, authorUserID))
+	s.newTestBlock(authorUserID, blockedUserID)
 
 	tests := []struct {
 		name         string
@@ -95,25 +122,31 @@ func (s *HandlersTestSuite) TestLikePost() {
 			name:         "fail to like a post with a invalid JSON field",
 			userID:       likerUserID,
 			body:         `{ "invalid": "test" }`,
-			expectedCode: http.StatusInternalServerError,
+			expectedCode: http.StatusBadRequest,
 		},
 		{
 			name:         "fail to like a post with a pair of non-existent User and proper Post",
 			userID:       uuid.New().String(),
 			body:         fmt.Sprintf(`{ "post_id": "%s" }`, postID),
-			expectedCode: http.StatusInternalServerError,
+			expectedCode: http.StatusNotFound,
 		},
 		{
 			name:         "fail to like a post with a pair of proper User and non-existent Post",
 			userID:       likerUserID,
 			body:         fmt.Sprintf(`{ "post_id": "%s" }`, uuid.New().String()),
-			expectedCode: http.StatusInternalServerError,
+			expectedCode: http.StatusNotFound,
 		},
 		{
-			name:         "fail to like another user's post duplicately with a proper pair of User and Post",
+			name:         "fail to like a post of a user who blocks the liker",
+			userID:       blockedUserID,
+			body:         fmt.Sprintf(`{ "post_id": "%s" }`, postID),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "like another user's post duplicately with a proper pair of User and Post",
 			userID:       likerUserID,
 			body:         fmt.Sprintf(`{ "post_id": "%s" }`, postID),
-			expectedCode: http.StatusInternalServerError,
+			expectedCode: http.StatusOK,
 		},
 	}
 
@@ -124,6 +157,7 @@ func (s *HandlersTestSuite) TestLikePost() {
 			strings.NewReader(test.body),
 		)
 		req.SetPathValue("id", test.userID)
+		req = withViewer(req, test.userID)
 
 		rr := httptest.NewRecorder()
 		LikePost(rr, req, s.likePostUsecase)
@@ -181,6 +215,7 @@ func (s *HandlersTestSuite) TestUnlikePost() {
 			strings.NewReader(""),
 		)
 		req.SetPathValue("id", test.userID)
+		req = withViewer(req, test.userID)
 		req.SetPathValue("post_id", test.postID)
 
 		rr := httptest.NewRecorder()
@@ -223,12 +258,22 @@ func (s *HandlersTestSuite) TestCreateMuting() {
 		{
 			name:         "invalid body",
 			body:         `{ "invalid": "test" }`,
-			expectedCode: http.StatusInternalServerError,
+			expectedCode: http.StatusBadRequest,
 		},
 		{
 			name:         "duplicated muting",
 			body:         `{ "target_user_id": "` + targetUserID + `" }`,
-			expectedCode: http.StatusInternalServerError,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "mute oneself",
+			body:         `{ "target_user_id": "` + sourceUserID + `" }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "mute a non-existent user",
+			body:         `{ "target_user_id": "` + uuid.New().String() + `" }`,
+			expectedCode: http.StatusNotFound,
 		},
 	}
 
@@ -239,6 +284,7 @@ func (s *HandlersTestSuite) TestCreateMuting() {
 			strings.NewReader(test.body),
 		)
 		req.SetPathValue("id", sourceUserID)
+		req = withViewer(req, sourceUserID)
 
 		rr := httptest.NewRecorder()
 		CreateMuting(rr, req, s.muteUserUsecase)
@@ -254,6 +300,349 @@ func (s *HandlersTestSuite) TestCreateMuting() {
 	}
 }
 
+func (s *HandlersTestSuite) TestCreateFollowship() {
+	sourceUserID := s.newTestUser(`{ "username": "source", "display_name": "source", "password": "securepassword" }`)
+	targetUserID := s.newTestUser(`{ "username": "target", "display_name": "target", "password": "securepassword" }`)
+	privateUserID := s.newTestUser(`{ "username": "private", "display_name": "private", "password": "securepassword" }`)
+	blockerUserID := s.newTestUser(`{ "username": "blocker", "display_name": "blocker", "password": "securepassword" }`)
+	s.makeTestUserPrivate(privateUserID)
+	s.newTestBlock(blockerUserID, sourceUserID)
+
+	tests := []struct {
+		name         string
+		userID       string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "create followship",
+			userID:       sourceUserID,
+			body:         `{ "target_user_id": "` + targetUserID + `" }`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "duplicated followship",
+			userID:       sourceUserID,
+			body:         `{ "target_user_id": "` + targetUserID + `" }`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "request to follow a private user",
+			userID:       sourceUserID,
+			body:         `{ "target_user_id": "` + privateUserID + `" }`,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "duplicated follow request",
+			userID:       sourceUserID,
+			body:         `{ "target_user_id": "` + privateUserID + `" }`,
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name:         "follow oneself",
+			userID:       sourceUserID,
+			body:         `{ "target_user_id": "` + sourceUserID + `" }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "follow a user who blocks the source user",
+			userID:       sourceUserID,
+			body:         `{ "target_user_id": "` + blockerUserID + `" }`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "follow a non-existent user",
+			userID:       sourceUserID,
+			body:         `{ "target_user_id": "` + uuid.New().String() + `" }`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "follow by a non-existent user",
+			userID:       uuid.New().String(),
+			body:         `{ "target_user_id": "` + targetUserID + `" }`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid target user id",
+			userID:       sourceUserID,
+			body:         `{ "target_user_id": "invalid" }`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(
+			"POST",
+			"/api/users/{id}/following",
+			strings.NewReader(test.body),
+		)
+		req.SetPathValue("id", test.userID)
+		req = withViewer(req, test.userID)
+
+		rr := httptest.NewRecorder()
+		CreateFollowship(rr, req, s.followUserUsecase)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf(
+				"%s: wrong code returned; expected %d, but got %d",
+				test.name,
+				test.expectedCode,
+				rr.Code,
+			)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestCreateBlocking() {
+	sourceUserID := s.newTestUser(`{ "username": "source", "display_name": "source", "password": "securepassword" }`)
+	targetUserID := s.newTestUser(`{ "username": "target", "display_name": "target", "password": "securepassword" }`)
+	s.newTestFollow(sourceUserID, targetUserID)
+	s.newTestFollow(targetUserID, sourceUserID)
+	if _, err := s.usersRepository.MuteUser(nil, sourceUserID, targetUserID); err != nil {
+		s.T().Fatalf("Failed to mute: %v", err)
+	}
+
+	blockUserUsecase := usecases.NewBlockUserUsecase(s.usersRepository)
+
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "create block",
+			body:         `{ "target_user_id": "` + targetUserID + `" }`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "duplicated block",
+			body:         `{ "target_user_id": "` + targetUserID + `" }`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "block oneself",
+			body:         `{ "target_user_id": "` + sourceUserID + `" }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "block a non-existent user",
+			body:         `{ "target_user_id": "` + uuid.New().String() + `" }`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid JSON body",
+			body:         `{ "target_user_id": "` + targetUserID,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(
+			"POST",
+			"/api/users/{id}/blocking",
+			strings.NewReader(test.body),
+		)
+		req.SetPathValue("id", sourceUserID)
+		req = withViewer(req, sourceUserID)
+
+		rr := httptest.NewRecorder()
+		CreateBlocking(rr, req, blockUserUsecase)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf(
+				"%s: wrong code returned; expected %d, but got %d",
+				test.name,
+				test.expectedCode,
+				rr.Code,
+			)
+		}
+	}
+
+	// Blocking removes the followships in both directions, and keeps the mute.
+	relationships, err := s.usersRepository.GetRelationships(nil, sourceUserID, []string{targetUserID})
+	if err != nil {
+		s.T().Fatalf("Failed to get relationships: %v", err)
+	}
+	if r := relationships[uuid.MustParse(targetUserID)]; r == nil || r.Following || r.FollowedBy || !r.Blocking || !r.Muting {
+		s.T().Errorf("unexpected relationship after blocking %+v", r)
+	}
+}
+
+func (s *HandlersTestSuite) TestAcceptFollowRequestBetweenBlockedUsers() {
+	privateUserID := s.newTestUser(`{ "username": "private", "display_name": "private", "password": "securepassword" }`)
+	requesterUserID := s.newTestUser(`{ "username": "requester", "display_name": "requester", "password": "securepassword" }`)
+	staleRequesterUserID := s.newTestUser(`{ "username": "stale", "display_name": "stale", "password": "securepassword" }`)
+	s.makeTestUserPrivate(privateUserID)
+	s.newTestFollow(requesterUserID, privateUserID)
+	s.newTestBlock(privateUserID, requesterUserID)
+	// A request left over from before blocks removed follow requests.
+	s.newTestBlock(privateUserID, staleRequesterUserID)
+	if _, err := s.usersRepository.RequestFollow(nil, staleRequesterUserID, privateUserID); err != nil {
+		s.T().Fatalf("Failed to request to follow: %v", err)
+	}
+
+	handler := NewFollowRequestsHandler(s.db)
+
+	tests := []struct {
+		name         string
+		sourceUserID string
+		expectedCode int
+	}{
+		{
+			name:         "accept a request removed by blocking",
+			sourceUserID: requesterUserID,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "accept a request from a blocked user",
+			sourceUserID: staleRequesterUserID,
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("POST", "/api/users/{id}/follow_requests/{sourceUserID}/accept", nil)
+		req = withViewer(req, privateUserID)
+
+		rr := httptest.NewRecorder()
+		handler.AcceptFollowRequest(rr, req, privateUserID, test.sourceUserID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf(
+				"%s: wrong code returned; expected %d, but got %d",
+				test.name,
+				test.expectedCode,
+				rr.Code,
+			)
+		}
+	}
+
+	relationships, err := s.usersRepository.GetRelationships(nil, privateUserID, []string{requesterUserID, staleRequesterUserID})
+	if err != nil {
+		s.T().Fatalf("Failed to get relationships: %v", err)
+	}
+	for id, r := range relationships {
+		if r.FollowedBy {
+			s.T().Errorf("unexpected followship from blocked user %s", id)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestUserActionsWithInvalidUserID() {
+	targetUserID := s.newTestUser(`{ "username": "target", "display_name": "target", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, targetUserID))
+
+	tests := []struct {
+		name    string
+		body    string
+		handler http.HandlerFunc
+	}{
+		{
+			name:    "like",
+			body:    `{ "post_id": "` + postID + `" }`,
+			handler: func(w http.ResponseWriter, r *http.Request) { LikePost(w, r, s.likePostUsecase) },
+		},
+		{
+			name:    "follow",
+			body:    `{ "target_user_id": "` + targetUserID + `" }`,
+			handler: func(w http.ResponseWriter, r *http.Request) { CreateFollowship(w, r, s.followUserUsecase) },
+		},
+		{
+			name:    "mute",
+			body:    `{ "target_user_id": "` + targetUserID + `" }`,
+			handler: func(w http.ResponseWriter, r *http.Request) { CreateMuting(w, r, s.muteUserUsecase) },
+		},
+		{
+			name: "block",
+			body: `{ "target_user_id": "` + targetUserID + `" }`,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				CreateBlocking(w, r, usecases.NewBlockUserUsecase(s.usersRepository))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("POST", "/api/users/{id}/"+test.name, strings.NewReader(test.body))
+		req.SetPathValue("id", "invalid")
+
+		rr := httptest.NewRecorder()
+		test.handler(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, http.StatusBadRequest, rr.Code)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestUserActionsAsAnotherUser() {
+	sourceUserID := s.newTestUser(`{ "username": "source", "display_name": "source", "password": "securepassword" }`)
+	otherUserID := s.newTestUser(`{ "username": "other", "display_name": "other", "password": "securepassword" }`)
+	targetUserID := s.newTestUser(`{ "username": "target", "display_name": "target", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, targetUserID))
+
+	handlers := []struct {
+		name    string
+		body    string
+		handler http.HandlerFunc
+	}{
+		{
+			name:    "like",
+			body:    `{ "post_id": "` + postID + `" }`,
+			handler: func(w http.ResponseWriter, r *http.Request) { LikePost(w, r, s.likePostUsecase) },
+		},
+		{
+			name:    "follow",
+			body:    `{ "target_user_id": "` + targetUserID + `" }`,
+			handler: func(w http.ResponseWriter, r *http.Request) { CreateFollowship(w, r, s.followUserUsecase) },
+		},
+		{
+			name:    "mute",
+			body:    `{ "target_user_id": "` + targetUserID + `" }`,
+			handler: func(w http.ResponseWriter, r *http.Request) { CreateMuting(w, r, s.muteUserUsecase) },
+		},
+		{
+			name: "block",
+			body: `{ "target_user_id": "` + targetUserID + `" }`,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				CreateBlocking(w, r, usecases.NewBlockUserUsecase(s.usersRepository))
+			},
+		},
+	}
+
+	viewers := []struct {
+		name         string
+		viewerID     string
+		expectedCode int
+	}{
+		{
+			name:         "without authentication",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "as another user",
+			viewerID:     otherUserID,
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range handlers {
+		for _, viewer := range viewers {
+			req := httptest.NewRequest("POST", "/api/users/{id}/"+test.name, strings.NewReader(test.body))
+			req.SetPathValue("id", sourceUserID)
+			if viewer.viewerID != "" {
+				req = withViewer(req, viewer.viewerID)
+			}
+
+			rr := httptest.NewRecorder()
+			test.handler(rr, req)
+
+			if rr.Code != viewer.expectedCode {
+				s.T().Errorf("%s %s: wrong code returned; expected %d, but got %d", test.name, viewer.name, viewer.expectedCode, rr.Code)
+			}
+		}
+	}
+}
+
 func (s *HandlersTestSuite) newTestRepost(userID, postID string) string {
 	req := httptest.NewRequest(
 		"POST",
@@ -262,7 +651,7 @@ func (s *HandlersTestSuite) newTestRepost(userID, postID string) string {
 	)
 	rr := httptest.NewRecorder()
 
-	createRepostHandler := NewCreateRepostHandler(s.db, &s.mu, &s.userChannels)
+	createRepostHandler := NewCreateRepostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels)
 	createRepostHandler.CreateRepost(rr, req, userID)
 
 	var repost entities.Repost
@@ -280,7 +669,7 @@ func (s *HandlersTestSuite) newTestDeleteRepost(userID string, postID string, re
 
 	rr := httptest.NewRecorder()
 
-	deleteRepostHandler := NewDeleteRepostHandler(s.db, &s.mu, &s.userChannels)
+	deleteRepostHandler := NewDeleteRepostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels)
 	deleteRepostHandler.DeleteRepost(rr, req, userID, postID)
 }
 
@@ -292,7 +681,7 @@ func (s *HandlersTestSuite) newTestQuoteRepost(userID, postID string) string {
 	)
 	rr := httptest.NewRecorder()
 
-	createRepostHandler := NewCreateQuoteRepostHandler(s.db, &s.mu, &s.userChannels)
+	createRepostHandler := NewCreateQuoteRepostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
 	createRepostHandler.CreateQuoteRepost(rr, req, userID)
 
 	var repost entities.Repost
@@ -332,12 +721,13 @@ func (s *HandlersTestSuite) newTestUser(body string) string {
 	return sourceUserID
 }
 
-func (s *HandlersTestSuite) newTestDeletePost(postID string) {
+func (s *HandlersTestSuite) newTestDeletePost(userID, postID string) {
 	req := httptest.NewRequest("DELETE", "/api/posts{postID}", nil)
 	req.SetPathValue("postID", postID)
+	req = withViewer(req, userID)
 
 	rr := httptest.NewRecorder()
-	DeletePost(rr, req, s.db, &s.mu, &s.userChannels)
+	DeletePost(rr, req, s.db, &s.mu, &s.userChannels, &s.listChannels)
 }
 
 func (s *HandlersTestSuite) newTestPost(body string) string {
@@ -348,7 +738,7 @@ func (s *HandlersTestSuite) newTestPost(body string) string {
 	)
 	rr := httptest.NewRecorder()
 
-	createPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels)
+	createPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
 	createPostHandler.CreatePost(rr, req)
 
 	var post entities.Post
@@ -364,6 +754,7 @@ func (s *HandlersTestSuite) newTestLike(userID string, postID string) {
 		strings.NewReader(fmt.Sprintf(`{ "post_id": "%s" }`, postID)),
 	)
 	req.SetPathValue("id", userID)
+	req = withViewer(req, userID)
 
 	rr := httptest.NewRecorder()
 	LikePost(rr, req, s.likePostUsecase)
@@ -376,11 +767,43 @@ func (s *HandlersTestSuite) newTestFollow(sourceUserID string, targetUserID stri
 		strings.NewReader(fmt.Sprintf(`{ "target_user_id": "%s" }`, targetUserID)),
 	)
 	req.SetPathValue("id", sourceUserID)
+	req = withViewer(req, sourceUserID)
 
 	rr := httptest.NewRecorder()
 	CreateFollowship(rr, req, s.followUserUsecase)
 }
 
+func (s *HandlersTestSuite) newTestBlock(sourceUserID string, targetUserID string) {
+	req := httptest.NewRequest(
+		"POST",
+		"/api/users/{id}/blocking",
+		strings.NewReader(fmt.Sprintf(`{ "target_user_id": "%s" }`, targetUserID)),
+	)
+	req.SetPathValue("id", sourceUserID)
+	req = withViewer(req, sourceUserID)
+
+	rr := httptest.NewRecorder()
+	CreateBlocking(rr, req, usecases.NewBlockUserUsecase(s.usersRepository))
+}
+
+// makeTestUserPrivate turns the specified user into a private account.
+func (s *HandlersTestSuite) makeTestUserPrivate(userID string) {
+	_, err := s.db.Exec(`UPDATE users SET is_private = TRUE WHERE id = $1`, userID)
+	if err != nil {
+		s.T().Fatalf("Failed to make a user private: %v", err)
+	}
+}
+
+// withViewer returns a copy of req authenticated as the specified user,
+// as if it had passed through the JWT middleware.
+func withViewer(req *http.Request, userID string) *http.Request {
+	claims := &services.UserClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: userID},
+	}
+	ctx := context.WithValue(req.Context(), middlewares.UserContextKey, claims)
+	return req.WithContext(ctx)
+}
+
 // TestHandlersTestSuite runs all of the tests attached to HandlersTestSuite.
 func TestHandlersTestSuite(t *testing.T) {
 	suite.Run(t, new(HandlersTestSuite))
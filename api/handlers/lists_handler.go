@@ -0,0 +1,450 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"sync"
+	openapi "x-clone-backend/gen"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type ListsHandler struct {
+	mu           *sync.Mutex
+	listChans    *map[string]map[chan entities.TimelineEvent]struct{}
+	listsUsecase usecases.ListsUsecase
+}
+
+func NewListsHandler(db *sql.DB, mu *sync.Mutex, listChans *map[string]map[chan entities.TimelineEvent]struct{}) ListsHandler {
+	listsRepository := infrastructure.NewListsRepository(db)
+	postsRepository := infrastructure.NewPostsRepository(db)
+	usersRepository := infrastructure.NewUsersRepository(db)
+	listsUsecase := usecases.NewListsUsecase(listsRepository, postsRepository, usersRepository)
+	return ListsHandler{
+		mu:           mu,
+		listChans:    listChans,
+		listsUsecase: listsUsecase,
+	}
+}
+
+// CreateList creates a list owned by the authenticated user.
+func (h *ListsHandler) CreateList(w http.ResponseWriter, r *http.Request) {
+	slog.Info("POST /api/lists was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var body createListRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.listsUsecase.CreateList(userID.String(), body.Name, body.Description, body.IsPrivate)
+	if err != nil {
+		writeListError(w, err, "Could not create a list.")
+		return
+	}
+
+	writeListResponse(w, http.StatusCreated, list)
+}
+
+// GetList gets the list, which must be public unless the viewer owns it.
+func (h *ListsHandler) GetList(w http.ResponseWriter, r *http.Request, listID string) {
+	slog.Info("GET /api/lists/{listID} was called.")
+
+	if !validListID(w, listID) {
+		return
+	}
+
+	list, err := h.listsUsecase.GetList(listID, viewerIDFromContext(r))
+	if err != nil {
+		writeListError(w, err, "Could not get the list.")
+		return
+	}
+
+	writeListResponse(w, http.StatusOK, list)
+}
+
+// UpdateList changes the name, the description or the privacy of a list
+// owned by the authenticated user.
+func (h *ListsHandler) UpdateList(w http.ResponseWriter, r *http.Request, listID string) {
+	slog.Info("PATCH /api/lists/{listID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validListID(w, listID) {
+		return
+	}
+
+	var body entities.ListPatch
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.listsUsecase.UpdateList(listID, userID.String(), body)
+	if err != nil {
+		writeListError(w, err, "Could not update the list.")
+		return
+	}
+
+	writeListResponse(w, http.StatusOK, list)
+}
+
+// DeleteList deletes a list owned by the authenticated user.
+func (h *ListsHandler) DeleteList(w http.ResponseWriter, r *http.Request, listID string) {
+	slog.Info("DELETE /api/lists/{listID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validListID(w, listID) {
+		return
+	}
+
+	err := h.listsUsecase.DeleteList(listID, userID.String())
+	if err != nil {
+		writeListError(w, err, "Could not delete the list.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetListMembers gets the members of the list in reverse chronological order of the additions.
+func (h *ListsHandler) GetListMembers(w http.ResponseWriter, r *http.Request, listID string, params openapi.GetListMembersParams) {
+	slog.Info("GET /api/lists/{listID}/members was called.")
+
+	if !validListID(w, listID) {
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.listsUsecase.GetMembers(listID, viewerIDFromContext(r), cursor, limit)
+	if err != nil {
+		writeListError(w, err, "Could not get the members of the list.")
+		return
+	}
+
+	writeListResponse(w, http.StatusOK, userSummaryPageResponseBody{
+		Users:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	})
+}
+
+// AddListMember adds a user to a list owned by the authenticated user.
+// It returns 201 for a new member and 200 if the user was already a member.
+func (h *ListsHandler) AddListMember(w http.ResponseWriter, r *http.Request, listID string) {
+	slog.Info("POST /api/lists/{listID}/members was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validListID(w, listID) {
+		return
+	}
+
+	var body addListMemberRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+	if body.UserID == uuid.Nil {
+		http.Error(w, fmt.Sprintln("user_id is required."), http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.listsUsecase.AddMember(listID, userID.String(), body.UserID.String())
+	if err != nil {
+		writeListError(w, err, "Could not add a member to the list.")
+		return
+	}
+
+	writeCreatedStatus(w, created)
+}
+
+// RemoveListMember removes a user from a list owned by the authenticated user.
+func (h *ListsHandler) RemoveListMember(w http.ResponseWriter, r *http.Request, listID string, memberID string) {
+	slog.Info("DELETE /api/lists/{listID}/members/{userID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validListID(w, listID) {
+		return
+	}
+	if _, err := uuid.Parse(memberID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a userID (ID: %s)\n", memberID), http.StatusBadRequest)
+		return
+	}
+
+	err := h.listsUsecase.RemoveMember(listID, userID.String(), memberID)
+	if err != nil {
+		writeListError(w, err, "Could not remove the member from the list.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SubscribeList subscribes the authenticated user to the list.
+// It returns 201 for a new subscription and 200 if the user already subscribed.
+func (h *ListsHandler) SubscribeList(w http.ResponseWriter, r *http.Request, listID string) {
+	slog.Info("POST /api/lists/{listID}/subscription was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validListID(w, listID) {
+		return
+	}
+
+	created, err := h.listsUsecase.Subscribe(listID, userID.String())
+	if err != nil {
+		writeListError(w, err, "Could not subscribe to the list.")
+		return
+	}
+
+	writeCreatedStatus(w, created)
+}
+
+// UnsubscribeList unsubscribes the authenticated user from the list.
+func (h *ListsHandler) UnsubscribeList(w http.ResponseWriter, r *http.Request, listID string) {
+	slog.Info("DELETE /api/lists/{listID}/subscription was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if !validListID(w, listID) {
+		return
+	}
+
+	err := h.listsUsecase.Unsubscribe(listID, userID.String())
+	if err != nil {
+		writeListError(w, err, "Could not unsubscribe from the list.")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetListTimeline gets the posts, quote reposts and reposts by the members of the list
+// in reverse chronological order, visible to the viewer.
+func (h *ListsHandler) GetListTimeline(w http.ResponseWriter, r *http.Request, listID string, params openapi.GetListTimelineParams) {
+	slog.Info("GET /api/lists/{listID}/timeline was called.")
+
+	if !validListID(w, listID) {
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.listsUsecase.GetTimeline(listID, viewerIDFromContext(r), cursor, limit)
+	if err != nil {
+		writeListError(w, err, "Could not get the timeline of the list.")
+		return
+	}
+
+	writeListResponse(w, http.StatusOK, timelineItemPageResponseBody{
+		Items:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	})
+}
+
+// StreamListTimeline streams the posts and reposts created or deleted by the members of the list
+// as server-sent events, in the same way as the reverse chronological home timeline.
+func (h *ListsHandler) StreamListTimeline(w http.ResponseWriter, r *http.Request, listID string) {
+	slog.Info("GET /api/lists/{listID}/timeline/stream was called.")
+
+	if !validListID(w, listID) {
+		return
+	}
+
+	viewerID := viewerIDFromContext(r)
+	if _, err := h.listsUsecase.GetList(listID, viewerID); err != nil {
+		writeListError(w, err, "Could not stream the timeline of the list.")
+		return
+	}
+
+	// Unlike the home timeline, a list can be streamed by many viewers at once,
+	// so each connection has its own channel.
+	listChan := make(chan entities.TimelineEvent, 1)
+	h.mu.Lock()
+	if _, exists := (*h.listChans)[listID]; !exists {
+		(*h.listChans)[listID] = make(map[chan entities.TimelineEvent]struct{})
+	}
+	(*h.listChans)[listID][listChan] = struct{}{}
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete((*h.listChans)[listID], listChan)
+		if len((*h.listChans)[listID]) == 0 {
+			delete(*h.listChans, listID)
+		}
+		h.mu.Unlock()
+	}()
+
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case event := <-listChan:
+			event, err := h.listsUsecase.FilterTimelineEvent(viewerID, event)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+			if len(event.Posts) == 0 && len(event.Reposts) == 0 {
+				continue
+			}
+
+			jsonData, err := json.Marshal(event)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+
+			fmt.Fprintf(w, "data: %s\n\n", jsonData)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// GetUserLists gets the lists owned by the user, only the public ones unless the viewer is the user.
+func (h *ListsHandler) GetUserLists(w http.ResponseWriter, r *http.Request, id string, params openapi.GetUserListsParams) {
+	slog.Info("GET /api/users/{id}/lists was called.")
+
+	if _, err := uuid.Parse(id); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a userID (ID: %s)\n", id), http.StatusBadRequest)
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.listsUsecase.GetOwnedLists(id, viewerIDFromContext(r), cursor, limit)
+	if err != nil {
+		writeListError(w, err, "Could not get lists.")
+		return
+	}
+
+	writeListResponse(w, http.StatusOK, listPageResponseBody{
+		Lists:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	})
+}
+
+// GetSubscribedLists gets the lists the authenticated user subscribes to
+// in reverse chronological order of the subscriptions.
+func (h *ListsHandler) GetSubscribedLists(w http.ResponseWriter, r *http.Request, id string, params openapi.GetSubscribedListsParams) {
+	slog.Info("GET /api/users/{id}/subscribed_lists was called.")
+
+	if !authorizeOwner(w, r, id) {
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.listsUsecase.GetSubscribedLists(id, cursor, limit)
+	if err != nil {
+		writeListError(w, err, "Could not get subscribed lists.")
+		return
+	}
+
+	writeListResponse(w, http.StatusOK, listPageResponseBody{
+		Lists:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	})
+}
+
+// validListID writes 400 and returns false if the list ID cannot be parsed.
+func validListID(w http.ResponseWriter, listID string) bool {
+	if _, err := uuid.Parse(listID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a listID (ID: %s)\n", listID), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// writeListError writes the status code corresponding to an error
+// returned by the lists usecase.
+func writeListError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrListNotFound),
+		errors.Is(err, domainerrors.ErrListMemberNotFound),
+		errors.Is(err, domainerrors.ErrListSubscriptionNotFound),
+		errors.Is(err, domainerrors.ErrUserNotFound):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrBlocked), errors.Is(err, domainerrors.ErrNotListOwner):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusForbidden)
+	case errors.Is(err, domainerrors.ErrInvalidList), errors.Is(err, domainerrors.ErrOwnListSubscription):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusBadRequest)
+	default:
+		http.Error(w, fmt.Sprintln(message), http.StatusInternalServerError)
+	}
+}
+
+func writeListResponse(w http.ResponseWriter, code int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	encoder := json.NewEncoder(w)
+	err := encoder.Encode(response)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
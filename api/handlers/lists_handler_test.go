@@ -0,0 +1,445 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+func (s *HandlersTestSuite) TestLists() {
+	ownerID := s.newTestUser(`{ "username": "owner", "display_name": "owner", "password": "securepassword" }`)
+	viewerID := s.newTestUser(`{ "username": "viewer", "display_name": "viewer", "password": "securepassword" }`)
+	blockerID := s.newTestUser(`{ "username": "blocker", "display_name": "blocker", "password": "securepassword" }`)
+	s.newTestBlock(blockerID, ownerID)
+
+	listsHandler := NewListsHandler(s.db, &s.mu, &s.listChannels)
+
+	createTests := []struct {
+		name         string
+		viewerID     string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "anonymous viewer",
+			body:         `{ "name": "friends" }`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "blank name",
+			viewerID:     ownerID,
+			body:         `{ "name": "  " }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "too long name",
+			viewerID:     ownerID,
+			body:         fmt.Sprintf(`{ "name": "%s" }`, strings.Repeat("a", entities.MaxListNameLength+1)),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "too long description",
+			viewerID:     ownerID,
+			body:         fmt.Sprintf(`{ "name": "friends", "description": "%s" }`, strings.Repeat("a", entities.MaxListDescriptionLength+1)),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "valid list",
+			viewerID:     ownerID,
+			body:         `{ "name": " friends ", "description": "people I know" }`,
+			expectedCode: http.StatusCreated,
+		},
+	}
+
+	for _, test := range createTests {
+		req := httptest.NewRequest("POST", "/api/lists", strings.NewReader(test.body))
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+		listsHandler.CreateList(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	publicList := s.newTestList(ownerID, `{ "name": "news" }`)
+	if publicList.Name != "news" || publicList.IsPrivate || publicList.Owner == nil || publicList.Owner.ID.String() != ownerID {
+		s.T().Errorf("wrong list returned; got %+v", publicList)
+	}
+	privateList := s.newTestList(ownerID, `{ "name": "secret", "is_private": true }`)
+
+	getTests := []struct {
+		name         string
+		viewerID     string
+		listID       string
+		expectedCode int
+	}{
+		{name: "public list to anonymous viewer", listID: publicList.ID.String(), expectedCode: http.StatusOK},
+		{name: "private list to its owner", viewerID: ownerID, listID: privateList.ID.String(), expectedCode: http.StatusOK},
+		{name: "private list to another user", viewerID: viewerID, listID: privateList.ID.String(), expectedCode: http.StatusNotFound},
+		{name: "list of a blocked owner", viewerID: blockerID, listID: publicList.ID.String(), expectedCode: http.StatusForbidden},
+		{name: "non-existent list", viewerID: viewerID, listID: uuid.New().String(), expectedCode: http.StatusNotFound},
+		{name: "invalid list id", viewerID: viewerID, listID: "invalid", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, test := range getTests {
+		req := httptest.NewRequest("GET", "/api/lists/{listID}", nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+		listsHandler.GetList(rr, req, test.listID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	// Only the owner can update and delete the list.
+	req := withViewer(httptest.NewRequest("PATCH", "/api/lists/{listID}", strings.NewReader(`{ "name": "stolen" }`)), viewerID)
+	rr := httptest.NewRecorder()
+	listsHandler.UpdateList(rr, req, publicList.ID.String())
+	if rr.Code != http.StatusForbidden {
+		s.T().Errorf("update another user's list: expected %d, but got %d", http.StatusForbidden, rr.Code)
+	}
+
+	req = withViewer(httptest.NewRequest("PATCH", "/api/lists/{listID}", strings.NewReader(`{ "description": "daily news" }`)), ownerID)
+	rr = httptest.NewRecorder()
+	listsHandler.UpdateList(rr, req, publicList.ID.String())
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("update a list: expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+	var updated entities.List
+	if err := json.NewDecoder(rr.Body).Decode(&updated); err != nil {
+		s.T().Fatalf("failed to decode response")
+	}
+	if updated.Name != "news" || updated.Description != "daily news" {
+		s.T().Errorf("expected only the description to be changed, but got %+v", updated)
+	}
+
+	// Subscriptions.
+	subscribeTests := []struct {
+		name         string
+		viewerID     string
+		listID       string
+		expectedCode int
+	}{
+		{name: "own list", viewerID: ownerID, listID: publicList.ID.String(), expectedCode: http.StatusBadRequest},
+		{name: "private list", viewerID: viewerID, listID: privateList.ID.String(), expectedCode: http.StatusNotFound},
+		{name: "new subscription", viewerID: viewerID, listID: publicList.ID.String(), expectedCode: http.StatusCreated},
+		{name: "existing subscription", viewerID: viewerID, listID: publicList.ID.String(), expectedCode: http.StatusOK},
+	}
+
+	for _, test := range subscribeTests {
+		req := withViewer(httptest.NewRequest("POST", "/api/lists/{listID}/subscription", nil), test.viewerID)
+		rr := httptest.NewRecorder()
+		listsHandler.SubscribeList(rr, req, test.listID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	if list := s.getTestList(viewerID, publicList.ID.String()); list.SubscriberCount != 1 || !list.SubscribedByMe {
+		s.T().Errorf("expected the viewer to subscribe to the list, but got %+v", list)
+	}
+	if lists := s.getTestSubscribedLists(viewerID); len(lists) != 1 || lists[0].ID != publicList.ID {
+		s.T().Errorf("expected 1 subscribed list, but got %+v", lists)
+	}
+
+	// The private list is listed only for its owner.
+	for _, viewer := range []struct {
+		id       string
+		expected int
+	}{
+		{id: ownerID, expected: 3},
+		{id: viewerID, expected: 2},
+	} {
+		req := withViewer(httptest.NewRequest("GET", "/api/users/{id}/lists", nil), viewer.id)
+		rr := httptest.NewRecorder()
+		listsHandler.GetUserLists(rr, req, ownerID, openapi.GetUserListsParams{})
+		if rr.Code != http.StatusOK {
+			s.T().Fatalf("get lists: expected %d, but got %d", http.StatusOK, rr.Code)
+		}
+		var res listPageResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Fatalf("failed to decode response")
+		}
+		if len(res.Lists) != viewer.expected {
+			s.T().Errorf("expected %d lists for %s, but got %d", viewer.expected, viewer.id, len(res.Lists))
+		}
+	}
+
+	// Turning the list private hides it from its subscribers.
+	req = withViewer(httptest.NewRequest("PATCH", "/api/lists/{listID}", strings.NewReader(`{ "is_private": true }`)), ownerID)
+	rr = httptest.NewRecorder()
+	listsHandler.UpdateList(rr, req, publicList.ID.String())
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("make a list private: expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+	if lists := s.getTestSubscribedLists(viewerID); len(lists) != 0 {
+		s.T().Errorf("expected no subscribed lists, but got %+v", lists)
+	}
+
+	req = withViewer(httptest.NewRequest("DELETE", "/api/lists/{listID}/subscription", nil), viewerID)
+	rr = httptest.NewRecorder()
+	listsHandler.UnsubscribeList(rr, req, publicList.ID.String())
+	if rr.Code != http.StatusNoContent {
+		s.T().Errorf("unsubscribe: expected %d, but got %d", http.StatusNoContent, rr.Code)
+	}
+
+	req = withViewer(httptest.NewRequest("DELETE", "/api/lists/{listID}", nil), viewerID)
+	rr = httptest.NewRecorder()
+	listsHandler.DeleteList(rr, req, privateList.ID.String())
+	if rr.Code != http.StatusNotFound {
+		s.T().Errorf("delete another user's private list: expected %d, but got %d", http.StatusNotFound, rr.Code)
+	}
+
+	req = withViewer(httptest.NewRequest("DELETE", "/api/lists/{listID}", nil), ownerID)
+	rr = httptest.NewRecorder()
+	listsHandler.DeleteList(rr, req, privateList.ID.String())
+	if rr.Code != http.StatusNoContent {
+		s.T().Errorf("delete a list: expected %d, but got %d", http.StatusNoContent, rr.Code)
+	}
+}
+
+func (s *HandlersTestSuite) TestListMembers() {
+	ownerID := s.newTestUser(`{ "username": "owner", "display_name": "owner", "password": "securepassword" }`)
+	memberID := s.newTestUser(`{ "username": "member", "display_name": "member", "password": "securepassword" }`)
+	blockerID := s.newTestUser(`{ "username": "blocker", "display_name": "blocker", "password": "securepassword" }`)
+	s.newTestBlock(blockerID, ownerID)
+
+	listsHandler := NewListsHandler(s.db, &s.mu, &s.listChannels)
+	list := s.newTestList(ownerID, `{ "name": "friends" }`)
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "missing user id",
+			viewerID:     ownerID,
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "another user's list",
+			viewerID:     memberID,
+			body:         fmt.Sprintf(`{ "user_id": "%s" }`, ownerID),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "user blocking the owner",
+			viewerID:     ownerID,
+			body:         fmt.Sprintf(`{ "user_id": "%s" }`, blockerID),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "non-existent user",
+			viewerID:     ownerID,
+			body:         fmt.Sprintf(`{ "user_id": "%s" }`, uuid.New()),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "new member",
+			viewerID:     ownerID,
+			body:         fmt.Sprintf(`{ "user_id": "%s" }`, memberID),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "existing member",
+			viewerID:     ownerID,
+			body:         fmt.Sprintf(`{ "user_id": "%s" }`, memberID),
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("POST", "/api/lists/{listID}/members", strings.NewReader(test.body)), test.viewerID)
+		rr := httptest.NewRecorder()
+		listsHandler.AddListMember(rr, req, list.ID.String())
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/lists/{listID}/members", nil)
+	rr := httptest.NewRecorder()
+	listsHandler.GetListMembers(rr, req, list.ID.String(), openapi.GetListMembersParams{})
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("get members: expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+	var res userSummaryPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		s.T().Fatalf("failed to decode response")
+	}
+	if len(res.Users) != 1 || res.Users[0].ID.String() != memberID {
+		s.T().Errorf("expected the member to be listed, but got %+v", res.Users)
+	}
+	if list := s.getTestList(ownerID, list.ID.String()); list.MemberCount != 1 {
+		s.T().Errorf("expected 1 member, but got %d", list.MemberCount)
+	}
+
+	for _, expectedCode := range []int{http.StatusNoContent, http.StatusNotFound} {
+		req := withViewer(httptest.NewRequest("DELETE", "/api/lists/{listID}/members/{userID}", nil), ownerID)
+		rr := httptest.NewRecorder()
+		listsHandler.RemoveListMember(rr, req, list.ID.String(), memberID)
+		if rr.Code != expectedCode {
+			s.T().Errorf("remove a member: expected %d, but got %d", expectedCode, rr.Code)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestListTimeline() {
+	ownerID := s.newTestUser(`{ "username": "owner", "display_name": "owner", "password": "securepassword" }`)
+	memberID := s.newTestUser(`{ "username": "member", "display_name": "member", "password": "securepassword" }`)
+	mutedMemberID := s.newTestUser(`{ "username": "muted", "display_name": "muted", "password": "securepassword" }`)
+	blockerID := s.newTestUser(`{ "username": "blocker", "display_name": "blocker", "password": "securepassword" }`)
+	outsiderID := s.newTestUser(`{ "username": "outsider", "display_name": "outsider", "password": "securepassword" }`)
+
+	list := s.newTestList(ownerID, `{ "name": "friends" }`)
+	for _, userID := range []string{memberID, mutedMemberID, blockerID} {
+		s.newTestListMember(ownerID, list.ID.String(), userID)
+	}
+
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "member's post" }`, memberID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "muted member's post" }`, mutedMemberID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "outsider's post" }`, outsiderID))
+	outsiderPostID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "reposted post" }`, outsiderID))
+	repostID := s.newTestRepost(memberID, outsiderPostID)
+	quoteID := s.newTestQuoteRepost(memberID, postID)
+	blockedPostID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "blocked post" }`, blockerID))
+	s.newTestRepost(memberID, blockedPostID)
+
+	// The owner mutes a member, and another member blocks the owner after being added.
+	req := httptest.NewRequest(
+		"POST",
+		"/api/users/{id}/muting",
+		strings.NewReader(fmt.Sprintf(`{ "target_user_id": "%s" }`, mutedMemberID)),
+	)
+	req.SetPathValue("id", ownerID)
+	req = withViewer(req, ownerID)
+	CreateMuting(httptest.NewRecorder(), req, s.muteUserUsecase)
+	s.newTestBlock(blockerID, ownerID)
+
+	listsHandler := NewListsHandler(s.db, &s.mu, &s.listChannels)
+	req = withViewer(httptest.NewRequest("GET", "/api/lists/{listID}/timeline", nil), ownerID)
+	rr := httptest.NewRecorder()
+	listsHandler.GetListTimeline(rr, req, list.ID.String(), openapi.GetListTimelineParams{})
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("get the timeline: expected %d, but got %d", http.StatusOK, rr.Code)
+	}
+
+	var res timelineItemPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		s.T().Fatalf("failed to decode response")
+	}
+	if len(res.Items) != 3 {
+		s.T().Fatalf("expected 3 items, but got %d", len(res.Items))
+	}
+	if quote := res.Items[0].Quote; quote == nil || quote.ID.String() != quoteID || res.Items[0].Repost != nil {
+		s.T().Errorf("expected the quote repost first, but got %+v", res.Items[0])
+	}
+	if item := res.Items[1]; item.Post == nil || item.Post.ID.String() != outsiderPostID || item.Repost == nil || item.Repost.ID.String() != repostID {
+		s.T().Errorf("expected the reposted post along with the repost, but got %+v", item)
+	} else if item.Repost.Author == nil || item.Repost.Author.ID.String() != memberID {
+		s.T().Errorf("expected the reposter to be filled in, but got %+v", item.Repost.Author)
+	}
+	if post := res.Items[2].Post; post == nil || post.ID.String() != postID {
+		s.T().Errorf("expected the member's post last, but got %+v", res.Items[2])
+	}
+
+	// New posts by the members are published to the streams of the list.
+	listChan := make(chan entities.TimelineEvent, 1)
+	s.mu.Lock()
+	s.listChannels[list.ID.String()] = map[chan entities.TimelineEvent]struct{}{listChan: {}}
+	s.mu.Unlock()
+
+	newPostID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "new post" }`, memberID))
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-listChan:
+			// The events of the earlier posts may still be on their way.
+			if event.EventType == entities.PostCreated && len(event.Posts) == 1 && event.Posts[0].ID.String() == newPostID {
+				return
+			}
+		case <-timeout:
+			s.T().Fatalf("expected an event of the new post to be published to the list")
+		}
+	}
+}
+
+func (s *HandlersTestSuite) newTestList(ownerID, body string) entities.List {
+	req := withViewer(httptest.NewRequest("POST", "/api/lists", strings.NewReader(body)), ownerID)
+	rr := httptest.NewRecorder()
+
+	listsHandler := NewListsHandler(s.db, &s.mu, &s.listChannels)
+	listsHandler.CreateList(rr, req)
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("Failed to create a list: %d", rr.Code)
+	}
+
+	var list entities.List
+	if err := json.NewDecoder(rr.Body).Decode(&list); err != nil {
+		s.T().Fatalf("Failed to decode a list: %v", err)
+	}
+	return list
+}
+
+func (s *HandlersTestSuite) newTestListMember(ownerID, listID, userID string) {
+	req := withViewer(httptest.NewRequest("POST", "/api/lists/{listID}/members", strings.NewReader(fmt.Sprintf(`{ "user_id": "%s" }`, userID))), ownerID)
+	rr := httptest.NewRecorder()
+
+	listsHandler := NewListsHandler(s.db, &s.mu, &s.listChannels)
+	listsHandler.AddListMember(rr, req, listID)
+	if rr.Code != http.StatusCreated {
+		s.T().Fatalf("Failed to add a list member: %d", rr.Code)
+	}
+}
+
+func (s *HandlersTestSuite) getTestList(viewerID, listID string) entities.List {
+	req := withViewer(httptest.NewRequest("GET", "/api/lists/{listID}", nil), viewerID)
+	rr := httptest.NewRecorder()
+
+	listsHandler := NewListsHandler(s.db, &s.mu, &s.listChannels)
+	listsHandler.GetList(rr, req, listID)
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get a list: %d", rr.Code)
+	}
+
+	var list entities.List
+	if err := json.NewDecoder(rr.Body).Decode(&list); err != nil {
+		s.T().Fatalf("Failed to decode a list: %v", err)
+	}
+	return list
+}
+
+func (s *HandlersTestSuite) getTestSubscribedLists(userID string) []*entities.List {
+	req := withViewer(httptest.NewRequest("GET", "/api/users/{id}/subscribed_lists", nil), userID)
+	rr := httptest.NewRecorder()
+
+	listsHandler := NewListsHandler(s.db, &s.mu, &s.listChannels)
+	listsHandler.GetSubscribedLists(rr, req, userID, openapi.GetSubscribedListsParams{})
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get subscribed lists: %d", rr.Code)
+	}
+
+	var res listPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+		s.T().Fatalf("Failed to decode lists: %v", err)
+	}
+	return res.Lists
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type MarkNotificationsAsReadHandler struct {
+	markNotificationsAsReadUsecase usecases.MarkNotificationsAsReadUsecase
+}
+
+func NewMarkNotificationsAsReadHandler(db *sql.DB) MarkNotificationsAsReadHandler {
+	notificationsRepository := infrastructure.NewNotificationsRepository(db)
+	markNotificationsAsReadUsecase := usecases.NewMarkNotificationsAsReadUsecase(notificationsRepository)
+	return MarkNotificationsAsReadHandler{
+		markNotificationsAsReadUsecase,
+	}
+}
+
+// MarkNotificationAsRead marks the specified notification of the authenticated user,
+// together with the other notifications of its group, as read.
+func (h *MarkNotificationsAsReadHandler) MarkNotificationAsRead(w http.ResponseWriter, r *http.Request, notificationID string) {
+	slog.Info("POST /api/notifications/{notificationID}/read was called.")
+
+	viewerID := viewerIDFromContext(r)
+	if viewerID == "" {
+		http.Error(w, "Authentication required.", http.StatusUnauthorized)
+		return
+	}
+
+	if _, err := uuid.Parse(notificationID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a notificationID (ID: %s)\n", notificationID), http.StatusBadRequest)
+		return
+	}
+
+	err := h.markNotificationsAsReadUsecase.MarkNotificationAsRead(viewerID, notificationID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrNotificationNotFound):
+			http.Error(w, fmt.Sprintf("Could not find a notification (ID: %s)\n", notificationID), http.StatusNotFound)
+		default:
+			http.Error(w, fmt.Sprintln("Could not mark a notification as read."), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkAllNotificationsAsRead marks all of the authenticated user's notifications as read.
+func (h *MarkNotificationsAsReadHandler) MarkAllNotificationsAsRead(w http.ResponseWriter, r *http.Request) {
+	slog.Info("POST /api/notifications/read was called.")
+
+	viewerID := viewerIDFromContext(r)
+	if viewerID == "" {
+		http.Error(w, "Authentication required.", http.StatusUnauthorized)
+		return
+	}
+
+	err := h.markNotificationsAsReadUsecase.MarkAllNotificationsAsRead(viewerID)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not mark notifications as read."), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
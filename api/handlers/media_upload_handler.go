@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type MediaUploadHandler struct {
+	mediaUploadUsecase usecases.MediaUploadUsecase
+}
+
+func NewMediaUploadHandler(db *sql.DB, uploadStore repositories.BlobStore) MediaUploadHandler {
+	mediaUploadRepository := infrastructure.NewMediaUploadRepository(db)
+	mediaRepository := infrastructure.NewMediaRepository(db)
+	mediaUploadUsecase := usecases.NewMediaUploadUsecase(mediaUploadRepository, mediaRepository, uploadStore)
+	return MediaUploadHandler{
+		mediaUploadUsecase,
+	}
+}
+
+// InitMediaUpload starts an upload of media sent in segments by the authenticated user.
+func (h *MediaUploadHandler) InitMediaUpload(w http.ResponseWriter, r *http.Request) {
+	slog.Info("POST /api/media/uploads was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var body initMediaUploadRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Request body was invalid: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	upload, err := h.mediaUploadUsecase.InitUpload(userID, body.MediaType, body.TotalBytes, body.SHA256)
+	if err != nil {
+		writeMediaUploadError(w, err, "Could not start the upload.")
+		return
+	}
+
+	writeMediaUploadResponse(w, http.StatusCreated, upload)
+}
+
+// AppendMediaUploadSegment stores the request body as the segment of the index.
+func (h *MediaUploadHandler) AppendMediaUploadSegment(w http.ResponseWriter, r *http.Request, mediaID string, segmentIndex int) {
+	slog.Info("PUT /api/media/uploads/{mediaID}/segments/{segmentIndex} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if _, err := uuid.Parse(mediaID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a mediaID (ID: %s)\n", mediaID), http.StatusBadRequest)
+		return
+	}
+
+	segment, err := h.mediaUploadUsecase.AppendSegment(userID, mediaID, segmentIndex, r.Body)
+	if err != nil {
+		writeMediaUploadError(w, err, "Could not store the segment.")
+		return
+	}
+
+	writeMediaUploadResponse(w, http.StatusOK, segment)
+}
+
+// FinalizeMediaUpload assembles the segments into media, which is processed asynchronously.
+func (h *MediaUploadHandler) FinalizeMediaUpload(w http.ResponseWriter, r *http.Request, mediaID string) {
+	slog.Info("POST /api/media/uploads/{mediaID}/finalize was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if _, err := uuid.Parse(mediaID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a mediaID (ID: %s)\n", mediaID), http.StatusBadRequest)
+		return
+	}
+
+	media, err := h.mediaUploadUsecase.FinalizeUpload(userID, mediaID)
+	if err != nil {
+		writeMediaUploadError(w, err, "Could not finalize the upload.")
+		return
+	}
+
+	writeMediaUploadResponse(w, http.StatusOK, media)
+}
+
+// GetMediaUploadStatus gets the received segments of the upload, and the processing
+// state of its media once the upload is finalized.
+func (h *MediaUploadHandler) GetMediaUploadStatus(w http.ResponseWriter, r *http.Request, mediaID string) {
+	slog.Info("GET /api/media/uploads/{mediaID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+	if _, err := uuid.Parse(mediaID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a mediaID (ID: %s)\n", mediaID), http.StatusBadRequest)
+		return
+	}
+
+	upload, media, err := h.mediaUploadUsecase.GetUpload(userID, mediaID)
+	if err != nil {
+		writeMediaUploadError(w, err, "Could not get the upload.")
+		return
+	}
+
+	response := mediaUploadStatusResponseBody{
+		MediaUpload:   upload,
+		ReceivedBytes: upload.ReceivedBytes(),
+		Media:         media,
+	}
+	if media != nil && (media.Status == entities.MediaPending || media.Status == entities.MediaInProgress) {
+		response.CheckAfterSecs = int(usecases.MediaProcessingInterval.Seconds())
+	}
+
+	writeMediaUploadResponse(w, http.StatusOK, response)
+}
+
+// writeMediaUploadError writes the status code corresponding to an error
+// returned by a step of a media upload.
+func writeMediaUploadError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrMediaUploadNotFound), errors.Is(err, domainerrors.ErrMediaNotFound):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrNotMediaOwner):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusForbidden)
+	case errors.Is(err, domainerrors.ErrMediaUploadFinalized):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusConflict)
+	case errors.Is(err, domainerrors.ErrInvalidMediaUpload),
+		errors.Is(err, domainerrors.ErrIncompleteMediaUpload),
+		errors.Is(err, domainerrors.ErrMediaChecksumMismatch):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusBadRequest)
+	case errors.Is(err, domainerrors.ErrMediaTooLarge):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, domainerrors.ErrUnsupportedMediaType):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusUnsupportedMediaType)
+	case errors.Is(err, domainerrors.ErrMediaUploadQuotaExceeded):
+		http.Error(w, fmt.Sprintf("%s %v\n", message, err), http.StatusTooManyRequests)
+	default:
+		http.Error(w, fmt.Sprintln(message), http.StatusInternalServerError)
+	}
+}
+
+func writeMediaUploadResponse(w http.ResponseWriter, code int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	encoder := json.NewEncoder(w)
+	err := encoder.Encode(response)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
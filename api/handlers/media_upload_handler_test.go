@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"x-clone-backend/internal/domain/entities"
+)
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func newInitMediaUploadRequest(mediaType string, totalBytes int, checksum string) *http.Request {
+	return httptest.NewRequest(
+		"POST",
+		"/api/media/uploads",
+		strings.NewReader(fmt.Sprintf(`{ "media_type": %q, "total_bytes": %d, "sha256": %q }`, mediaType, totalBytes, checksum)),
+	)
+}
+
+func (s *HandlersTestSuite) TestInitMediaUpload() {
+	userID := s.newTestUser(`{ "username": "uploader", "display_name": "uploader", "password": "securepassword" }`)
+	checksum := sha256Hex([]byte("video"))
+
+	mediaUploadHandler := NewMediaUploadHandler(s.db, s.newTestBlobStore())
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		mediaType    string
+		totalBytes   int
+		checksum     string
+		expectedCode int
+	}{
+		{
+			name:         "start an upload of a video",
+			viewerID:     userID,
+			mediaType:    "video/mp4",
+			totalBytes:   100 << 20,
+			checksum:     checksum,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "fail without authentication",
+			mediaType:    "video/mp4",
+			totalBytes:   100,
+			checksum:     checksum,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "fail to upload an unsupported type",
+			viewerID:     userID,
+			mediaType:    "video/webm",
+			totalBytes:   100,
+			checksum:     checksum,
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:         "fail to upload a too large GIF",
+			viewerID:     userID,
+			mediaType:    "image/gif",
+			totalBytes:   entities.MaxGIFSize + 1,
+			checksum:     checksum,
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:         "fail without a valid checksum",
+			viewerID:     userID,
+			mediaType:    "video/mp4",
+			totalBytes:   100,
+			checksum:     "md5",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "start an upload within the quota",
+			viewerID:     userID,
+			mediaType:    "video/mp4",
+			totalBytes:   entities.MaxVideoSize,
+			checksum:     checksum,
+			expectedCode: http.StatusCreated,
+		},
+	}
+
+	// Two of the largest videos are started first, so that the quota of 2 GiB
+	// is exceeded by one more after the uploads below.
+	for i := 0; i < 2; i++ {
+		rr := httptest.NewRecorder()
+		mediaUploadHandler.InitMediaUpload(rr, withViewer(newInitMediaUploadRequest("video/mp4", entities.MaxVideoSize, checksum), userID))
+		if rr.Code != http.StatusCreated {
+			s.T().Fatalf("failed to start an upload; got %d", rr.Code)
+		}
+	}
+
+	for _, test := range tests {
+		req := newInitMediaUploadRequest(test.mediaType, test.totalBytes, test.checksum)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		mediaUploadHandler.InitMediaUpload(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	mediaUploadHandler.InitMediaUpload(rr, withViewer(newInitMediaUploadRequest("video/mp4", entities.MaxVideoSize, checksum), userID))
+	if rr.Code != http.StatusTooManyRequests {
+		s.T().Errorf("expected the quota to be exceeded, but got %d", rr.Code)
+	}
+}
+
+func (s *HandlersTestSuite) TestMediaUpload() {
+	userID := s.newTestUser(`{ "username": "uploader", "display_name": "uploader", "password": "securepassword" }`)
+	otherID := s.newTestUser(`{ "username": "other", "display_name": "other", "password": "securepassword" }`)
+
+	blobStore := s.newTestBlobStore()
+	mediaUploadHandler := NewMediaUploadHandler(s.db, blobStore)
+
+	content := newTestPNG(64, 64)
+	half := len(content) / 2
+
+	rr := httptest.NewRecorder()
+	mediaUploadHandler.InitMediaUpload(rr, withViewer(newInitMediaUploadRequest("image/png", len(content), sha256Hex(content)), userID))
+	var upload entities.MediaUpload
+	if err := json.NewDecoder(rr.Body).Decode(&upload); err != nil || rr.Code != http.StatusCreated {
+		s.T().Fatalf("failed to start an upload; got %d", rr.Code)
+	}
+	mediaID := upload.ID.String()
+
+	steps := []struct {
+		name         string
+		viewerID     string
+		segment      int
+		content      []byte
+		finalize     bool
+		expectedCode int
+	}{
+		{
+			name:         "send the second segment first",
+			viewerID:     userID,
+			segment:      1,
+			content:      content[half:],
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "fail to finalize with a missing segment",
+			viewerID:     userID,
+			finalize:     true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail to send a segment of the upload of another user",
+			viewerID:     otherID,
+			segment:      0,
+			content:      content[:half],
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "fail to send segments exceeding total_bytes",
+			viewerID:     userID,
+			segment:      0,
+			content:      content,
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:         "fail to send a segment of an invalid index",
+			viewerID:     userID,
+			segment:      entities.MaxUploadSegments,
+			content:      content[:half],
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "send a corrupted first segment",
+			viewerID:     userID,
+			segment:      0,
+			content:      append(bytes.Clone(content[:half-1]), 0),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "fail to finalize with a checksum mismatch",
+			viewerID:     userID,
+			finalize:     true,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "resend the first segment",
+			viewerID:     userID,
+			segment:      0,
+			content:      content[:half],
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "finalize the upload",
+			viewerID:     userID,
+			finalize:     true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "fail to send a segment after the finalization",
+			viewerID:     userID,
+			segment:      0,
+			content:      content[:half],
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "fail to finalize twice",
+			viewerID:     userID,
+			finalize:     true,
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, step := range steps {
+		rr := httptest.NewRecorder()
+		if step.finalize {
+			req := withViewer(httptest.NewRequest("POST", "/api/media/uploads/{mediaID}/finalize", nil), step.viewerID)
+			mediaUploadHandler.FinalizeMediaUpload(rr, req, mediaID)
+		} else {
+			req := withViewer(httptest.NewRequest("PUT", "/api/media/uploads/{mediaID}/segments/{segmentIndex}", bytes.NewReader(step.content)), step.viewerID)
+			mediaUploadHandler.AppendMediaUploadSegment(rr, req, mediaID, step.segment)
+		}
+
+		if rr.Code != step.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", step.name, step.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code == http.StatusOK && !step.finalize {
+			var segment entities.UploadSegment
+			if err := json.NewDecoder(rr.Body).Decode(&segment); err != nil || segment.SHA256 != sha256Hex(step.content) {
+				s.T().Errorf("%s: unexpected segment %+v", step.name, segment)
+			}
+		}
+	}
+
+	getStatus := func() mediaUploadStatusResponseBody {
+		rr := httptest.NewRecorder()
+		mediaUploadHandler.GetMediaUploadStatus(rr, withViewer(httptest.NewRequest("GET", "/api/media/uploads/{mediaID}", nil), userID), mediaID)
+		var status mediaUploadStatusResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&status); err != nil {
+			s.T().Fatalf("failed to get the status; got %d", rr.Code)
+		}
+		return status
+	}
+
+	status := getStatus()
+	if status.Media == nil || status.Media.Status != entities.MediaPending || status.CheckAfterSecs == 0 || status.ReceivedBytes != int64(len(content)) {
+		s.T().Errorf("expected the media to be pending, but got %+v", status)
+	}
+
+	s.processTestMedia(blobStore)
+
+	status = getStatus()
+	if status.Media == nil || status.Media.Status != entities.MediaSucceeded || status.Media.Width != 64 || status.CheckAfterSecs != 0 {
+		s.T().Errorf("expected the media to be processed, but got %+v", status)
+	}
+
+	rr = httptest.NewRecorder()
+	mediaUploadHandler.GetMediaUploadStatus(rr, withViewer(httptest.NewRequest("GET", "/api/media/uploads/{mediaID}", nil), otherID), mediaID)
+	if rr.Code != http.StatusForbidden {
+		s.T().Errorf("expected another user to be forbidden, but got %d", rr.Code)
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+type NotificationPreferencesHandler struct {
+	notificationPreferencesUsecase usecases.NotificationPreferencesUsecase
+}
+
+func NewNotificationPreferencesHandler(db *sql.DB) NotificationPreferencesHandler {
+	notificationsRepository := infrastructure.NewNotificationsRepository(db)
+	notificationPreferencesUsecase := usecases.NewNotificationPreferencesUsecase(notificationsRepository)
+	return NotificationPreferencesHandler{
+		notificationPreferencesUsecase,
+	}
+}
+
+// GetNotificationPreferences gets the notification preferences of the authenticated user.
+func (h *NotificationPreferencesHandler) GetNotificationPreferences(w http.ResponseWriter, r *http.Request, id string) {
+	slog.Info("GET /api/users/{id}/settings/notifications was called.")
+
+	if !authorizeOwner(w, r, id) {
+		return
+	}
+
+	preferences, err := h.notificationPreferencesUsecase.GetNotificationPreferences(id)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not get notification preferences."), http.StatusInternalServerError)
+		return
+	}
+
+	writeNotificationPreferences(w, preferences)
+}
+
+// UpdateNotificationPreferences changes the notification preferences of the authenticated user.
+// Preferences missing from the request body are left as they are.
+func (h *NotificationPreferencesHandler) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request, id string) {
+	slog.Info("PATCH /api/users/{id}/settings/notifications was called.")
+
+	if !authorizeOwner(w, r, id) {
+		return
+	}
+
+	var body entities.NotificationPreferencesPatch
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Request body was invalid: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	preferences, err := h.notificationPreferencesUsecase.UpdateNotificationPreferences(id, body)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrUserNotFound):
+			http.Error(w, fmt.Sprintf("Could not find a user (ID: %s)\n", id), http.StatusNotFound)
+		default:
+			http.Error(w, fmt.Sprintln("Could not update notification preferences."), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeNotificationPreferences(w, preferences)
+}
+
+func writeNotificationPreferences(w http.ResponseWriter, preferences entities.NotificationPreferences) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err := encoder.Encode(preferences)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
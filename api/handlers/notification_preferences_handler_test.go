@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"x-clone-backend/internal/domain/entities"
+)
+
+func (s *HandlersTestSuite) TestNotificationPreferences() {
+	ownerID := s.newTestUser(`{ "username": "owner", "display_name": "owner", "password": "securepassword" }`)
+	otherID := s.newTestUser(`{ "username": "other", "display_name": "other", "password": "securepassword" }`)
+
+	notificationPreferencesHandler := NewNotificationPreferencesHandler(s.db)
+
+	tests := []struct {
+		name         string
+		method       string
+		viewerID     string
+		body         string
+		expectedCode int
+		expected     entities.NotificationPreferences
+	}{
+		{
+			name:         "get default preferences",
+			method:       "GET",
+			viewerID:     ownerID,
+			expectedCode: http.StatusOK,
+			expected:     entities.DefaultNotificationPreferences(),
+		},
+		{
+			name:         "turn off likes",
+			method:       "PATCH",
+			viewerID:     ownerID,
+			body:         `{ "likes": false }`,
+			expectedCode: http.StatusOK,
+			expected:     entities.NotificationPreferences{Reposts: true, Follows: true, Mentions: true},
+		},
+		{
+			name:         "turn on a filter leaving likes off",
+			method:       "PATCH",
+			viewerID:     ownerID,
+			body:         `{ "only_from_following": true }`,
+			expectedCode: http.StatusOK,
+			expected:     entities.NotificationPreferences{Reposts: true, Follows: true, Mentions: true, OnlyFromFollowing: true},
+		},
+		{
+			name:         "unknown preference",
+			method:       "PATCH",
+			viewerID:     ownerID,
+			body:         `{ "unknown": true }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "another user",
+			method:       "GET",
+			viewerID:     otherID,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "anonymous viewer",
+			method:       "PATCH",
+			body:         `{ "likes": true }`,
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, fmt.Sprintf("/api/users/%s/settings/notifications", ownerID), strings.NewReader(test.body))
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		if test.method == "GET" {
+			notificationPreferencesHandler.GetNotificationPreferences(rr, req, ownerID)
+		} else {
+			notificationPreferencesHandler.UpdateNotificationPreferences(rr, req, ownerID)
+		}
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res entities.NotificationPreferences
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+		if res != test.expected {
+			s.T().Errorf("%s: expected %+v, but got %+v", test.name, test.expected, res)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestNotificationPreferencesAreApplied() {
+	ownerID := s.newTestUser(`{ "username": "owner", "display_name": "owner", "password": "securepassword" }`)
+	followeeID := s.newTestUser(`{ "username": "followee", "display_name": "followee", "password": "securepassword" }`)
+	strangerID := s.newTestUser(`{ "username": "stranger", "display_name": "stranger", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "test post" }`, ownerID))
+	s.newTestFollow(ownerID, followeeID)
+
+	req := httptest.NewRequest("PATCH", "/api/users/{id}/settings/notifications", strings.NewReader(`{ "likes": false, "only_from_following": true }`))
+	req = withViewer(req, ownerID)
+	rr := httptest.NewRecorder()
+	notificationPreferencesHandler := NewNotificationPreferencesHandler(s.db)
+	notificationPreferencesHandler.UpdateNotificationPreferences(rr, req, ownerID)
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to update notification preferences: %d", rr.Code)
+	}
+
+	// Likes are turned off, and the stranger is not followed by the owner.
+	s.newTestLike(followeeID, postID)
+	s.newTestFollow(strangerID, ownerID)
+	s.newTestFollow(followeeID, ownerID)
+
+	res := s.getTestNotifications(ownerID, nil)
+	if len(res.Notifications) != 1 {
+		s.T().Fatalf("expected only the followee's follow, but got %d notifications", len(res.Notifications))
+	}
+	notification := res.Notifications[0]
+	if notification.Type != entities.NotificationFollow || notification.Actors[0].ID.String() != followeeID {
+		s.T().Errorf("unexpected notification %+v", notification)
+	}
+}
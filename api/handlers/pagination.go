@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"fmt"
+
+	"x-clone-backend/internal/domain/entities"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// parsePageParams validates the cursor and limit query parameters
+// shared by the paginated endpoints, and applies the default limit.
+func parsePageParams(cursor *string, limit *int) (*entities.Cursor, int, error) {
+	pageLimit := defaultPageLimit
+	if limit != nil {
+		if *limit < 1 || *limit > maxPageLimit {
+			return nil, 0, fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+		}
+		pageLimit = *limit
+	}
+
+	if cursor == nil || *cursor == "" {
+		return nil, pageLimit, nil
+	}
+
+	c, err := entities.DecodeCursor(*cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return c, pageLimit, nil
+}
+
+// encodeNextCursor returns the string representation of the cursor,
+// or an empty string if there are no more items.
+func encodeNextCursor(cursor *entities.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	return cursor.Encode()
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+	openapi "x-clone-backend/gen"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type ScheduledPostsHandler struct {
+	scheduledPostsUsecase usecases.ScheduledPostsUsecase
+}
+
+func NewScheduledPostsHandler(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}, notificationChans *map[string]chan *entities.Notification) ScheduledPostsHandler {
+	return ScheduledPostsHandler{
+		scheduledPostsUsecase: newScheduledPostsUsecase(db, mu, usersChan, listChans, notificationChans),
+	}
+}
+
+// newScheduledPostsUsecase builds the usecase publishing the scheduled posts
+// in the same way as CreatePost publishes new posts.
+func newScheduledPostsUsecase(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}, notificationChans *map[string]chan *entities.Notification) usecases.ScheduledPostsUsecase {
+	notificationsRepository := infrastructure.NewNotificationsRepository(db)
+	postsRepository := infrastructure.NewPostsRepository(db)
+	usersRepository := infrastructure.NewUsersRepository(db)
+	notifyUsecase := usecases.NewNotifyUsecase(notificationsRepository, postsRepository, mu, notificationChans)
+	createPostEntitiesUsecase := usecases.NewCreatePostEntitiesUsecase(postsRepository, usersRepository, notifyUsecase)
+	attachMediaUsecase := usecases.NewAttachMediaUsecase(infrastructure.NewMediaRepository(db))
+	createPollUsecase := usecases.NewCreatePollUsecase(infrastructure.NewPollsRepository(db))
+	return usecases.NewScheduledPostsUsecase(
+		infrastructure.NewScheduledPostsRepository(db),
+		createPostEntitiesUsecase,
+		attachMediaUsecase,
+		createPollUsecase,
+		newPublishTimelineEventUsecase(db, mu, usersChan, listChans),
+	)
+}
+
+// GetScheduledPosts gets the posts the authenticated user has scheduled
+// and which are still waiting to be published, the soonest first.
+func (h *ScheduledPostsHandler) GetScheduledPosts(w http.ResponseWriter, r *http.Request, params openapi.GetScheduledPostsParams) {
+	slog.Info("GET /api/scheduled_posts was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.scheduledPostsUsecase.GetScheduledPosts(userID, cursor, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not get the scheduled posts."), http.StatusInternalServerError)
+		return
+	}
+
+	writeScheduledPostResponse(w, http.StatusOK, scheduledPostPageResponseBody{
+		ScheduledPosts: page.Items,
+		NextCursor:     encodeNextCursor(page.NextCursor),
+	})
+}
+
+// ReschedulePost changes the time a post of the authenticated user is published at.
+func (h *ScheduledPostsHandler) ReschedulePost(w http.ResponseWriter, r *http.Request, scheduledPostIDStr string) {
+	slog.Info("PATCH /api/scheduled_posts/{scheduledPostID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	scheduledPostID, err := uuid.Parse(scheduledPostIDStr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a scheduledPostID (ID: %s)\n", scheduledPostIDStr), http.StatusBadRequest)
+		return
+	}
+
+	var body reschedulePostRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err = decoder.Decode(&body)
+	if err != nil || body.ScheduledAt == nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.scheduledPostsUsecase.ReschedulePost(userID, scheduledPostID, *body.ScheduledAt, time.Now())
+	if err != nil {
+		writeScheduledPostError(w, err, scheduledPostID, "Could not reschedule a post")
+		return
+	}
+
+	writeScheduledPostResponse(w, http.StatusOK, post)
+}
+
+// CancelScheduledPost deletes a post the authenticated user has scheduled before it is published.
+func (h *ScheduledPostsHandler) CancelScheduledPost(w http.ResponseWriter, r *http.Request, scheduledPostIDStr string) {
+	slog.Info("DELETE /api/scheduled_posts/{scheduledPostID} was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	scheduledPostID, err := uuid.Parse(scheduledPostIDStr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a scheduledPostID (ID: %s)\n", scheduledPostIDStr), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.scheduledPostsUsecase.CancelScheduledPost(userID, scheduledPostID); err != nil {
+		writeScheduledPostError(w, err, scheduledPostID, "Could not cancel a scheduled post")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeScheduledPostError maps the errors of the scheduled posts usecase to the status codes.
+func writeScheduledPostError(w http.ResponseWriter, err error, scheduledPostID uuid.UUID, message string) {
+	switch {
+	case errors.Is(err, domainerrors.ErrScheduledPostNotFound):
+		http.Error(w, fmt.Sprintf("Could not find a scheduled post (ID: %s)\n", scheduledPostID), http.StatusNotFound)
+	case errors.Is(err, domainerrors.ErrInvalidScheduledTime):
+		http.Error(w, fmt.Sprintf("Scheduled time was invalid: %v\n", err), http.StatusBadRequest)
+	default:
+		http.Error(w, fmt.Sprintf("%s (ID: %s)\n", message, scheduledPostID), http.StatusInternalServerError)
+	}
+}
+
+func writeScheduledPostResponse(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	encoder := json.NewEncoder(w)
+	err := encoder.Encode(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/domain/entities"
+
+	openapi "x-clone-backend/gen"
+)
+
+func (s *HandlersTestSuite) TestSchedulePost() {
+	userID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	createPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+
+	tests := []struct {
+		name         string
+		scheduledAt  time.Time
+		poll         string
+		expectedCode int
+	}{
+		{
+			name:         "past time",
+			scheduledAt:  time.Now().Add(-time.Minute),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "too far ahead",
+			scheduledAt:  time.Now().Add(entities.MaxSchedulingPeriod + time.Hour),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid poll",
+			scheduledAt:  time.Now().Add(time.Hour),
+			poll:         `, "poll": { "choices": ["yes"], "duration_minutes": 60 }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "valid time",
+			scheduledAt:  time.Now().Add(time.Hour),
+			poll:         `, "poll": { "choices": [" yes ", "no"], "duration_minutes": 60 }`,
+			expectedCode: http.StatusAccepted,
+		},
+	}
+
+	for _, test := range tests {
+		body := fmt.Sprintf(`{ "user_id": "%s", "text": "hello", "scheduled_at": "%s"%s }`, userID, test.scheduledAt.Format(time.RFC3339), test.poll)
+		req := httptest.NewRequest("POST", "/api/posts", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		createPostHandler.CreatePost(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusAccepted {
+			continue
+		}
+
+		var post entities.ScheduledPost
+		if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+			s.T().Fatalf("%s: failed to decode the scheduled post: %v", test.name, err)
+		}
+		if post.Text != "hello" || post.Poll == nil || post.Poll.Choices[0] != "yes" {
+			s.T().Errorf("%s: wrong scheduled post returned; got %+v", test.name, post)
+		}
+	}
+
+	// The scheduled post is not published until the time comes.
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM posts WHERE user_id = $1`, userID).Scan(&count); err != nil {
+		s.T().Fatalf("failed to count the posts: %v", err)
+	}
+	if count != 0 {
+		s.T().Errorf("expected no posts, but got %d", count)
+	}
+	if posts := s.getTestScheduledPosts(userID); len(posts) != 1 {
+		s.T().Errorf("expected 1 scheduled post, but got %d", len(posts))
+	}
+}
+
+func (s *HandlersTestSuite) TestReschedulePost() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	scheduledPostID := s.newTestScheduledPost(aliceID, time.Now().Add(time.Hour))
+
+	scheduledPostsHandler := NewScheduledPostsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels)
+	newTime := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name            string
+		viewerID        string
+		scheduledPostID string
+		body            string
+		expectedCode    int
+	}{
+		{
+			name:            "unauthenticated",
+			scheduledPostID: scheduledPostID,
+			body:            fmt.Sprintf(`{ "scheduled_at": "%s" }`, newTime.Format(time.RFC3339)),
+			expectedCode:    http.StatusUnauthorized,
+		},
+		{
+			name:            "invalid scheduled post ID",
+			viewerID:        aliceID,
+			scheduledPostID: "invalid",
+			body:            fmt.Sprintf(`{ "scheduled_at": "%s" }`, newTime.Format(time.RFC3339)),
+			expectedCode:    http.StatusBadRequest,
+		},
+		{
+			name:            "another user's scheduled post",
+			viewerID:        bobID,
+			scheduledPostID: scheduledPostID,
+			body:            fmt.Sprintf(`{ "scheduled_at": "%s" }`, newTime.Format(time.RFC3339)),
+			expectedCode:    http.StatusNotFound,
+		},
+		{
+			name:            "past time",
+			viewerID:        aliceID,
+			scheduledPostID: scheduledPostID,
+			body:            fmt.Sprintf(`{ "scheduled_at": "%s" }`, time.Now().Add(-time.Hour).Format(time.RFC3339)),
+			expectedCode:    http.StatusBadRequest,
+		},
+		{
+			name:            "valid time",
+			viewerID:        aliceID,
+			scheduledPostID: scheduledPostID,
+			body:            fmt.Sprintf(`{ "scheduled_at": "%s" }`, newTime.Format(time.RFC3339)),
+			expectedCode:    http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("PATCH", "/api/scheduled_posts/{scheduledPostID}", strings.NewReader(test.body)), test.viewerID)
+		rr := httptest.NewRecorder()
+		scheduledPostsHandler.ReschedulePost(rr, req, test.scheduledPostID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	posts := s.getTestScheduledPosts(aliceID)
+	if len(posts) != 1 || !posts[0].ScheduledAt.Equal(newTime) {
+		s.T().Errorf("wrong scheduled posts returned; got %+v", posts)
+	}
+}
+
+func (s *HandlersTestSuite) TestCancelScheduledPost() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	scheduledPostID := s.newTestScheduledPost(aliceID, time.Now().Add(time.Hour))
+
+	scheduledPostsHandler := NewScheduledPostsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels)
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		expectedCode int
+	}{
+		{name: "another user's scheduled post", viewerID: bobID, expectedCode: http.StatusNotFound},
+		{name: "own scheduled post", viewerID: aliceID, expectedCode: http.StatusNoContent},
+		{name: "already canceled", viewerID: aliceID, expectedCode: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		req := withViewer(httptest.NewRequest("DELETE", "/api/scheduled_posts/{scheduledPostID}", nil), test.viewerID)
+		rr := httptest.NewRecorder()
+		scheduledPostsHandler.CancelScheduledPost(rr, req, scheduledPostID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+	}
+
+	if posts := s.getTestScheduledPosts(aliceID); len(posts) != 0 {
+		s.T().Errorf("expected no scheduled posts, but got %d", len(posts))
+	}
+}
+
+func (s *HandlersTestSuite) TestPublishDuePosts() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	bobID := s.newTestUser(`{ "username": "bob", "display_name": "bob", "password": "securepassword" }`)
+	s.newTestFollow(bobID, aliceID)
+	dueID := s.newTestScheduledPost(aliceID, time.Now().Add(time.Hour))
+	s.newTestScheduledPost(aliceID, time.Now().Add(2*time.Hour))
+
+	// The time of the scheduled post is moved to the past, as if it had come.
+	if _, err := s.db.Exec(`UPDATE scheduled_posts SET scheduled_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, dueID); err != nil {
+		s.T().Fatalf("failed to update the scheduled post: %v", err)
+	}
+
+	userChan := make(chan entities.TimelineEvent, 1)
+	s.mu.Lock()
+	s.userChannels[bobID] = userChan
+	s.mu.Unlock()
+
+	scheduledPostsUsecase := newScheduledPostsUsecase(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels)
+	n, err := scheduledPostsUsecase.PublishDuePosts()
+	if err != nil || n != 1 {
+		s.T().Fatalf("expected 1 post to be published, but got %d: %v", n, err)
+	}
+
+	select {
+	case event := <-userChan:
+		if event.EventType != entities.PostCreated || len(event.Posts) != 1 || event.Posts[0].Text != "hello" {
+			s.T().Errorf("unexpected event %+v", event)
+		}
+	default:
+		s.T().Errorf("expected the scheduled post to be published")
+	}
+
+	// The published post is neither listed as scheduled nor published again.
+	if posts := s.getTestScheduledPosts(aliceID); len(posts) != 1 || posts[0].ID.String() == dueID {
+		s.T().Errorf("wrong scheduled posts returned; got %+v", posts)
+	}
+	if n, err := scheduledPostsUsecase.PublishDuePosts(); err != nil || n != 0 {
+		s.T().Errorf("expected no posts to be published, but got %d: %v", n, err)
+	}
+}
+
+func (s *HandlersTestSuite) TestPublishDuePostsWithFailingPost() {
+	aliceID := s.newTestUser(`{ "username": "alice", "display_name": "alice", "password": "securepassword" }`)
+	failingID := s.newTestScheduledPost(aliceID, time.Now().Add(time.Hour))
+	dueID := s.newTestScheduledPost(aliceID, time.Now().Add(time.Hour))
+
+	// The poll of the failing post cannot be opened, as it has a single choice.
+	if _, err := s.db.Exec(`UPDATE scheduled_posts SET poll = '{"choices": ["yes"], "duration_minutes": 5}' WHERE id = $1`, failingID); err != nil {
+		s.T().Fatalf("failed to update the scheduled post: %v", err)
+	}
+	if _, err := s.db.Exec(`UPDATE scheduled_posts SET scheduled_at = NOW() - INTERVAL '1 minute' WHERE id IN ($1, $2)`, failingID, dueID); err != nil {
+		s.T().Fatalf("failed to update the scheduled posts: %v", err)
+	}
+
+	scheduledPostsUsecase := newScheduledPostsUsecase(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels)
+	if n, err := scheduledPostsUsecase.PublishDuePosts(); err != nil || n != 1 {
+		s.T().Fatalf("expected 1 post to be published, but got %d: %v", n, err)
+	}
+
+	// The failing post is put off, without holding up the others.
+	var (
+		attempts int
+		waiting  bool
+	)
+	err := s.db.QueryRow(`SELECT publish_attempts, next_attempt_at > NOW() FROM scheduled_posts WHERE id = $1`, failingID).Scan(&attempts, &waiting)
+	if err != nil || attempts != 1 || !waiting {
+		s.T().Fatalf("expected the failing post to wait for its next attempt, but got %d attempts, waiting %t: %v", attempts, waiting, err)
+	}
+	if n, err := scheduledPostsUsecase.PublishDuePosts(); err != nil || n != 0 {
+		s.T().Errorf("expected no posts to be published, but got %d: %v", n, err)
+	}
+
+	// The failing post is given up on at its last attempt.
+	if _, err := s.db.Exec(`UPDATE scheduled_posts SET publish_attempts = $2, next_attempt_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, failingID, entities.MaxPublishAttempts-1); err != nil {
+		s.T().Fatalf("failed to update the scheduled post: %v", err)
+	}
+	if n, err := scheduledPostsUsecase.PublishDuePosts(); err != nil || n != 0 {
+		s.T().Errorf("expected no posts to be published, but got %d: %v", n, err)
+	}
+	if posts := s.getTestScheduledPosts(aliceID); len(posts) != 1 || posts[0].ID.String() != failingID || posts[0].FailedAt == nil {
+		s.T().Errorf("expected the failing post to be listed as failed; got %+v", posts)
+	}
+}
+
+func (s *HandlersTestSuite) newTestScheduledPost(userID string, scheduledAt time.Time) string {
+	body := fmt.Sprintf(`{ "user_id": "%s", "text": "hello", "scheduled_at": "%s" }`, userID, scheduledAt.Format(time.RFC3339))
+	req := httptest.NewRequest("POST", "/api/posts", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	createPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	createPostHandler.CreatePost(rr, req)
+	if rr.Code != http.StatusAccepted {
+		s.T().Fatalf("Failed to schedule a post: %d", rr.Code)
+	}
+
+	var post entities.ScheduledPost
+	if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+		s.T().Fatalf("Failed to decode the scheduled post: %v", err)
+	}
+	return post.ID.String()
+}
+
+func (s *HandlersTestSuite) getTestScheduledPosts(userID string) []*entities.ScheduledPost {
+	req := withViewer(httptest.NewRequest("GET", "/api/scheduled_posts", nil), userID)
+	rr := httptest.NewRecorder()
+
+	scheduledPostsHandler := NewScheduledPostsHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels)
+	scheduledPostsHandler.GetScheduledPosts(rr, req, openapi.GetScheduledPostsParams{})
+	if rr.Code != http.StatusOK {
+		s.T().Fatalf("Failed to get the scheduled posts: %d", rr.Code)
+	}
+
+	var body scheduledPostPageResponseBody
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		s.T().Fatalf("Failed to decode the scheduled posts: %v", err)
+	}
+	return body.ScheduledPosts
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+type SearchPostsHandler struct {
+	searchPostsUsecase usecases.SearchPostsUsecase
+}
+
+func NewSearchPostsHandler(db *sql.DB) SearchPostsHandler {
+	postsRepository := infrastructure.NewPostsRepository(db)
+	searchPostsUsecase := usecases.NewSearchPostsUsecase(postsRepository)
+	return SearchPostsHandler{
+		searchPostsUsecase,
+	}
+}
+
+// SearchPosts searches posts and quote reposts visible to the viewer,
+// ordered by relevance unless the recency order is specified.
+func (h *SearchPostsHandler) SearchPosts(w http.ResponseWriter, r *http.Request, params openapi.SearchPostsParams) {
+	slog.Info("GET /api/search/posts was called.")
+
+	query, err := entities.ParseSearchQuery(params.Q)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid search query: %v\n", err), http.StatusBadRequest)
+		return
+	}
+
+	order := entities.SearchByRelevance
+	if params.Sort != nil {
+		order = entities.SearchOrder(*params.Sort)
+	}
+	if order != entities.SearchByRelevance && order != entities.SearchByRecency {
+		http.Error(w, fmt.Sprintf("Invalid sort order: %s\n", order), http.StatusBadRequest)
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+	// A cursor of the relevance order carries the rank of the last result,
+	// so a cursor of one order cannot be used with the other.
+	if cursor != nil && (cursor.Rank != nil) != (order == entities.SearchByRelevance) {
+		http.Error(w, fmt.Sprintln("Invalid pagination parameters: the cursor does not match the sort order"), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.searchPostsUsecase.SearchPosts(query, order, viewerIDFromContext(r), cursor, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not search posts."), http.StatusInternalServerError)
+		return
+	}
+
+	res := timelineItemPageResponseBody{
+		Items:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(res)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
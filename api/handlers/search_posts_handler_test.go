@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	openapi "x-clone-backend/gen"
+)
+
+func (s *HandlersTestSuite) TestSearchPosts() {
+	viewerID := s.newTestUser(`{ "username": "viewer", "display_name": "viewer", "password": "securepassword" }`)
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	mutedID := s.newTestUser(`{ "username": "muted", "display_name": "muted", "password": "securepassword" }`)
+	privateID := s.newTestUser(`{ "username": "private", "display_name": "private", "password": "securepassword" }`)
+	s.makeTestUserPrivate(privateID)
+
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "golang is fun" }`, authorID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "golang golang tips" }`, authorID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "#golang rocks" }`, authorID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "the quick brown fox" }`, authorID))
+	postID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "a test post" }`, authorID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "golang spam" }`, mutedID))
+	s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "golang secrets" }`, privateID))
+	s.newTestQuoteRepost(authorID, postID)
+
+	req := httptest.NewRequest(
+		"POST",
+		"/api/users/{id}/muting",
+		strings.NewReader(fmt.Sprintf(`{ "target_user_id": "%s" }`, mutedID)),
+	)
+	req.SetPathValue("id", viewerID)
+	req = withViewer(req, viewerID)
+	CreateMuting(httptest.NewRecorder(), req, s.muteUserUsecase)
+
+	searchPostsHandler := NewSearchPostsHandler(s.db)
+
+	recency := openapi.Recency
+	invalidSort := openapi.SearchPostsParamsSort("popular")
+
+	tests := []struct {
+		name          string
+		q             string
+		sort          *openapi.SearchPostsParamsSort
+		viewerID      string
+		expectedCode  int
+		expectedCount int
+		expectedFirst string
+	}{
+		{
+			name:          "words ranked by relevance",
+			q:             "golang",
+			expectedCode:  http.StatusOK,
+			expectedCount: 4,
+			expectedFirst: "golang golang tips",
+		},
+		{
+			name:          "words in recency order",
+			q:             "golang",
+			sort:          &recency,
+			expectedCode:  http.StatusOK,
+			expectedCount: 4,
+			expectedFirst: "golang spam",
+		},
+		{
+			name:          "viewer muting an author",
+			q:             "golang",
+			viewerID:      viewerID,
+			expectedCode:  http.StatusOK,
+			expectedCount: 3,
+		},
+		{
+			name:          "private author themself",
+			q:             "golang",
+			viewerID:      privateID,
+			expectedCode:  http.StatusOK,
+			expectedCount: 5,
+		},
+		{
+			name:          "phrase",
+			q:             `"quick brown"`,
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+		},
+		{
+			name:          "phrase in another order",
+			q:             `"brown quick"`,
+			expectedCode:  http.StatusOK,
+			expectedCount: 0,
+		},
+		{
+			name:          "excluded word",
+			q:             "golang -tips",
+			expectedCode:  http.StatusOK,
+			expectedCount: 3,
+		},
+		{
+			name:          "hashtag",
+			q:             "#GoLang",
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+			expectedFirst: "#golang rocks",
+		},
+		{
+			name:          "author",
+			q:             "golang from:Muted",
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+			expectedFirst: "golang spam",
+		},
+		{
+			name:          "quote reposts only",
+			q:             "test filter:quotes",
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+		},
+		{
+			name:          "quote reposts left out",
+			q:             "test -filter:quotes",
+			expectedCode:  http.StatusOK,
+			expectedCount: 1,
+			expectedFirst: "a test post",
+		},
+		{
+			name:         "empty query",
+			q:            "-golang",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid operator",
+			q:            "golang since:yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid sort order",
+			q:            "golang",
+			sort:         &invalidSort,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/api/search/posts", nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		searchPostsHandler.SearchPosts(rr, req, openapi.SearchPostsParams{Q: test.q, Sort: test.sort})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res timelineItemPageResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		if len(res.Items) != test.expectedCount {
+			s.T().Errorf("%s: wrong number of items returned; expected %d, but got %d", test.name, test.expectedCount, len(res.Items))
+			continue
+		}
+		if test.expectedFirst != "" && (res.Items[0].Post == nil || res.Items[0].Post.Text != test.expectedFirst) {
+			s.T().Errorf("%s: expected %q first", test.name, test.expectedFirst)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestSearchPostsPagination() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	for i := 0; i < 5; i++ {
+		s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "%s" }`, authorID, strings.Repeat("golang ", i+1)))
+	}
+
+	searchPostsHandler := NewSearchPostsHandler(s.db)
+
+	limit := 2
+
+	for _, sort := range []openapi.SearchPostsParamsSort{openapi.Relevance, openapi.Recency} {
+		seen := make(map[string]bool)
+		var cursor *string
+		for pages := 0; pages < 5; pages++ {
+			req := httptest.NewRequest("GET", "/api/search/posts", nil)
+			rr := httptest.NewRecorder()
+
+			searchPostsHandler.SearchPosts(rr, req, openapi.SearchPostsParams{Q: "golang", Sort: &sort, Cursor: cursor, Limit: &limit})
+
+			if rr.Code != http.StatusOK {
+				s.T().Fatalf("%s: wrong code returned; expected %d, but got %d", sort, http.StatusOK, rr.Code)
+			}
+
+			var res timelineItemPageResponseBody
+			if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+				s.T().Fatalf("%s: failed to decode response", sort)
+			}
+			for _, item := range res.Items {
+				if seen[item.Post.Text] {
+					s.T().Errorf("%s: %q returned twice", sort, item.Post.Text)
+				}
+				seen[item.Post.Text] = true
+			}
+
+			if res.NextCursor == "" {
+				break
+			}
+			cursor = &res.NextCursor
+		}
+
+		if len(seen) != 5 {
+			s.T().Errorf("%s: expected 5 posts through the pages, but got %d", sort, len(seen))
+		}
+
+		// A cursor of one order is rejected with the other.
+		if cursor != nil {
+			other := openapi.Recency
+			if sort == openapi.Recency {
+				other = openapi.Relevance
+			}
+			req := httptest.NewRequest("GET", "/api/search/posts", nil)
+			rr := httptest.NewRecorder()
+
+			searchPostsHandler.SearchPosts(rr, req, openapi.SearchPostsParams{Q: "golang", Sort: &other, Cursor: cursor, Limit: &limit})
+
+			if rr.Code != http.StatusBadRequest {
+				s.T().Errorf("%s: wrong code returned for a cursor of another order; expected %d, but got %d", sort, http.StatusBadRequest, rr.Code)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+// maxUserQueryLength is the maximum length of a user search query or a typeahead prefix,
+// which is long enough for any username or display name.
+const maxUserQueryLength = 50
+
+type SearchUsersHandler struct {
+	searchUsersUsecase usecases.SearchUsersUsecase
+}
+
+func NewSearchUsersHandler(db *sql.DB) SearchUsersHandler {
+	usersRepository := infrastructure.NewUsersRepository(db)
+	searchUsersUsecase := usecases.NewSearchUsersUsecase(usersRepository)
+	return SearchUsersHandler{
+		searchUsersUsecase,
+	}
+}
+
+// SearchUsers searches users by their usernames and display names,
+// each annotated with the viewer's relationship to them.
+func (h *SearchUsersHandler) SearchUsers(w http.ResponseWriter, r *http.Request, params openapi.SearchUsersParams) {
+	slog.Info("GET /api/search/users was called.")
+
+	query, err := normalizeUserQuery(params.Q)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid search query: %v\n", err), http.StatusBadRequest)
+		return
+	}
+
+	cursor, limit, err := parsePageParams(params.Cursor, params.Limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid pagination parameters: %v", err), http.StatusBadRequest)
+		return
+	}
+	// Users are ordered by relevance, so the cursor must carry the rank of the last user.
+	if cursor != nil && cursor.Rank == nil {
+		http.Error(w, fmt.Sprintln("Invalid pagination parameters: the cursor is not one of a user search"), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.searchUsersUsecase.SearchUsers(query, viewerIDFromContext(r), cursor, limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not search users."), http.StatusInternalServerError)
+		return
+	}
+
+	res := relatedUserPageResponseBody{
+		Users:      page.Items,
+		NextCursor: encodeNextCursor(page.NextCursor),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(res)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
+
+// normalizeUserQuery trims the spaces and the leading "@" of a user search query
+// or a typeahead prefix, and fails if nothing is left or the query is too long.
+func normalizeUserQuery(q string) (string, error) {
+	q = strings.TrimSpace(q)
+	q = strings.TrimLeft(q, "@＠")
+	if q == "" {
+		return "", errors.New("the query is empty")
+	}
+	if utf8.RuneCountInString(q) > maxUserQueryLength {
+		return "", fmt.Errorf("the query must be at most %d characters", maxUserQueryLength)
+	}
+
+	return q, nil
+}
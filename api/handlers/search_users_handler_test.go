@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	openapi "x-clone-backend/gen"
+)
+
+func (s *HandlersTestSuite) TestSearchUsers() {
+	viewerID := s.newTestUser(`{ "username": "viewer", "display_name": "viewer", "password": "securepassword" }`)
+	followedID := s.newTestUser(`{ "username": "gopher_fan", "display_name": "Followed Gopher", "password": "securepassword" }`)
+	s.newTestUser(`{ "username": "gopher", "display_name": "The Gopher", "password": "securepassword" }`)
+	blockerID := s.newTestUser(`{ "username": "gopher_blocker", "display_name": "Blocker", "password": "securepassword" }`)
+	s.newTestUser(`{ "username": "rustacean", "display_name": "Crab", "password": "securepassword" }`)
+	s.newTestFollow(viewerID, followedID)
+	s.newTestBlock(blockerID, viewerID)
+
+	searchUsersHandler := NewSearchUsersHandler(s.db)
+
+	limit := 1
+
+	tests := []struct {
+		name              string
+		q                 string
+		viewerID          string
+		limit             *int
+		expectedCode      int
+		expectedUsernames []string
+		expectNext        bool
+	}{
+		{
+			// Users matching equally well are ordered by recency.
+			name:              "anonymous viewer",
+			q:                 "gopher",
+			expectedCode:      http.StatusOK,
+			expectedUsernames: []string{"gopher_blocker", "gopher", "gopher_fan"},
+		},
+		{
+			name:              "followed user boosted and blocker left out",
+			q:                 "@Gopher",
+			viewerID:          viewerID,
+			expectedCode:      http.StatusOK,
+			expectedUsernames: []string{"gopher_fan", "gopher"},
+		},
+		{
+			name:              "misspelled query",
+			q:                 "rustacaen",
+			expectedCode:      http.StatusOK,
+			expectedUsernames: []string{"rustacean"},
+		},
+		{
+			name:              "limited",
+			q:                 "gopher",
+			limit:             &limit,
+			expectedCode:      http.StatusOK,
+			expectedUsernames: []string{"gopher_blocker"},
+			expectNext:        true,
+		},
+		{
+			name:         "empty query",
+			q:            " @ ",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "too long query",
+			q:            strings.Repeat("a", maxUserQueryLength+1),
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/api/search/users", nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		searchUsersHandler.SearchUsers(rr, req, openapi.SearchUsersParams{Q: test.q, Limit: test.limit})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res relatedUserPageResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		var usernames []string
+		for _, user := range res.Users {
+			usernames = append(usernames, user.Username)
+		}
+		if strings.Join(usernames, ",") != strings.Join(test.expectedUsernames, ",") {
+			s.T().Errorf("%s: expected users %v, but got %v", test.name, test.expectedUsernames, usernames)
+		}
+		if (res.NextCursor != "") != test.expectNext {
+			s.T().Errorf("%s: unexpected next cursor %q", test.name, res.NextCursor)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestTypeaheadUsers() {
+	viewerID := s.newTestUser(`{ "username": "viewer", "display_name": "viewer", "password": "securepassword" }`)
+	followerID := s.newTestUser(`{ "username": "alice_follower", "display_name": "Alice", "password": "securepassword" }`)
+	s.newTestUser(`{ "username": "alice", "display_name": "Alice", "password": "securepassword" }`)
+	s.newTestUser(`{ "username": "alicia", "display_name": "Alicia", "password": "securepassword" }`)
+	s.newTestUser(`{ "username": "bob", "display_name": "Bob Alison", "password": "securepassword" }`)
+	s.newTestUser(`{ "username": "al_x", "display_name": "Al", "password": "securepassword" }`)
+	blockedID := s.newTestUser(`{ "username": "alice_blocked", "display_name": "Blocked", "password": "securepassword" }`)
+	s.newTestFollow(followerID, viewerID)
+	s.newTestBlock(viewerID, blockedID)
+
+	typeaheadUsersHandler := NewTypeaheadUsersHandler(s.db)
+
+	invalidLimit := maxTypeaheadLimit + 1
+
+	tests := []struct {
+		name              string
+		prefix            string
+		viewerID          string
+		limit             *int
+		expectedCode      int
+		expectedUsernames []string
+		anyOrder          bool
+	}{
+		{
+			name:              "exact username first, then a follower",
+			prefix:            "@alice",
+			viewerID:          viewerID,
+			expectedCode:      http.StatusOK,
+			expectedUsernames: []string{"alice", "alice_follower"},
+		},
+		{
+			name:              "usernames and words of display names",
+			prefix:            "ali",
+			expectedCode:      http.StatusOK,
+			expectedUsernames: []string{"alice", "alice_blocked", "alice_follower", "alicia", "bob"},
+			anyOrder:          true,
+		},
+		{
+			name:              "underscore matched literally",
+			prefix:            "al_",
+			expectedCode:      http.StatusOK,
+			expectedUsernames: []string{"al_x"},
+		},
+		{
+			name:         "empty prefix",
+			prefix:       "",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid limit",
+			prefix:       "al",
+			limit:        &invalidLimit,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/api/users/typeahead", nil)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		typeaheadUsersHandler.TypeaheadUsers(rr, req, openapi.TypeaheadUsersParams{Prefix: test.prefix, Limit: test.limit})
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var res usersResponseBody
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		var usernames []string
+		for _, user := range res.Users {
+			usernames = append(usernames, user.Username)
+		}
+		if test.anyOrder {
+			sort.Strings(usernames)
+		}
+		if strings.Join(usernames, ",") != strings.Join(test.expectedUsernames, ",") {
+			s.T().Errorf("%s: expected users %v, but got %v", test.name, test.expectedUsernames, usernames)
+		}
+	}
+}
@@ -85,6 +85,9 @@ type HandlersTestSuite struct {
 	followUserUsecase              usecases.FollowUserUsecase
 	muteUserUsecase                usecases.MuteUserUsecase
 	userChannels                   map[string]chan entities.TimelineEvent
+	listChannels                   map[string]map[chan entities.TimelineEvent]struct{}
+	notificationChannels           map[string]chan *entities.Notification
+	messageChannels                map[string]chan *entities.DirectMessageEvent
 	mu                             sync.Mutex
 }
 
@@ -126,11 +129,18 @@ func (s *HandlersTestSuite) SetupTest() {
 	s.getSpecificUserPostsUsecase = usecases.NewGetSpecificUserPostsUsecase(postsRepository)
 	s.getUserAndFolloweePostsUsecase = usecases.NewGetUserAndFolloweePostsUsecase(postsRepository)
 
+	notifyUsecase := usecases.NewNotifyUsecase(
+		infrastructure.NewNotificationsRepository(s.db),
+		postsRepository,
+		&s.mu,
+		&s.notificationChannels,
+	)
+
 	s.usersRepository = infrastructure.NewUsersRepository(s.db)
 	s.createUserUsecase = usecases.NewCreateUserUsecase(s.usersRepository)
-	s.likePostUsecase = usecases.NewLikePostUsecase(s.usersRepository)
+	s.likePostUsecase = usecases.NewLikePostUsecase(s.usersRepository, postsRepository, notifyUsecase)
 	s.unlikePostUsecase = usecases.NewUnlikePostUsecase(s.usersRepository)
-	s.followUserUsecase = usecases.NewFollowUserUsecase(s.usersRepository)
+	s.followUserUsecase = usecases.NewFollowUserUsecase(s.usersRepository, notifyUsecase)
 	s.muteUserUsecase = usecases.NewMuteUserUsecase(s.usersRepository)
 
 	secretKey := "test_secret_key"
@@ -138,6 +148,9 @@ func (s *HandlersTestSuite) SetupTest() {
 
 	s.mu = sync.Mutex{}
 	s.userChannels = make(map[string]chan entities.TimelineEvent)
+	s.listChannels = make(map[string]map[chan entities.TimelineEvent]struct{})
+	s.notificationChannels = make(map[string]chan *entities.Notification)
+	s.messageChannels = make(map[string]chan *entities.DirectMessageEvent)
 
 	m.Up()
 }
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"x-clone-backend/internal/domain/entities"
+)
+
+type StreamNotificationsHandler struct {
+	mu                *sync.Mutex
+	notificationChans *map[string]chan *entities.Notification
+}
+
+func NewStreamNotificationsHandler(mu *sync.Mutex, notificationChans *map[string]chan *entities.Notification) StreamNotificationsHandler {
+	return StreamNotificationsHandler{
+		mu:                mu,
+		notificationChans: notificationChans,
+	}
+}
+
+// StreamNotifications streams the authenticated user's new notifications as server-sent events,
+// in the same way as the reverse chronological home timeline streams posts.
+func (h *StreamNotificationsHandler) StreamNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := viewerIDFromContext(r)
+	if userID == "" {
+		http.Error(w, "Authentication required.", http.StatusUnauthorized)
+		return
+	}
+
+	h.mu.Lock()
+	if _, exists := (*h.notificationChans)[userID]; !exists {
+		(*h.notificationChans)[userID] = make(chan *entities.Notification, 1)
+	}
+	notificationChan := (*h.notificationChans)[userID]
+	h.mu.Unlock()
+
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case notification := <-notificationChan:
+			jsonData, err := json.Marshal(notification)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+
+			fmt.Fprintf(w, "data: %s\n\n", jsonData)
+			flusher.Flush()
+		case <-r.Context().Done():
+			h.mu.Lock()
+			delete(*h.notificationChans, userID)
+			h.mu.Unlock()
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	openapi "x-clone-backend/gen"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+const (
+	defaultTypeaheadLimit = 10
+	maxTypeaheadLimit     = 20
+)
+
+type TypeaheadUsersHandler struct {
+	typeaheadUsersUsecase usecases.TypeaheadUsersUsecase
+}
+
+func NewTypeaheadUsersHandler(db *sql.DB) TypeaheadUsersHandler {
+	usersRepository := infrastructure.NewUsersRepository(db)
+	typeaheadUsersUsecase := usecases.NewTypeaheadUsersUsecase(usersRepository)
+	return TypeaheadUsersHandler{
+		typeaheadUsersUsecase,
+	}
+}
+
+// TypeaheadUsers gets the users whose usernames or display names start with the prefix,
+// so that clients can complete mentions as they are typed.
+func (h *TypeaheadUsersHandler) TypeaheadUsers(w http.ResponseWriter, r *http.Request, params openapi.TypeaheadUsersParams) {
+	slog.Info("GET /api/users/typeahead was called.")
+
+	prefix, err := normalizeUserQuery(params.Prefix)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid prefix: %v\n", err), http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultTypeaheadLimit
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > maxTypeaheadLimit {
+			http.Error(w, fmt.Sprintf("limit must be between 1 and %d\n", maxTypeaheadLimit), http.StatusBadRequest)
+			return
+		}
+		limit = *params.Limit
+	}
+
+	users, err := h.typeaheadUsersUsecase.TypeaheadUsers(prefix, viewerIDFromContext(r), limit)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not get users."), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(usersResponseBody{Users: users})
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
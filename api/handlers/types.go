@@ -1,14 +1,34 @@
 package handlers
 
 import (
+	"time"
+	"x-clone-backend/internal/domain/entities"
+
 	"github.com/google/uuid"
 )
 
 // createPostRequestBody is the type of the "CreatePost"
 // endpoint request body.
 type createPostRequestBody struct {
-	UserID uuid.UUID `json:"user_id,omitempty"`
-	Text   string    `json:"text"`
+	UserID   uuid.UUID              `json:"user_id,omitempty"`
+	Text     string                 `json:"text"`
+	MediaIDs []uuid.UUID            `json:"media_ids,omitempty"`
+	Poll     *createPollRequestBody `json:"poll,omitempty"`
+	// ScheduledAt queues the post to be published later instead of now.
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+}
+
+// createPollRequestBody is the type of the poll
+// in the "CreatePost" endpoint request body.
+type createPollRequestBody struct {
+	Choices         []string `json:"choices"`
+	DurationMinutes int      `json:"duration_minutes"`
+}
+
+// editPostRequestBody is the type of the "EditPost"
+// endpoint request body.
+type editPostRequestBody struct {
+	Text string `json:"text"`
 }
 
 // likePostRequestBody is the type of the "LikePost"
@@ -32,8 +52,9 @@ type createRepostRequestBody struct {
 // createQuoteRepostRequestBody is the type of the "CreateQuoteRepost"
 // endpoint request body.
 type createQuoteRepostRequestBody struct {
-	PostID uuid.UUID `json:"post_id,omitempty"`
-	Text   string    `json:"text"`
+	PostID   uuid.UUID   `json:"post_id,omitempty"`
+	Text     string      `json:"text"`
+	MediaIDs []uuid.UUID `json:"media_ids,omitempty"`
 }
 
 // deleteRepostRequestBody is the type of the "DeleteRepost"
@@ -42,6 +63,53 @@ type deleteRepostRequestBody struct {
 	RepostID uuid.UUID `json:"repost_id,omitempty"`
 }
 
+// votePollRequestBody is the type of the "VotePoll"
+// endpoint request body.
+type votePollRequestBody struct {
+	Position *int `json:"position"`
+}
+
+// createBookmarkRequestBody is the type of the "CreateBookmark"
+// endpoint request body.
+type createBookmarkRequestBody struct {
+	PostID   uuid.UUID  `json:"post_id"`
+	FolderID *uuid.UUID `json:"folder_id,omitempty"`
+}
+
+// bookmarkFolderRequestBody is the type of the "CreateBookmarkFolder"
+// and "UpdateBookmarkFolder" endpoint request body.
+type bookmarkFolderRequestBody struct {
+	Name string `json:"name"`
+}
+
+// createListRequestBody is the type of the "CreateList"
+// endpoint request body.
+type createListRequestBody struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsPrivate   bool   `json:"is_private"`
+}
+
+// addListMemberRequestBody is the type of the "AddListMember"
+// endpoint request body.
+type addListMemberRequestBody struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// updateMediaRequestBody is the type of the "UpdateMedia"
+// endpoint request body.
+type updateMediaRequestBody struct {
+	AltText *string `json:"alt_text"`
+}
+
+// initMediaUploadRequestBody is the type of the "InitMediaUpload"
+// endpoint request body.
+type initMediaUploadRequestBody struct {
+	MediaType  string `json:"media_type"`
+	TotalBytes int64  `json:"total_bytes"`
+	SHA256     string `json:"sha256"`
+}
+
 // createMutingRequestBody is the type of the "CreateMute"
 // endpoint request body.
 type createMutingRequestBody struct {
@@ -53,3 +121,179 @@ type createMutingRequestBody struct {
 type createBlockingRequestBody struct {
 	TargetUserID string `json:"target_user_id,omitempty"`
 }
+
+// userSummaryPageResponseBody is the type of the response body
+// of the endpoints returning a page of users.
+type userSummaryPageResponseBody struct {
+	Users      []*entities.UserSummary `json:"users"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+// postPageResponseBody is the type of the response body
+// of the endpoints returning a page of posts.
+type postPageResponseBody struct {
+	Posts      []*entities.Post `json:"posts"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// quotePageResponseBody is the type of the "GetPostQuotes"
+// endpoint response body.
+type quotePageResponseBody struct {
+	Quotes     []*entities.Repost `json:"quotes"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// relatedUserPageResponseBody is the type of the response body
+// of the endpoints returning a page of users with their relationships to the viewer.
+type relatedUserPageResponseBody struct {
+	Users      []*entities.RelatedUser `json:"users"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+// relationshipsResponseBody is the type of the "GetRelationships"
+// endpoint response body.
+type relationshipsResponseBody struct {
+	Relationships map[uuid.UUID]*entities.Relationship `json:"relationships"`
+}
+
+// timestampedUserPageResponseBody is the type of the response body
+// of the endpoints returning a page of muted or blocked users.
+type timestampedUserPageResponseBody struct {
+	Users      []*entities.TimestampedUser `json:"users"`
+	NextCursor string                      `json:"next_cursor,omitempty"`
+}
+
+// listPageResponseBody is the type of the response body
+// of the endpoints returning a page of lists.
+type listPageResponseBody struct {
+	Lists      []*entities.List `json:"lists"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// createConversationRequestBody is the type of the "StartConversation"
+// endpoint request body.
+type createConversationRequestBody struct {
+	ParticipantID uuid.UUID `json:"participant_id"`
+}
+
+// createGroupConversationRequestBody is the type of the "CreateGroupConversation"
+// endpoint request body.
+type createGroupConversationRequestBody struct {
+	Name           string      `json:"name"`
+	ParticipantIDs []uuid.UUID `json:"participant_ids"`
+}
+
+// renameConversationRequestBody is the type of the "RenameConversation"
+// endpoint request body.
+type renameConversationRequestBody struct {
+	Name string `json:"name"`
+}
+
+// addConversationParticipantRequestBody is the type of the "AddConversationParticipant"
+// endpoint request body.
+type addConversationParticipantRequestBody struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// addReactionRequestBody is the type of the "AddDirectMessageReaction"
+// endpoint request body.
+type addReactionRequestBody struct {
+	Emoji string `json:"emoji"`
+}
+
+// sendDirectMessageRequestBody is the type of the "SendDirectMessage"
+// endpoint request body.
+type sendDirectMessageRequestBody struct {
+	Text string `json:"text"`
+}
+
+// conversationPageResponseBody is the type of the "GetConversations"
+// endpoint response body.
+type conversationPageResponseBody struct {
+	Conversations []*entities.Conversation `json:"conversations"`
+	NextCursor    string                   `json:"next_cursor,omitempty"`
+}
+
+// directMessagePageResponseBody is the type of the "GetDirectMessages"
+// endpoint response body.
+type directMessagePageResponseBody struct {
+	Messages   []*entities.DirectMessage `json:"messages"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+}
+
+// bookmarkFoldersResponseBody is the type of the "GetBookmarkFolders"
+// endpoint response body.
+type bookmarkFoldersResponseBody struct {
+	Folders []*entities.BookmarkFolder `json:"folders"`
+}
+
+// notificationPageResponseBody is the type of the "GetNotifications"
+// endpoint response body.
+type notificationPageResponseBody struct {
+	Notifications []*entities.Notification `json:"notifications"`
+	UnreadCount   int                      `json:"unread_count"`
+	NextCursor    string                   `json:"next_cursor,omitempty"`
+}
+
+// timelineItemPageResponseBody is the type of the response body
+// of the endpoints returning a page of posts mixed with quote reposts.
+type timelineItemPageResponseBody struct {
+	Items      []*entities.TimelineItem `json:"items"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+}
+
+// postHistoryResponseBody is the type of the "GetPostHistory"
+// endpoint response body.
+type postHistoryResponseBody struct {
+	Versions []*entities.PostEdit `json:"versions"`
+}
+
+// reschedulePostRequestBody is the type of the "ReschedulePost"
+// endpoint request body.
+type reschedulePostRequestBody struct {
+	ScheduledAt *time.Time `json:"scheduled_at"`
+}
+
+// scheduledPostPageResponseBody is the type of the "GetScheduledPosts"
+// endpoint response body.
+type scheduledPostPageResponseBody struct {
+	ScheduledPosts []*entities.ScheduledPost `json:"scheduled_posts"`
+	NextCursor     string                    `json:"next_cursor,omitempty"`
+}
+
+// draftRequestBody is the type of the "CreateDraft"
+// and "UpdateDraft" endpoints request body.
+type draftRequestBody struct {
+	Text         string                 `json:"text"`
+	MediaIDs     []uuid.UUID            `json:"media_ids,omitempty"`
+	Poll         *createPollRequestBody `json:"poll,omitempty"`
+	QuotedPostID *uuid.UUID             `json:"quoted_post_id,omitempty"`
+}
+
+// draftPageResponseBody is the type of the "GetDrafts"
+// endpoint response body.
+type draftPageResponseBody struct {
+	Drafts     []*entities.Draft `json:"drafts"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// trendsResponseBody is the type of the "GetTrends"
+// endpoint response body.
+type trendsResponseBody struct {
+	Trends []*entities.Trend `json:"trends"`
+}
+
+// usersResponseBody is the type of the "TypeaheadUsers"
+// endpoint response body.
+type usersResponseBody struct {
+	Users []*entities.UserSummary `json:"users"`
+}
+
+// mediaUploadStatusResponseBody is the type of the "GetMediaUploadStatus"
+// endpoint response body. CheckAfterSecs is set while the media is processed.
+type mediaUploadStatusResponseBody struct {
+	*entities.MediaUpload
+	ReceivedBytes  int64           `json:"received_bytes"`
+	Media          *entities.Media `json:"media,omitempty"`
+	CheckAfterSecs int             `json:"check_after_secs,omitempty"`
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type UpdateMediaHandler struct {
+	updateMediaUsecase usecases.UpdateMediaUsecase
+}
+
+func NewUpdateMediaHandler(db *sql.DB) UpdateMediaHandler {
+	mediaRepository := infrastructure.NewMediaRepository(db)
+	updateMediaUsecase := usecases.NewUpdateMediaUsecase(mediaRepository)
+	return UpdateMediaHandler{
+		updateMediaUsecase,
+	}
+}
+
+// UpdateMedia sets the alt text of the media uploaded by the authenticated user.
+func (h *UpdateMediaHandler) UpdateMedia(w http.ResponseWriter, r *http.Request, mediaID string) {
+	slog.Info("PATCH /api/media/{mediaID} was called.")
+
+	if _, err := uuid.Parse(mediaID); err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a mediaID (ID: %s)\n", mediaID), http.StatusBadRequest)
+		return
+	}
+
+	viewerID, err := uuid.Parse(viewerIDFromContext(r))
+	if err != nil {
+		http.Error(w, "Authentication required.", http.StatusUnauthorized)
+		return
+	}
+
+	var body updateMediaRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Request body was invalid: %v", err), http.StatusBadRequest)
+		return
+	}
+	if body.AltText == nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid: alt_text is required"), http.StatusBadRequest)
+		return
+	}
+
+	media, err := h.updateMediaUsecase.UpdateAltText(viewerID, mediaID, *body.AltText)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrAltTextTooLong):
+			http.Error(w, fmt.Sprintf("Request body was invalid: %v\n", err), http.StatusBadRequest)
+		case errors.Is(err, domainerrors.ErrMediaNotFound):
+			http.Error(w, fmt.Sprintf("Could not find media (ID: %s)\n", mediaID), http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrNotMediaOwner):
+			http.Error(w, "Not allowed to change the media of another user.", http.StatusForbidden)
+		default:
+			http.Error(w, fmt.Sprintf("Could not update media (ID: %s)\n", mediaID), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(media)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+)
+
+// maxUploadRequestSize is the maximum size of an upload request,
+// leaving room for the multipart headers around the file.
+const maxUploadRequestSize = entities.MaxImageSize + 1<<20
+
+type UploadMediaHandler struct {
+	uploadMediaUsecase usecases.UploadMediaUsecase
+}
+
+func NewUploadMediaHandler(db *sql.DB, uploadStore repositories.BlobStore) UploadMediaHandler {
+	mediaRepository := infrastructure.NewMediaRepository(db)
+	uploadMediaUsecase := usecases.NewUploadMediaUsecase(mediaRepository, uploadStore)
+	return UploadMediaHandler{
+		uploadMediaUsecase,
+	}
+}
+
+// UploadMedia stores the image in the "media" field of the multipart form
+// as media of the authenticated user.
+func (h *UploadMediaHandler) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	slog.Info("POST /api/media was called.")
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadRequestSize)
+	file, header, err := r.FormFile("media")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, fmt.Sprintf("The file must be at most %d bytes.\n", entities.MaxImageSize), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, fmt.Sprintln("Request body must have a media file."), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	if header.Size > entities.MaxImageSize {
+		http.Error(w, fmt.Sprintf("The file must be at most %d bytes.\n", entities.MaxImageSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	media, err := h.uploadMediaUsecase.UploadMedia(userID, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrUnsupportedMediaType):
+			http.Error(w, fmt.Sprintln("The file must be a JPEG, PNG, GIF or WebP image."), http.StatusUnsupportedMediaType)
+		case errors.Is(err, domainerrors.ErrMediaTooLarge):
+			http.Error(w, fmt.Sprintf("The file must be at most %d bytes.\n", entities.MaxImageSize), http.StatusRequestEntityTooLarge)
+		default:
+			http.Error(w, fmt.Sprintln("Could not upload the media."), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(media)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
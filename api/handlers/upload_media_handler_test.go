@@ -0,0 +1,420 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"x-clone-backend/internal/app/services"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+	"x-clone-backend/internal/infrastructure/storage"
+)
+
+// pngHeader is the signature of PNG files, which is enough for an upload to be accepted.
+const pngHeader = "\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"
+
+// newMediaUploadRequest returns an upload request with the content as the media file.
+func newMediaUploadRequest(content []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile("media", "image.png")
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/media", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// newTestPNG returns a PNG image of the size which can be processed.
+func newTestPNG(width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func (s *HandlersTestSuite) newTestBlobStore() repositories.BlobStore {
+	blobStore, err := storage.NewLocalBlobStore(s.T().TempDir(), "/media")
+	if err != nil {
+		s.T().Fatalf("Failed to create a blob store: %v", err)
+	}
+	return blobStore
+}
+
+// processTestMedia processes all the pending media whose originals are stored in the upload store.
+// Media stored in other blob stores are marked as failed.
+func (s *HandlersTestSuite) processTestMedia(uploadStore repositories.BlobStore) {
+	processMediaUsecase := usecases.NewProcessMediaUsecase(infrastructure.NewMediaRepository(s.db), uploadStore, s.newTestBlobStore(), services.NewImageService(), services.NewVideoService())
+	for {
+		n, err := processMediaUsecase.ProcessPendingMedia()
+		if err != nil {
+			s.T().Fatalf("Failed to process media: %v", err)
+		}
+		if n == 0 {
+			return
+		}
+	}
+}
+
+// newTestMedia uploads an image for the user and processes it, so that it can be attached.
+func (s *HandlersTestSuite) newTestMedia(userID string) string {
+	blobStore := s.newTestBlobStore()
+	uploadMediaHandler := NewUploadMediaHandler(s.db, blobStore)
+
+	rr := httptest.NewRecorder()
+	uploadMediaHandler.UploadMedia(rr, withViewer(newMediaUploadRequest(newTestPNG(16, 16)), userID))
+
+	var media entities.Media
+	_ = json.NewDecoder(rr.Body).Decode(&media)
+	s.processTestMedia(blobStore)
+	return media.ID.String()
+}
+
+func (s *HandlersTestSuite) TestUploadMedia() {
+	userID := s.newTestUser(`{ "username": "uploader", "display_name": "uploader", "password": "securepassword" }`)
+
+	uploadMediaHandler := NewUploadMediaHandler(s.db, s.newTestBlobStore())
+	getMediaByIDHandler := NewGetMediaByIDHandler(s.db)
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		content      []byte
+		expectedCode int
+		expectedType string
+	}{
+		{
+			name:         "upload a PNG image",
+			viewerID:     userID,
+			content:      []byte(pngHeader),
+			expectedCode: http.StatusCreated,
+			expectedType: "image/png",
+		},
+		{
+			name:         "upload a GIF image",
+			viewerID:     userID,
+			content:      []byte("GIF89a\x01\x00\x01\x00"),
+			expectedCode: http.StatusCreated,
+			expectedType: "image/gif",
+		},
+		{
+			name:         "fail to upload without authentication",
+			content:      []byte(pngHeader),
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "fail to upload a file which is not an image",
+			viewerID:     userID,
+			content:      []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>"),
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:         "fail to upload a too large image",
+			viewerID:     userID,
+			content:      append([]byte(pngHeader), make([]byte, entities.MaxImageSize)...),
+			expectedCode: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, test := range tests {
+		req := newMediaUploadRequest(test.content)
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		uploadMediaHandler.UploadMedia(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusCreated {
+			continue
+		}
+
+		var media entities.Media
+		if err := json.NewDecoder(rr.Body).Decode(&media); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+		if media.ContentType != test.expectedType || media.Size != int64(len(test.content)) || media.Status != entities.MediaPending || media.URL != "" {
+			s.T().Errorf("%s: unexpected media %+v", test.name, media)
+		}
+
+		rr = httptest.NewRecorder()
+		getMediaByIDHandler.GetMediaByID(rr, httptest.NewRequest("GET", "/api/media/{mediaID}", nil), media.ID.String())
+		if rr.Code != http.StatusOK {
+			s.T().Errorf("%s: failed to get the uploaded media; got %d", test.name, rr.Code)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestCreatePostWithMedia() {
+	userID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	otherID := s.newTestUser(`{ "username": "other", "display_name": "other", "password": "securepassword" }`)
+
+	var mediaIDs []string
+	for i := 0; i < entities.MaxMediaPerPost+1; i++ {
+		mediaIDs = append(mediaIDs, fmt.Sprintf("%q", s.newTestMedia(userID)))
+	}
+	otherMediaID := s.newTestMedia(otherID)
+
+	uploadMediaHandler := NewUploadMediaHandler(s.db, s.newTestBlobStore())
+	rr := httptest.NewRecorder()
+	uploadMediaHandler.UploadMedia(rr, withViewer(newMediaUploadRequest(newTestPNG(16, 16)), userID))
+	var pendingMedia entities.Media
+	_ = json.NewDecoder(rr.Body).Decode(&pendingMedia)
+
+	createPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+
+	tests := []struct {
+		name          string
+		mediaIDs      string
+		expectedCode  int
+		expectedCount int
+	}{
+		{
+			name:          "attach up to four media",
+			mediaIDs:      strings.Join(mediaIDs[:entities.MaxMediaPerPost], ","),
+			expectedCode:  http.StatusCreated,
+			expectedCount: entities.MaxMediaPerPost,
+		},
+		{
+			name:         "fail to attach more than four media",
+			mediaIDs:     strings.Join(mediaIDs, ","),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail to attach the same media twice",
+			mediaIDs:     mediaIDs[0] + "," + mediaIDs[0],
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail to attach media of another user",
+			mediaIDs:     fmt.Sprintf("%q", otherMediaID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail to attach media which has not been processed",
+			mediaIDs:     fmt.Sprintf("%q", pendingMedia.ID.String()),
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(
+			"POST",
+			"/api/posts",
+			strings.NewReader(fmt.Sprintf(`{ "user_id": "%s", "text": "photos", "media_ids": [%s] }`, userID, test.mediaIDs)),
+		)
+		rr := httptest.NewRecorder()
+
+		createPostHandler.CreatePost(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusCreated {
+			continue
+		}
+
+		var post entities.Post
+		if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+		if len(post.Media) != test.expectedCount {
+			s.T().Errorf("%s: expected %d media, but got %d", test.name, test.expectedCount, len(post.Media))
+			continue
+		}
+
+		fetched, err := NewGetPostByIDHandler(s.db).getSpecificPostUsecase.GetSpecificPost(post.ID.String(), "")
+		if err != nil || len(fetched.Media) != test.expectedCount || fetched.Media[0].ID != post.Media[0].ID {
+			s.T().Errorf("%s: the media are not returned with the post", test.name)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestProcessMedia() {
+	userID := s.newTestUser(`{ "username": "uploader", "display_name": "uploader", "password": "securepassword" }`)
+
+	blobStore := s.newTestBlobStore()
+	uploadMediaHandler := NewUploadMediaHandler(s.db, blobStore)
+	getMediaByIDHandler := NewGetMediaByIDHandler(s.db)
+
+	tests := []struct {
+		name               string
+		content            []byte
+		expectedStatus     entities.MediaStatus
+		expectedRenditions []entities.Rendition
+	}{
+		{
+			name:           "process an image into renditions",
+			content:        newTestPNG(1000, 500),
+			expectedStatus: entities.MediaSucceeded,
+			expectedRenditions: []entities.Rendition{
+				{Name: "thumb", ContentType: "image/jpeg", Width: 150, Height: 75},
+				{Name: "small", ContentType: "image/jpeg", Width: 680, Height: 340},
+				{Name: "large", ContentType: "image/jpeg", Width: 1000, Height: 500},
+			},
+		},
+		{
+			name:           "fail to process a broken image",
+			content:        []byte(pngHeader),
+			expectedStatus: entities.MediaFailed,
+		},
+	}
+
+	for _, test := range tests {
+		rr := httptest.NewRecorder()
+		uploadMediaHandler.UploadMedia(rr, withViewer(newMediaUploadRequest(test.content), userID))
+		var uploaded entities.Media
+		if err := json.NewDecoder(rr.Body).Decode(&uploaded); err != nil {
+			s.T().Errorf("%s: failed to upload media", test.name)
+			continue
+		}
+
+		s.processTestMedia(blobStore)
+
+		rr = httptest.NewRecorder()
+		getMediaByIDHandler.GetMediaByID(rr, httptest.NewRequest("GET", "/api/media/{mediaID}", nil), uploaded.ID.String())
+		var media entities.Media
+		if err := json.NewDecoder(rr.Body).Decode(&media); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+
+		if media.Status != test.expectedStatus {
+			s.T().Errorf("%s: expected status %s, but got %s", test.name, test.expectedStatus, media.Status)
+			continue
+		}
+		if _, err := blobStore.Get(uploaded.ID.String() + "/original"); !errors.Is(err, fs.ErrNotExist) {
+			s.T().Errorf("%s: expected the original to be deleted, but got %v", test.name, err)
+		}
+		if len(media.Renditions) != len(test.expectedRenditions) {
+			s.T().Errorf("%s: expected %d renditions, but got %d", test.name, len(test.expectedRenditions), len(media.Renditions))
+			continue
+		}
+		for i, expected := range test.expectedRenditions {
+			rendition := *media.Renditions[i]
+			expected.URL = rendition.URL
+			if rendition != expected || rendition.URL == "" {
+				s.T().Errorf("%s: expected rendition %+v, but got %+v", test.name, expected, rendition)
+			}
+		}
+		if test.expectedStatus == entities.MediaSucceeded && (media.URL != media.Renditions[len(media.Renditions)-1].URL || media.Blurhash == "") {
+			s.T().Errorf("%s: unexpected media %+v", test.name, media)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestUpdateMedia() {
+	userID := s.newTestUser(`{ "username": "uploader", "display_name": "uploader", "password": "securepassword" }`)
+	otherID := s.newTestUser(`{ "username": "other", "display_name": "other", "password": "securepassword" }`)
+	mediaID := s.newTestMedia(userID)
+
+	updateMediaHandler := NewUpdateMediaHandler(s.db)
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		mediaID      string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "set the alt text",
+			viewerID:     userID,
+			mediaID:      mediaID,
+			body:         `{ "alt_text": "A cat sleeping on a keyboard" }`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "set the longest alt text",
+			viewerID:     userID,
+			mediaID:      mediaID,
+			body:         fmt.Sprintf(`{ "alt_text": "%s" }`, strings.Repeat("猫", entities.MaxAltTextLength)),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "fail to set too long alt text",
+			viewerID:     userID,
+			mediaID:      mediaID,
+			body:         fmt.Sprintf(`{ "alt_text": "%s" }`, strings.Repeat("a", entities.MaxAltTextLength+1)),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail without alt text",
+			viewerID:     userID,
+			mediaID:      mediaID,
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail without authentication",
+			mediaID:      mediaID,
+			body:         `{ "alt_text": "a cat" }`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "fail to set the alt text of media of another user",
+			viewerID:     otherID,
+			mediaID:      mediaID,
+			body:         `{ "alt_text": "a cat" }`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "fail to set the alt text of non-existent media",
+			viewerID:     userID,
+			mediaID:      "00000000-0000-0000-0000-000000000000",
+			body:         `{ "alt_text": "a cat" }`,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("PATCH", "/api/media/{mediaID}", strings.NewReader(test.body))
+		if test.viewerID != "" {
+			req = withViewer(req, test.viewerID)
+		}
+		rr := httptest.NewRecorder()
+
+		updateMediaHandler.UpdateMedia(rr, req, test.mediaID)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusOK {
+			continue
+		}
+
+		var body updateMediaRequestBody
+		_ = json.Unmarshal([]byte(test.body), &body)
+		media, err := NewGetMediaByIDHandler(s.db).getMediaUsecase.GetMedia(test.mediaID)
+		if err != nil || media.AltText != *body.AltText {
+			s.T().Errorf("%s: the alt text is not stored", test.name)
+		}
+	}
+}
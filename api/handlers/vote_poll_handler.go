@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/usecases"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+type VotePollHandler struct {
+	votePollUsecase usecases.VotePollUsecase
+}
+
+func NewVotePollHandler(db *sql.DB) VotePollHandler {
+	pollsRepository := infrastructure.NewPollsRepository(db)
+	postsRepository := infrastructure.NewPostsRepository(db)
+	usersRepository := infrastructure.NewUsersRepository(db)
+	votePollUsecase := usecases.NewVotePollUsecase(pollsRepository, postsRepository, usersRepository)
+	return VotePollHandler{
+		votePollUsecase,
+	}
+}
+
+// VotePoll votes for a choice in the poll of the specified post as the authenticated user,
+// and returns the poll with its results.
+func (h *VotePollHandler) VotePoll(w http.ResponseWriter, r *http.Request, postIDStr string) {
+	slog.Info("POST /api/posts/{postID}/poll/votes was called.")
+
+	postID, err := uuid.Parse(postIDStr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not parse a postID (ID: %s)\n", postIDStr), http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := authenticatedUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var body votePollRequestBody
+
+	decoder := json.NewDecoder(r.Body)
+	err = decoder.Decode(&body)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid."), http.StatusBadRequest)
+		return
+	}
+	if body.Position == nil {
+		http.Error(w, fmt.Sprintln("Request body was invalid: position is required"), http.StatusBadRequest)
+		return
+	}
+
+	poll, err := h.votePollUsecase.Vote(userID, postID, *body.Position)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrPostNotFound):
+			http.Error(w, fmt.Sprintf("Could not find a post (ID: %s)\n", postID), http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrPollNotFound):
+			http.Error(w, fmt.Sprintf("The post has no poll (ID: %s)\n", postID), http.StatusNotFound)
+		case errors.Is(err, domainerrors.ErrBlocked), errors.Is(err, domainerrors.ErrPrivateAccount):
+			http.Error(w, fmt.Sprintf("Not allowed to vote in the poll: %v\n", err), http.StatusForbidden)
+		case errors.Is(err, domainerrors.ErrInvalidPollChoice):
+			http.Error(w, fmt.Sprintf("The poll has no choice at position %d.\n", *body.Position), http.StatusBadRequest)
+		case errors.Is(err, domainerrors.ErrAlreadyVoted), errors.Is(err, domainerrors.ErrPollClosed):
+			http.Error(w, fmt.Sprintf("Could not vote in the poll: %v\n", err), http.StatusConflict)
+		default:
+			http.Error(w, fmt.Sprintln("Could not vote in the poll."), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(poll)
+	if err != nil {
+		http.Error(w, fmt.Sprintln("Could not encode response."), http.StatusInternalServerError)
+		return
+	}
+}
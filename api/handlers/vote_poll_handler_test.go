@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/app/usecases"
+	"x-clone-backend/internal/domain/entities"
+	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+
+	"github.com/google/uuid"
+)
+
+func (s *HandlersTestSuite) TestCreatePostWithPoll() {
+	userID := s.newTestUser(`{ "username": "pollster", "display_name": "pollster", "password": "securepassword" }`)
+	mediaID := s.newTestMedia(userID)
+
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "valid poll",
+			body:         fmt.Sprintf(`{ "user_id": "%s", "text": "which?", "poll": { "choices": [" cats ", "dogs"], "duration_minutes": 60 } }`, userID),
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "only one choice",
+			body:         fmt.Sprintf(`{ "user_id": "%s", "text": "which?", "poll": { "choices": ["cats"], "duration_minutes": 60 } }`, userID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "duplicate choices",
+			body:         fmt.Sprintf(`{ "user_id": "%s", "text": "which?", "poll": { "choices": ["cats", "Cats"], "duration_minutes": 60 } }`, userID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "too short duration",
+			body:         fmt.Sprintf(`{ "user_id": "%s", "text": "which?", "poll": { "choices": ["cats", "dogs"], "duration_minutes": 1 } }`, userID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "poll with media",
+			body:         fmt.Sprintf(`{ "user_id": "%s", "text": "which?", "media_ids": ["%s"], "poll": { "choices": ["cats", "dogs"], "duration_minutes": 60 } }`, userID, mediaID),
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	createPostHandler := NewCreatePostHandler(s.db, &s.mu, &s.userChannels, &s.listChannels, &s.notificationChannels, text.DefaultConfig())
+	for _, test := range tests {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/posts", strings.NewReader(test.body))
+		createPostHandler.CreatePost(rr, req)
+
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusCreated {
+			continue
+		}
+
+		var post entities.Post
+		if err := json.NewDecoder(rr.Body).Decode(&post); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+		if post.Poll == nil || len(post.Poll.Choices) != 2 || post.Poll.Choices[0].Label != "cats" || post.Poll.Closed {
+			s.T().Errorf("%s: wrong poll returned; got %+v", test.name, post.Poll)
+		}
+	}
+}
+
+func (s *HandlersTestSuite) TestVotePoll() {
+	authorID := s.newTestUser(`{ "username": "author", "display_name": "author", "password": "securepassword" }`)
+	voterID := s.newTestUser(`{ "username": "voter", "display_name": "voter", "password": "securepassword" }`)
+	viewerID := s.newTestUser(`{ "username": "viewer", "display_name": "viewer", "password": "securepassword" }`)
+	postID := s.newTestPost(fmt.Sprintf(
+		`{ "user_id": "%s", "text": "which?", "poll": { "choices": ["cats", "dogs", "birds"], "duration_minutes": 60 } }`, authorID,
+	))
+	noPollPostID := s.newTestPost(fmt.Sprintf(`{ "user_id": "%s", "text": "no poll" }`, authorID))
+
+	votePollHandler := NewVotePollHandler(s.db)
+	vote := func(viewerID, postID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/api/posts/%s/poll/votes", postID), strings.NewReader(body))
+		if viewerID != "" {
+			req = withViewer(req, viewerID)
+		}
+		rr := httptest.NewRecorder()
+		votePollHandler.VotePoll(rr, req, postID)
+		return rr
+	}
+
+	tests := []struct {
+		name         string
+		viewerID     string
+		postID       string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "anonymous viewer",
+			postID:       postID,
+			body:         `{ "position": 1 }`,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "missing position",
+			viewerID:     voterID,
+			postID:       postID,
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "non-existent choice",
+			viewerID:     voterID,
+			postID:       postID,
+			body:         `{ "position": 3 }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "post without a poll",
+			viewerID:     voterID,
+			postID:       noPollPostID,
+			body:         `{ "position": 0 }`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "non-existent post",
+			viewerID:     voterID,
+			postID:       uuid.New().String(),
+			body:         `{ "position": 0 }`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "valid vote",
+			viewerID:     voterID,
+			postID:       postID,
+			body:         `{ "position": 1 }`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "second vote",
+			viewerID:     voterID,
+			postID:       postID,
+			body:         `{ "position": 0 }`,
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, test := range tests {
+		rr := vote(test.viewerID, test.postID, test.body)
+		if rr.Code != test.expectedCode {
+			s.T().Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+			continue
+		}
+		if rr.Code != http.StatusCreated {
+			continue
+		}
+
+		var poll entities.Poll
+		if err := json.NewDecoder(rr.Body).Decode(&poll); err != nil {
+			s.T().Errorf("%s: failed to decode response", test.name)
+			continue
+		}
+		if poll.VotedChoice == nil || *poll.VotedChoice != 1 || poll.TotalVotes != 1 {
+			s.T().Errorf("%s: wrong poll returned; got %+v", test.name, poll)
+		}
+		if count := poll.Choices[1].VoteCount; count == nil || *count != 1 {
+			s.T().Errorf("%s: expected 1 vote for the choice, but got %v", test.name, count)
+		}
+	}
+
+	// The results are hidden from the viewers who have not voted until the poll closes.
+	for _, viewer := range []struct {
+		id             string
+		resultsVisible bool
+	}{
+		{id: voterID, resultsVisible: true},
+		{id: authorID, resultsVisible: true},
+		{id: viewerID, resultsVisible: false},
+	} {
+		poll := s.getTestPoll(viewer.id, postID)
+		if visible := poll.Choices[1].VoteCount != nil; visible != viewer.resultsVisible {
+			s.T().Errorf("wrong visibility of the results for %s; expected %t, but got %t", viewer.id, viewer.resultsVisible, visible)
+		}
+	}
+
+	_, err := s.db.Exec(`UPDATE polls SET ends_at = CURRENT_TIMESTAMP - interval '1 minute' WHERE post_id = $1`, postID)
+	if err != nil {
+		s.T().Fatalf("failed to end the poll: %v", err)
+	}
+
+	if rr := vote(viewerID, postID, `{ "position": 0 }`); rr.Code != http.StatusConflict {
+		s.T().Errorf("vote after the poll ended: expected %d, but got %d", http.StatusConflict, rr.Code)
+	}
+	if poll := s.getTestPoll(viewerID, postID); !poll.Closed || poll.Choices[1].VoteCount == nil || *poll.Choices[1].VoteCount != 1 {
+		s.T().Errorf("expected the results of the ended poll to be visible; got %+v", poll)
+	}
+
+	closePollsUsecase := usecases.NewClosePollsUsecase(
+		infrastructure.NewPollsRepository(s.db),
+		infrastructure.NewPostsRepository(s.db),
+		usecases.NewNotifyUsecase(infrastructure.NewNotificationsRepository(s.db), infrastructure.NewPostsRepository(s.db), &s.mu, &s.notificationChannels),
+	)
+	n, err := closePollsUsecase.CloseEndedPolls()
+	if err != nil || n != 1 {
+		s.T().Fatalf("expected 1 poll to be closed, but got %d (%v)", n, err)
+	}
+	if n, _ := closePollsUsecase.CloseEndedPolls(); n != 0 {
+		s.T().Errorf("expected no poll to be closed again, but got %d", n)
+	}
+
+	for _, recipientID := range []string{authorID, voterID} {
+		notifications := s.getTestNotifications(recipientID, nil).Notifications
+		if len(notifications) != 1 || notifications[0].Type != entities.NotificationPollClosed {
+			s.T().Errorf("expected a poll_closed notification for %s, but got %+v", recipientID, notifications)
+		}
+	}
+	if notifications := s.getTestNotifications(viewerID, nil).Notifications; len(notifications) != 0 {
+		s.T().Errorf("expected no notifications for the viewer who did not vote, but got %+v", notifications)
+	}
+}
+
+func (s *HandlersTestSuite) getTestPoll(viewerID, postID string) *entities.Poll {
+	post := s.getTestPost(viewerID, postID)
+	if post.Poll == nil {
+		s.T().Fatalf("Expected the post to have a poll")
+	}
+	return post.Poll
+}
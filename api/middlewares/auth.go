@@ -44,3 +44,20 @@ func JWTMiddleware(s *services.AuthService) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// OptionalJWTMiddleware is a middleware function that validates JWT tokens
+// only when the Authorization header is present.
+// Requests without the header are passed through as anonymous ones,
+// so that public endpoints can tailor their responses to the viewer if known.
+func OptionalJWTMiddleware(s *services.AuthService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			JWTMiddleware(s)(next).ServeHTTP(w, r)
+		})
+	}
+}
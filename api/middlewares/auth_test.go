@@ -72,3 +72,65 @@ func TestJWTMiddleware(t *testing.T) {
 		t.Errorf("Handler returned wrong status code for missing header: got %v want %v", status, http.StatusUnauthorized)
 	}
 }
+
+// TestOptionalJWTMiddleware tests the OptionalJWTMiddleware function by verifying
+// that requests without an Authorization header pass through without claims,
+// a valid token stores claims in the context, and an invalid token is rejected.
+func TestOptionalJWTMiddleware(t *testing.T) {
+	secretKey := "test_secret_key"
+	authService := services.NewAuthService(secretKey)
+
+	tokenString, _ := authService.GenerateJWT(uuid.New(), "test_user")
+
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(UserContextKey).(*services.UserClaims); ok {
+			w.Write([]byte("Authenticated"))
+			return
+		}
+		w.Write([]byte("Anonymous"))
+	})
+
+	handlerToTest := OptionalJWTMiddleware(authService)(testHandler)
+
+	tests := []struct {
+		name         string
+		header       string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "missing Authorization header",
+			header:       "",
+			expectedCode: http.StatusOK,
+			expectedBody: "Anonymous",
+		},
+		{
+			name:         "valid token",
+			header:       "Bearer " + tokenString,
+			expectedCode: http.StatusOK,
+			expectedBody: "Authenticated",
+		},
+		{
+			name:         "invalid token",
+			header:       "Bearer invalidtoken",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+		rr := httptest.NewRecorder()
+
+		handlerToTest.ServeHTTP(rr, req)
+
+		if rr.Code != test.expectedCode {
+			t.Errorf("%s: wrong code returned; expected %d, but got %d", test.name, test.expectedCode, rr.Code)
+		}
+		if test.expectedBody != "" && rr.Body.String() != test.expectedBody {
+			t.Errorf("%s: unexpected body; expected %s, but got %s", test.name, test.expectedBody, rr.Body.String())
+		}
+	}
+}
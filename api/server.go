@@ -7,7 +7,9 @@ import (
 	"x-clone-backend/api/handlers"
 	openapi "x-clone-backend/gen"
 	"x-clone-backend/internal/app/services"
+	"x-clone-backend/internal/app/text"
 	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
 )
 
 var _ openapi.ServerInterface = (*Server)(nil)
@@ -17,6 +19,39 @@ type Server struct {
 	handlers.CreateUserHandler
 	handlers.FindUserByIDHandler
 	handlers.CreatePostHandler
+	handlers.GetPostByIDHandler
+	handlers.EditPostHandler
+	handlers.VotePollHandler
+	handlers.GetPostLikersHandler
+	handlers.GetPostRepostersHandler
+	handlers.GetPostQuotesHandler
+	handlers.GetLikedPostsHandler
+	handlers.GetMentionTimelineHandler
+	handlers.GetHashtagPostsHandler
+	handlers.GetTrendsHandler
+	handlers.SearchPostsHandler
+	handlers.UploadMediaHandler
+	handlers.GetMediaByIDHandler
+	handlers.UpdateMediaHandler
+	handlers.MediaUploadHandler
+	handlers.FollowLinkHandler
+	handlers.SearchUsersHandler
+	handlers.TypeaheadUsersHandler
+	handlers.GetFollowersHandler
+	handlers.GetFollowingHandler
+	handlers.GetRelationshipsHandler
+	handlers.GetMutedUsersHandler
+	handlers.GetBlockedUsersHandler
+	handlers.BookmarksHandler
+	handlers.ListsHandler
+	handlers.ScheduledPostsHandler
+	handlers.DraftsHandler
+	handlers.FollowRequestsHandler
+	handlers.DirectMessagesHandler
+	handlers.GetNotificationsHandler
+	handlers.MarkNotificationsAsReadHandler
+	handlers.StreamNotificationsHandler
+	handlers.NotificationPreferencesHandler
 	handlers.CreateRepostHandler
 	handlers.CreateQuoteRepostHandler
 	handlers.DeleteRepostHandler
@@ -24,14 +59,47 @@ type Server struct {
 	handlers.GetReverseChronologicalHomeTimelineHandler
 }
 
-func NewServer(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, authService *services.AuthService) Server {
+func NewServer(db *sql.DB, mu *sync.Mutex, usersChan *map[string]chan entities.TimelineEvent, listChans *map[string]map[chan entities.TimelineEvent]struct{}, notificationChans *map[string]chan *entities.Notification, messageChans *map[string]chan *entities.DirectMessageEvent, authService *services.AuthService, uploadStore repositories.BlobStore, textConfig text.Config, editPolicy entities.PostEditPolicy) Server {
 	return Server{
 		CreateUserHandler:                          handlers.NewCreateUserHandler(db, authService),
 		FindUserByIDHandler:                        handlers.NewFindUserByIDHandler(db),
-		CreatePostHandler:                          handlers.NewCreatePostHandler(db, mu, usersChan),
-		CreateRepostHandler:                        handlers.NewCreateRepostHandler(db, mu, usersChan),
-		CreateQuoteRepostHandler:                   handlers.NewCreateQuoteRepostHandler(db, mu, usersChan),
-		DeleteRepostHandler:                        handlers.NewDeleteRepostHandler(db, mu, usersChan),
+		CreatePostHandler:                          handlers.NewCreatePostHandler(db, mu, usersChan, listChans, notificationChans, textConfig),
+		GetPostByIDHandler:                         handlers.NewGetPostByIDHandler(db),
+		EditPostHandler:                            handlers.NewEditPostHandler(db, mu, usersChan, listChans, notificationChans, textConfig, editPolicy),
+		VotePollHandler:                            handlers.NewVotePollHandler(db),
+		GetPostLikersHandler:                       handlers.NewGetPostLikersHandler(db),
+		GetPostRepostersHandler:                    handlers.NewGetPostRepostersHandler(db),
+		GetPostQuotesHandler:                       handlers.NewGetPostQuotesHandler(db),
+		GetLikedPostsHandler:                       handlers.NewGetLikedPostsHandler(db),
+		GetMentionTimelineHandler:                  handlers.NewGetMentionTimelineHandler(db),
+		GetHashtagPostsHandler:                     handlers.NewGetHashtagPostsHandler(db),
+		GetTrendsHandler:                           handlers.NewGetTrendsHandler(db),
+		UploadMediaHandler:                         handlers.NewUploadMediaHandler(db, uploadStore),
+		GetMediaByIDHandler:                        handlers.NewGetMediaByIDHandler(db),
+		UpdateMediaHandler:                         handlers.NewUpdateMediaHandler(db),
+		MediaUploadHandler:                         handlers.NewMediaUploadHandler(db, uploadStore),
+		FollowLinkHandler:                          handlers.NewFollowLinkHandler(db),
+		SearchPostsHandler:                         handlers.NewSearchPostsHandler(db),
+		SearchUsersHandler:                         handlers.NewSearchUsersHandler(db),
+		TypeaheadUsersHandler:                      handlers.NewTypeaheadUsersHandler(db),
+		GetFollowersHandler:                        handlers.NewGetFollowersHandler(db),
+		GetFollowingHandler:                        handlers.NewGetFollowingHandler(db),
+		GetRelationshipsHandler:                    handlers.NewGetRelationshipsHandler(db),
+		GetMutedUsersHandler:                       handlers.NewGetMutedUsersHandler(db),
+		GetBlockedUsersHandler:                     handlers.NewGetBlockedUsersHandler(db),
+		BookmarksHandler:                           handlers.NewBookmarksHandler(db),
+		ListsHandler:                               handlers.NewListsHandler(db, mu, listChans),
+		ScheduledPostsHandler:                      handlers.NewScheduledPostsHandler(db, mu, usersChan, listChans, notificationChans),
+		DraftsHandler:                              handlers.NewDraftsHandler(db, mu, usersChan, listChans, notificationChans, textConfig),
+		FollowRequestsHandler:                      handlers.NewFollowRequestsHandler(db),
+		DirectMessagesHandler:                      handlers.NewDirectMessagesHandler(db, mu, messageChans),
+		GetNotificationsHandler:                    handlers.NewGetNotificationsHandler(db),
+		MarkNotificationsAsReadHandler:             handlers.NewMarkNotificationsAsReadHandler(db),
+		StreamNotificationsHandler:                 handlers.NewStreamNotificationsHandler(mu, notificationChans),
+		NotificationPreferencesHandler:             handlers.NewNotificationPreferencesHandler(db),
+		CreateRepostHandler:                        handlers.NewCreateRepostHandler(db, mu, usersChan, listChans, notificationChans),
+		CreateQuoteRepostHandler:                   handlers.NewCreateQuoteRepostHandler(db, mu, usersChan, listChans, notificationChans, textConfig),
+		DeleteRepostHandler:                        handlers.NewDeleteRepostHandler(db, mu, usersChan, listChans),
 		GetUserPostsTimelineHandler:                handlers.NewGetUserPostsTimelineHandler(db),
 		GetReverseChronologicalHomeTimelineHandler: handlers.NewGetReverseChronologicalHomeTimelineHandler(db, mu, usersChan),
 	}
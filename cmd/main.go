@@ -3,9 +3,15 @@ package main
 import (
 	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"x-clone-backend/api"
 	"x-clone-backend/api/handlers"
@@ -13,13 +19,27 @@ import (
 	"x-clone-backend/db"
 	openapi "x-clone-backend/gen"
 	"x-clone-backend/internal/app/services"
+	"x-clone-backend/internal/app/text"
 	"x-clone-backend/internal/app/usecases"
 	"x-clone-backend/internal/domain/entities"
 	infrastructure "x-clone-backend/internal/infrastructure/persistence"
+	"x-clone-backend/internal/infrastructure/storage"
+	"x-clone-backend/internal/infrastructure/unfurl"
 )
 
 const (
 	port = 80
+	// trendsInterval is how often trends are recomputed.
+	trendsInterval = 5 * time.Minute
+	// expiredMediaUploadsInterval is how often expired media uploads are deleted.
+	expiredMediaUploadsInterval = time.Hour
+	// defaultMediaDir is where processed media are stored unless MEDIA_DIR is set.
+	defaultMediaDir = "media"
+	// defaultUploadDir is where the originals of uploaded media are kept until they
+	// are processed unless UPLOAD_DIR is set. It is never served.
+	defaultUploadDir = "uploads"
+	// mediaURLPath is the path the stored media are served under.
+	mediaURLPath = "/media"
 )
 
 func main() {
@@ -31,69 +51,168 @@ func main() {
 	defer db.Close()
 
 	var userChannels = make(map[string]chan entities.TimelineEvent)
+	var listChannels = make(map[string]map[chan entities.TimelineEvent]struct{})
+	var notificationChannels = make(map[string]chan *entities.Notification)
+	var messageChannels = make(map[string]chan *entities.DirectMessageEvent)
 	var mu sync.Mutex
 
 	authService := services.NewAuthService(secretKey)
 
-	server := api.NewServer(db, &mu, &userChannels, authService)
+	mediaDir := os.Getenv("MEDIA_DIR")
+	if mediaDir == "" {
+		mediaDir = defaultMediaDir
+	}
+	mediaStore, err := storage.NewLocalBlobStore(mediaDir, mediaURLPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	uploadDir := os.Getenv("UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = defaultUploadDir
+	}
+	if filepath.Clean(uploadDir) == filepath.Clean(mediaDir) {
+		log.Fatalln("UPLOAD_DIR must be different from MEDIA_DIR.")
+	}
+	// The originals have no URL, as they are never served.
+	uploadStore, err := storage.NewLocalBlobStore(uploadDir, "")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	textConfig := text.DefaultConfig()
+	if maxPostLength := os.Getenv("MAX_POST_LENGTH"); maxPostLength != "" {
+		textConfig.MaxWeightedLength, err = strconv.Atoi(maxPostLength)
+		if err != nil || textConfig.MaxWeightedLength <= 0 {
+			log.Fatalln("MAX_POST_LENGTH must be a positive integer.")
+		}
+	}
+
+	editPolicy := entities.DefaultPostEditPolicy()
+	if maxPostEdits := os.Getenv("MAX_POST_EDITS"); maxPostEdits != "" {
+		editPolicy.MaxEdits, err = strconv.Atoi(maxPostEdits)
+		if err != nil || editPolicy.MaxEdits < 0 {
+			log.Fatalln("MAX_POST_EDITS must be a non-negative integer.")
+		}
+	}
+	if postEditWindow := os.Getenv("POST_EDIT_WINDOW"); postEditWindow != "" {
+		editPolicy.Window, err = time.ParseDuration(postEditWindow)
+		if err != nil || editPolicy.Window < 0 {
+			log.Fatalln("POST_EDIT_WINDOW must be a non-negative duration such as 1h.")
+		}
+	}
+
+	draftMaxAge := entities.DefaultDraftMaxAge
+	if maxAge := os.Getenv("DRAFT_MAX_AGE"); maxAge != "" {
+		draftMaxAge, err = time.ParseDuration(maxAge)
+		if err != nil || draftMaxAge <= 0 {
+			log.Fatalln("DRAFT_MAX_AGE must be a positive duration such as 720h.")
+		}
+	}
+
+	server := api.NewServer(db, &mu, &userChannels, &listChannels, &notificationChannels, &messageChannels, authService, uploadStore, textConfig, editPolicy)
 	mux := http.NewServeMux()
 
 	usersRepository := infrastructure.NewUsersRepository(db)
+	notifyUsecase := usecases.NewNotifyUsecase(
+		infrastructure.NewNotificationsRepository(db),
+		infrastructure.NewPostsRepository(db),
+		&mu,
+		&notificationChannels,
+	)
 	deleteUserUsecase := usecases.NewDeleteUserUsecase(usersRepository)
-	likePostUsecase := usecases.NewLikePostUsecase(usersRepository)
+	likePostUsecase := usecases.NewLikePostUsecase(usersRepository, infrastructure.NewPostsRepository(db), notifyUsecase)
 	unlikePostUsecase := usecases.NewUnlikePostUsecase(usersRepository)
-	followUserUsecase := usecases.NewFollowUserUsecase(usersRepository)
+	followUserUsecase := usecases.NewFollowUserUsecase(usersRepository, notifyUsecase)
 	unfollowUserUsecase := usecases.NewUnfollowUserUsecase(usersRepository)
 	muteUserUsecase := usecases.NewMuteUserUsecase(usersRepository)
 	unmuteUserUsecase := usecases.NewUnmuteUserUsecase(usersRepository)
 	blockUserUsecase := usecases.NewBlockUserUsecase(usersRepository)
 	unblockUserUsecase := usecases.NewUnblockUserUsecase(usersRepository)
+	computeTrendsUsecase := usecases.NewComputeTrendsUsecase(infrastructure.NewTrendsRepository(db))
 
-	mux.HandleFunc("DELETE /api/posts/{postID}", func(w http.ResponseWriter, r *http.Request) {
-		handlers.DeletePost(w, r, db, &mu, &userChannels)
-	})
+	mediaRepository := infrastructure.NewMediaRepository(db)
+	processMediaUsecase := usecases.NewProcessMediaUsecase(mediaRepository, uploadStore, mediaStore, services.NewImageService(), services.NewVideoService())
+	mediaUploadUsecase := usecases.NewMediaUploadUsecase(infrastructure.NewMediaUploadRepository(db), mediaRepository, uploadStore)
+	unfurlLinksUsecase := usecases.NewUnfurlLinksUsecase(infrastructure.NewLinksRepository(db), unfurl.NewHTTPUnfurlFetcher())
+	closePollsUsecase := usecases.NewClosePollsUsecase(infrastructure.NewPollsRepository(db), infrastructure.NewPostsRepository(db), notifyUsecase)
+	scheduledPostsUsecase := usecases.NewScheduledPostsUsecase(
+		infrastructure.NewScheduledPostsRepository(db),
+		usecases.NewCreatePostEntitiesUsecase(infrastructure.NewPostsRepository(db), usersRepository, notifyUsecase),
+		usecases.NewAttachMediaUsecase(mediaRepository),
+		usecases.NewCreatePollUsecase(infrastructure.NewPollsRepository(db)),
+		usecases.NewPublishTimelineEventUsecase(usersRepository, infrastructure.NewListsRepository(db), &mu, &userChannels, &listChannels),
+	)
+	draftsUsecase := usecases.NewDraftsUsecase(infrastructure.NewDraftsRepository(db))
+
+	go computeTrendsPeriodically(computeTrendsUsecase)
+	go processMediaPeriodically(processMediaUsecase)
+	go deleteExpiredMediaUploadsPeriodically(mediaUploadUsecase)
+	go unfurlLinksPeriodically(unfurlLinksUsecase)
+	go closePollsPeriodically(closePollsUsecase)
+	go publishScheduledPostsPeriodically(scheduledPostsUsecase)
+	go purgeDraftsPeriodically(draftsUsecase, draftMaxAge)
 
-	mux.HandleFunc("DELETE /api/users/{userID}", func(w http.ResponseWriter, r *http.Request) {
+	// The routes below act as the user in the path, so they are authenticated in the same
+	// way as those of the OpenAPI spec, and the handlers authorize the user.
+	authenticated := func(handler http.HandlerFunc) http.Handler {
+		return middlewares.OptionalJWTMiddleware(authService)(handler)
+	}
+
+	mux.Handle("DELETE /api/posts/{postID}", authenticated(func(w http.ResponseWriter, r *http.Request) {
+		handlers.DeletePost(w, r, db, &mu, &userChannels, &listChannels)
+	}))
+
+	mux.Handle("DELETE /api/users/{userID}", authenticated(func(w http.ResponseWriter, r *http.Request) {
 		handlers.DeleteUserByID(w, r, deleteUserUsecase)
-	})
+	}))
 
-	mux.HandleFunc("POST /api/users/{id}/likes", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("POST /api/users/{id}/likes", authenticated(func(w http.ResponseWriter, r *http.Request) {
 		handlers.LikePost(w, r, likePostUsecase)
-	})
+	}))
 
-	mux.HandleFunc("DELETE /api/users/{id}/likes/{post_id}", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("DELETE /api/users/{id}/likes/{post_id}", authenticated(func(w http.ResponseWriter, r *http.Request) {
 		handlers.UnlikePost(w, r, unlikePostUsecase)
-	})
+	}))
 
-	mux.HandleFunc("POST /api/users/{id}/following", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("POST /api/users/{id}/following", authenticated(func(w http.ResponseWriter, r *http.Request) {
 		handlers.CreateFollowship(w, r, followUserUsecase)
-	})
+	}))
 
-	mux.HandleFunc("DELETE /api/users/{source_user_id}/following/{target_user_id}", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("DELETE /api/users/{source_user_id}/following/{target_user_id}", authenticated(func(w http.ResponseWriter, r *http.Request) {
 		handlers.DeleteFollowship(w, r, unfollowUserUsecase)
-	})
+	}))
 
-	mux.HandleFunc("POST /api/users/{id}/muting", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("POST /api/users/{id}/muting", authenticated(func(w http.ResponseWriter, r *http.Request) {
 		handlers.CreateMuting(w, r, muteUserUsecase)
-	})
+	}))
 
-	mux.HandleFunc("DELETE /api/users/{source_user_id}/muting/{target_user_id}", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("DELETE /api/users/{source_user_id}/muting/{target_user_id}", authenticated(func(w http.ResponseWriter, r *http.Request) {
 		handlers.DeleteMuting(w, r, unmuteUserUsecase)
-	})
+	}))
 
-	mux.HandleFunc("POST /api/users/{id}/blocking", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("POST /api/users/{id}/blocking", authenticated(func(w http.ResponseWriter, r *http.Request) {
 		handlers.CreateBlocking(w, r, blockUserUsecase)
-	})
+	}))
 
-	mux.HandleFunc("DELETE /api/users/{source_user_id}/blocking/{target_user_id}", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("DELETE /api/users/{source_user_id}/blocking/{target_user_id}", authenticated(func(w http.ResponseWriter, r *http.Request) {
 		handlers.DeleteBlocking(w, r, unblockUserUsecase)
-	})
+	}))
 
-	mux.HandleFunc("/api/notifications", func(w http.ResponseWriter, r *http.Request) {
-		fmt.Fprintf(w, "Notifications\n")
+	mediaServer := http.StripPrefix(mediaURLPath+"/", http.FileServer(http.Dir(mediaDir)))
+	mux.HandleFunc("GET "+mediaURLPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		// Directories are not listed, so that media cannot be enumerated.
+		// Originals stored here before they were moved to UPLOAD_DIR are not served either.
+		if strings.HasSuffix(r.URL.Path, "/") || path.Base(r.URL.Path) == "original" || strings.Contains(r.URL.Path, "/segments/") {
+			http.NotFound(w, r)
+			return
+		}
+		mediaServer.ServeHTTP(w, r)
 	})
 
-	handler := middlewares.CORS(openapi.HandlerFromMux(&server, mux))
+	handler := middlewares.CORS(openapi.HandlerWithOptions(&server, openapi.StdHTTPServerOptions{
+		BaseRouter:  mux,
+		Middlewares: []openapi.MiddlewareFunc{middlewares.OptionalJWTMiddleware(authService)},
+	}))
 	s := http.Server{
 		Handler: handler,
 		Addr:    fmt.Sprintf(":%d", port),
@@ -106,3 +225,130 @@ func main() {
 		log.Fatalln(err)
 	}
 }
+
+// computeTrendsPeriodically computes trends right away and then every trendsInterval.
+// Failures are logged and retried at the next interval.
+func computeTrendsPeriodically(computeTrendsUsecase usecases.ComputeTrendsUsecase) {
+	ticker := time.NewTicker(trendsInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := computeTrendsUsecase.ComputeTrends(time.Now()); err != nil {
+			slog.Error("Could not compute trends.", "error", err)
+		}
+		<-ticker.C
+	}
+}
+
+// processMediaPeriodically processes uploaded media every MediaProcessingInterval,
+// without waiting for the next interval as long as full batches are left.
+// Failures are logged and retried at the next interval.
+func processMediaPeriodically(processMediaUsecase usecases.ProcessMediaUsecase) {
+	ticker := time.NewTicker(usecases.MediaProcessingInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := processMediaUsecase.ProcessPendingMedia()
+		if err != nil {
+			slog.Error("Could not process media.", "error", err)
+		}
+		if err != nil || n < usecases.MediaProcessingBatchSize {
+			<-ticker.C
+		}
+	}
+}
+
+// unfurlLinksPeriodically fetches the preview cards of posted links every
+// LinkUnfurlingInterval, without waiting for the next interval as long as full batches are left.
+// Failures are logged and retried at the next interval.
+func unfurlLinksPeriodically(unfurlLinksUsecase usecases.UnfurlLinksUsecase) {
+	ticker := time.NewTicker(usecases.LinkUnfurlingInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := unfurlLinksUsecase.UnfurlPendingLinks()
+		if err != nil {
+			slog.Error("Could not unfurl links.", "error", err)
+		}
+		if err != nil || n < usecases.LinkUnfurlingBatchSize {
+			<-ticker.C
+		}
+	}
+}
+
+// closePollsPeriodically closes the ended polls every PollClosingInterval,
+// without waiting for the next interval as long as full batches are left.
+// Failures are logged and retried at the next interval.
+func closePollsPeriodically(closePollsUsecase usecases.ClosePollsUsecase) {
+	ticker := time.NewTicker(usecases.PollClosingInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := closePollsUsecase.CloseEndedPolls()
+		if err != nil {
+			slog.Error("Could not close ended polls.", "error", err)
+		}
+		if err != nil || n < usecases.PollClosingBatchSize {
+			<-ticker.C
+		}
+	}
+}
+
+// publishScheduledPostsPeriodically publishes the due scheduled posts every ScheduledPostPublishingInterval,
+// without waiting for the next interval as long as full batches are left.
+// Failures are logged and retried at the next interval.
+func publishScheduledPostsPeriodically(scheduledPostsUsecase usecases.ScheduledPostsUsecase) {
+	ticker := time.NewTicker(usecases.ScheduledPostPublishingInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := scheduledPostsUsecase.PublishDuePosts()
+		if err != nil {
+			slog.Error("Could not publish due scheduled posts.", "error", err)
+		}
+		if err != nil || n < usecases.ScheduledPostPublishingBatchSize {
+			<-ticker.C
+		}
+	}
+}
+
+// purgeDraftsPeriodically deletes the drafts which have not been updated for maxAge
+// every DraftPurgingInterval. Failures are logged and retried at the next interval.
+func purgeDraftsPeriodically(draftsUsecase usecases.DraftsUsecase, maxAge time.Duration) {
+	ticker := time.NewTicker(usecases.DraftPurgingInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := draftsUsecase.PurgeDrafts(time.Now().Add(-maxAge))
+			if err != nil {
+				slog.Error("Could not purge expired drafts.", "error", err)
+			}
+			if err != nil || n < usecases.DraftPurgingBatchSize {
+				break
+			}
+		}
+		<-ticker.C
+	}
+}
+
+// deleteExpiredMediaUploadsPeriodically deletes the media uploads which expired
+// without being finalized, and their segments, every expiredMediaUploadsInterval.
+// Failures are logged and retried at the next interval.
+func deleteExpiredMediaUploadsPeriodically(mediaUploadUsecase usecases.MediaUploadUsecase) {
+	ticker := time.NewTicker(expiredMediaUploadsInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := mediaUploadUsecase.DeleteExpiredUploads(time.Now())
+			if err != nil {
+				slog.Error("Could not delete expired media uploads.", "error", err)
+			}
+			if err != nil || n == 0 {
+				break
+			}
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS likes_user_id_idx;
+DROP INDEX IF EXISTS reposts_parent_post_id_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS reposts_parent_post_id_idx ON reposts (parent_post_id);
+CREATE INDEX IF NOT EXISTS likes_user_id_idx ON likes (user_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS likes_post_id_created_at_idx;
+DROP INDEX IF EXISTS likes_user_id_created_at_idx;
+
+ALTER TABLE likes
+DROP COLUMN IF EXISTS "created_at";
@@ -0,0 +1,5 @@
+ALTER TABLE likes
+ADD COLUMN "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS likes_user_id_created_at_idx ON likes (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS likes_post_id_created_at_idx ON likes (post_id, created_at DESC);
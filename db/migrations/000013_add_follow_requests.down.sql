@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS follow_requests;
+
+DROP INDEX IF EXISTS followships_source_user_id_created_at_idx;
+DROP INDEX IF EXISTS followships_target_user_id_created_at_idx;
+
+ALTER TABLE followships
+DROP COLUMN IF EXISTS "created_at";
@@ -0,0 +1,14 @@
+ALTER TABLE followships
+ADD COLUMN "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS followships_target_user_id_created_at_idx ON followships (target_user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS followships_source_user_id_created_at_idx ON followships (source_user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS follow_requests (
+    "source_user_id" UUID NOT NULL,
+    "target_user_id" UUID NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (source_user_id, target_user_id),
+    FOREIGN KEY (source_user_id) REFERENCES users(id),
+    FOREIGN KEY (target_user_id) REFERENCES users(id)
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS mutes_source_user_id_created_at_idx;
+DROP INDEX IF EXISTS blocks_source_user_id_created_at_idx;
+
+ALTER TABLE mutes
+DROP COLUMN IF EXISTS "created_at";
+
+ALTER TABLE blocks
+DROP COLUMN IF EXISTS "created_at";
@@ -0,0 +1,8 @@
+ALTER TABLE mutes
+ADD COLUMN "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE blocks
+ADD COLUMN "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS mutes_source_user_id_created_at_idx ON mutes (source_user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS blocks_source_user_id_created_at_idx ON blocks (source_user_id, created_at DESC);
@@ -0,0 +1,11 @@
+ALTER TABLE followships
+DROP CONSTRAINT IF EXISTS followships_not_self_check;
+
+ALTER TABLE follow_requests
+DROP CONSTRAINT IF EXISTS follow_requests_not_self_check;
+
+ALTER TABLE mutes
+DROP CONSTRAINT IF EXISTS mutes_not_self_check;
+
+ALTER TABLE blocks
+DROP CONSTRAINT IF EXISTS blocks_not_self_check;
@@ -0,0 +1,11 @@
+ALTER TABLE followships
+ADD CONSTRAINT followships_not_self_check CHECK (source_user_id <> target_user_id);
+
+ALTER TABLE follow_requests
+ADD CONSTRAINT follow_requests_not_self_check CHECK (source_user_id <> target_user_id);
+
+ALTER TABLE mutes
+ADD CONSTRAINT mutes_not_self_check CHECK (source_user_id <> target_user_id);
+
+ALTER TABLE blocks
+ADD CONSTRAINT blocks_not_self_check CHECK (source_user_id <> target_user_id);
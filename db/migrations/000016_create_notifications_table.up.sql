@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "recipient_id" UUID NOT NULL,
+    "actor_id" UUID NOT NULL,
+    "type" VARCHAR(32) NOT NULL,
+    "post_id" UUID,
+    "source_id" UUID,
+    "group_key" TEXT,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "read_at" TIMESTAMPTZ,
+    FOREIGN KEY (recipient_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS notifications_recipient_id_created_at_idx ON notifications (recipient_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS notifications_recipient_id_unread_idx ON notifications (recipient_id) WHERE read_at IS NULL;
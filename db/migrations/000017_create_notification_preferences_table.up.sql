@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    "user_id" UUID PRIMARY KEY,
+    "likes" BOOLEAN NOT NULL DEFAULT TRUE,
+    "reposts" BOOLEAN NOT NULL DEFAULT TRUE,
+    "follows" BOOLEAN NOT NULL DEFAULT TRUE,
+    "mentions" BOOLEAN NOT NULL DEFAULT TRUE,
+    "only_from_following" BOOLEAN NOT NULL DEFAULT FALSE,
+    "filter_no_bio" BOOLEAN NOT NULL DEFAULT FALSE,
+    "filter_new_accounts" BOOLEAN NOT NULL DEFAULT FALSE,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS users_lower_username_idx;
+DROP TABLE IF EXISTS post_mentions;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS post_mentions (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "post_id" UUID,
+    "repost_id" UUID,
+    "user_id" UUID NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (repost_id) REFERENCES reposts(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT post_mentions_source_check CHECK ((post_id IS NULL) <> (repost_id IS NULL)),
+    UNIQUE (post_id, user_id),
+    UNIQUE (repost_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS post_mentions_user_id_created_at_idx ON post_mentions (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS users_lower_username_idx ON users (LOWER(username));
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS hashtags (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "tag" TEXT UNIQUE NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS post_hashtags (
+    "post_id" UUID NOT NULL,
+    "hashtag_id" UUID NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (post_id, hashtag_id),
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (hashtag_id) REFERENCES hashtags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS post_hashtags_hashtag_id_created_at_idx ON post_hashtags (hashtag_id, created_at DESC, post_id DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS post_hashtags_created_at_idx;
+DROP TABLE IF EXISTS trends;
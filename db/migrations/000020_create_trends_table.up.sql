@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS trends (
+    "hashtag_id" UUID PRIMARY KEY,
+    "score" DOUBLE PRECISION NOT NULL,
+    "post_count" INTEGER NOT NULL,
+    "author_count" INTEGER NOT NULL,
+    "window_start" TIMESTAMPTZ NOT NULL,
+    "window_end" TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (hashtag_id) REFERENCES hashtags(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS post_hashtags_created_at_idx ON post_hashtags (created_at);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS reposts_search_vector_idx;
+DROP INDEX IF EXISTS posts_search_vector_idx;
+
+ALTER TABLE reposts
+DROP COLUMN IF EXISTS "search_vector";
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS "search_vector";
@@ -0,0 +1,8 @@
+ALTER TABLE posts
+ADD COLUMN IF NOT EXISTS "search_vector" TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple'::regconfig, text)) STORED;
+
+ALTER TABLE reposts
+ADD COLUMN IF NOT EXISTS "search_vector" TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple'::regconfig, text)) STORED;
+
+CREATE INDEX IF NOT EXISTS posts_search_vector_idx ON posts USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS reposts_search_vector_idx ON reposts USING GIN (search_vector) WHERE is_quote;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS users_display_name_trgm_idx;
+DROP INDEX IF EXISTS users_username_trgm_idx;
+
+DROP EXTENSION IF EXISTS pg_trgm;
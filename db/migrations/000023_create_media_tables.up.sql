@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS media (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "content_type" TEXT NOT NULL,
+    "size" BIGINT NOT NULL,
+    "storage_key" TEXT NOT NULL,
+    "url" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS post_media (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "post_id" UUID,
+    "repost_id" UUID,
+    "media_id" UUID NOT NULL,
+    "position" SMALLINT NOT NULL,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (repost_id) REFERENCES reposts(id) ON DELETE CASCADE,
+    FOREIGN KEY (media_id) REFERENCES media(id) ON DELETE CASCADE,
+    CONSTRAINT post_media_source_check CHECK ((post_id IS NULL) <> (repost_id IS NULL)),
+    UNIQUE (post_id, position),
+    UNIQUE (repost_id, position)
+);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS media_unprocessed_idx;
+
+ALTER TABLE media
+DROP COLUMN IF EXISTS "updated_at",
+DROP COLUMN IF EXISTS "renditions",
+DROP COLUMN IF EXISTS "alt_text",
+DROP COLUMN IF EXISTS "blurhash",
+DROP COLUMN IF EXISTS "height",
+DROP COLUMN IF EXISTS "width",
+DROP COLUMN IF EXISTS "status";
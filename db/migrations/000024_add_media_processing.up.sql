@@ -0,0 +1,13 @@
+ALTER TABLE media
+ADD COLUMN "status" TEXT NOT NULL DEFAULT 'pending',
+ADD COLUMN "width" INTEGER,
+ADD COLUMN "height" INTEGER,
+ADD COLUMN "blurhash" TEXT,
+ADD COLUMN "alt_text" TEXT NOT NULL DEFAULT '',
+ADD COLUMN "renditions" JSONB NOT NULL DEFAULT '[]',
+ADD COLUMN "updated_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- The files uploaded so far have been served as they are, so they are processed as well.
+UPDATE media SET url = '';
+
+CREATE INDEX IF NOT EXISTS media_unprocessed_idx ON media (created_at) WHERE status IN ('pending', 'in_progress');
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS media_upload_segments;
+
+DROP TABLE IF EXISTS media_uploads;
+
+ALTER TABLE media
+DROP COLUMN IF EXISTS "processing_error",
+DROP COLUMN IF EXISTS "duration_ms";
@@ -0,0 +1,29 @@
+ALTER TABLE media
+ADD COLUMN "duration_ms" INTEGER,
+ADD COLUMN "processing_error" TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS media_uploads (
+    "id" UUID PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "content_type" TEXT NOT NULL,
+    "total_bytes" BIGINT NOT NULL CHECK ("total_bytes" > 0),
+    "sha256" TEXT NOT NULL,
+    "finalized_at" TIMESTAMPTZ,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS media_uploads_user_id_created_at_idx ON media_uploads (user_id, created_at);
+CREATE INDEX IF NOT EXISTS media_uploads_unfinalized_idx ON media_uploads (expires_at) WHERE finalized_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS media_upload_segments (
+    "upload_id" UUID NOT NULL,
+    "segment_index" INTEGER NOT NULL CHECK ("segment_index" >= 0),
+    "size" BIGINT NOT NULL,
+    "sha256" TEXT NOT NULL,
+    "storage_key" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("upload_id", "segment_index"),
+    FOREIGN KEY (upload_id) REFERENCES media_uploads(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS post_links;
+
+DROP TABLE IF EXISTS links;
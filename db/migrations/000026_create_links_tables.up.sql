@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS links (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "code" TEXT NOT NULL UNIQUE,
+    "url" TEXT NOT NULL UNIQUE,
+    "click_count" BIGINT NOT NULL DEFAULT 0,
+    "unfurl_status" TEXT NOT NULL DEFAULT 'pending',
+    "card" JSONB,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS links_ununfurled_idx ON links (created_at) WHERE unfurl_status IN ('pending', 'in_progress');
+
+CREATE TABLE IF NOT EXISTS post_links (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "post_id" UUID,
+    "repost_id" UUID,
+    "link_id" UUID NOT NULL,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (repost_id) REFERENCES reposts(id) ON DELETE CASCADE,
+    FOREIGN KEY (link_id) REFERENCES links(id) ON DELETE CASCADE,
+    CONSTRAINT post_links_source_check CHECK ((post_id IS NULL) <> (repost_id IS NULL)),
+    UNIQUE (post_id, link_id),
+    UNIQUE (repost_id, link_id)
+);
@@ -0,0 +1,18 @@
+DROP INDEX IF EXISTS reposts_search_vector_idx;
+DROP INDEX IF EXISTS posts_search_vector_idx;
+
+ALTER TABLE reposts DROP COLUMN IF EXISTS "search_vector";
+ALTER TABLE posts DROP COLUMN IF EXISTS "search_vector";
+
+-- Texts longer than 140 code points, which can be up to 280 Latin characters, are truncated.
+ALTER TABLE posts ALTER COLUMN "text" TYPE VARCHAR(140) USING LEFT(text, 140);
+ALTER TABLE reposts ALTER COLUMN "text" TYPE VARCHAR(140) USING LEFT(text, 140);
+
+ALTER TABLE posts
+ADD COLUMN "search_vector" TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple'::regconfig, text)) STORED;
+
+ALTER TABLE reposts
+ADD COLUMN "search_vector" TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple'::regconfig, text)) STORED;
+
+CREATE INDEX IF NOT EXISTS posts_search_vector_idx ON posts USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS reposts_search_vector_idx ON reposts USING GIN (search_vector) WHERE is_quote;
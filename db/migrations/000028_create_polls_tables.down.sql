@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS poll_votes;
+
+DROP TABLE IF EXISTS poll_choices;
+
+DROP TABLE IF EXISTS polls;
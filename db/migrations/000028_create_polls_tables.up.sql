@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS polls (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "post_id" UUID NOT NULL UNIQUE,
+    "ends_at" TIMESTAMPTZ NOT NULL,
+    "closed_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS polls_unclosed_idx ON polls (ends_at) WHERE closed_at IS NULL;
+
+-- vote_count holds the final result, which is stored when the poll is closed.
+CREATE TABLE IF NOT EXISTS poll_choices (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "poll_id" UUID NOT NULL,
+    "position" SMALLINT NOT NULL,
+    "label" TEXT NOT NULL,
+    "vote_count" INTEGER,
+    FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    UNIQUE (poll_id, position),
+    UNIQUE (id, poll_id)
+);
+
+-- The primary key allows a vote per user, and the foreign key to the pair of
+-- the choice and the poll makes sure the choice belongs to the poll.
+CREATE TABLE IF NOT EXISTS poll_votes (
+    "poll_id" UUID NOT NULL,
+    "user_id" UUID NOT NULL,
+    "choice_id" UUID NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (poll_id, user_id),
+    FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (choice_id, poll_id) REFERENCES poll_choices(id, poll_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS poll_votes_choice_id_idx ON poll_votes (choice_id);
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS bookmark_folders (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "name" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (user_id, name),
+    UNIQUE (id, user_id)
+);
+
+-- The foreign key to the pair of the folder and the user makes sure the folder
+-- belongs to the user who bookmarked the post. Deleting a folder deletes its bookmarks.
+CREATE TABLE IF NOT EXISTS bookmarks (
+    "user_id" UUID NOT NULL,
+    "post_id" UUID NOT NULL,
+    "folder_id" UUID,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, post_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (folder_id, user_id) REFERENCES bookmark_folders(id, user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS bookmarks_user_id_created_at_idx ON bookmarks (user_id, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS bookmarks_folder_id_created_at_idx ON bookmarks (folder_id, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS bookmarks_post_id_idx ON bookmarks (post_id);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS reposts_user_id_created_at_idx;
+
+DROP INDEX IF EXISTS posts_user_id_created_at_idx;
+
+DROP TABLE IF EXISTS list_subscriptions;
+
+DROP TABLE IF EXISTS list_members;
+
+DROP TABLE IF EXISTS lists;
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS lists (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "owner_id" UUID NOT NULL,
+    "name" TEXT NOT NULL,
+    "description" TEXT NOT NULL DEFAULT '',
+    "is_private" BOOLEAN NOT NULL DEFAULT FALSE,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS lists_owner_id_created_at_idx ON lists (owner_id, created_at DESC, id DESC);
+
+CREATE TABLE IF NOT EXISTS list_members (
+    "list_id" UUID NOT NULL,
+    "user_id" UUID NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (list_id, user_id),
+    FOREIGN KEY (list_id) REFERENCES lists(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS list_members_user_id_idx ON list_members (user_id);
+
+CREATE TABLE IF NOT EXISTS list_subscriptions (
+    "list_id" UUID NOT NULL,
+    "user_id" UUID NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (list_id, user_id),
+    FOREIGN KEY (list_id) REFERENCES lists(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS list_subscriptions_user_id_created_at_idx ON list_subscriptions (user_id, created_at DESC, list_id DESC);
+
+-- The timelines of lists merge the recent posts and reposts of the members.
+CREATE INDEX IF NOT EXISTS posts_user_id_created_at_idx ON posts (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS reposts_user_id_created_at_idx ON reposts (user_id, created_at DESC);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS direct_message_settings;
+
+DROP TABLE IF EXISTS direct_message_deletions;
+
+DROP TABLE IF EXISTS direct_messages;
+
+DROP TABLE IF EXISTS conversation_participants;
+
+DROP TABLE IF EXISTS conversations;
@@ -0,0 +1,49 @@
+-- A one-to-one conversation has the IDs of its two participants joined in ascending order
+-- as its direct_key, so that each pair of users has at most one conversation.
+CREATE TABLE IF NOT EXISTS conversations (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "direct_key" TEXT UNIQUE,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "last_message_at" TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS conversation_participants (
+    "conversation_id" UUID NOT NULL,
+    "user_id" UUID NOT NULL,
+    "last_read_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (conversation_id, user_id),
+    FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS conversation_participants_user_id_idx ON conversation_participants (user_id);
+
+CREATE TABLE IF NOT EXISTS direct_messages (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "conversation_id" UUID NOT NULL,
+    "sender_id" UUID NOT NULL,
+    "text" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE,
+    FOREIGN KEY (sender_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS direct_messages_conversation_id_created_at_idx ON direct_messages (conversation_id, created_at DESC, id DESC);
+
+-- Deleting a message hides it only from the user who deleted it.
+CREATE TABLE IF NOT EXISTS direct_message_deletions (
+    "message_id" UUID NOT NULL,
+    "user_id" UUID NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, user_id),
+    FOREIGN KEY (message_id) REFERENCES direct_messages(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS direct_message_settings (
+    "user_id" UUID PRIMARY KEY,
+    "allow_from" TEXT NOT NULL DEFAULT 'everyone' CHECK (allow_from IN ('everyone', 'following')),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS direct_message_reactions;
+
+ALTER TABLE direct_messages
+DROP COLUMN IF EXISTS "target_user_id",
+DROP COLUMN IF EXISTS "type";
+
+ALTER TABLE conversations
+DROP COLUMN IF EXISTS "name";
@@ -0,0 +1,20 @@
+-- Group conversations have no direct_key, and can be named.
+ALTER TABLE conversations
+ADD COLUMN "name" TEXT NOT NULL DEFAULT '';
+
+-- System messages record membership changes of group conversations:
+-- the sender is the user who made the change, target_user_id is the user added,
+-- and the text of a rename is the new name.
+ALTER TABLE direct_messages
+ADD COLUMN "type" TEXT NOT NULL DEFAULT 'text' CHECK (type IN ('text', 'participant_added', 'participant_left', 'conversation_renamed')),
+ADD COLUMN "target_user_id" UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS direct_message_reactions (
+    "message_id" UUID NOT NULL,
+    "user_id" UUID NOT NULL,
+    "emoji" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, user_id, emoji),
+    FOREIGN KEY (message_id) REFERENCES direct_messages(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
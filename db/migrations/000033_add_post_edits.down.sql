@@ -0,0 +1,8 @@
+ALTER TABLE reposts
+DROP COLUMN IF EXISTS "quoted_post_version";
+
+DROP TABLE IF EXISTS post_edits;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS "edited_at",
+DROP COLUMN IF EXISTS "edit_count";
@@ -0,0 +1,21 @@
+ALTER TABLE posts
+ADD COLUMN "edit_count" INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN "edited_at" TIMESTAMPTZ;
+
+-- Every version of an edited post is kept, the original text being version 0.
+-- Posts never edited have no versions here; their text is the only version.
+CREATE TABLE IF NOT EXISTS post_edits (
+    "post_id" UUID NOT NULL,
+    "version" INTEGER NOT NULL,
+    "text" TEXT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (post_id, version),
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+);
+
+-- Quote reposts keep referencing the version of the post they quoted.
+-- It is NULL for quotes of reposts, which cannot be edited.
+ALTER TABLE reposts
+ADD COLUMN "quoted_post_version" INTEGER;
+
+UPDATE reposts SET quoted_post_version = 0 WHERE is_quote AND parent_post_id IS NOT NULL;
@@ -0,0 +1,20 @@
+-- Posts queued by their authors to be published at scheduled_at.
+-- The media are attached and the poll is opened when a post is published,
+-- which sets published_at; post_id is the published post unless it has been deleted.
+CREATE TABLE IF NOT EXISTS scheduled_posts (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "text" TEXT NOT NULL,
+    "media_ids" UUID[] NOT NULL DEFAULT '{}',
+    "poll" JSONB,
+    "scheduled_at" TIMESTAMPTZ NOT NULL,
+    "published_at" TIMESTAMPTZ,
+    "post_id" UUID,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_posts_due_idx ON scheduled_posts (scheduled_at) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS scheduled_posts_user_id_scheduled_at_idx ON scheduled_posts (user_id, scheduled_at, id) WHERE published_at IS NULL;
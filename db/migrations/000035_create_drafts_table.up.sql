@@ -0,0 +1,17 @@
+-- Posts and quote reposts being written, saved so that they can be continued on another device.
+-- quoted_post_id is the post or the repost quoted when a draft is published, so it has no foreign key;
+-- it is checked when the draft is published, as the content of a draft is.
+CREATE TABLE IF NOT EXISTS drafts (
+    "id" UUID DEFAULT gen_random_uuid () PRIMARY KEY,
+    "user_id" UUID NOT NULL,
+    "text" TEXT NOT NULL DEFAULT '',
+    "media_ids" UUID[] NOT NULL DEFAULT '{}',
+    "poll" JSONB,
+    "quoted_post_id" UUID,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS drafts_user_id_updated_at_idx ON drafts (user_id, updated_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS drafts_updated_at_idx ON drafts (updated_at);
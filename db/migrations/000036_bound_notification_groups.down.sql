@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS notifications_recipient_id_group_key_idx;
+
+UPDATE notifications
+SET group_key = regexp_replace(group_key, ':\d{4}-\d{2}-\d{2}$', '')
+WHERE type IN ('like', 'repost') AND group_key IS NOT NULL;
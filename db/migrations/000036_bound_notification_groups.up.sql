@@ -0,0 +1,7 @@
+-- Likes and reposts of a post are grouped per day, as follows already are,
+-- so that listing notifications only aggregates the groups of the page.
+UPDATE notifications
+SET group_key = group_key || ':' || to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD')
+WHERE type IN ('like', 'repost') AND group_key IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS notifications_recipient_id_group_key_idx ON notifications (recipient_id, group_key, created_at DESC);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS scheduled_posts_due_idx;
+CREATE INDEX IF NOT EXISTS scheduled_posts_due_idx ON scheduled_posts (scheduled_at) WHERE published_at IS NULL;
+
+ALTER TABLE scheduled_posts
+DROP COLUMN IF EXISTS "failed_at",
+DROP COLUMN IF EXISTS "next_attempt_at",
+DROP COLUMN IF EXISTS "publish_attempts";
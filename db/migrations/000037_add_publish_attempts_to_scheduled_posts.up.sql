@@ -0,0 +1,11 @@
+-- Publishing a scheduled post which fails is tried again at next_attempt_at,
+-- and given up after a few attempts by setting failed_at, so that posts failing
+-- every time cannot hold up the posts scheduled after them.
+ALTER TABLE scheduled_posts
+ADD COLUMN "publish_attempts" INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN "next_attempt_at" TIMESTAMPTZ,
+ADD COLUMN "failed_at" TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS scheduled_posts_due_idx;
+CREATE INDEX IF NOT EXISTS scheduled_posts_due_idx ON scheduled_posts (COALESCE(next_attempt_at, scheduled_at))
+WHERE published_at IS NULL AND failed_at IS NULL;
@@ -5,35 +5,253 @@ package openapi
 
 import (
 	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for DirectMessageType.
+const (
+	ConversationRenamed DirectMessageType = "conversation_renamed"
+	ParticipantAdded    DirectMessageType = "participant_added"
+	ParticipantLeft     DirectMessageType = "participant_left"
+	Text                DirectMessageType = "text"
+)
+
+// Defines values for DirectMessageEventEventType.
+const (
+	ConversationRead DirectMessageEventEventType = "conversation_read"
+	MessageCreated   DirectMessageEventEventType = "message_created"
+	MessageReacted   DirectMessageEventEventType = "message_reacted"
+)
+
+// Defines values for DirectMessageSettingsAllowFrom.
+const (
+	DirectMessageSettingsAllowFromEveryone  DirectMessageSettingsAllowFrom = "everyone"
+	DirectMessageSettingsAllowFromFollowing DirectMessageSettingsAllowFrom = "following"
+)
+
+// Defines values for InitMediaUploadRequestMediaType.
+const (
+	InitMediaUploadRequestMediaTypeImagegif       InitMediaUploadRequestMediaType = "image/gif"
+	InitMediaUploadRequestMediaTypeImagejpeg      InitMediaUploadRequestMediaType = "image/jpeg"
+	InitMediaUploadRequestMediaTypeImagepng       InitMediaUploadRequestMediaType = "image/png"
+	InitMediaUploadRequestMediaTypeImagewebp      InitMediaUploadRequestMediaType = "image/webp"
+	InitMediaUploadRequestMediaTypeVideomp4       InitMediaUploadRequestMediaType = "video/mp4"
+	InitMediaUploadRequestMediaTypeVideoquicktime InitMediaUploadRequestMediaType = "video/quicktime"
+)
+
+// Defines values for MediaContentType.
+const (
+	MediaContentTypeImagegif       MediaContentType = "image/gif"
+	MediaContentTypeImagejpeg      MediaContentType = "image/jpeg"
+	MediaContentTypeImagepng       MediaContentType = "image/png"
+	MediaContentTypeImagewebp      MediaContentType = "image/webp"
+	MediaContentTypeVideomp4       MediaContentType = "video/mp4"
+	MediaContentTypeVideoquicktime MediaContentType = "video/quicktime"
+)
+
+// Defines values for MediaStatus.
+const (
+	Failed     MediaStatus = "failed"
+	InProgress MediaStatus = "in_progress"
+	Pending    MediaStatus = "pending"
+	Succeeded  MediaStatus = "succeeded"
 )
 
+// Defines values for NotificationType.
+const (
+	NotificationTypeFollow     NotificationType = "follow"
+	NotificationTypeLike       NotificationType = "like"
+	NotificationTypeMention    NotificationType = "mention"
+	NotificationTypePollClosed NotificationType = "poll_closed"
+	NotificationTypeQuote      NotificationType = "quote"
+	NotificationTypeRepost     NotificationType = "repost"
+)
+
+// Defines values for RenditionContentType.
+const (
+	Imagegif       RenditionContentType = "image/gif"
+	Imagejpeg      RenditionContentType = "image/jpeg"
+	Imagepng       RenditionContentType = "image/png"
+	Videomp4       RenditionContentType = "video/mp4"
+	Videoquicktime RenditionContentType = "video/quicktime"
+)
+
+// Defines values for RenditionName.
+const (
+	Animated RenditionName = "animated"
+	Large    RenditionName = "large"
+	Small    RenditionName = "small"
+	Thumb    RenditionName = "thumb"
+	Video    RenditionName = "video"
+)
+
+// Defines values for UpdateDirectMessageSettingsRequestAllowFrom.
+const (
+	UpdateDirectMessageSettingsRequestAllowFromEveryone  UpdateDirectMessageSettingsRequestAllowFrom = "everyone"
+	UpdateDirectMessageSettingsRequestAllowFromFollowing UpdateDirectMessageSettingsRequestAllowFrom = "following"
+)
+
+// Defines values for SearchPostsParamsSort.
+const (
+	Recency   SearchPostsParamsSort = "recency"
+	Relevance SearchPostsParamsSort = "relevance"
+)
+
+// AddConversationParticipantRequest defines model for add_conversation_participant_request.
+type AddConversationParticipantRequest struct {
+	UserId string `json:"user_id"`
+}
+
+// AddListMemberRequest defines model for add_list_member_request.
+type AddListMemberRequest struct {
+	UserId string `json:"user_id"`
+}
+
+// AddReactionRequest defines model for add_reaction_request.
+type AddReactionRequest struct {
+	// Emoji Up to 10 characters of emoji.
+	Emoji string `json:"emoji"`
+}
+
+// BookmarkFolder A folder the bookmarks are sorted into, which is private to its owner.
+type BookmarkFolder struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+}
+
+// BookmarkFolderRequest defines model for bookmark_folder_request.
+type BookmarkFolderRequest struct {
+	// Name Up to 25 characters, which must be unique among the user's folders.
+	Name string `json:"name"`
+}
+
+// Conversation A private conversation exchanging direct messages between its participants.
+// A group conversation can have up to 50 participants and a name,
+// while a one-to-one conversation always has the same two participants.
+// The last message and the unread count are relative to the authenticated user:
+// the messages the user has deleted are left out, and only the messages of the other
+// participants sent after the user last read the conversation are counted as unread.
+type Conversation struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+	IsGroup   bool      `json:"is_group"`
+
+	// LastMessage A message written by its sender, or a system message recording a membership change
+	// of a group conversation: participant_added is sent by the participant who added
+	// target_user_id, participant_left by the participant who left, and conversation_renamed
+	// by the participant who renamed the conversation, with the new name as its text.
+	LastMessage *DirectMessage `json:"last_message,omitempty"`
+
+	// Name Omitted for one-to-one conversations and unnamed groups.
+	Name         *string       `json:"name,omitempty"`
+	Participants []UserSummary `json:"participants"`
+
+	// ReadReceipts The read receipt of each participant, in the same order as the participants.
+	ReadReceipts []ReadReceipt `json:"read_receipts"`
+	UnreadCount  int           `json:"unread_count"`
+}
+
+// ConversationPage defines model for conversation_page.
+type ConversationPage struct {
+	Conversations []Conversation `json:"conversations"`
+
+	// NextCursor Omitted if there are no more conversations.
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// CreateBookmarkRequest defines model for create_bookmark_request.
+type CreateBookmarkRequest struct {
+	// FolderId The folder to bookmark the post into. The bookmark is not in any folder if omitted.
+	FolderId *string `json:"folder_id,omitempty"`
+	PostId   string  `json:"post_id"`
+}
+
+// CreateConversationRequest defines model for create_conversation_request.
+type CreateConversationRequest struct {
+	ParticipantId string `json:"participant_id"`
+}
+
+// CreateGroupConversationRequest defines model for create_group_conversation_request.
+type CreateGroupConversationRequest struct {
+	// Name Up to 50 characters.
+	Name *string `json:"name,omitempty"`
+
+	// ParticipantIds The participants other than the authenticated user, up to 49 users.
+	ParticipantIds []string `json:"participant_ids"`
+}
+
+// CreateListRequest defines model for create_list_request.
+type CreateListRequest struct {
+	// Description Up to 100 characters.
+	Description *string `json:"description,omitempty"`
+
+	// IsPrivate Defaults to false.
+	IsPrivate *bool `json:"is_private,omitempty"`
+
+	// Name Up to 25 characters.
+	Name string `json:"name"`
+}
+
+// CreatePollRequest A poll cannot be attached with media.
+type CreatePollRequest struct {
+	// Choices 2 to 4 distinct choices of up to 25 characters.
+	Choices []string `json:"choices"`
+
+	// DurationMinutes How long the poll accepts votes, from 5 minutes to 7 days.
+	DurationMinutes int `json:"duration_minutes"`
+}
+
 // CreatePostRequest defines model for create_post_request.
 type CreatePostRequest struct {
+	// MediaIds Up to 4 distinct IDs of media uploaded by the user, attached in order.
+	MediaIds *[]string `json:"media_ids,omitempty"`
+
+	// Poll A poll cannot be attached with media.
+	Poll *CreatePollRequest `json:"poll,omitempty"`
+
+	// ScheduledAt Queues the post to be published at the time, in the future up to 540 days ahead, instead of publishing it now. The media are attached and the poll is opened when the post is published.
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+
+	// Text Normalized into NFC and up to 280 in weighted length by default, where CJK characters and emojis count as 2 and URLs as 23. Must not be empty or whitespace-only unless media are attached.
 	Text   string `json:"text"`
 	UserId string `json:"user_id"`
 }
 
 // CreatePostResponse defines model for create_post_response.
 type CreatePostResponse struct {
-	CreatedAt time.Time `json:"created_at"`
-	Id        string    `json:"id"`
-	Text      string    `json:"text"`
-	UserId    string    `json:"user_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	Entities  PostEntities `json:"entities"`
+	Id        string       `json:"id"`
+
+	// Media The attached media in order. Omitted if there are none.
+	Media  *[]Media `json:"media,omitempty"`
+	Text   string   `json:"text"`
+	UserId string   `json:"user_id"`
 }
 
 // CreateQuoteRepostRequest defines model for create_quote_repost_request.
 type CreateQuoteRepostRequest struct {
-	PostId string `json:"post_id"`
-	Text   string `json:"text"`
+	// MediaIds Up to 4 distinct IDs of media uploaded by the user, attached in order.
+	MediaIds *[]string `json:"media_ids,omitempty"`
+	PostId   string    `json:"post_id"`
+
+	// Text Normalized into NFC and up to 280 in weighted length by default, where CJK characters and emojis count as 2 and URLs as 23. Must not be empty or whitespace-only unless media are attached.
+	Text string `json:"text"`
 }
 
 // CreateQuoteRepostResponse defines model for create_quote_repost_response.
 type CreateQuoteRepostResponse struct {
-	CreatedAt time.Time `json:"created_at"`
-	Id        string    `json:"id"`
-	ParentId  string    `json:"parent_id"`
-	Text      string    `json:"text"`
-	UserId    string    `json:"user_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	Entities  PostEntities `json:"entities"`
+	Id        string       `json:"id"`
+
+	// Media The attached media in order. Omitted if there are none.
+	Media    *[]Media `json:"media,omitempty"`
+	ParentId string   `json:"parent_id"`
+	Text     string   `json:"text"`
+	UserId   string   `json:"user_id"`
 }
 
 // CreateRepostRequest defines model for create_repost_request.
@@ -78,6 +296,108 @@ type DeleteRepostRequest struct {
 	RepostId string `json:"repost_id"`
 }
 
+// DirectMessage A message written by its sender, or a system message recording a membership change
+// of a group conversation: participant_added is sent by the participant who added
+// target_user_id, participant_left by the participant who left, and conversation_renamed
+// by the participant who renamed the conversation, with the new name as its text.
+type DirectMessage struct {
+	ConversationId string    `json:"conversation_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	Id             string    `json:"id"`
+
+	// Reactions Ordered by the first reaction with each emoji. Omitted if there are no reactions.
+	Reactions    *[]Reaction       `json:"reactions,omitempty"`
+	SenderId     string            `json:"sender_id"`
+	TargetUserId *string           `json:"target_user_id,omitempty"`
+	Text         string            `json:"text"`
+	Type         DirectMessageType `json:"type"`
+}
+
+// DirectMessageType defines model for DirectMessage.Type.
+type DirectMessageType string
+
+// DirectMessageEvent message_created carries a new message, including system messages,
+// message_reacted carries a message whose reactions have changed,
+// and conversation_read carries the read receipt of a participant.
+type DirectMessageEvent struct {
+	EventType DirectMessageEventEventType `json:"event_type"`
+
+	// Message A message written by its sender, or a system message recording a membership change
+	// of a group conversation: participant_added is sent by the participant who added
+	// target_user_id, participant_left by the participant who left, and conversation_renamed
+	// by the participant who renamed the conversation, with the new name as its text.
+	Message *DirectMessage `json:"message,omitempty"`
+
+	// ReadReceipt The messages sent at or before read_at have been read by the participant.
+	ReadReceipt *ReadReceipt `json:"read_receipt,omitempty"`
+}
+
+// DirectMessageEventEventType defines model for DirectMessageEvent.EventType.
+type DirectMessageEventEventType string
+
+// DirectMessagePage defines model for direct_message_page.
+type DirectMessagePage struct {
+	Messages []DirectMessage `json:"messages"`
+
+	// NextCursor Omitted if there are no more messages.
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// DirectMessageSettings Users who accept direct messages only from the users they follow can still be replied to in the conversations they have sent a message to.
+type DirectMessageSettings struct {
+	AllowFrom DirectMessageSettingsAllowFrom `json:"allow_from"`
+}
+
+// DirectMessageSettingsAllowFrom defines model for DirectMessageSettings.AllowFrom.
+type DirectMessageSettingsAllowFrom string
+
+// Draft defines model for draft.
+type Draft struct {
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+
+	// MediaIds Omitted if there are none.
+	MediaIds *[]string `json:"media_ids,omitempty"`
+
+	// Poll A poll cannot be attached with media.
+	Poll *CreatePollRequest `json:"poll,omitempty"`
+
+	// QuotedPostId The post or the repost quoted when the draft is published. Omitted for a draft of a post.
+	QuotedPostId *string   `json:"quoted_post_id,omitempty"`
+	Text         string    `json:"text"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	UserId       string    `json:"user_id"`
+}
+
+// DraftPage defines model for draft_page.
+type DraftPage struct {
+	Drafts []Draft `json:"drafts"`
+
+	// NextCursor Omitted if there are no more drafts.
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// DraftRequest The content of a draft. Only the length of the text, the number of media and whether a poll is combined with a quote are checked when it is saved; the rest is validated when it is published.
+type DraftRequest struct {
+	// MediaIds Up to 4 IDs of media uploaded by the user.
+	MediaIds *[]string `json:"media_ids,omitempty"`
+
+	// Poll A poll cannot be attached with media.
+	Poll *CreatePollRequest `json:"poll,omitempty"`
+
+	// QuotedPostId The post or the repost to quote. A draft of a quote repost cannot have a poll.
+	QuotedPostId *string `json:"quoted_post_id,omitempty"`
+
+	// Text Normalized into NFC and up to 280 in weighted length by default. May be empty.
+	Text *string `json:"text,omitempty"`
+}
+
+// EditPostRequest defines model for edit_post_request.
+type EditPostRequest struct {
+	// Text The new text, which can be empty only if media are attached to the post.
+	Text string `json:"text"`
+}
+
 // FindUserByIdResponse defines model for find_user_by_id_response.
 type FindUserByIdResponse struct {
 	Bio         string    `json:"bio"`
@@ -89,6 +409,40 @@ type FindUserByIdResponse struct {
 	Username    string    `json:"username"`
 }
 
+// GetBookmarkFoldersResponse defines model for get_bookmark_folders_response.
+type GetBookmarkFoldersResponse struct {
+	Folders []BookmarkFolder `json:"folders"`
+}
+
+// GetNotificationsResponse defines model for get_notifications_response.
+type GetNotificationsResponse struct {
+	// NextCursor Omitted if there are no more notifications.
+	NextCursor    *string        `json:"next_cursor,omitempty"`
+	Notifications []Notification `json:"notifications"`
+	UnreadCount   int            `json:"unread_count"`
+}
+
+// GetPostByIdResponse defines model for get_post_by_id_response.
+type GetPostByIdResponse = Post
+
+// GetPostHistoryResponse defines model for get_post_history_response.
+type GetPostHistoryResponse struct {
+	Versions []PostEdit `json:"versions"`
+}
+
+// GetPostQuotesResponse defines model for get_post_quotes_response.
+type GetPostQuotesResponse struct {
+	// NextCursor Omitted if there are no more quote reposts.
+	NextCursor *string       `json:"next_cursor,omitempty"`
+	Quotes     []QuoteRepost `json:"quotes"`
+}
+
+// GetRelationshipsResponse defines model for get_relationships_response.
+type GetRelationshipsResponse struct {
+	// Relationships The viewer's relationships keyed by the user ID.
+	Relationships map[string]Relationship `json:"relationships"`
+}
+
 // GetReverseChronologicalHomeTimelineResponse defines model for get_reverse_chronological_home_timeline_response.
 type GetReverseChronologicalHomeTimelineResponse struct {
 	Data *struct {
@@ -109,17 +463,858 @@ type GetReverseChronologicalHomeTimelineResponse struct {
 	} `json:"data,omitempty"`
 }
 
+// GetTrendsResponse defines model for get_trends_response.
+type GetTrendsResponse struct {
+	Trends []Trend `json:"trends"`
+}
+
 // GetUserPostsTimelineResponse defines model for get_user_posts_timeline_response.
-type GetUserPostsTimelineResponse = []struct {
+type GetUserPostsTimelineResponse = []Post
+
+// InitMediaUploadRequest defines model for init_media_upload_request.
+type InitMediaUploadRequest struct {
+	MediaType InitMediaUploadRequestMediaType `json:"media_type"`
+
+	// Sha256 The hex-encoded SHA-256 checksum of the whole file, verified when the upload is finalized.
+	Sha256 string `json:"sha256"`
+
+	// TotalBytes The size of the whole file: at most 5 MiB for JPEG, PNG and WebP images,
+	// 15 MiB for GIFs, and 512 MiB for videos.
+	TotalBytes int64 `json:"total_bytes"`
+}
+
+// InitMediaUploadRequestMediaType defines model for InitMediaUploadRequest.MediaType.
+type InitMediaUploadRequestMediaType string
+
+// LinkCard The preview of the linked page. Omitted until the page is fetched, or if it has no preview.
+type LinkCard struct {
+	Description *string `json:"description,omitempty"`
+	ImageUrl    *string `json:"image_url,omitempty"`
+	SiteName    *string `json:"site_name,omitempty"`
+	Title       string  `json:"title"`
+}
+
+// List A curated group of users whose posts and reposts make up the timeline of the list.
+type List struct {
+	CreatedAt   time.Time `json:"created_at"`
+	Description string    `json:"description"`
+	Id          string    `json:"id"`
+
+	// IsPrivate A private list can only be seen by its owner.
+	IsPrivate       bool         `json:"is_private"`
+	MemberCount     int          `json:"member_count"`
+	Name            string       `json:"name"`
+	Owner           *UserSummary `json:"owner,omitempty"`
+	OwnerId         string       `json:"owner_id"`
+	SubscribedByMe  bool         `json:"subscribed_by_me"`
+	SubscriberCount int          `json:"subscriber_count"`
+}
+
+// ListPage defines model for list_page.
+type ListPage struct {
+	Lists []List `json:"lists"`
+
+	// NextCursor Omitted if there are no more lists.
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// Media A file uploaded by a user, which is processed into renditions asynchronously
+// and can be attached to their posts and quote reposts once the processing succeeds.
+type Media struct {
+	AltText string `json:"alt_text"`
+
+	// Blurhash The BlurHash placeholder of the image, set once the processing of an image succeeds.
+	Blurhash *string `json:"blurhash,omitempty"`
+
+	// ContentType The type of the uploaded file.
+	ContentType MediaContentType `json:"content_type"`
+	CreatedAt   time.Time        `json:"created_at"`
+
+	// DurationMs The duration of an animated GIF or a video, set once the processing succeeds.
+	DurationMs *int `json:"duration_ms,omitempty"`
+
+	// Height The height of the image, set once the processing succeeds.
+	Height *int   `json:"height,omitempty"`
+	Id     string `json:"id"`
+
+	// ProcessingError Why the processing failed, set when the status is failed.
+	ProcessingError *string      `json:"processing_error,omitempty"`
+	Renditions      *[]Rendition `json:"renditions,omitempty"`
+
+	// Size The size of the uploaded file in bytes.
+	Size   int64       `json:"size"`
+	Status MediaStatus `json:"status"`
+
+	// Url The URL of the last rendition, which is the large image, the animated GIF
+	// or the video, set once the processing succeeds.
+	Url    *string `json:"url,omitempty"`
+	UserId string  `json:"user_id"`
+
+	// Width The width of the image, set once the processing succeeds.
+	Width *int `json:"width,omitempty"`
+}
+
+// MediaContentType The type of the uploaded file.
+type MediaContentType string
+
+// MediaStatus defines model for Media.Status.
+type MediaStatus string
+
+// MediaUpload An upload of media sent in segments, which can be resumed after a disconnection
+// by sending the segments missing from segments. It expires 24 hours after it starts
+// unless finalized.
+type MediaUpload struct {
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	FinalizedAt *time.Time `json:"finalized_at,omitempty"`
+
+	// MediaId The ID of the upload, which the media gets once the upload is finalized.
+	MediaId   string          `json:"media_id"`
+	MediaType string          `json:"media_type"`
+	Segments  []UploadSegment `json:"segments"`
+
+	// Sha256 The hex-encoded SHA-256 checksum of the whole file.
+	Sha256     string `json:"sha256"`
+	TotalBytes int64  `json:"total_bytes"`
+	UserId     string `json:"user_id"`
+}
+
+// MediaUploadStatusResponse defines model for media_upload_status_response.
+type MediaUploadStatusResponse struct {
+	// CheckAfterSecs How many seconds later the status should be checked again, set while the media is processed.
+	CheckAfterSecs *int       `json:"check_after_secs,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	FinalizedAt    *time.Time `json:"finalized_at,omitempty"`
+
+	// Media A file uploaded by a user, which is processed into renditions asynchronously
+	// and can be attached to their posts and quote reposts once the processing succeeds.
+	Media *Media `json:"media,omitempty"`
+
+	// MediaId The ID of the upload, which the media gets once the upload is finalized.
+	MediaId       string          `json:"media_id"`
+	MediaType     string          `json:"media_type"`
+	ReceivedBytes int64           `json:"received_bytes"`
+	Segments      []UploadSegment `json:"segments"`
+
+	// Sha256 The hex-encoded SHA-256 checksum of the whole file.
+	Sha256     string `json:"sha256"`
+	TotalBytes int64  `json:"total_bytes"`
+	UserId     string `json:"user_id"`
+}
+
+// Mention defines model for mention.
+type Mention struct {
+	// End The exclusive end offset of the mention in Unicode code points.
+	End int `json:"end"`
+
+	// Start The offset of the "@" in Unicode code points.
+	Start    int    `json:"start"`
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// Notification A notification, or a group of them such as "A and 3 others liked your post". Likes and reposts of the same post, and follows on the same day, are grouped.
+type Notification struct {
+	ActorCount int `json:"actor_count"`
+
+	// Actors Up to 3 of the most recent actors.
+	Actors    []UserSummary `json:"actors"`
+	CreatedAt time.Time     `json:"created_at"`
+
+	// Id The ID of the most recent notification of the group.
+	Id string `json:"id"`
+
+	// PostId The post the activity was done to, or the post of the closed poll.
+	PostId *string `json:"post_id,omitempty"`
+	Read   bool    `json:"read"`
+
+	// SourceId The repost, quote repost or post which caused the notification.
+	SourceId *string          `json:"source_id,omitempty"`
+	Type     NotificationType `json:"type"`
+}
+
+// NotificationType defines model for Notification.Type.
+type NotificationType string
+
+// NotificationPreferences The per-type toggles turn notifications of the type on or off; reposts covers quote reposts. The filters drop notifications from users the owner doesn't follow, users without a bio, or accounts created within the last 7 days. Preferences apply to notifications created after they are changed.
+type NotificationPreferences struct {
+	FilterNewAccounts bool `json:"filter_new_accounts"`
+	FilterNoBio       bool `json:"filter_no_bio"`
+	Follows           bool `json:"follows"`
+	Likes             bool `json:"likes"`
+	Mentions          bool `json:"mentions"`
+	OnlyFromFollowing bool `json:"only_from_following"`
+	Reposts           bool `json:"reposts"`
+}
+
+// Poll A poll attached to a post. The vote counts are hidden from viewers who have not voted until the poll is closed, except for its author.
+type Poll struct {
+	Choices []PollChoice `json:"choices"`
+
+	// Closed True once ends_at has passed.
+	Closed     bool      `json:"closed"`
+	EndsAt     time.Time `json:"ends_at"`
+	Id         string    `json:"id"`
+	TotalVotes int       `json:"total_votes"`
+
+	// VotedChoice The position of the choice the viewer voted for. Omitted if the viewer has not voted.
+	VotedChoice *int `json:"voted_choice,omitempty"`
+}
+
+// PollChoice defines model for poll_choice.
+type PollChoice struct {
+	Label string `json:"label"`
+
+	// Position The 0-based index of the choice.
+	Position int `json:"position"`
+
+	// VoteCount Omitted while the results are hidden from the viewer.
+	VoteCount *int `json:"vote_count,omitempty"`
+}
+
+// Post defines model for post.
+type Post struct {
+	Author *UserSummary `json:"author,omitempty"`
+
+	// BookmarkCount The number of users who bookmarked the post. Only returned to the author.
+	BookmarkCount *int `json:"bookmark_count,omitempty"`
+
+	// BookmarkedByMe Always false for anonymous requests.
+	BookmarkedByMe bool      `json:"bookmarked_by_me"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	// EditCount The number of times the post has been edited.
+	EditCount int `json:"edit_count"`
+
+	// EditedAt When the post was last edited. Omitted if it has never been edited.
+	EditedAt  *time.Time   `json:"edited_at,omitempty"`
+	Entities  PostEntities `json:"entities"`
+	Id        string       `json:"id"`
+	LikeCount int          `json:"like_count"`
+
+	// LikedByMe Always false for anonymous requests.
+	LikedByMe bool `json:"liked_by_me"`
+
+	// Media The attached media in order. Omitted if there are none.
+	Media *[]Media `json:"media,omitempty"`
+
+	// Poll A poll attached to a post. The vote counts are hidden from viewers who have not voted until the poll is closed, except for its author.
+	Poll        *Poll `json:"poll,omitempty"`
+	QuoteCount  int   `json:"quote_count"`
+	RepostCount int   `json:"repost_count"`
+
+	// RepostedByMe Always false for anonymous requests.
+	RepostedByMe bool   `json:"reposted_by_me"`
+	Text         string `json:"text"`
+	UserId       string `json:"user_id"`
+}
+
+// PostEdit defines model for post_edit.
+type PostEdit struct {
+	// CreatedAt When the version was written.
 	CreatedAt time.Time `json:"created_at"`
-	Id        string    `json:"id"`
+	PostId    string    `json:"post_id"`
 	Text      string    `json:"text"`
-	UserId    string    `json:"user_id"`
+
+	// Version 0 for the original text, incremented by each edit.
+	Version int `json:"version"`
 }
 
+// PostEntities defines model for post_entities.
+type PostEntities struct {
+	// Cashtags Omitted if the text has no cashtags.
+	Cashtags *[]Tag `json:"cashtags,omitempty"`
+
+	// Hashtags Omitted if the text has no hashtags.
+	Hashtags *[]Tag `json:"hashtags,omitempty"`
+
+	// Mentions Omitted if the text mentions no existing users.
+	Mentions *[]Mention `json:"mentions,omitempty"`
+
+	// Urls Omitted if the text has no URLs.
+	Urls *[]UrlEntity `json:"urls,omitempty"`
+}
+
+// PostPage defines model for post_page.
+type PostPage struct {
+	// NextCursor Omitted if there are no more posts.
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Posts      []Post  `json:"posts"`
+}
+
+// QuoteRepost defines model for quote_repost.
+type QuoteRepost struct {
+	Author    *UserSummary `json:"author,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	Entities  PostEntities `json:"entities"`
+	Id        string       `json:"id"`
+
+	// Media The attached media in order. Omitted if there are none.
+	Media    *[]Media `json:"media,omitempty"`
+	ParentId string   `json:"parent_id"`
+
+	// QuotedVersion The version of the quoted post at the time it was quoted. Omitted for quotes of reposts.
+	QuotedVersion *int   `json:"quoted_version,omitempty"`
+	Text          string `json:"text"`
+	UserId        string `json:"user_id"`
+}
+
+// Reaction defines model for reaction.
+type Reaction struct {
+	Emoji string `json:"emoji"`
+
+	// UserIds The users who reacted with the emoji, in chronological order.
+	UserIds []string `json:"user_ids"`
+}
+
+// ReadReceipt The messages sent at or before read_at have been read by the participant.
+type ReadReceipt struct {
+	ConversationId string    `json:"conversation_id"`
+	ReadAt         time.Time `json:"read_at"`
+	UserId         string    `json:"user_id"`
+}
+
+// RelatedUser defines model for related_user.
+type RelatedUser struct {
+	DisplayName  string        `json:"display_name"`
+	Id           string        `json:"id"`
+	IsPrivate    bool          `json:"is_private"`
+	Relationship *Relationship `json:"relationship,omitempty"`
+	Username     string        `json:"username"`
+}
+
+// RelatedUserPage defines model for related_user_page.
+type RelatedUserPage struct {
+	// NextCursor Omitted if there are no more users.
+	NextCursor *string       `json:"next_cursor,omitempty"`
+	Users      []RelatedUser `json:"users"`
+}
+
+// Relationship defines model for relationship.
+type Relationship struct {
+	Blocking bool `json:"blocking"`
+
+	// FollowRequestSent True while the viewer's request to follow a private account is waiting for approval.
+	FollowRequestSent bool `json:"follow_request_sent"`
+	FollowedBy        bool `json:"followed_by"`
+	Following         bool `json:"following"`
+	Muting            bool `json:"muting"`
+}
+
+// RenameConversationRequest defines model for rename_conversation_request.
+type RenameConversationRequest struct {
+	// Name Up to 50 characters. A blank name removes the name.
+	Name string `json:"name"`
+}
+
+// Rendition A processed version of media. Images are re-encoded and carry none of the metadata
+// of the uploaded file, while videos are served as uploaded.
+type Rendition struct {
+	ContentType RenditionContentType `json:"content_type"`
+	Height      int                  `json:"height"`
+
+	// Name thumb fits within 150x150, small within 680x680, and large within 2048x2048 pixels.
+	// animated is the re-encoded animated GIF, and video is the uploaded video as it is.
+	Name  RenditionName `json:"name"`
+	Url   string        `json:"url"`
+	Width int           `json:"width"`
+}
+
+// RenditionContentType defines model for Rendition.ContentType.
+type RenditionContentType string
+
+// RenditionName thumb fits within 150x150, small within 680x680, and large within 2048x2048 pixels.
+// animated is the re-encoded animated GIF, and video is the uploaded video as it is.
+type RenditionName string
+
+// ReschedulePostRequest defines model for reschedule_post_request.
+type ReschedulePostRequest struct {
+	// ScheduledAt In the future, up to 540 days ahead.
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+// ScheduledPost defines model for scheduled_post.
+type ScheduledPost struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// FailedAt When the post was given up after failing to be published 5 times. Omitted unless it has failed; rescheduling the post tries it again.
+	FailedAt *time.Time `json:"failed_at,omitempty"`
+	Id       string     `json:"id"`
+
+	// MediaIds The media attached when the post is published. Omitted if there are none.
+	MediaIds *[]string `json:"media_ids,omitempty"`
+
+	// Poll A poll cannot be attached with media.
+	Poll        *CreatePollRequest `json:"poll,omitempty"`
+	ScheduledAt time.Time          `json:"scheduled_at"`
+	Text        string             `json:"text"`
+	UserId      string             `json:"user_id"`
+}
+
+// ScheduledPostPage defines model for scheduled_post_page.
+type ScheduledPostPage struct {
+	// NextCursor Omitted if there are no more scheduled posts.
+	NextCursor     *string         `json:"next_cursor,omitempty"`
+	ScheduledPosts []ScheduledPost `json:"scheduled_posts"`
+}
+
+// SendDirectMessageRequest defines model for send_direct_message_request.
+type SendDirectMessageRequest struct {
+	// Text Up to 10000 characters.
+	Text string `json:"text"`
+}
+
+// Tag defines model for tag.
+type Tag struct {
+	// End The exclusive end offset of the tag in Unicode code points.
+	End int `json:"end"`
+
+	// Start The offset of the "#" or "$" in Unicode code points.
+	Start int `json:"start"`
+
+	// Text The hashtag or cashtag without the leading "#" or "$".
+	Text string `json:"text"`
+}
+
+// TimelineItem Exactly one of post and quote is set. repost is also set if the item appears because a user reposted it.
+type TimelineItem struct {
+	Post   *Post        `json:"post,omitempty"`
+	Quote  *QuoteRepost `json:"quote,omitempty"`
+	Repost *QuoteRepost `json:"repost,omitempty"`
+}
+
+// TimelineItemPage defines model for timeline_item_page.
+type TimelineItemPage struct {
+	Items []TimelineItem `json:"items"`
+
+	// NextCursor Omitted if there are no more items.
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+// TimestampedUser defines model for timestamped_user.
+type TimestampedUser struct {
+	// CreatedAt When the user was muted or blocked.
+	CreatedAt   time.Time `json:"created_at"`
+	DisplayName string    `json:"display_name"`
+	Id          string    `json:"id"`
+	IsPrivate   bool      `json:"is_private"`
+	Username    string    `json:"username"`
+}
+
+// TimestampedUserPage defines model for timestamped_user_page.
+type TimestampedUserPage struct {
+	// NextCursor Omitted if there are no more users.
+	NextCursor *string           `json:"next_cursor,omitempty"`
+	Users      []TimestampedUser `json:"users"`
+}
+
+// Trend defines model for trend.
+type Trend struct {
+	// PostCount The number of posts with the hashtag in the last hour visible to the viewer.
+	PostCount int     `json:"post_count"`
+	Score     float32 `json:"score"`
+
+	// Tag The normalized hashtag without the leading "#".
+	Tag string `json:"tag"`
+}
+
+// TypeaheadUsersResponse defines model for typeahead_users_response.
+type TypeaheadUsersResponse struct {
+	Users []UserSummary `json:"users"`
+}
+
+// UpdateDirectMessageSettingsRequest Settings missing from the request are left as they are.
+type UpdateDirectMessageSettingsRequest struct {
+	AllowFrom *UpdateDirectMessageSettingsRequestAllowFrom `json:"allow_from,omitempty"`
+}
+
+// UpdateDirectMessageSettingsRequestAllowFrom defines model for UpdateDirectMessageSettingsRequest.AllowFrom.
+type UpdateDirectMessageSettingsRequestAllowFrom string
+
+// UpdateListRequest The omitted properties are left as they are.
+type UpdateListRequest struct {
+	// Description Up to 100 characters.
+	Description *string `json:"description,omitempty"`
+	IsPrivate   *bool   `json:"is_private,omitempty"`
+
+	// Name Up to 25 characters.
+	Name *string `json:"name,omitempty"`
+}
+
+// UpdateMediaRequest defines model for update_media_request.
+type UpdateMediaRequest struct {
+	// AltText The description of the media for screen readers. An empty string removes it.
+	AltText string `json:"alt_text"`
+}
+
+// UpdateNotificationPreferencesRequest Preferences missing from the request are left as they are.
+type UpdateNotificationPreferencesRequest struct {
+	FilterNewAccounts *bool `json:"filter_new_accounts,omitempty"`
+	FilterNoBio       *bool `json:"filter_no_bio,omitempty"`
+	Follows           *bool `json:"follows,omitempty"`
+	Likes             *bool `json:"likes,omitempty"`
+	Mentions          *bool `json:"mentions,omitempty"`
+	OnlyFromFollowing *bool `json:"only_from_following,omitempty"`
+	Reposts           *bool `json:"reposts,omitempty"`
+}
+
+// UploadSegment A received segment of a media upload.
+type UploadSegment struct {
+	Index int `json:"index"`
+
+	// Sha256 The hex-encoded SHA-256 checksum of the segment as received,
+	// by which the client can tell whether the segment needs to be resent.
+	Sha256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// UrlEntity defines model for url_entity.
+type UrlEntity struct {
+	// Card The preview of the linked page. Omitted until the page is fetched, or if it has no preview.
+	Card *LinkCard `json:"card,omitempty"`
+
+	// DisplayUrl The URL without its scheme, truncated with "…" to be shown in place of it.
+	DisplayUrl string `json:"display_url"`
+
+	// End The exclusive end offset of the URL in Unicode code points.
+	End int `json:"end"`
+
+	// ExpandedUrl The URL as written in the text.
+	ExpandedUrl string `json:"expanded_url"`
+
+	// Start The offset of the URL in Unicode code points.
+	Start int `json:"start"`
+
+	// Url The short link which redirects to the URL, such as "/l/Ab3dE6g".
+	Url string `json:"url"`
+}
+
+// UserSummary defines model for user_summary.
+type UserSummary struct {
+	DisplayName string `json:"display_name"`
+	Id          string `json:"id"`
+	IsPrivate   bool   `json:"is_private"`
+	Username    string `json:"username"`
+}
+
+// UserSummaryPage defines model for user_summary_page.
+type UserSummaryPage struct {
+	// NextCursor Omitted if there are no more users.
+	NextCursor *string       `json:"next_cursor,omitempty"`
+	Users      []UserSummary `json:"users"`
+}
+
+// VotePollRequest defines model for vote_poll_request.
+type VotePollRequest struct {
+	// Position The position of the choice to vote for.
+	Position int `json:"position"`
+}
+
+// GetBookmarksParams defines parameters for GetBookmarks.
+type GetBookmarksParams struct {
+	// FolderId Only the bookmarks in the folder are listed if given.
+	FolderId *string `form:"folder_id,omitempty" json:"folder_id,omitempty"`
+
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetConversationsParams defines parameters for GetConversations.
+type GetConversationsParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetDirectMessagesParams defines parameters for GetDirectMessages.
+type GetDirectMessagesParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetDraftsParams defines parameters for GetDrafts.
+type GetDraftsParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetHashtagPostsParams defines parameters for GetHashtagPosts.
+type GetHashtagPostsParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetListMembersParams defines parameters for GetListMembers.
+type GetListMembersParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetListTimelineParams defines parameters for GetListTimeline.
+type GetListTimelineParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// UploadMediaMultipartBody defines parameters for UploadMedia.
+type UploadMediaMultipartBody struct {
+	// Media The image file of at most 5 MiB.
+	Media openapi_types.File `json:"media"`
+}
+
+// GetNotificationsParams defines parameters for GetNotifications.
+type GetNotificationsParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPostLikersParams defines parameters for GetPostLikers.
+type GetPostLikersParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPostQuotesParams defines parameters for GetPostQuotes.
+type GetPostQuotesParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetPostRepostersParams defines parameters for GetPostReposters.
+type GetPostRepostersParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetScheduledPostsParams defines parameters for GetScheduledPosts.
+type GetScheduledPostsParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// SearchPostsParams defines parameters for SearchPosts.
+type SearchPostsParams struct {
+	// Q The search query. Besides words, it supports "quoted phrases", -excluded words
+	// and -"phrases", #hashtags, from:username, to:username (posts mentioning the user),
+	// since:YYYY-MM-DD, until:YYYY-MM-DD (exclusive) and filter:quotes or -filter:quotes.
+	Q string `form:"q" json:"q"`
+
+	// Sort The order of the results. Posts ranked equally are ordered by recency.
+	Sort *SearchPostsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// SearchPostsParamsSort defines parameters for SearchPosts.
+type SearchPostsParamsSort string
+
+// SearchUsersParams defines parameters for SearchUsers.
+type SearchUsersParams struct {
+	// Q The search query. A leading "@" is ignored.
+	Q string `form:"q" json:"q"`
+
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetTrendsParams defines parameters for GetTrends.
+type GetTrendsParams struct {
+	// Limit The maximum number of trends to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetRelationshipsParams defines parameters for GetRelationships.
+type GetRelationshipsParams struct {
+	Ids []string `form:"ids" json:"ids"`
+}
+
+// TypeaheadUsersParams defines parameters for TypeaheadUsers.
+type TypeaheadUsersParams struct {
+	// Prefix The text typed so far. A leading "@" is ignored.
+	Prefix string `form:"prefix" json:"prefix"`
+
+	// Limit The maximum number of users to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetBlockedUsersParams defines parameters for GetBlockedUsers.
+type GetBlockedUsersParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetFollowersParams defines parameters for GetFollowers.
+type GetFollowersParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetFollowingParams defines parameters for GetFollowing.
+type GetFollowingParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetLikedPostsParams defines parameters for GetLikedPosts.
+type GetLikedPostsParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUserListsParams defines parameters for GetUserLists.
+type GetUserListsParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetMentionTimelineParams defines parameters for GetMentionTimeline.
+type GetMentionTimelineParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetMutedUsersParams defines parameters for GetMutedUsers.
+type GetMutedUsersParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetSubscribedListsParams defines parameters for GetSubscribedLists.
+type GetSubscribedListsParams struct {
+	// Cursor The next_cursor returned with the previous page. The first page is returned if omitted.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit The maximum number of items to return.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// CreateBookmarkJSONRequestBody defines body for CreateBookmark for application/json ContentType.
+type CreateBookmarkJSONRequestBody = CreateBookmarkRequest
+
+// CreateBookmarkFolderJSONRequestBody defines body for CreateBookmarkFolder for application/json ContentType.
+type CreateBookmarkFolderJSONRequestBody = BookmarkFolderRequest
+
+// UpdateBookmarkFolderJSONRequestBody defines body for UpdateBookmarkFolder for application/json ContentType.
+type UpdateBookmarkFolderJSONRequestBody = BookmarkFolderRequest
+
+// StartConversationJSONRequestBody defines body for StartConversation for application/json ContentType.
+type StartConversationJSONRequestBody = CreateConversationRequest
+
+// RenameConversationJSONRequestBody defines body for RenameConversation for application/json ContentType.
+type RenameConversationJSONRequestBody = RenameConversationRequest
+
+// SendDirectMessageJSONRequestBody defines body for SendDirectMessage for application/json ContentType.
+type SendDirectMessageJSONRequestBody = SendDirectMessageRequest
+
+// AddDirectMessageReactionJSONRequestBody defines body for AddDirectMessageReaction for application/json ContentType.
+type AddDirectMessageReactionJSONRequestBody = AddReactionRequest
+
+// AddConversationParticipantJSONRequestBody defines body for AddConversationParticipant for application/json ContentType.
+type AddConversationParticipantJSONRequestBody = AddConversationParticipantRequest
+
+// CreateGroupConversationJSONRequestBody defines body for CreateGroupConversation for application/json ContentType.
+type CreateGroupConversationJSONRequestBody = CreateGroupConversationRequest
+
+// CreateDraftJSONRequestBody defines body for CreateDraft for application/json ContentType.
+type CreateDraftJSONRequestBody = DraftRequest
+
+// UpdateDraftJSONRequestBody defines body for UpdateDraft for application/json ContentType.
+type UpdateDraftJSONRequestBody = DraftRequest
+
+// CreateListJSONRequestBody defines body for CreateList for application/json ContentType.
+type CreateListJSONRequestBody = CreateListRequest
+
+// UpdateListJSONRequestBody defines body for UpdateList for application/json ContentType.
+type UpdateListJSONRequestBody = UpdateListRequest
+
+// AddListMemberJSONRequestBody defines body for AddListMember for application/json ContentType.
+type AddListMemberJSONRequestBody = AddListMemberRequest
+
+// UploadMediaMultipartRequestBody defines body for UploadMedia for multipart/form-data ContentType.
+type UploadMediaMultipartRequestBody UploadMediaMultipartBody
+
+// InitMediaUploadJSONRequestBody defines body for InitMediaUpload for application/json ContentType.
+type InitMediaUploadJSONRequestBody = InitMediaUploadRequest
+
+// UpdateMediaJSONRequestBody defines body for UpdateMedia for application/json ContentType.
+type UpdateMediaJSONRequestBody = UpdateMediaRequest
+
 // CreatePostJSONRequestBody defines body for CreatePost for application/json ContentType.
 type CreatePostJSONRequestBody = CreatePostRequest
 
+// EditPostJSONRequestBody defines body for EditPost for application/json ContentType.
+type EditPostJSONRequestBody = EditPostRequest
+
+// VotePollJSONRequestBody defines body for VotePoll for application/json ContentType.
+type VotePollJSONRequestBody = VotePollRequest
+
+// ReschedulePostJSONRequestBody defines body for ReschedulePost for application/json ContentType.
+type ReschedulePostJSONRequestBody = ReschedulePostRequest
+
 // CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
 type CreateUserJSONRequestBody = CreateUserRequest
 
@@ -129,5 +1324,11 @@ type CreateQuoteRepostJSONRequestBody = CreateQuoteRepostRequest
 // CreateRepostJSONRequestBody defines body for CreateRepost for application/json ContentType.
 type CreateRepostJSONRequestBody = CreateRepostRequest
 
+// UpdateDirectMessageSettingsJSONRequestBody defines body for UpdateDirectMessageSettings for application/json ContentType.
+type UpdateDirectMessageSettingsJSONRequestBody = UpdateDirectMessageSettingsRequest
+
+// UpdateNotificationPreferencesJSONRequestBody defines body for UpdateNotificationPreferences for application/json ContentType.
+type UpdateNotificationPreferencesJSONRequestBody = UpdateNotificationPreferencesRequest
+
 // DeleteRepostJSONRequestBody defines body for DeleteRepost for application/json ContentType.
 type DeleteRepostJSONRequestBody = DeleteRepostRequest
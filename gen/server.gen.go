@@ -14,12 +14,234 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get a collection of posts bookmarked by the authenticated user.
+	// (GET /api/bookmarks)
+	GetBookmarks(w http.ResponseWriter, r *http.Request, params GetBookmarksParams)
+	// Bookmark a post as the authenticated user.
+	// (POST /api/bookmarks)
+	CreateBookmark(w http.ResponseWriter, r *http.Request)
+	// Get the bookmark folders of the authenticated user.
+	// (GET /api/bookmarks/folders)
+	GetBookmarkFolders(w http.ResponseWriter, r *http.Request)
+	// Create a bookmark folder of the authenticated user.
+	// (POST /api/bookmarks/folders)
+	CreateBookmarkFolder(w http.ResponseWriter, r *http.Request)
+	// Delete a bookmark folder of the authenticated user along with the bookmarks in it.
+	// (DELETE /api/bookmarks/folders/{folderID})
+	DeleteBookmarkFolder(w http.ResponseWriter, r *http.Request, folderID string)
+	// Rename a bookmark folder of the authenticated user.
+	// (PATCH /api/bookmarks/folders/{folderID})
+	UpdateBookmarkFolder(w http.ResponseWriter, r *http.Request, folderID string)
+	// Remove the authenticated user's bookmark of the post.
+	// (DELETE /api/bookmarks/{postID})
+	DeleteBookmark(w http.ResponseWriter, r *http.Request, postID string)
+	// Get a collection of the authenticated user's conversations.
+	// (GET /api/dm/conversations)
+	GetConversations(w http.ResponseWriter, r *http.Request, params GetConversationsParams)
+	// Start a one-to-one conversation between the authenticated user and the specified user.
+	// (POST /api/dm/conversations)
+	StartConversation(w http.ResponseWriter, r *http.Request)
+	// Get the specified conversation of the authenticated user.
+	// (GET /api/dm/conversations/{conversationID})
+	GetConversation(w http.ResponseWriter, r *http.Request, conversationID string)
+	// Rename the specified group conversation.
+	// (PATCH /api/dm/conversations/{conversationID})
+	RenameConversation(w http.ResponseWriter, r *http.Request, conversationID string)
+	// Leave the specified group conversation.
+	// (POST /api/dm/conversations/{conversationID}/leave)
+	LeaveConversation(w http.ResponseWriter, r *http.Request, conversationID string)
+	// Get a collection of messages of the specified conversation.
+	// (GET /api/dm/conversations/{conversationID}/messages)
+	GetDirectMessages(w http.ResponseWriter, r *http.Request, conversationID string, params GetDirectMessagesParams)
+	// Send a message to the specified conversation.
+	// (POST /api/dm/conversations/{conversationID}/messages)
+	SendDirectMessage(w http.ResponseWriter, r *http.Request, conversationID string)
+	// Delete a message of the specified conversation for the authenticated user only.
+	// (DELETE /api/dm/conversations/{conversationID}/messages/{messageID})
+	DeleteDirectMessage(w http.ResponseWriter, r *http.Request, conversationID string, messageID string)
+	// React to a message of the specified conversation with an emoji.
+	// (POST /api/dm/conversations/{conversationID}/messages/{messageID}/reactions)
+	AddDirectMessageReaction(w http.ResponseWriter, r *http.Request, conversationID string, messageID string)
+	// Remove a reaction of the authenticated user from a message of the specified conversation.
+	// (DELETE /api/dm/conversations/{conversationID}/messages/{messageID}/reactions/{emoji})
+	RemoveDirectMessageReaction(w http.ResponseWriter, r *http.Request, conversationID string, messageID string, emoji string)
+	// Add a user to the specified group conversation.
+	// (POST /api/dm/conversations/{conversationID}/participants)
+	AddConversationParticipant(w http.ResponseWriter, r *http.Request, conversationID string)
+	// Mark the specified conversation as read by the authenticated user.
+	// (POST /api/dm/conversations/{conversationID}/read)
+	MarkConversationAsRead(w http.ResponseWriter, r *http.Request, conversationID string)
+	// Create a group conversation of the authenticated user and the specified users.
+	// (POST /api/dm/groups)
+	CreateGroupConversation(w http.ResponseWriter, r *http.Request)
+	// Stream the changes to the conversations of the authenticated user as server-sent events.
+	// (GET /api/dm/stream)
+	StreamDirectMessages(w http.ResponseWriter, r *http.Request)
+	// Get the drafts of the authenticated user.
+	// (GET /api/drafts)
+	GetDrafts(w http.ResponseWriter, r *http.Request, params GetDraftsParams)
+	// Save a draft of a post or a quote repost for the authenticated user.
+	// (POST /api/drafts)
+	CreateDraft(w http.ResponseWriter, r *http.Request)
+	// Delete a draft of the authenticated user.
+	// (DELETE /api/drafts/{draftID})
+	DeleteDraft(w http.ResponseWriter, r *http.Request, draftID string)
+	// Get a draft of the authenticated user.
+	// (GET /api/drafts/{draftID})
+	GetDraft(w http.ResponseWriter, r *http.Request, draftID string)
+	// Replace the content of a draft of the authenticated user.
+	// (PUT /api/drafts/{draftID})
+	UpdateDraft(w http.ResponseWriter, r *http.Request, draftID string)
+	// Publish a draft of the authenticated user.
+	// (POST /api/drafts/{draftID}/publish)
+	PublishDraft(w http.ResponseWriter, r *http.Request, draftID string)
+	// Get a collection of posts with the specified hashtag.
+	// (GET /api/hashtags/{tag}/posts)
+	GetHashtagPosts(w http.ResponseWriter, r *http.Request, tag string, params GetHashtagPostsParams)
+	// Create a list owned by the authenticated user.
+	// (POST /api/lists)
+	CreateList(w http.ResponseWriter, r *http.Request)
+	// Delete the specified list owned by the authenticated user.
+	// (DELETE /api/lists/{listID})
+	DeleteList(w http.ResponseWriter, r *http.Request, listID string)
+	// Get the specified list.
+	// (GET /api/lists/{listID})
+	GetList(w http.ResponseWriter, r *http.Request, listID string)
+	// Update the specified list owned by the authenticated user.
+	// (PATCH /api/lists/{listID})
+	UpdateList(w http.ResponseWriter, r *http.Request, listID string)
+	// Get a collection of members of the specified list.
+	// (GET /api/lists/{listID}/members)
+	GetListMembers(w http.ResponseWriter, r *http.Request, listID string, params GetListMembersParams)
+	// Add a user to the specified list owned by the authenticated user.
+	// (POST /api/lists/{listID}/members)
+	AddListMember(w http.ResponseWriter, r *http.Request, listID string)
+	// Remove a user from the specified list owned by the authenticated user.
+	// (DELETE /api/lists/{listID}/members/{userID})
+	RemoveListMember(w http.ResponseWriter, r *http.Request, listID string, userID string)
+	// Unsubscribe from the specified list as the authenticated user.
+	// (DELETE /api/lists/{listID}/subscription)
+	UnsubscribeList(w http.ResponseWriter, r *http.Request, listID string)
+	// Subscribe to the specified list as the authenticated user.
+	// (POST /api/lists/{listID}/subscription)
+	SubscribeList(w http.ResponseWriter, r *http.Request, listID string)
+	// Get a collection of posts and reposts by the members of the specified list.
+	// (GET /api/lists/{listID}/timeline)
+	GetListTimeline(w http.ResponseWriter, r *http.Request, listID string, params GetListTimelineParams)
+	// Stream the new posts and reposts by the members of the specified list as server-sent events.
+	// (GET /api/lists/{listID}/timeline/stream)
+	StreamListTimeline(w http.ResponseWriter, r *http.Request, listID string)
+	// Upload a JPEG, PNG, GIF or WebP image.
+	// (POST /api/media)
+	UploadMedia(w http.ResponseWriter, r *http.Request)
+	// Start an upload of media sent in segments.
+	// (POST /api/media/uploads)
+	InitMediaUpload(w http.ResponseWriter, r *http.Request)
+	// Get the status of an upload of the authenticated user.
+	// (GET /api/media/uploads/{mediaID})
+	GetMediaUploadStatus(w http.ResponseWriter, r *http.Request, mediaID string)
+	// Finalize an upload of the authenticated user.
+	// (POST /api/media/uploads/{mediaID}/finalize)
+	FinalizeMediaUpload(w http.ResponseWriter, r *http.Request, mediaID string)
+	// Send a segment of an upload of the authenticated user.
+	// (PUT /api/media/uploads/{mediaID}/segments/{segmentIndex})
+	AppendMediaUploadSegment(w http.ResponseWriter, r *http.Request, mediaID string, segmentIndex int)
+	// Get the media with the specified ID.
+	// (GET /api/media/{mediaID})
+	GetMediaByID(w http.ResponseWriter, r *http.Request, mediaID string)
+	// Set the alt text of the media uploaded by the authenticated user.
+	// (PATCH /api/media/{mediaID})
+	UpdateMedia(w http.ResponseWriter, r *http.Request, mediaID string)
+	// Get the authenticated user's notifications, most recent first.
+	// (GET /api/notifications)
+	GetNotifications(w http.ResponseWriter, r *http.Request, params GetNotificationsParams)
+	// Mark all of the authenticated user's notifications as read.
+	// (POST /api/notifications/read)
+	MarkAllNotificationsAsRead(w http.ResponseWriter, r *http.Request)
+	// Stream the authenticated user's new notifications as server-sent events.
+	// (GET /api/notifications/stream)
+	StreamNotifications(w http.ResponseWriter, r *http.Request)
+	// Mark a notification, together with its group, as read.
+	// (POST /api/notifications/{notificationID}/read)
+	MarkNotificationAsRead(w http.ResponseWriter, r *http.Request, notificationID string)
 	// Creates a new post.
 	// (POST /api/posts)
 	CreatePost(w http.ResponseWriter, r *http.Request)
+	// Get a post by ID with its author and engagement counts.
+	// (GET /api/posts/{postID})
+	GetPostByID(w http.ResponseWriter, r *http.Request, postID string)
+	// Edit the text of a post.
+	// (PATCH /api/posts/{postID})
+	EditPost(w http.ResponseWriter, r *http.Request, postID string)
+	// Get the versions of a post.
+	// (GET /api/posts/{postID}/history)
+	GetPostHistory(w http.ResponseWriter, r *http.Request, postID string)
+	// Get a collection of users who liked the specified post.
+	// (GET /api/posts/{postID}/likes)
+	GetPostLikers(w http.ResponseWriter, r *http.Request, postID string, params GetPostLikersParams)
+	// Vote in the poll of the post as the authenticated user.
+	// (POST /api/posts/{postID}/poll/votes)
+	VotePoll(w http.ResponseWriter, r *http.Request, postID string)
+	// Get a collection of quote reposts of the specified post.
+	// (GET /api/posts/{postID}/quotes)
+	GetPostQuotes(w http.ResponseWriter, r *http.Request, postID string, params GetPostQuotesParams)
+	// Get a collection of users who reposted the specified post.
+	// (GET /api/posts/{postID}/reposts)
+	GetPostReposters(w http.ResponseWriter, r *http.Request, postID string, params GetPostRepostersParams)
+	// Get the posts the authenticated user has scheduled.
+	// (GET /api/scheduled_posts)
+	GetScheduledPosts(w http.ResponseWriter, r *http.Request, params GetScheduledPostsParams)
+	// Cancel a post the authenticated user has scheduled.
+	// (DELETE /api/scheduled_posts/{scheduledPostID})
+	CancelScheduledPost(w http.ResponseWriter, r *http.Request, scheduledPostID string)
+	// Reschedule a post of the authenticated user.
+	// (PATCH /api/scheduled_posts/{scheduledPostID})
+	ReschedulePost(w http.ResponseWriter, r *http.Request, scheduledPostID string)
+	// Search posts and quote reposts.
+	// (GET /api/search/posts)
+	SearchPosts(w http.ResponseWriter, r *http.Request, params SearchPostsParams)
+	// Search users by their usernames and display names.
+	// (GET /api/search/users)
+	SearchUsers(w http.ResponseWriter, r *http.Request, params SearchUsersParams)
+	// Get the top trending hashtags.
+	// (GET /api/trends)
+	GetTrends(w http.ResponseWriter, r *http.Request, params GetTrendsParams)
 	// Creates a new user.
 	// (POST /api/users)
 	CreateUser(w http.ResponseWriter, r *http.Request)
+	// Get the authenticated user's relationships to the specified users.
+	// (GET /api/users/relationships)
+	GetRelationships(w http.ResponseWriter, r *http.Request, params GetRelationshipsParams)
+	// Complete a username or a display name being typed, such as a mention.
+	// (GET /api/users/typeahead)
+	TypeaheadUsers(w http.ResponseWriter, r *http.Request, params TypeaheadUsersParams)
+	// Get a collection of users blocked by the authenticated user.
+	// (GET /api/users/{id}/blocking)
+	GetBlockedUsers(w http.ResponseWriter, r *http.Request, id string, params GetBlockedUsersParams)
+	// Decline a follow request sent to the authenticated user.
+	// (DELETE /api/users/{id}/follow_requests/{sourceUserID})
+	DeclineFollowRequest(w http.ResponseWriter, r *http.Request, id string, sourceUserID string)
+	// Accept a follow request sent to the authenticated user.
+	// (POST /api/users/{id}/follow_requests/{sourceUserID}/accept)
+	AcceptFollowRequest(w http.ResponseWriter, r *http.Request, id string, sourceUserID string)
+	// Get a collection of users following the specified user.
+	// (GET /api/users/{id}/followers)
+	GetFollowers(w http.ResponseWriter, r *http.Request, id string, params GetFollowersParams)
+	// Get a collection of users followed by the specified user.
+	// (GET /api/users/{id}/following)
+	GetFollowing(w http.ResponseWriter, r *http.Request, id string, params GetFollowingParams)
+	// Get a collection of posts liked by the specified user.
+	// (GET /api/users/{id}/likes)
+	GetLikedPosts(w http.ResponseWriter, r *http.Request, id string, params GetLikedPostsParams)
+	// Get a collection of lists owned by the specified user.
+	// (GET /api/users/{id}/lists)
+	GetUserLists(w http.ResponseWriter, r *http.Request, id string, params GetUserListsParams)
+	// Get a collection of posts and quote reposts mentioning the authenticated user.
+	// (GET /api/users/{id}/mentions)
+	GetMentionTimeline(w http.ResponseWriter, r *http.Request, id string, params GetMentionTimelineParams)
+	// Get a collection of users muted by the authenticated user.
+	// (GET /api/users/{id}/muting)
+	GetMutedUsers(w http.ResponseWriter, r *http.Request, id string, params GetMutedUsersParams)
 	// Get a collection of posts by the specified user.
 	// (GET /api/users/{id}/posts)
 	GetUserPostsTimeline(w http.ResponseWriter, r *http.Request, id string)
@@ -29,6 +251,21 @@ type ServerInterface interface {
 	// Creates a new repost.
 	// (POST /api/users/{id}/reposts)
 	CreateRepost(w http.ResponseWriter, r *http.Request, id string)
+	// Get the direct message settings of the authenticated user.
+	// (GET /api/users/{id}/settings/direct_messages)
+	GetDirectMessageSettings(w http.ResponseWriter, r *http.Request, id string)
+	// Change the direct message settings of the authenticated user.
+	// (PATCH /api/users/{id}/settings/direct_messages)
+	UpdateDirectMessageSettings(w http.ResponseWriter, r *http.Request, id string)
+	// Get the notification preferences of the authenticated user.
+	// (GET /api/users/{id}/settings/notifications)
+	GetNotificationPreferences(w http.ResponseWriter, r *http.Request, id string)
+	// Change the notification preferences of the authenticated user.
+	// (PATCH /api/users/{id}/settings/notifications)
+	UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request, id string)
+	// Get a collection of lists the authenticated user subscribes to.
+	// (GET /api/users/{id}/subscribed_lists)
+	GetSubscribedLists(w http.ResponseWriter, r *http.Request, id string, params GetSubscribedListsParams)
 	// Get a collection of posts by the specified user and users they follow.
 	// (GET /api/users/{id}/timelines/reverse_chronological)
 	GetReverseChronologicalHomeTimeline(w http.ResponseWriter, r *http.Request, id string)
@@ -38,6 +275,9 @@ type ServerInterface interface {
 	// Deletes a repost.
 	// (DELETE /api/users/{user_id}/reposts/{post_id})
 	DeleteRepost(w http.ResponseWriter, r *http.Request, userId string, postId string)
+	// Redirect to the URL of the short link with the specified code, counting the click.
+	// (GET /l/{code})
+	FollowLink(w http.ResponseWriter, r *http.Request, code string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -49,11 +289,2093 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// GetBookmarks operation middleware
+func (siw *ServerInterfaceWrapper) GetBookmarks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBookmarksParams
+
+	// ------------- Optional query parameter "folder_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "folder_id", r.URL.Query(), &params.FolderId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "folder_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookmarks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateBookmark operation middleware
+func (siw *ServerInterfaceWrapper) CreateBookmark(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBookmark(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBookmarkFolders operation middleware
+func (siw *ServerInterfaceWrapper) GetBookmarkFolders(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBookmarkFolders(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateBookmarkFolder operation middleware
+func (siw *ServerInterfaceWrapper) CreateBookmarkFolder(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBookmarkFolder(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBookmarkFolder operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBookmarkFolder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "folderID" -------------
+	var folderID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "folderID", r.PathValue("folderID"), &folderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "folderID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBookmarkFolder(w, r, folderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateBookmarkFolder operation middleware
+func (siw *ServerInterfaceWrapper) UpdateBookmarkFolder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "folderID" -------------
+	var folderID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "folderID", r.PathValue("folderID"), &folderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "folderID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateBookmarkFolder(w, r, folderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBookmark operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBookmark(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postID" -------------
+	var postID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postID", r.PathValue("postID"), &postID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBookmark(w, r, postID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetConversations operation middleware
+func (siw *ServerInterfaceWrapper) GetConversations(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetConversationsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConversations(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StartConversation operation middleware
+func (siw *ServerInterfaceWrapper) StartConversation(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartConversation(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetConversation operation middleware
+func (siw *ServerInterfaceWrapper) GetConversation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conversationID" -------------
+	var conversationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conversationID", r.PathValue("conversationID"), &conversationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conversationID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConversation(w, r, conversationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameConversation operation middleware
+func (siw *ServerInterfaceWrapper) RenameConversation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conversationID" -------------
+	var conversationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conversationID", r.PathValue("conversationID"), &conversationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conversationID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RenameConversation(w, r, conversationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LeaveConversation operation middleware
+func (siw *ServerInterfaceWrapper) LeaveConversation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conversationID" -------------
+	var conversationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conversationID", r.PathValue("conversationID"), &conversationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conversationID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LeaveConversation(w, r, conversationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDirectMessages operation middleware
+func (siw *ServerInterfaceWrapper) GetDirectMessages(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conversationID" -------------
+	var conversationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conversationID", r.PathValue("conversationID"), &conversationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conversationID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDirectMessagesParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDirectMessages(w, r, conversationID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SendDirectMessage operation middleware
+func (siw *ServerInterfaceWrapper) SendDirectMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conversationID" -------------
+	var conversationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conversationID", r.PathValue("conversationID"), &conversationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conversationID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SendDirectMessage(w, r, conversationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDirectMessage operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDirectMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conversationID" -------------
+	var conversationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conversationID", r.PathValue("conversationID"), &conversationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conversationID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "messageID" -------------
+	var messageID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "messageID", r.PathValue("messageID"), &messageID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "messageID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDirectMessage(w, r, conversationID, messageID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddDirectMessageReaction operation middleware
+func (siw *ServerInterfaceWrapper) AddDirectMessageReaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conversationID" -------------
+	var conversationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conversationID", r.PathValue("conversationID"), &conversationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conversationID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "messageID" -------------
+	var messageID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "messageID", r.PathValue("messageID"), &messageID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "messageID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddDirectMessageReaction(w, r, conversationID, messageID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveDirectMessageReaction operation middleware
+func (siw *ServerInterfaceWrapper) RemoveDirectMessageReaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conversationID" -------------
+	var conversationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conversationID", r.PathValue("conversationID"), &conversationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conversationID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "messageID" -------------
+	var messageID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "messageID", r.PathValue("messageID"), &messageID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "messageID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "emoji" -------------
+	var emoji string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "emoji", r.PathValue("emoji"), &emoji, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "emoji", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveDirectMessageReaction(w, r, conversationID, messageID, emoji)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddConversationParticipant operation middleware
+func (siw *ServerInterfaceWrapper) AddConversationParticipant(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conversationID" -------------
+	var conversationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conversationID", r.PathValue("conversationID"), &conversationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conversationID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddConversationParticipant(w, r, conversationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MarkConversationAsRead operation middleware
+func (siw *ServerInterfaceWrapper) MarkConversationAsRead(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "conversationID" -------------
+	var conversationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "conversationID", r.PathValue("conversationID"), &conversationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "conversationID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MarkConversationAsRead(w, r, conversationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateGroupConversation operation middleware
+func (siw *ServerInterfaceWrapper) CreateGroupConversation(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateGroupConversation(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamDirectMessages operation middleware
+func (siw *ServerInterfaceWrapper) StreamDirectMessages(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamDirectMessages(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDrafts operation middleware
+func (siw *ServerInterfaceWrapper) GetDrafts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDraftsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDrafts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateDraft operation middleware
+func (siw *ServerInterfaceWrapper) CreateDraft(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateDraft(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDraft operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDraft(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "draftID" -------------
+	var draftID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "draftID", r.PathValue("draftID"), &draftID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "draftID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDraft(w, r, draftID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDraft operation middleware
+func (siw *ServerInterfaceWrapper) GetDraft(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "draftID" -------------
+	var draftID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "draftID", r.PathValue("draftID"), &draftID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "draftID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDraft(w, r, draftID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateDraft operation middleware
+func (siw *ServerInterfaceWrapper) UpdateDraft(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "draftID" -------------
+	var draftID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "draftID", r.PathValue("draftID"), &draftID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "draftID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateDraft(w, r, draftID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PublishDraft operation middleware
+func (siw *ServerInterfaceWrapper) PublishDraft(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "draftID" -------------
+	var draftID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "draftID", r.PathValue("draftID"), &draftID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "draftID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PublishDraft(w, r, draftID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHashtagPosts operation middleware
+func (siw *ServerInterfaceWrapper) GetHashtagPosts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tag" -------------
+	var tag string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tag", r.PathValue("tag"), &tag, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHashtagPostsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHashtagPosts(w, r, tag, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateList operation middleware
+func (siw *ServerInterfaceWrapper) CreateList(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateList(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteList operation middleware
+func (siw *ServerInterfaceWrapper) DeleteList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listID" -------------
+	var listID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listID", r.PathValue("listID"), &listID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteList(w, r, listID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetList operation middleware
+func (siw *ServerInterfaceWrapper) GetList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listID" -------------
+	var listID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listID", r.PathValue("listID"), &listID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetList(w, r, listID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateList operation middleware
+func (siw *ServerInterfaceWrapper) UpdateList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listID" -------------
+	var listID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listID", r.PathValue("listID"), &listID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateList(w, r, listID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetListMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetListMembers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listID" -------------
+	var listID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listID", r.PathValue("listID"), &listID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetListMembersParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetListMembers(w, r, listID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddListMember operation middleware
+func (siw *ServerInterfaceWrapper) AddListMember(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listID" -------------
+	var listID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listID", r.PathValue("listID"), &listID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddListMember(w, r, listID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveListMember operation middleware
+func (siw *ServerInterfaceWrapper) RemoveListMember(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listID" -------------
+	var listID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listID", r.PathValue("listID"), &listID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", r.PathValue("userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveListMember(w, r, listID, userID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnsubscribeList operation middleware
+func (siw *ServerInterfaceWrapper) UnsubscribeList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listID" -------------
+	var listID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listID", r.PathValue("listID"), &listID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnsubscribeList(w, r, listID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SubscribeList operation middleware
+func (siw *ServerInterfaceWrapper) SubscribeList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listID" -------------
+	var listID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listID", r.PathValue("listID"), &listID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SubscribeList(w, r, listID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetListTimeline operation middleware
+func (siw *ServerInterfaceWrapper) GetListTimeline(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listID" -------------
+	var listID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listID", r.PathValue("listID"), &listID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetListTimelineParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetListTimeline(w, r, listID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamListTimeline operation middleware
+func (siw *ServerInterfaceWrapper) StreamListTimeline(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "listID" -------------
+	var listID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "listID", r.PathValue("listID"), &listID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamListTimeline(w, r, listID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UploadMedia operation middleware
+func (siw *ServerInterfaceWrapper) UploadMedia(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadMedia(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// InitMediaUpload operation middleware
+func (siw *ServerInterfaceWrapper) InitMediaUpload(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.InitMediaUpload(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMediaUploadStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetMediaUploadStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "mediaID" -------------
+	var mediaID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "mediaID", r.PathValue("mediaID"), &mediaID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mediaID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMediaUploadStatus(w, r, mediaID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// FinalizeMediaUpload operation middleware
+func (siw *ServerInterfaceWrapper) FinalizeMediaUpload(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "mediaID" -------------
+	var mediaID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "mediaID", r.PathValue("mediaID"), &mediaID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mediaID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FinalizeMediaUpload(w, r, mediaID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AppendMediaUploadSegment operation middleware
+func (siw *ServerInterfaceWrapper) AppendMediaUploadSegment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "mediaID" -------------
+	var mediaID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "mediaID", r.PathValue("mediaID"), &mediaID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mediaID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "segmentIndex" -------------
+	var segmentIndex int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "segmentIndex", r.PathValue("segmentIndex"), &segmentIndex, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "segmentIndex", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AppendMediaUploadSegment(w, r, mediaID, segmentIndex)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMediaByID operation middleware
+func (siw *ServerInterfaceWrapper) GetMediaByID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "mediaID" -------------
+	var mediaID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "mediaID", r.PathValue("mediaID"), &mediaID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mediaID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMediaByID(w, r, mediaID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateMedia operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMedia(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "mediaID" -------------
+	var mediaID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "mediaID", r.PathValue("mediaID"), &mediaID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mediaID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateMedia(w, r, mediaID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetNotifications operation middleware
+func (siw *ServerInterfaceWrapper) GetNotifications(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNotificationsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNotifications(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MarkAllNotificationsAsRead operation middleware
+func (siw *ServerInterfaceWrapper) MarkAllNotificationsAsRead(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MarkAllNotificationsAsRead(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamNotifications operation middleware
+func (siw *ServerInterfaceWrapper) StreamNotifications(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamNotifications(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MarkNotificationAsRead operation middleware
+func (siw *ServerInterfaceWrapper) MarkNotificationAsRead(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "notificationID" -------------
+	var notificationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "notificationID", r.PathValue("notificationID"), &notificationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "notificationID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MarkNotificationAsRead(w, r, notificationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // CreatePost operation middleware
 func (siw *ServerInterfaceWrapper) CreatePost(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreatePost(w, r)
+		siw.Handler.CreatePost(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPostByID operation middleware
+func (siw *ServerInterfaceWrapper) GetPostByID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postID" -------------
+	var postID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postID", r.PathValue("postID"), &postID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPostByID(w, r, postID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// EditPost operation middleware
+func (siw *ServerInterfaceWrapper) EditPost(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postID" -------------
+	var postID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postID", r.PathValue("postID"), &postID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EditPost(w, r, postID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPostHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetPostHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postID" -------------
+	var postID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postID", r.PathValue("postID"), &postID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPostHistory(w, r, postID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPostLikers operation middleware
+func (siw *ServerInterfaceWrapper) GetPostLikers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postID" -------------
+	var postID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postID", r.PathValue("postID"), &postID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPostLikersParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPostLikers(w, r, postID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// VotePoll operation middleware
+func (siw *ServerInterfaceWrapper) VotePoll(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postID" -------------
+	var postID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postID", r.PathValue("postID"), &postID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VotePoll(w, r, postID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPostQuotes operation middleware
+func (siw *ServerInterfaceWrapper) GetPostQuotes(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postID" -------------
+	var postID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postID", r.PathValue("postID"), &postID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPostQuotesParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPostQuotes(w, r, postID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPostReposters operation middleware
+func (siw *ServerInterfaceWrapper) GetPostReposters(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postID" -------------
+	var postID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postID", r.PathValue("postID"), &postID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPostRepostersParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPostReposters(w, r, postID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetScheduledPosts operation middleware
+func (siw *ServerInterfaceWrapper) GetScheduledPosts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetScheduledPostsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetScheduledPosts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelScheduledPost operation middleware
+func (siw *ServerInterfaceWrapper) CancelScheduledPost(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "scheduledPostID" -------------
+	var scheduledPostID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "scheduledPostID", r.PathValue("scheduledPostID"), &scheduledPostID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheduledPostID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelScheduledPost(w, r, scheduledPostID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReschedulePost operation middleware
+func (siw *ServerInterfaceWrapper) ReschedulePost(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "scheduledPostID" -------------
+	var scheduledPostID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "scheduledPostID", r.PathValue("scheduledPostID"), &scheduledPostID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheduledPostID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReschedulePost(w, r, scheduledPostID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchPosts operation middleware
+func (siw *ServerInterfaceWrapper) SearchPosts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchPostsParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchPosts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchUsers operation middleware
+func (siw *ServerInterfaceWrapper) SearchUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchUsersParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTrends operation middleware
+func (siw *ServerInterfaceWrapper) GetTrends(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTrendsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTrends(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRelationships operation middleware
+func (siw *ServerInterfaceWrapper) GetRelationships(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRelationshipsParams
+
+	// ------------- Required query parameter "ids" -------------
+
+	if paramValue := r.URL.Query().Get("ids"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "ids"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", false, true, "ids", r.URL.Query(), &params.Ids)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ids", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRelationships(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TypeaheadUsers operation middleware
+func (siw *ServerInterfaceWrapper) TypeaheadUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params TypeaheadUsersParams
+
+	// ------------- Required query parameter "prefix" -------------
+
+	if paramValue := r.URL.Query().Get("prefix"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "prefix"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "prefix", r.URL.Query(), &params.Prefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TypeaheadUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetBlockedUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetBlockedUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetBlockedUsersParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBlockedUsers(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeclineFollowRequest operation middleware
+func (siw *ServerInterfaceWrapper) DeclineFollowRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "sourceUserID" -------------
+	var sourceUserID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sourceUserID", r.PathValue("sourceUserID"), &sourceUserID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sourceUserID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeclineFollowRequest(w, r, id, sourceUserID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AcceptFollowRequest operation middleware
+func (siw *ServerInterfaceWrapper) AcceptFollowRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "sourceUserID" -------------
+	var sourceUserID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sourceUserID", r.PathValue("sourceUserID"), &sourceUserID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sourceUserID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AcceptFollowRequest(w, r, id, sourceUserID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFollowers operation middleware
+func (siw *ServerInterfaceWrapper) GetFollowers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFollowersParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFollowers(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFollowing operation middleware
+func (siw *ServerInterfaceWrapper) GetFollowing(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFollowingParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFollowing(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLikedPosts operation middleware
+func (siw *ServerInterfaceWrapper) GetLikedPosts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLikedPostsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLikedPosts(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -63,11 +2385,129 @@ func (siw *ServerInterfaceWrapper) CreatePost(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// CreateUser operation middleware
-func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+// GetUserLists operation middleware
+func (siw *ServerInterfaceWrapper) GetUserLists(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserListsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateUser(w, r)
+		siw.Handler.GetUserLists(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMentionTimeline operation middleware
+func (siw *ServerInterfaceWrapper) GetMentionTimeline(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMentionTimelineParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMentionTimeline(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMutedUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetMutedUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMutedUsersParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMutedUsers(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -152,6 +2592,150 @@ func (siw *ServerInterfaceWrapper) CreateRepost(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// GetDirectMessageSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetDirectMessageSettings(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDirectMessageSettings(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateDirectMessageSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateDirectMessageSettings(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateDirectMessageSettings(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetNotificationPreferences(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateNotificationPreferences(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSubscribedLists operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscribedLists(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSubscribedListsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSubscribedLists(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetReverseChronologicalHomeTimeline operation middleware
 func (siw *ServerInterfaceWrapper) GetReverseChronologicalHomeTimeline(w http.ResponseWriter, r *http.Request) {
 
@@ -236,6 +2820,31 @@ func (siw *ServerInterfaceWrapper) DeleteRepost(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// FollowLink operation middleware
+func (siw *ServerInterfaceWrapper) FollowLink(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", r.PathValue("code"), &code, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.FollowLink(w, r, code)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -356,14 +2965,94 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	m.HandleFunc("GET "+options.BaseURL+"/api/bookmarks", wrapper.GetBookmarks)
+	m.HandleFunc("POST "+options.BaseURL+"/api/bookmarks", wrapper.CreateBookmark)
+	m.HandleFunc("GET "+options.BaseURL+"/api/bookmarks/folders", wrapper.GetBookmarkFolders)
+	m.HandleFunc("POST "+options.BaseURL+"/api/bookmarks/folders", wrapper.CreateBookmarkFolder)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/bookmarks/folders/{folderID}", wrapper.DeleteBookmarkFolder)
+	m.HandleFunc("PATCH "+options.BaseURL+"/api/bookmarks/folders/{folderID}", wrapper.UpdateBookmarkFolder)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/bookmarks/{postID}", wrapper.DeleteBookmark)
+	m.HandleFunc("GET "+options.BaseURL+"/api/dm/conversations", wrapper.GetConversations)
+	m.HandleFunc("POST "+options.BaseURL+"/api/dm/conversations", wrapper.StartConversation)
+	m.HandleFunc("GET "+options.BaseURL+"/api/dm/conversations/{conversationID}", wrapper.GetConversation)
+	m.HandleFunc("PATCH "+options.BaseURL+"/api/dm/conversations/{conversationID}", wrapper.RenameConversation)
+	m.HandleFunc("POST "+options.BaseURL+"/api/dm/conversations/{conversationID}/leave", wrapper.LeaveConversation)
+	m.HandleFunc("GET "+options.BaseURL+"/api/dm/conversations/{conversationID}/messages", wrapper.GetDirectMessages)
+	m.HandleFunc("POST "+options.BaseURL+"/api/dm/conversations/{conversationID}/messages", wrapper.SendDirectMessage)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/dm/conversations/{conversationID}/messages/{messageID}", wrapper.DeleteDirectMessage)
+	m.HandleFunc("POST "+options.BaseURL+"/api/dm/conversations/{conversationID}/messages/{messageID}/reactions", wrapper.AddDirectMessageReaction)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/dm/conversations/{conversationID}/messages/{messageID}/reactions/{emoji}", wrapper.RemoveDirectMessageReaction)
+	m.HandleFunc("POST "+options.BaseURL+"/api/dm/conversations/{conversationID}/participants", wrapper.AddConversationParticipant)
+	m.HandleFunc("POST "+options.BaseURL+"/api/dm/conversations/{conversationID}/read", wrapper.MarkConversationAsRead)
+	m.HandleFunc("POST "+options.BaseURL+"/api/dm/groups", wrapper.CreateGroupConversation)
+	m.HandleFunc("GET "+options.BaseURL+"/api/dm/stream", wrapper.StreamDirectMessages)
+	m.HandleFunc("GET "+options.BaseURL+"/api/drafts", wrapper.GetDrafts)
+	m.HandleFunc("POST "+options.BaseURL+"/api/drafts", wrapper.CreateDraft)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/drafts/{draftID}", wrapper.DeleteDraft)
+	m.HandleFunc("GET "+options.BaseURL+"/api/drafts/{draftID}", wrapper.GetDraft)
+	m.HandleFunc("PUT "+options.BaseURL+"/api/drafts/{draftID}", wrapper.UpdateDraft)
+	m.HandleFunc("POST "+options.BaseURL+"/api/drafts/{draftID}/publish", wrapper.PublishDraft)
+	m.HandleFunc("GET "+options.BaseURL+"/api/hashtags/{tag}/posts", wrapper.GetHashtagPosts)
+	m.HandleFunc("POST "+options.BaseURL+"/api/lists", wrapper.CreateList)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/lists/{listID}", wrapper.DeleteList)
+	m.HandleFunc("GET "+options.BaseURL+"/api/lists/{listID}", wrapper.GetList)
+	m.HandleFunc("PATCH "+options.BaseURL+"/api/lists/{listID}", wrapper.UpdateList)
+	m.HandleFunc("GET "+options.BaseURL+"/api/lists/{listID}/members", wrapper.GetListMembers)
+	m.HandleFunc("POST "+options.BaseURL+"/api/lists/{listID}/members", wrapper.AddListMember)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/lists/{listID}/members/{userID}", wrapper.RemoveListMember)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/lists/{listID}/subscription", wrapper.UnsubscribeList)
+	m.HandleFunc("POST "+options.BaseURL+"/api/lists/{listID}/subscription", wrapper.SubscribeList)
+	m.HandleFunc("GET "+options.BaseURL+"/api/lists/{listID}/timeline", wrapper.GetListTimeline)
+	m.HandleFunc("GET "+options.BaseURL+"/api/lists/{listID}/timeline/stream", wrapper.StreamListTimeline)
+	m.HandleFunc("POST "+options.BaseURL+"/api/media", wrapper.UploadMedia)
+	m.HandleFunc("POST "+options.BaseURL+"/api/media/uploads", wrapper.InitMediaUpload)
+	m.HandleFunc("GET "+options.BaseURL+"/api/media/uploads/{mediaID}", wrapper.GetMediaUploadStatus)
+	m.HandleFunc("POST "+options.BaseURL+"/api/media/uploads/{mediaID}/finalize", wrapper.FinalizeMediaUpload)
+	m.HandleFunc("PUT "+options.BaseURL+"/api/media/uploads/{mediaID}/segments/{segmentIndex}", wrapper.AppendMediaUploadSegment)
+	m.HandleFunc("GET "+options.BaseURL+"/api/media/{mediaID}", wrapper.GetMediaByID)
+	m.HandleFunc("PATCH "+options.BaseURL+"/api/media/{mediaID}", wrapper.UpdateMedia)
+	m.HandleFunc("GET "+options.BaseURL+"/api/notifications", wrapper.GetNotifications)
+	m.HandleFunc("POST "+options.BaseURL+"/api/notifications/read", wrapper.MarkAllNotificationsAsRead)
+	m.HandleFunc("GET "+options.BaseURL+"/api/notifications/stream", wrapper.StreamNotifications)
+	m.HandleFunc("POST "+options.BaseURL+"/api/notifications/{notificationID}/read", wrapper.MarkNotificationAsRead)
 	m.HandleFunc("POST "+options.BaseURL+"/api/posts", wrapper.CreatePost)
+	m.HandleFunc("GET "+options.BaseURL+"/api/posts/{postID}", wrapper.GetPostByID)
+	m.HandleFunc("PATCH "+options.BaseURL+"/api/posts/{postID}", wrapper.EditPost)
+	m.HandleFunc("GET "+options.BaseURL+"/api/posts/{postID}/history", wrapper.GetPostHistory)
+	m.HandleFunc("GET "+options.BaseURL+"/api/posts/{postID}/likes", wrapper.GetPostLikers)
+	m.HandleFunc("POST "+options.BaseURL+"/api/posts/{postID}/poll/votes", wrapper.VotePoll)
+	m.HandleFunc("GET "+options.BaseURL+"/api/posts/{postID}/quotes", wrapper.GetPostQuotes)
+	m.HandleFunc("GET "+options.BaseURL+"/api/posts/{postID}/reposts", wrapper.GetPostReposters)
+	m.HandleFunc("GET "+options.BaseURL+"/api/scheduled_posts", wrapper.GetScheduledPosts)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/scheduled_posts/{scheduledPostID}", wrapper.CancelScheduledPost)
+	m.HandleFunc("PATCH "+options.BaseURL+"/api/scheduled_posts/{scheduledPostID}", wrapper.ReschedulePost)
+	m.HandleFunc("GET "+options.BaseURL+"/api/search/posts", wrapper.SearchPosts)
+	m.HandleFunc("GET "+options.BaseURL+"/api/search/users", wrapper.SearchUsers)
+	m.HandleFunc("GET "+options.BaseURL+"/api/trends", wrapper.GetTrends)
 	m.HandleFunc("POST "+options.BaseURL+"/api/users", wrapper.CreateUser)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/relationships", wrapper.GetRelationships)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/typeahead", wrapper.TypeaheadUsers)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/blocking", wrapper.GetBlockedUsers)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/users/{id}/follow_requests/{sourceUserID}", wrapper.DeclineFollowRequest)
+	m.HandleFunc("POST "+options.BaseURL+"/api/users/{id}/follow_requests/{sourceUserID}/accept", wrapper.AcceptFollowRequest)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/followers", wrapper.GetFollowers)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/following", wrapper.GetFollowing)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/likes", wrapper.GetLikedPosts)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/lists", wrapper.GetUserLists)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/mentions", wrapper.GetMentionTimeline)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/muting", wrapper.GetMutedUsers)
 	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/posts", wrapper.GetUserPostsTimeline)
 	m.HandleFunc("POST "+options.BaseURL+"/api/users/{id}/quote_reposts", wrapper.CreateQuoteRepost)
 	m.HandleFunc("POST "+options.BaseURL+"/api/users/{id}/reposts", wrapper.CreateRepost)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/settings/direct_messages", wrapper.GetDirectMessageSettings)
+	m.HandleFunc("PATCH "+options.BaseURL+"/api/users/{id}/settings/direct_messages", wrapper.UpdateDirectMessageSettings)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/settings/notifications", wrapper.GetNotificationPreferences)
+	m.HandleFunc("PATCH "+options.BaseURL+"/api/users/{id}/settings/notifications", wrapper.UpdateNotificationPreferences)
+	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/subscribed_lists", wrapper.GetSubscribedLists)
 	m.HandleFunc("GET "+options.BaseURL+"/api/users/{id}/timelines/reverse_chronological", wrapper.GetReverseChronologicalHomeTimeline)
 	m.HandleFunc("GET "+options.BaseURL+"/api/users/{userID}", wrapper.FindUserByID)
 	m.HandleFunc("DELETE "+options.BaseURL+"/api/users/{user_id}/reposts/{post_id}", wrapper.DeleteRepost)
+	m.HandleFunc("GET "+options.BaseURL+"/l/{code}", wrapper.FollowLink)
 
 	return m
 }
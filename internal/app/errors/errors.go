@@ -7,3 +7,60 @@ var ErrLikeNotFound = errors.New("like not found")
 var ErrFollowshipNotFound = errors.New("followship not found")
 var ErrMuteNotFound = errors.New("mute not found")
 var ErrBlockNotFound = errors.New("block not found")
+var ErrPostNotFound = errors.New("post not found")
+var ErrPrivateAccount = errors.New("account is private")
+var ErrBlocked = errors.New("blocked")
+var ErrFollowRequestNotFound = errors.New("follow request not found")
+var ErrSelfAction = errors.New("users cannot follow, mute or block themselves")
+var ErrNotificationNotFound = errors.New("notification not found")
+var ErrMediaNotFound = errors.New("media not found")
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+var ErrMediaTooLarge = errors.New("media is too large")
+var ErrInvalidMediaAttachment = errors.New("media cannot be attached")
+var ErrNotMediaOwner = errors.New("media is uploaded by another user")
+var ErrAltTextTooLong = errors.New("alt text is too long")
+var ErrMediaUploadNotFound = errors.New("media upload not found")
+var ErrMediaUploadFinalized = errors.New("media upload is already finalized")
+var ErrIncompleteMediaUpload = errors.New("media upload is incomplete")
+var ErrInvalidMediaUpload = errors.New("invalid media upload")
+var ErrMediaChecksumMismatch = errors.New("media checksum mismatch")
+var ErrMediaUploadQuotaExceeded = errors.New("media upload quota exceeded")
+var ErrInvalidMedia = errors.New("media cannot be decoded")
+var ErrVideoTooLong = errors.New("video is too long")
+var ErrLinkNotFound = errors.New("link not found")
+var ErrEmptyPostText = errors.New("text is empty")
+var ErrPostTextTooLong = errors.New("text is too long")
+var ErrInvalidPoll = errors.New("invalid poll")
+var ErrPollNotFound = errors.New("poll not found")
+var ErrPollClosed = errors.New("poll is closed")
+var ErrAlreadyVoted = errors.New("already voted in the poll")
+var ErrInvalidPollChoice = errors.New("invalid poll choice")
+var ErrBookmarkNotFound = errors.New("bookmark not found")
+var ErrBookmarkFolderNotFound = errors.New("bookmark folder not found")
+var ErrInvalidBookmarkFolder = errors.New("invalid bookmark folder")
+var ErrBookmarkFolderExists = errors.New("bookmark folder already exists")
+var ErrListNotFound = errors.New("list not found")
+var ErrInvalidList = errors.New("invalid list")
+var ErrNotListOwner = errors.New("list is owned by another user")
+var ErrListMemberNotFound = errors.New("list member not found")
+var ErrListSubscriptionNotFound = errors.New("list subscription not found")
+var ErrOwnListSubscription = errors.New("users cannot subscribe to their own lists")
+var ErrConversationNotFound = errors.New("conversation not found")
+var ErrSelfConversation = errors.New("users cannot start a conversation with themselves")
+var ErrDirectMessageNotFound = errors.New("direct message not found")
+var ErrInvalidDirectMessage = errors.New("invalid direct message")
+var ErrDirectMessageNotAllowed = errors.New("the recipient does not accept direct messages from the user")
+var ErrInvalidDirectMessageSettings = errors.New("invalid direct message settings")
+var ErrInvalidConversation = errors.New("invalid conversation")
+var ErrNotGroupConversation = errors.New("not a group conversation")
+var ErrConversationFull = errors.New("conversation has too many participants")
+var ErrInvalidReaction = errors.New("invalid reaction")
+var ErrReactionNotFound = errors.New("reaction not found")
+var ErrNotPostAuthor = errors.New("post is written by another user")
+var ErrPostEditWindowClosed = errors.New("post can no longer be edited")
+var ErrPostEditLimitReached = errors.New("post has been edited too many times")
+var ErrScheduledPostNotFound = errors.New("scheduled post not found")
+var ErrInvalidScheduledTime = errors.New("invalid scheduled time")
+var ErrDraftNotFound = errors.New("draft not found")
+var ErrInvalidDraft = errors.New("invalid draft")
+var ErrDraftChanged = errors.New("draft has been updated while being published")
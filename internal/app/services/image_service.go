@@ -0,0 +1,310 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+
+	"golang.org/x/image/draw"
+
+	// The decoder of WebP, which can be uploaded besides JPEG, PNG and GIF.
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// maxImagePixels is the maximum number of pixels of an image to be processed,
+	// so that a small file declaring huge dimensions cannot exhaust the memory.
+	// It applies to the total of all the frames of animated GIFs.
+	maxImagePixels = 50_000_000
+	// jpegQuality is the quality renditions are encoded with as JPEG.
+	jpegQuality = 85
+
+	// exifOrientationTag is the tag of the EXIF orientation in IFD0.
+	exifOrientationTag = 0x0112
+)
+
+var errImageTooLarge = fmt.Errorf("%w: the image has more than %d pixels", domainerrors.ErrInvalidMedia, maxImagePixels)
+
+// ImageService processes uploaded images into the renditions served to clients.
+type ImageService struct{}
+
+func NewImageService() *ImageService {
+	return &ImageService{}
+}
+
+// ProcessedImage is the result of processing an image. Width and Height are
+// the dimensions of the image as displayed, after its EXIF orientation is applied.
+// DurationMs is set only for animated GIFs.
+type ProcessedImage struct {
+	Width      int
+	Height     int
+	Blurhash   string
+	DurationMs int
+	Renditions []*EncodedRendition
+}
+
+// EncodedRendition is a rendition with its encoded file; its URL is left empty
+// until the file is stored.
+type EncodedRendition struct {
+	entities.Rendition
+	Data []byte
+}
+
+// Process decodes the image, applies its EXIF orientation, and encodes it into
+// entities.ImageRenditions, which carry none of the metadata of the original file,
+// such as EXIF and GPS, as only the pixels are re-encoded. Opaque images are
+// encoded as JPEG and the others as PNG. The renditions of animated GIFs are of
+// the first frame, followed by the "animated" rendition, the re-encoded GIF.
+func (s *ImageService) Process(r io.Reader) (*ProcessedImage, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidMedia, err)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, errImageTooLarge
+	}
+
+	decoded, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerrors.ErrInvalidMedia, err)
+	}
+	img := toNRGBA(decoded)
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	processed := &ProcessedImage{
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+	}
+	opaque := img.Opaque()
+
+	// Renditions are scaled down from the next larger one, which is much faster
+	// than scaling every one of them down from the original.
+	renditions := make([]*EncodedRendition, len(entities.ImageRenditions))
+	source := img
+	for i := len(entities.ImageRenditions) - 1; i >= 0; i-- {
+		spec := entities.ImageRenditions[i]
+		width, height := entities.FitWithin(processed.Width, processed.Height, spec.MaxSize)
+		resized := source
+		if width != source.Bounds().Dx() || height != source.Bounds().Dy() {
+			resized = image.NewNRGBA(image.Rect(0, 0, width, height))
+			draw.CatmullRom.Scale(resized, resized.Bounds(), source, source.Bounds(), draw.Src, nil)
+		}
+
+		rendition, err := encodeRendition(resized, spec.Name, opaque)
+		if err != nil {
+			return nil, err
+		}
+		renditions[i] = rendition
+		source = resized
+	}
+	processed.Renditions = renditions
+	processed.Blurhash = EncodeBlurhash(source)
+
+	if format == "gif" {
+		animated, durationMs, err := encodeAnimation(data)
+		if err != nil {
+			return nil, err
+		}
+		if animated != nil {
+			processed.Renditions = append(processed.Renditions, animated)
+			processed.DurationMs = durationMs
+		}
+	}
+
+	return processed, nil
+}
+
+// encodeAnimation re-encodes the GIF, dropping its comments and application data
+// other than the loop count, and returns it with its duration. It returns
+// a nil rendition if the GIF has only one frame.
+func encodeAnimation(data []byte) (*EncodedRendition, int, error) {
+	animation, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", domainerrors.ErrInvalidMedia, err)
+	}
+	if len(animation.Image) < 2 {
+		return nil, 0, nil
+	}
+	if len(animation.Image)*animation.Config.Width*animation.Config.Height > maxImagePixels {
+		return nil, 0, errImageTooLarge
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, animation); err != nil {
+		return nil, 0, err
+	}
+
+	durationMs := 0
+	for _, delay := range animation.Delay {
+		durationMs += delay * 10
+	}
+
+	return &EncodedRendition{
+		Rendition: entities.Rendition{
+			Name:        "animated",
+			ContentType: "image/gif",
+			Width:       animation.Config.Width,
+			Height:      animation.Config.Height,
+		},
+		Data: buf.Bytes(),
+	}, durationMs, nil
+}
+
+func encodeRendition(img *image.NRGBA, name string, opaque bool) (*EncodedRendition, error) {
+	var (
+		buf         bytes.Buffer
+		contentType string
+		err         error
+	)
+	if opaque {
+		contentType = "image/jpeg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		contentType = "image/png"
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncodedRendition{
+		Rendition: entities.Rendition{
+			Name:        name,
+			ContentType: contentType,
+			Width:       img.Bounds().Dx(),
+			Height:      img.Bounds().Dy(),
+		},
+		Data: buf.Bytes(),
+	}, nil
+}
+
+// toNRGBA converts the image into an NRGBA image whose bounds start at the origin.
+func toNRGBA(img image.Image) *image.NRGBA {
+	bounds := img.Bounds()
+	nrgba := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(nrgba, nrgba.Bounds(), img, bounds.Min, draw.Src)
+	return nrgba
+}
+
+// applyOrientation transforms the image as its EXIF orientation (1 to 8) specifies,
+// so that it is displayed upright once the EXIF metadata is dropped.
+func applyOrientation(img *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				sx, sy = w-1-x, y
+			case 3: // rotated by 180 degrees
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // to be rotated by 90 degrees clockwise
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // to be rotated by 90 degrees counterclockwise
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], img.Pix[img.PixOffset(sx, sy):img.PixOffset(sx, sy)+4])
+		}
+	}
+
+	return dst
+}
+
+// jpegOrientation returns the EXIF orientation of the JPEG file,
+// or 1, the upright orientation, if it has none or its EXIF is malformed.
+func jpegOrientation(data []byte) int {
+	if len(data) < 2 || data[0] != 0xff || data[1] != 0xd8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xff {
+			return 1
+		}
+		marker := data[i+1]
+		// The metadata segments come before the start of the scan.
+		if marker == 0xda || marker == 0xd9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xe1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+
+	return 1
+}
+
+// exifOrientation reads the orientation from IFD0 of the TIFF structure of EXIF.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == exifOrientationTag {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+
+	return 1
+}
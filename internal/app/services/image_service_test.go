@@ -0,0 +1,193 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// newTestImage returns a width x height image whose left half is red and right half is blue.
+func newTestImage(width, height int, alpha uint8) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < width/2 {
+				img.Set(x, y, color.NRGBA{R: 255, A: alpha})
+			} else {
+				img.Set(x, y, color.NRGBA{B: 255, A: alpha})
+			}
+		}
+	}
+	return img
+}
+
+// withOrientation inserts an EXIF segment with the orientation right after the SOI marker of the JPEG.
+func withOrientation(jpegData []byte, orientation byte) []byte {
+	exif := []byte{
+		0xff, 0xe1, 0x00, 0x22, // APP1 of 34 bytes
+		'E', 'x', 'i', 'f', 0x00, 0x00,
+		'M', 'M', 0x00, 0x2a, 0x00, 0x00, 0x00, 0x08, // big-endian TIFF header, IFD0 at 8
+		0x00, 0x01, // 1 entry
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, orientation, 0x00, 0x00, // orientation, SHORT
+		0x00, 0x00, 0x00, 0x00, // no next IFD
+	}
+
+	var data []byte
+	data = append(data, jpegData[:2]...)
+	data = append(data, exif...)
+	return append(data, jpegData[2:]...)
+}
+
+func TestProcessImage(t *testing.T) {
+	imageService := NewImageService()
+
+	var jpegData bytes.Buffer
+	if err := jpeg.Encode(&jpegData, newTestImage(800, 400, 255), nil); err != nil {
+		t.Fatalf("Failed to encode a JPEG image: %v", err)
+	}
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, newTestImage(100, 50, 128)); err != nil {
+		t.Fatalf("Failed to encode a PNG image: %v", err)
+	}
+
+	tests := []struct {
+		name               string
+		data               []byte
+		expectedWidth      int
+		expectedHeight     int
+		expectedSizes      [][2]int
+		expectedType       string
+		expectedLeftIsBlue bool
+	}{
+		{
+			name:           "opaque JPEG",
+			data:           jpegData.Bytes(),
+			expectedWidth:  800,
+			expectedHeight: 400,
+			expectedSizes:  [][2]int{{150, 75}, {680, 340}, {800, 400}},
+			expectedType:   "image/jpeg",
+		},
+		{
+			name:               "JPEG to be rotated by 90 degrees clockwise",
+			data:               withOrientation(jpegData.Bytes(), 6),
+			expectedWidth:      400,
+			expectedHeight:     800,
+			expectedSizes:      [][2]int{{75, 150}, {340, 680}, {400, 800}},
+			expectedType:       "image/jpeg",
+			expectedLeftIsBlue: false,
+		},
+		{
+			name:               "JPEG rotated by 180 degrees",
+			data:               withOrientation(jpegData.Bytes(), 3),
+			expectedWidth:      800,
+			expectedHeight:     400,
+			expectedSizes:      [][2]int{{150, 75}, {680, 340}, {800, 400}},
+			expectedType:       "image/jpeg",
+			expectedLeftIsBlue: true,
+		},
+		{
+			name:           "translucent PNG",
+			data:           pngData.Bytes(),
+			expectedWidth:  100,
+			expectedHeight: 50,
+			expectedSizes:  [][2]int{{100, 50}, {100, 50}, {100, 50}},
+			expectedType:   "image/png",
+		},
+	}
+
+	for _, test := range tests {
+		processed, err := imageService.Process(bytes.NewReader(test.data))
+		if err != nil {
+			t.Errorf("%s: failed to process the image: %v", test.name, err)
+			continue
+		}
+
+		if processed.Width != test.expectedWidth || processed.Height != test.expectedHeight {
+			t.Errorf("%s: expected %dx%d, but got %dx%d", test.name, test.expectedWidth, test.expectedHeight, processed.Width, processed.Height)
+		}
+		if len(processed.Blurhash) != 28 {
+			t.Errorf("%s: unexpected blurhash %q", test.name, processed.Blurhash)
+		}
+		if len(processed.Renditions) != len(test.expectedSizes) {
+			t.Errorf("%s: expected %d renditions, but got %d", test.name, len(test.expectedSizes), len(processed.Renditions))
+			continue
+		}
+
+		for i, rendition := range processed.Renditions {
+			if rendition.Width != test.expectedSizes[i][0] || rendition.Height != test.expectedSizes[i][1] || rendition.ContentType != test.expectedType {
+				t.Errorf("%s: unexpected rendition %s of %dx%d %s", test.name, rendition.Name, rendition.Width, rendition.Height, rendition.ContentType)
+			}
+			if bytes.Contains(rendition.Data, []byte("Exif")) {
+				t.Errorf("%s: the EXIF metadata is left in the rendition %s", test.name, rendition.Name)
+			}
+		}
+
+		// The pixel at the top left tells whether the image has been turned.
+		large, _, err := image.Decode(bytes.NewReader(processed.Renditions[len(processed.Renditions)-1].Data))
+		if err != nil {
+			t.Errorf("%s: failed to decode the rendition: %v", test.name, err)
+			continue
+		}
+		r, _, b, _ := large.At(2, 2).RGBA()
+		if (b > r) != test.expectedLeftIsBlue {
+			t.Errorf("%s: the image is not oriented as expected", test.name)
+		}
+	}
+}
+
+func TestProcessAnimatedGIF(t *testing.T) {
+	palette := color.Palette{color.Black, color.White}
+	animation := &gif.GIF{}
+	for i := 0; i < 3; i++ {
+		frame := image.NewPaletted(image.Rect(0, 0, 40, 20), palette)
+		frame.SetColorIndex(i, i, 1)
+		animation.Image = append(animation.Image, frame)
+		animation.Delay = append(animation.Delay, 50)
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, animation); err != nil {
+		t.Fatalf("failed to encode the GIF: %v", err)
+	}
+
+	processed, err := NewImageService().Process(&buf)
+	if err != nil {
+		t.Fatalf("failed to process the GIF: %v", err)
+	}
+	if processed.DurationMs != 1500 {
+		t.Errorf("expected a duration of 1500ms, but got %d", processed.DurationMs)
+	}
+
+	last := processed.Renditions[len(processed.Renditions)-1]
+	if last.Name != "animated" || last.ContentType != "image/gif" || last.Width != 40 || last.Height != 20 {
+		t.Fatalf("unexpected animated rendition %+v", last.Rendition)
+	}
+	decoded, err := gif.DecodeAll(bytes.NewReader(last.Data))
+	if err != nil || len(decoded.Image) != 3 {
+		t.Errorf("expected the animated rendition to have 3 frames")
+	}
+}
+
+func TestProcessInvalidImage(t *testing.T) {
+	if _, err := NewImageService().Process(bytes.NewReader([]byte("\x89PNG\r\n\x1a\nbroken"))); err == nil {
+		t.Errorf("expected a broken image to fail")
+	}
+}
+
+func TestEncodeBlurhash(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 32, 32))
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			img.Set(x, y, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+		}
+	}
+
+	// "L" stands for 4x3 components, and "TSUA" for the DC of 0xffffff.
+	hash := EncodeBlurhash(img)
+	if len(hash) != 28 || hash[0] != 'L' || hash[2:6] != "TSUA" {
+		t.Errorf("unexpected blurhash %q", hash)
+	}
+}
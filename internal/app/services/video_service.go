@@ -0,0 +1,350 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+	domainerrors "x-clone-backend/internal/app/errors"
+)
+
+// maxMoovSize is the maximum size of the "moov" box of a video to be read into memory.
+const maxMoovSize = 64 << 20
+
+// VideoService inspects uploaded videos. Videos are not transcoded, as no codec
+// is available; they are served as uploaded, without their metadata, once they
+// are found to be valid.
+type VideoService struct{}
+
+func NewVideoService() *VideoService {
+	return &VideoService{}
+}
+
+// VideoInfo is the result of probing a video. Width and Height are
+// the dimensions of the video as displayed, after its rotation is applied.
+type VideoInfo struct {
+	Width    int
+	Height   int
+	Duration time.Duration
+}
+
+// Probe reads the MP4 or QuickTime video from r and returns its dimensions and
+// duration found in the "moov" box. The media data is skipped without being
+// held in memory, so "moov" can be either before or after it.
+func (s *VideoService) Probe(r io.Reader) (*VideoInfo, error) {
+	for {
+		boxType, size, err := readBoxHeader(r)
+		if err == io.EOF {
+			return nil, fmt.Errorf("%w: the video has no moov box", domainerrors.ErrInvalidMedia)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if boxType != "moov" {
+			if size < 0 {
+				return nil, fmt.Errorf("%w: the video has no moov box", domainerrors.ErrInvalidMedia)
+			}
+			if _, err := io.CopyN(io.Discard, r, size); err != nil {
+				return nil, invalidVideo(err)
+			}
+			continue
+		}
+
+		if size < 0 || size > maxMoovSize {
+			return nil, fmt.Errorf("%w: the moov box is too large", domainerrors.ErrInvalidMedia)
+		}
+		moov := make([]byte, size)
+		if _, err := io.ReadFull(r, moov); err != nil {
+			return nil, invalidVideo(err)
+		}
+		return parseMoov(moov)
+	}
+}
+
+// StripMetadata copies the MP4 or QuickTime video from r to w, replacing the
+// metadata boxes, which can hold the GPS location or the device the video was
+// recorded with, by "free" boxes of the same size filled with zeros. Boxes
+// are never removed or resized, so the offsets of the media data in "moov"
+// stay valid.
+func (s *VideoService) StripMetadata(r io.Reader, w io.Writer) error {
+	for {
+		var header bytes.Buffer
+		boxType, size, err := readBoxHeader(io.TeeReader(r, &header))
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case isMetadataBox(boxType):
+			if size < 0 {
+				// Nothing follows the box, so it can be dropped.
+				return nil
+			}
+			copy(header.Bytes()[4:8], "free")
+			if _, err := w.Write(header.Bytes()); err != nil {
+				return err
+			}
+			if _, err := io.CopyN(io.Discard, r, size); err != nil {
+				return invalidVideo(err)
+			}
+			if _, err := io.CopyN(w, zeroReader{}, size); err != nil {
+				return err
+			}
+		case boxType == "moov":
+			if size < 0 || size > maxMoovSize {
+				return fmt.Errorf("%w: the moov box is too large", domainerrors.ErrInvalidMedia)
+			}
+			moov := make([]byte, size)
+			if _, err := io.ReadFull(r, moov); err != nil {
+				return invalidVideo(err)
+			}
+			if err := blankMetadataBoxes(moov); err != nil {
+				return err
+			}
+			if _, err := w.Write(append(header.Bytes(), moov...)); err != nil {
+				return err
+			}
+		default:
+			if _, err := w.Write(header.Bytes()); err != nil {
+				return err
+			}
+			if size < 0 {
+				_, err := io.Copy(w, r)
+				return err
+			}
+			if _, err := io.CopyN(w, r, size); err != nil {
+				return invalidVideo(err)
+			}
+		}
+	}
+}
+
+// isMetadataBox reports whether the box holds user data or metadata rather
+// than what is needed to play the video.
+func isMetadataBox(boxType string) bool {
+	return boxType == "udta" || boxType == "meta" || boxType == "uuid"
+}
+
+// zeroReader reads an endless sequence of zeros.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// blankMetadataBoxes replaces the metadata boxes in the "moov" box and its
+// tracks in place by "free" boxes filled with zeros.
+func blankMetadataBoxes(data []byte) error {
+	return walkBoxes(data, func(boxType string, header, body []byte) error {
+		switch {
+		case isMetadataBox(boxType):
+			copy(header[4:8], "free")
+			clear(body)
+		case boxType == "trak":
+			return blankMetadataBoxes(body)
+		}
+		return nil
+	})
+}
+
+// readBoxHeader reads the header of an ISO base media file format box and
+// returns its type and the size of its body, or -1 if the box extends to
+// the end of the file.
+func readBoxHeader(r io.Reader) (string, int64, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return "", 0, invalidVideo(err)
+		}
+		return "", 0, err
+	}
+
+	size := int64(binary.BigEndian.Uint32(header[:4]))
+	boxType := string(header[4:])
+	switch size {
+	case 0:
+		return boxType, -1, nil
+	case 1:
+		var largeSize [8]byte
+		if _, err := io.ReadFull(r, largeSize[:]); err != nil {
+			return "", 0, invalidVideo(err)
+		}
+		size = int64(binary.BigEndian.Uint64(largeSize[:]))
+		if size < 16 {
+			return "", 0, fmt.Errorf("%w: the %q box has an invalid size", domainerrors.ErrInvalidMedia, boxType)
+		}
+		return boxType, size - 16, nil
+	default:
+		if size < 8 {
+			return "", 0, fmt.Errorf("%w: the %q box has an invalid size", domainerrors.ErrInvalidMedia, boxType)
+		}
+		return boxType, size - 8, nil
+	}
+}
+
+// forEachBox calls fn with the type and the body of each box in data.
+func forEachBox(data []byte, fn func(boxType string, body []byte) error) error {
+	return walkBoxes(data, func(boxType string, _, body []byte) error {
+		return fn(boxType, body)
+	})
+}
+
+// walkBoxes calls fn with the type, the header and the body of each box in data.
+// The header and the body are slices of data, so that fn can modify the box in place.
+func walkBoxes(data []byte, fn func(boxType string, header, body []byte) error) error {
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return fmt.Errorf("%w: a box is truncated", domainerrors.ErrInvalidMedia)
+		}
+		size := uint64(binary.BigEndian.Uint32(data[:4]))
+		boxType := string(data[4:8])
+		headerSize := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return fmt.Errorf("%w: the %q box is truncated", domainerrors.ErrInvalidMedia, boxType)
+			}
+			size = binary.BigEndian.Uint64(data[8:16])
+			headerSize = 16
+		}
+		if size < headerSize || size > uint64(len(data)) {
+			return fmt.Errorf("%w: the %q box has an invalid size", domainerrors.ErrInvalidMedia, boxType)
+		}
+
+		if err := fn(boxType, data[:headerSize], data[headerSize:size]); err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+
+	return nil
+}
+
+// parseMoov finds the duration in the "mvhd" box and the dimensions in the
+// "tkhd" box of the video track.
+func parseMoov(moov []byte) (*VideoInfo, error) {
+	var (
+		info      VideoInfo
+		hasHeader bool
+		hasVideo  bool
+	)
+	err := forEachBox(moov, func(boxType string, body []byte) error {
+		switch boxType {
+		case "mvhd":
+			duration, err := parseMvhd(body)
+			if err != nil {
+				return err
+			}
+			info.Duration = duration
+			hasHeader = true
+		case "trak":
+			width, height, isVideo, err := parseTrak(body)
+			if err != nil {
+				return err
+			}
+			if isVideo && !hasVideo {
+				info.Width, info.Height = width, height
+				hasVideo = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !hasHeader || !hasVideo || info.Width <= 0 || info.Height <= 0 {
+		return nil, fmt.Errorf("%w: the video has no video track", domainerrors.ErrInvalidMedia)
+	}
+
+	return &info, nil
+}
+
+func parseMvhd(body []byte) (time.Duration, error) {
+	var timescale, duration uint64
+	switch {
+	case len(body) >= 32 && body[0] == 1:
+		timescale = uint64(binary.BigEndian.Uint32(body[20:24]))
+		duration = binary.BigEndian.Uint64(body[24:32])
+	case len(body) >= 20 && body[0] == 0:
+		timescale = uint64(binary.BigEndian.Uint32(body[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(body[16:20]))
+	default:
+		return 0, fmt.Errorf("%w: the mvhd box is invalid", domainerrors.ErrInvalidMedia)
+	}
+	if timescale == 0 {
+		return 0, fmt.Errorf("%w: the mvhd box has no timescale", domainerrors.ErrInvalidMedia)
+	}
+
+	seconds := duration / timescale
+	if seconds > uint64(time.Duration(1<<63-1)/time.Second) {
+		return 0, fmt.Errorf("%w: the video is too long", domainerrors.ErrInvalidMedia)
+	}
+	return time.Duration(seconds)*time.Second + time.Duration(duration%timescale)*time.Second/time.Duration(timescale), nil
+}
+
+// parseTrak returns the dimensions of the track and whether it is a video track,
+// whose handler type in the "hdlr" box is "vide".
+func parseTrak(trak []byte) (int, int, bool, error) {
+	var (
+		width, height int
+		isVideo       bool
+	)
+	err := forEachBox(trak, func(boxType string, body []byte) error {
+		switch boxType {
+		case "tkhd":
+			var err error
+			width, height, err = parseTkhd(body)
+			return err
+		case "mdia":
+			return forEachBox(body, func(boxType string, body []byte) error {
+				if boxType == "hdlr" && len(body) >= 12 && string(body[8:12]) == "vide" {
+					isVideo = true
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+
+	return width, height, isVideo, err
+}
+
+// parseTkhd returns the dimensions in the "tkhd" box, swapped if its matrix rotates
+// the track by 90 or 270 degrees.
+func parseTkhd(body []byte) (int, int, error) {
+	offset := 40
+	if len(body) > 0 && body[0] == 1 {
+		offset = 52
+	}
+	if len(body) < offset+44 {
+		return 0, 0, fmt.Errorf("%w: the tkhd box is invalid", domainerrors.ErrInvalidMedia)
+	}
+
+	matrix := body[offset : offset+36]
+	// The dimensions are 16.16 fixed-point numbers.
+	width := int(binary.BigEndian.Uint32(body[offset+36:offset+40]) >> 16)
+	height := int(binary.BigEndian.Uint32(body[offset+40:offset+44]) >> 16)
+
+	a := int32(binary.BigEndian.Uint32(matrix[0:4]))
+	d := int32(binary.BigEndian.Uint32(matrix[16:20]))
+	if a == 0 && d == 0 {
+		width, height = height, width
+	}
+
+	return width, height, nil
+}
+
+func invalidVideo(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w: the video is truncated", domainerrors.ErrInvalidMedia)
+	}
+	return err
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+	domainerrors "x-clone-backend/internal/app/errors"
+)
+
+func box(boxType string, body ...[]byte) []byte {
+	content := bytes.Join(body, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(content)))
+	b = append(b, boxType...)
+	return append(b, content...)
+}
+
+func mvhd(timescale, duration uint32) []byte {
+	body := make([]byte, 100)
+	binary.BigEndian.PutUint32(body[12:], timescale)
+	binary.BigEndian.PutUint32(body[16:], duration)
+	return box("mvhd", body)
+}
+
+// trak returns a track of the handler type whose matrix is rotated by 90 degrees if rotated is true.
+func trak(handlerType string, width, height uint32, rotated bool) []byte {
+	tkhd := make([]byte, 84)
+	matrix := tkhd[40:76]
+	if rotated {
+		binary.BigEndian.PutUint32(matrix[4:], 0x00010000)
+		binary.BigEndian.PutUint32(matrix[12:], 0xffff0000)
+	} else {
+		binary.BigEndian.PutUint32(matrix[0:], 0x00010000)
+		binary.BigEndian.PutUint32(matrix[16:], 0x00010000)
+	}
+	binary.BigEndian.PutUint32(matrix[32:], 0x40000000)
+	binary.BigEndian.PutUint32(tkhd[76:], width<<16)
+	binary.BigEndian.PutUint32(tkhd[80:], height<<16)
+
+	hdlr := make([]byte, 24)
+	copy(hdlr[8:], handlerType)
+
+	return box("trak", box("tkhd", tkhd), box("mdia", box("hdlr", hdlr)))
+}
+
+func TestProbeVideo(t *testing.T) {
+	ftyp := box("ftyp", []byte("isom\x00\x00\x02\x00isomiso2mp41"))
+	mdat := box("mdat", make([]byte, 4096))
+
+	tests := []struct {
+		name             string
+		video            []byte
+		expectedWidth    int
+		expectedHeight   int
+		expectedDuration time.Duration
+	}{
+		{
+			name:             "moov before mdat",
+			video:            bytes.Join([][]byte{ftyp, box("moov", mvhd(1000, 12345), trak("vide", 1280, 720, false)), mdat}, nil),
+			expectedWidth:    1280,
+			expectedHeight:   720,
+			expectedDuration: 12345 * time.Millisecond,
+		},
+		{
+			name:             "moov after mdat",
+			video:            bytes.Join([][]byte{ftyp, mdat, box("moov", mvhd(600, 1800), trak("soun", 0, 0, false), trak("vide", 640, 360, false))}, nil),
+			expectedWidth:    640,
+			expectedHeight:   360,
+			expectedDuration: 3 * time.Second,
+		},
+		{
+			name:             "rotated by 90 degrees",
+			video:            bytes.Join([][]byte{ftyp, box("moov", mvhd(1000, 1000), trak("vide", 1920, 1080, true)), mdat}, nil),
+			expectedWidth:    1080,
+			expectedHeight:   1920,
+			expectedDuration: time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		info, err := NewVideoService().Probe(bytes.NewReader(test.video))
+		if err != nil {
+			t.Errorf("%s: failed to probe: %v", test.name, err)
+			continue
+		}
+		if info.Width != test.expectedWidth || info.Height != test.expectedHeight || info.Duration != test.expectedDuration {
+			t.Errorf("%s: expected %dx%d of %v, but got %+v", test.name, test.expectedWidth, test.expectedHeight, test.expectedDuration, info)
+		}
+	}
+}
+
+func TestProbeInvalidVideo(t *testing.T) {
+	ftyp := box("ftyp", []byte("isom\x00\x00\x02\x00"))
+
+	tests := []struct {
+		name  string
+		video []byte
+	}{
+		{name: "no moov", video: bytes.Join([][]byte{ftyp, box("mdat", make([]byte, 16))}, nil)},
+		{name: "no video track", video: bytes.Join([][]byte{ftyp, box("moov", mvhd(1000, 1000), trak("soun", 0, 0, false))}, nil)},
+		{name: "truncated", video: bytes.Join([][]byte{ftyp, box("moov", mvhd(1000, 1000), trak("vide", 640, 360, false))}, nil)[:60]},
+		{name: "invalid box size", video: append(ftyp, 0, 0, 0, 4, 'm', 'o', 'o', 'v')},
+	}
+
+	for _, test := range tests {
+		if _, err := NewVideoService().Probe(bytes.NewReader(test.video)); !errors.Is(err, domainerrors.ErrInvalidMedia) {
+			t.Errorf("%s: expected ErrInvalidMedia, but got %v", test.name, err)
+		}
+	}
+}
+
+func TestStripVideoMetadata(t *testing.T) {
+	ftyp := box("ftyp", []byte("isom\x00\x00\x02\x00isomiso2mp41"))
+	location := []byte("+35.6586+139.7454/")
+	mdat := box("mdat", make([]byte, 4096))
+
+	video := bytes.Join([][]byte{
+		ftyp,
+		box("moov",
+			mvhd(1000, 12345),
+			trak("vide", 1280, 720, false),
+			box("udta", box("\xa9xyz", location)),
+			box("meta", box("keys", location)),
+		),
+		box("uuid", location),
+		mdat,
+	}, nil)
+
+	var stripped bytes.Buffer
+	if err := NewVideoService().StripMetadata(bytes.NewReader(video), &stripped); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stripped.Len() != len(video) {
+		t.Errorf("expected the size %d to be kept, but got %d", len(video), stripped.Len())
+	}
+	if bytes.Contains(stripped.Bytes(), location) {
+		t.Errorf("the location remains in the stripped video")
+	}
+	for _, boxType := range []string{"udta", "meta", "uuid", "\xa9xyz"} {
+		if bytes.Contains(stripped.Bytes(), []byte(boxType)) {
+			t.Errorf("the %q box remains in the stripped video", boxType)
+		}
+	}
+	if !bytes.HasSuffix(stripped.Bytes(), mdat) {
+		t.Errorf("the media data is not kept at the same offset")
+	}
+
+	info, err := NewVideoService().Probe(bytes.NewReader(stripped.Bytes()))
+	if err != nil || info.Width != 1280 || info.Height != 720 || info.Duration != 12345*time.Millisecond {
+		t.Errorf("unexpected probe result of the stripped video: %+v, %v", info, err)
+	}
+}
@@ -0,0 +1,124 @@
+package text
+
+const (
+	zeroWidthJoiner    = 0x200D
+	textPresentation   = 0xFE0E
+	emojiPresentation  = 0xFE0F
+	combiningKeycap    = 0x20E3
+	regionalIndicatorA = 0x1F1E6
+	regionalIndicatorZ = 0x1F1FF
+	skinToneLightest   = 0x1F3FB
+	skinToneDarkest    = 0x1F3FF
+	tagSpace           = 0xE0020
+	tagTilde           = 0xE007E
+	cancelTag          = 0xE007F
+)
+
+// pictographicRanges approximate the code points of Extended_Pictographic,
+// which can be presented as emojis.
+var pictographicRanges = []struct{ lo, hi rune }{
+	{0x00A9, 0x00A9},
+	{0x00AE, 0x00AE},
+	{0x203C, 0x203C},
+	{0x2049, 0x2049},
+	{0x2122, 0x2122},
+	{0x2139, 0x2139},
+	{0x2194, 0x2199},
+	{0x21A9, 0x21AA},
+	{0x231A, 0x231B},
+	{0x2328, 0x2328},
+	{0x23CF, 0x23CF},
+	{0x23E9, 0x23F3},
+	{0x23F8, 0x23FA},
+	{0x24C2, 0x24C2},
+	{0x25AA, 0x25AB},
+	{0x25B6, 0x25B6},
+	{0x25C0, 0x25C0},
+	{0x25FB, 0x25FE},
+	{0x2600, 0x27BF},
+	{0x2934, 0x2935},
+	{0x2B05, 0x2B07},
+	{0x2B1B, 0x2B1C},
+	{0x2B50, 0x2B50},
+	{0x2B55, 0x2B55},
+	{0x3030, 0x3030},
+	{0x303D, 0x303D},
+	{0x3297, 0x3297},
+	{0x3299, 0x3299},
+	{0x1F000, 0x1F1E5},
+	{0x1F200, 0x1FAFF},
+}
+
+// emojiLength returns the number of code points of the emoji at the beginning
+// of the runes, or 0 if they do not begin with an emoji. An emoji is a flag of
+// two regional indicators, a keycap such as "1️⃣", or a pictograph followed by
+// an optional presentation selector, skin tone and tags, which can be joined
+// to further pictographs by ZWJs.
+//
+// Pictographs weighing 1 on their own, such as "©", are emojis only if they are
+// followed by the emoji presentation selector, as they are shown as text otherwise.
+func emojiLength(runes []rune) int {
+	if isRegionalIndicator(runes[0]) {
+		if len(runes) > 1 && isRegionalIndicator(runes[1]) {
+			return 2
+		}
+		return 1
+	}
+	if isKeycapBase(runes[0]) {
+		n := 1
+		if n < len(runes) && runes[n] == emojiPresentation {
+			n++
+		}
+		if n < len(runes) && runes[n] == combiningKeycap {
+			return n + 1
+		}
+		return 0
+	}
+
+	n := 0
+	for {
+		if n >= len(runes) || !isPictographic(runes[n]) {
+			return n
+		}
+		start := n
+		n++
+		if n < len(runes) && (runes[n] == emojiPresentation || runes[n] == textPresentation) {
+			n++
+		}
+		if start == 0 && runeWeight(runes[0]) == 1 && (n == 1 || runes[1] != emojiPresentation) {
+			return 0
+		}
+		if n < len(runes) && skinToneLightest <= runes[n] && runes[n] <= skinToneDarkest {
+			n++
+		}
+		if n < len(runes) && tagSpace <= runes[n] && runes[n] <= tagTilde {
+			for n < len(runes) && tagSpace <= runes[n] && runes[n] <= tagTilde {
+				n++
+			}
+			if n < len(runes) && runes[n] == cancelTag {
+				n++
+			}
+		}
+		if n+1 >= len(runes) || runes[n] != zeroWidthJoiner || !isPictographic(runes[n+1]) {
+			return n
+		}
+		n++
+	}
+}
+
+func isPictographic(r rune) bool {
+	for _, pictographicRange := range pictographicRanges {
+		if pictographicRange.lo <= r && r <= pictographicRange.hi {
+			return true
+		}
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return regionalIndicatorA <= r && r <= regionalIndicatorZ
+}
+
+func isKeycapBase(r rune) bool {
+	return ('0' <= r && r <= '9') || r == '#' || r == '*'
+}
@@ -0,0 +1,121 @@
+// Package text validates the texts of posts following X's rules of counting characters.
+package text
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// DefaultMaxWeightedLength is the maximum weighted length of a post on X,
+	// which is 280 Latin characters or 140 CJK characters.
+	DefaultMaxWeightedLength = 280
+
+	// defaultWeight is the weight of the characters out of lightRanges,
+	// such as CJK characters, and of emojis however many code points they consist of.
+	defaultWeight = 2
+)
+
+// lightRanges are the ranges of code points weighing 1: Latin, Greek, Cyrillic and
+// other scripts up to Hangul Jamo, and general punctuations such as spaces and quotes.
+var lightRanges = []struct{ lo, hi rune }{
+	{0x0000, 0x10FF},
+	{0x2000, 0x200D},
+	{0x2010, 0x201F},
+	{0x2032, 0x2037},
+}
+
+// Config is the configuration of validating the texts of posts.
+type Config struct {
+	// MaxWeightedLength is the maximum weighted length of a text.
+	MaxWeightedLength int
+}
+
+// DefaultConfig returns the configuration compatible with X.
+func DefaultConfig() Config {
+	return Config{MaxWeightedLength: DefaultMaxWeightedLength}
+}
+
+// Result is the result of validating a text.
+type Result struct {
+	// Text is the text normalized into NFC, which is to be stored
+	// so that the length does not change when the text is read back.
+	Text           string
+	WeightedLength int
+}
+
+// Validate normalizes the text into NFC and checks that it is neither empty
+// nor longer than MaxWeightedLength. The errors tell the computed weighted length,
+// and the result is returned along with them.
+func (c Config) Validate(s string) (Result, error) {
+	normalized := norm.NFC.String(s)
+	result := Result{Text: normalized, WeightedLength: weightedLength(normalized)}
+
+	if IsBlank(normalized) {
+		return result, errors.ErrEmptyPostText
+	}
+	if result.WeightedLength > c.MaxWeightedLength {
+		return result, fmt.Errorf("%w: the weighted length is %d, which exceeds %d", errors.ErrPostTextTooLong, result.WeightedLength, c.MaxWeightedLength)
+	}
+
+	return result, nil
+}
+
+// WeightedLength returns the length of the text counted as X does after normalizing
+// it into NFC. Characters in lightRanges weigh 1, and the others, including CJK
+// characters, weigh 2. An emoji weighs 2 even if it is a sequence of code points,
+// such as a flag or a family joined by ZWJs, and a URL counts as entities.URLLength
+// characters whatever its actual length.
+func WeightedLength(s string) int {
+	return weightedLength(norm.NFC.String(s))
+}
+
+func weightedLength(normalized string) int {
+	runes := []rune(normalized)
+	urls := entities.ExtractURLs(normalized)
+
+	length := 0
+	for i := 0; i < len(runes); {
+		if len(urls) > 0 && urls[0].Start == i {
+			length += entities.URLLength
+			i = urls[0].End
+			urls = urls[1:]
+			continue
+		}
+		if n := emojiLength(runes[i:]); n > 0 {
+			length += defaultWeight
+			i += n
+			continue
+		}
+		length += runeWeight(runes[i])
+		i++
+	}
+
+	return length
+}
+
+func runeWeight(r rune) int {
+	for _, lightRange := range lightRanges {
+		if lightRange.lo <= r && r <= lightRange.hi {
+			return 1
+		}
+	}
+	return defaultWeight
+}
+
+// IsBlank reports whether the text consists only of spaces and invisible characters,
+// in which case it is treated as empty.
+func IsBlank(s string) bool {
+	return strings.TrimFunc(s, isBlank) == ""
+}
+
+// isBlank reports whether the rune is a space or an invisible character,
+// which cannot make a text non-empty by itself.
+func isBlank(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("\u200b\u200c\u200d\u2060\ufeff", r)
+}
@@ -0,0 +1,111 @@
+package text
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	domainerrors "x-clone-backend/internal/app/errors"
+)
+
+func TestWeightedLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected int
+	}{
+		{name: "latin", text: "Hello, world!", expected: 13},
+		{name: "accented letters", text: "café naïve", expected: 10},
+		{name: "decomposed letters normalized into nfc", text: "cafe\u0301", expected: 4},
+		{name: "japanese", text: "こんにちは、世界", expected: 16},
+		{name: "korean", text: "안녕", expected: 4},
+		{name: "general punctuations", text: "“quoted” — ok", expected: 13},
+		{name: "emoji", text: "👍", expected: 2},
+		{name: "emoji with skin tone", text: "👍🏽", expected: 2},
+		{name: "zwj sequence", text: "👨‍👩‍👧‍👦", expected: 2},
+		{name: "flag", text: "🇯🇵", expected: 2},
+		{name: "tag sequence", text: "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F", expected: 2},
+		{name: "keycap", text: "1️⃣", expected: 2},
+		{name: "copyright sign as text", text: "©", expected: 1},
+		{name: "copyright sign as emoji", text: "©️", expected: 2},
+		{name: "heart with presentation selector", text: "❤️", expected: 2},
+		{name: "url", text: "see https://example.com/a/very/long/path/which/is/longer/than/23", expected: 4 + 23},
+		{name: "short url", text: "https://a.io", expected: 23},
+		{name: "url in japanese", text: "見て https://example.com", expected: 5 + 23},
+		{name: "empty", text: "", expected: 0},
+	}
+
+	for _, test := range tests {
+		if got := WeightedLength(test.text); got != test.expected {
+			t.Errorf("%s: expected %d, but got %d", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	config := Config{MaxWeightedLength: 10}
+
+	tests := []struct {
+		name        string
+		text        string
+		expected    Result
+		expectedErr error
+	}{
+		{
+			name:     "within the limit",
+			text:     "cafe\u0301",
+			expected: Result{Text: "caf\u00e9", WeightedLength: 4},
+		},
+		{
+			name:     "exactly the limit",
+			text:     "日本語です",
+			expected: Result{Text: "日本語です", WeightedLength: 10},
+		},
+		{
+			name:        "empty",
+			text:        "",
+			expected:    Result{Text: "", WeightedLength: 0},
+			expectedErr: domainerrors.ErrEmptyPostText,
+		},
+		{
+			name:        "whitespaces and invisible characters only",
+			text:        " \n\t\u3000\u200b",
+			expected:    Result{Text: " \n\t\u3000\u200b", WeightedLength: 6},
+			expectedErr: domainerrors.ErrEmptyPostText,
+		},
+		{
+			name:        "too long",
+			text:        strings.Repeat("a", 11),
+			expected:    Result{Text: strings.Repeat("a", 11), WeightedLength: 11},
+			expectedErr: domainerrors.ErrPostTextTooLong,
+		},
+	}
+
+	for _, test := range tests {
+		result, err := config.Validate(test.text)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, but got %v", test.name, test.expectedErr, err)
+		}
+		if result != test.expected {
+			t.Errorf("%s: expected %+v, but got %+v", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestIsBlank(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected bool
+	}{
+		{name: "empty", text: "", expected: true},
+		{name: "whitespaces", text: " \n\t\u3000", expected: true},
+		{name: "invisible characters", text: "\u200b\ufeff", expected: true},
+		{name: "text surrounded by whitespaces", text: " a ", expected: false},
+	}
+
+	for _, test := range tests {
+		if got := IsBlank(test.text); got != test.expected {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, got)
+		}
+	}
+}
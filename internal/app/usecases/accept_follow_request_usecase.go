@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type AcceptFollowRequestUsecase interface {
+	// AcceptFollowRequest accepts the follow request sent to the user by sourceUserID.
+	// The user must be the one who is authenticated, since only they can accept their requests.
+	// It returns ErrBlocked if either user blocks the other.
+	AcceptFollowRequest(userID, sourceUserID string) error
+}
+
+type acceptFollowRequestUsecase struct {
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewAcceptFollowRequestUsecase(usersRepository repositories.UsersRepositoryInterface) AcceptFollowRequestUsecase {
+	return &acceptFollowRequestUsecase{usersRepository: usersRepository}
+}
+
+func (p *acceptFollowRequestUsecase) AcceptFollowRequest(userID, sourceUserID string) error {
+	return p.usersRepository.WithTransaction(func(tx *sql.Tx) error {
+		// The source user being a private account does not matter here, only blocks do.
+		err := p.usersRepository.CheckVisibility(tx, sourceUserID, userID)
+		if err != nil && !errors.Is(err, domainerrors.ErrPrivateAccount) {
+			return err
+		}
+		if err := p.usersRepository.DeleteFollowRequest(tx, sourceUserID, userID); err != nil {
+			return err
+		}
+		_, err = p.usersRepository.FollowUser(tx, sourceUserID, userID)
+		return err
+	})
+}
@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"database/sql"
+	"fmt"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type AttachMediaUsecase interface {
+	// GetAttachableMedia returns the media of the IDs in order, or an error wrapping
+	// ErrInvalidMediaAttachment if there are more than MaxMediaPerPost or duplicate IDs,
+	// or if any of them does not exist, is not uploaded by the user, or has not been processed.
+	// A video cannot be attached with any other media.
+	GetAttachableMedia(userID uuid.UUID, mediaIDs []uuid.UUID) ([]*entities.Media, error)
+	AttachMedia(tx *sql.Tx, sourceID uuid.UUID, isQuote bool, media []*entities.Media) error
+}
+
+type attachMediaUsecase struct {
+	mediaRepository repositories.MediaRepositoryInterface
+}
+
+func NewAttachMediaUsecase(mediaRepository repositories.MediaRepositoryInterface) AttachMediaUsecase {
+	return &attachMediaUsecase{mediaRepository: mediaRepository}
+}
+
+func (p *attachMediaUsecase) GetAttachableMedia(userID uuid.UUID, mediaIDs []uuid.UUID) ([]*entities.Media, error) {
+	if len(mediaIDs) == 0 {
+		return nil, nil
+	}
+	if len(mediaIDs) > entities.MaxMediaPerPost {
+		return nil, fmt.Errorf("%w: at most %d media can be attached", errors.ErrInvalidMediaAttachment, entities.MaxMediaPerPost)
+	}
+
+	found, err := p.mediaRepository.GetMediaByIDs(mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	mediaByID := make(map[uuid.UUID]*entities.Media, len(found))
+	for _, media := range found {
+		mediaByID[media.ID] = media
+	}
+
+	attachable := make([]*entities.Media, 0, len(mediaIDs))
+	seen := make(map[uuid.UUID]bool, len(mediaIDs))
+	for _, id := range mediaIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: media %s is specified twice", errors.ErrInvalidMediaAttachment, id)
+		}
+		seen[id] = true
+
+		media, ok := mediaByID[id]
+		if !ok || media.UserID != userID {
+			return nil, fmt.Errorf("%w: media %s is not found", errors.ErrInvalidMediaAttachment, id)
+		}
+		if media.Status != entities.MediaSucceeded {
+			return nil, fmt.Errorf("%w: media %s has not been processed", errors.ErrInvalidMediaAttachment, id)
+		}
+		attachable = append(attachable, media)
+	}
+	for _, media := range attachable {
+		if entities.IsVideo(media.ContentType) && len(attachable) > 1 {
+			return nil, fmt.Errorf("%w: a video cannot be attached with other media", errors.ErrInvalidMediaAttachment)
+		}
+	}
+
+	return attachable, nil
+}
+
+func (p *attachMediaUsecase) AttachMedia(tx *sql.Tx, sourceID uuid.UUID, isQuote bool, media []*entities.Media) error {
+	mediaIDs := make([]uuid.UUID, 0, len(media))
+	for _, m := range media {
+		mediaIDs = append(mediaIDs, m.ID)
+	}
+
+	return p.mediaRepository.AttachMedia(tx, sourceID, isQuote, mediaIDs)
+}
@@ -7,7 +7,10 @@ import (
 )
 
 type BlockUserUsecase interface {
-	BlockUser(sourceUserID, targetUserID string) error
+	// BlockUser blocks the target user and reports whether a new block was created.
+	// Followships and follow requests in both directions are removed, and the mute of the target
+	// is kept so that it still applies once the target is unblocked.
+	BlockUser(sourceUserID, targetUserID string) (bool, error)
 }
 
 type blockUserUsecase struct {
@@ -18,9 +21,12 @@ func NewBlockUserUsecase(usersRepository repositories.UsersRepositoryInterface)
 	return &blockUserUsecase{usersRepository: usersRepository}
 }
 
-func (p *blockUserUsecase) BlockUser(sourceUserID, targetUserID string) error {
-	return p.usersRepository.WithTransaction(func(tx *sql.Tx) error {
-		if err := p.usersRepository.BlockUser(tx, sourceUserID, targetUserID); err != nil {
+func (p *blockUserUsecase) BlockUser(sourceUserID, targetUserID string) (bool, error) {
+	var created bool
+	err := p.usersRepository.WithTransaction(func(tx *sql.Tx) error {
+		var err error
+		created, err = p.usersRepository.BlockUser(tx, sourceUserID, targetUserID)
+		if err != nil {
 			return err
 		}
 		if err := p.usersRepository.UnfollowUser(tx, sourceUserID, targetUserID); err != nil {
@@ -33,11 +39,18 @@ func (p *blockUserUsecase) BlockUser(sourceUserID, targetUserID string) error {
 				return err
 			}
 		}
-		if err := p.usersRepository.UnmuteUser(tx, sourceUserID, targetUserID); err != nil {
-			if err != errors.ErrMuteNotFound {
+		if err := p.usersRepository.DeleteFollowRequest(tx, sourceUserID, targetUserID); err != nil {
+			if err != errors.ErrFollowRequestNotFound {
+				return err
+			}
+		}
+		if err := p.usersRepository.DeleteFollowRequest(tx, targetUserID, sourceUserID); err != nil {
+			if err != errors.ErrFollowRequestNotFound {
 				return err
 			}
 		}
 		return nil
 	})
+
+	return created, err
 }
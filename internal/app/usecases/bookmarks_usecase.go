@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type BookmarksUsecase interface {
+	// AddBookmark bookmarks the post into the folder, which is nil for no folder,
+	// or moves the bookmark if the post is already bookmarked.
+	// It reports whether a new bookmark was created.
+	AddBookmark(userID, postID uuid.UUID, folderID *uuid.UUID) (bool, error)
+	RemoveBookmark(userID, postID uuid.UUID) error
+	// GetBookmarks lists the posts bookmarked by the user, only in the folder if given.
+	GetBookmarks(userID uuid.UUID, folderID *uuid.UUID, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error)
+
+	// CreateFolder and RenameFolder return an error wrapping ErrInvalidBookmarkFolder
+	// if the name is blank or longer than MaxBookmarkFolderNameLength.
+	CreateFolder(userID uuid.UUID, name string) (*entities.BookmarkFolder, error)
+	GetFolders(userID uuid.UUID) ([]*entities.BookmarkFolder, error)
+	RenameFolder(userID, folderID uuid.UUID, name string) (*entities.BookmarkFolder, error)
+	// DeleteFolder deletes the folder along with the bookmarks in it.
+	DeleteFolder(userID, folderID uuid.UUID) error
+}
+
+type bookmarksUsecase struct {
+	bookmarksRepository repositories.BookmarksRepositoryInterface
+	postsRepository     repositories.PostsRepositoryInterface
+	usersRepository     repositories.UsersRepositoryInterface
+}
+
+func NewBookmarksUsecase(
+	bookmarksRepository repositories.BookmarksRepositoryInterface,
+	postsRepository repositories.PostsRepositoryInterface,
+	usersRepository repositories.UsersRepositoryInterface,
+) BookmarksUsecase {
+	return &bookmarksUsecase{
+		bookmarksRepository: bookmarksRepository,
+		postsRepository:     postsRepository,
+		usersRepository:     usersRepository,
+	}
+}
+
+func (p *bookmarksUsecase) AddBookmark(userID, postID uuid.UUID, folderID *uuid.UUID) (bool, error) {
+	// Users cannot bookmark the posts they are not allowed to see.
+	if err := checkPostVisibility(p.postsRepository, p.usersRepository, postID.String(), userID.String()); err != nil {
+		return false, err
+	}
+
+	return p.bookmarksRepository.AddBookmark(userID.String(), postID.String(), nullableID(folderID))
+}
+
+func (p *bookmarksUsecase) RemoveBookmark(userID, postID uuid.UUID) error {
+	return p.bookmarksRepository.RemoveBookmark(userID.String(), postID.String())
+}
+
+func (p *bookmarksUsecase) GetBookmarks(userID uuid.UUID, folderID *uuid.UUID, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error) {
+	if folderID != nil {
+		if _, err := p.bookmarksRepository.GetBookmarkFolder(userID.String(), folderID.String()); err != nil {
+			return entities.Page[*entities.Post]{}, err
+		}
+	}
+
+	page, err := p.bookmarksRepository.GetBookmarkedPosts(userID.String(), nullableID(folderID), cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+
+	err = p.postsRepository.HydratePosts(userID.String(), page.Items)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+
+	return page, nil
+}
+
+func (p *bookmarksUsecase) CreateFolder(userID uuid.UUID, name string) (*entities.BookmarkFolder, error) {
+	name, err := normalizeBookmarkFolderName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.bookmarksRepository.CreateBookmarkFolder(userID.String(), name)
+}
+
+func (p *bookmarksUsecase) GetFolders(userID uuid.UUID) ([]*entities.BookmarkFolder, error) {
+	return p.bookmarksRepository.GetBookmarkFolders(userID.String())
+}
+
+func (p *bookmarksUsecase) RenameFolder(userID, folderID uuid.UUID, name string) (*entities.BookmarkFolder, error) {
+	name, err := normalizeBookmarkFolderName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.bookmarksRepository.RenameBookmarkFolder(userID.String(), folderID.String(), name)
+}
+
+func (p *bookmarksUsecase) DeleteFolder(userID, folderID uuid.UUID) error {
+	return p.bookmarksRepository.DeleteBookmarkFolder(userID.String(), folderID.String())
+}
+
+// normalizeBookmarkFolderName trims the name and checks its length.
+func normalizeBookmarkFolderName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: the name is empty", errors.ErrInvalidBookmarkFolder)
+	}
+	if utf8.RuneCountInString(name) > entities.MaxBookmarkFolderNameLength {
+		return "", fmt.Errorf("%w: the name is longer than %d characters", errors.ErrInvalidBookmarkFolder, entities.MaxBookmarkFolderNameLength)
+	}
+
+	return name, nil
+}
+
+// nullableID converts an optional ID into the form the repositories take.
+func nullableID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	s := id.String()
+	return &s
+}
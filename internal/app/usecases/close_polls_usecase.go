@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"log/slog"
+	"time"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+const (
+	// PollClosingBatchSize is the maximum number of polls closed at a time.
+	PollClosingBatchSize = 100
+	// PollClosingInterval is how often ended polls are checked for closing.
+	PollClosingInterval = 10 * time.Second
+)
+
+type ClosePollsUsecase interface {
+	// CloseEndedPolls stores the final results of a batch of ended polls, notifies
+	// their authors and voters, and returns the number of polls closed.
+	CloseEndedPolls() (int, error)
+}
+
+type closePollsUsecase struct {
+	pollsRepository repositories.PollsRepositoryInterface
+	postsRepository repositories.PostsRepositoryInterface
+	notifyUsecase   NotifyUsecase
+}
+
+func NewClosePollsUsecase(
+	pollsRepository repositories.PollsRepositoryInterface,
+	postsRepository repositories.PostsRepositoryInterface,
+	notifyUsecase NotifyUsecase,
+) ClosePollsUsecase {
+	return &closePollsUsecase{
+		pollsRepository: pollsRepository,
+		postsRepository: postsRepository,
+		notifyUsecase:   notifyUsecase,
+	}
+}
+
+func (p *closePollsUsecase) CloseEndedPolls() (int, error) {
+	closed, err := p.pollsRepository.CloseEndedPolls(PollClosingBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// The polls have been closed anyway, so failures to notify are only logged.
+	for _, poll := range closed {
+		post, err := p.postsRepository.GetPost(poll.PostID.String())
+		if err != nil {
+			slog.Error("Could not get the post of a closed poll.", "poll_id", poll.ID, "error", err)
+			continue
+		}
+		voterIDs, err := p.pollsRepository.GetPollVoters(poll.ID)
+		if err != nil {
+			slog.Error("Could not get the voters of a closed poll.", "poll_id", poll.ID, "error", err)
+			continue
+		}
+
+		p.notifyUsecase.NotifyPollClosed(post.ID, post.UserID, voterIDs)
+	}
+
+	return len(closed), nil
+}
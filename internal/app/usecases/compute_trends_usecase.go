@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"time"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type ComputeTrendsUsecase interface {
+	// ComputeTrends finds the hashtags trending at now and stores them,
+	// replacing the ones computed before.
+	ComputeTrends(now time.Time) error
+}
+
+type computeTrendsUsecase struct {
+	trendsRepository repositories.TrendsRepositoryInterface
+}
+
+func NewComputeTrendsUsecase(trendsRepository repositories.TrendsRepositoryInterface) ComputeTrendsUsecase {
+	return &computeTrendsUsecase{trendsRepository: trendsRepository}
+}
+
+func (p *computeTrendsUsecase) ComputeTrends(now time.Time) error {
+	activities, err := p.trendsRepository.GetTagActivities(now)
+	if err != nil {
+		return err
+	}
+
+	trends := entities.RankTrends(activities, entities.MaxTrendCandidates)
+
+	return p.trendsRepository.ReplaceTrends(trends, now)
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type CreatePollUsecase interface {
+	// NewPoll returns a poll of the choices open for the duration from now, or an error
+	// wrapping ErrInvalidPoll if there are fewer than MinPollChoices or more than
+	// MaxPollChoices choices, if any choice is blank, longer than MaxPollChoiceLength
+	// or a duplicate, or if the duration is out of the range of ValidPollDuration.
+	NewPoll(labels []string, duration time.Duration, now time.Time) (*entities.Poll, error)
+	// CreatePoll attaches the poll returned by NewPoll to the post.
+	CreatePoll(tx *sql.Tx, postID uuid.UUID, poll *entities.Poll) error
+}
+
+type createPollUsecase struct {
+	pollsRepository repositories.PollsRepositoryInterface
+}
+
+func NewCreatePollUsecase(pollsRepository repositories.PollsRepositoryInterface) CreatePollUsecase {
+	return &createPollUsecase{pollsRepository: pollsRepository}
+}
+
+func (p *createPollUsecase) NewPoll(labels []string, duration time.Duration, now time.Time) (*entities.Poll, error) {
+	if len(labels) < entities.MinPollChoices || len(labels) > entities.MaxPollChoices {
+		return nil, fmt.Errorf("%w: a poll must have %d to %d choices", errors.ErrInvalidPoll, entities.MinPollChoices, entities.MaxPollChoices)
+	}
+	if !entities.ValidPollDuration(duration) {
+		return nil, fmt.Errorf("%w: a poll must be open for %v to %v", errors.ErrInvalidPoll, entities.MinPollDuration, entities.MaxPollDuration)
+	}
+
+	poll := &entities.Poll{EndsAt: now.Add(duration)}
+	seen := make(map[string]bool, len(labels))
+	for i, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return nil, fmt.Errorf("%w: choice %d is empty", errors.ErrInvalidPoll, i)
+		}
+		if utf8.RuneCountInString(label) > entities.MaxPollChoiceLength {
+			return nil, fmt.Errorf("%w: choice %d is longer than %d characters", errors.ErrInvalidPoll, i, entities.MaxPollChoiceLength)
+		}
+		if seen[strings.ToLower(label)] {
+			return nil, fmt.Errorf("%w: choice %d is a duplicate", errors.ErrInvalidPoll, i)
+		}
+		seen[strings.ToLower(label)] = true
+
+		// The author can see the results of their poll, which has no votes yet.
+		voteCount := 0
+		poll.Choices = append(poll.Choices, &entities.PollChoice{Position: i, Label: label, VoteCount: &voteCount})
+	}
+
+	return poll, nil
+}
+
+func (p *createPollUsecase) CreatePoll(tx *sql.Tx, postID uuid.UUID, poll *entities.Poll) error {
+	poll.PostID = postID
+	return p.pollsRepository.CreatePoll(tx, poll)
+}
@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"database/sql"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type CreatePostEntitiesUsecase interface {
+	// CreatePostEntities extracts the entities from the text of a newly created post,
+	// or quote repost if isQuote is true. It records the mentioned users, the hashtags
+	// of posts, so that they can be looked up later, and the links, whose preview cards
+	// are fetched in the background.
+	CreatePostEntities(tx *sql.Tx, authorID, sourceID uuid.UUID, isQuote bool, text string) (entities.PostEntities, error)
+	// NotifyMentions notifies the users mentioned in the post, or the quote repost,
+	// who can see the author. It is called once the post is committed,
+	// so that users are never notified of posts which failed to be created.
+	NotifyMentions(authorID, sourceID uuid.UUID, mentions []*entities.Mention)
+}
+
+type createPostEntitiesUsecase struct {
+	postsRepository repositories.PostsRepositoryInterface
+	usersRepository repositories.UsersRepositoryInterface
+	notifyUsecase   NotifyUsecase
+}
+
+func NewCreatePostEntitiesUsecase(
+	postsRepository repositories.PostsRepositoryInterface,
+	usersRepository repositories.UsersRepositoryInterface,
+	notifyUsecase NotifyUsecase,
+) CreatePostEntitiesUsecase {
+	return &createPostEntitiesUsecase{
+		postsRepository: postsRepository,
+		usersRepository: usersRepository,
+		notifyUsecase:   notifyUsecase,
+	}
+}
+
+func (p *createPostEntitiesUsecase) CreatePostEntities(tx *sql.Tx, authorID, sourceID uuid.UUID, isQuote bool, text string) (entities.PostEntities, error) {
+	postEntities := entities.PostEntities{
+		Hashtags: entities.ExtractHashtags(text),
+		Cashtags: entities.ExtractCashtags(text),
+	}
+
+	// Hashtag timelines list posts only.
+	if !isQuote {
+		err := p.postsRepository.CreateHashtags(tx, sourceID.String(), text)
+		if err != nil {
+			return postEntities, err
+		}
+	}
+
+	mentions, err := p.postsRepository.CreateMentions(tx, sourceID.String(), isQuote, text)
+	if err != nil {
+		return postEntities, err
+	}
+	postEntities.Mentions = mentions
+
+	urls, err := p.postsRepository.CreateLinks(tx, sourceID.String(), isQuote, text)
+	if err != nil {
+		return postEntities, err
+	}
+	postEntities.URLs = urls
+
+	return postEntities, nil
+}
+
+func (p *createPostEntitiesUsecase) NotifyMentions(authorID, sourceID uuid.UUID, mentions []*entities.Mention) {
+	notified := make(map[uuid.UUID]bool)
+	for _, mention := range mentions {
+		if notified[mention.UserID] {
+			continue
+		}
+		notified[mention.UserID] = true
+
+		// Users who cannot see the author, such as a private account
+		// they do not follow, are not told about the mention.
+		if err := p.usersRepository.CheckVisibility(nil, authorID.String(), mention.UserID.String()); err != nil {
+			continue
+		}
+		p.notifyUsecase.NotifyMention(mention.UserID, authorID, sourceID)
+	}
+}
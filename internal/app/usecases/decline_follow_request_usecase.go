@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type DeclineFollowRequestUsecase interface {
+	// DeclineFollowRequest declines the follow request sent to the user by sourceUserID.
+	// The user must be the one who is authenticated, since only they can decline their requests.
+	DeclineFollowRequest(userID, sourceUserID string) error
+}
+
+type declineFollowRequestUsecase struct {
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewDeclineFollowRequestUsecase(usersRepository repositories.UsersRepositoryInterface) DeclineFollowRequestUsecase {
+	return &declineFollowRequestUsecase{usersRepository: usersRepository}
+}
+
+func (p *declineFollowRequestUsecase) DeclineFollowRequest(userID, sourceUserID string) error {
+	err := p.usersRepository.DeleteFollowRequest(nil, sourceUserID, userID)
+	return err
+}
@@ -0,0 +1,516 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"unicode/utf8"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type DirectMessagesUsecase interface {
+	// StartConversation returns the one-to-one conversation of the user and the participant,
+	// creating it if they have none, and reports whether it was created.
+	// It returns ErrSelfConversation if they are the same user, ErrBlocked if either of them
+	// blocks the other, and ErrDirectMessageNotAllowed for a new conversation with a participant
+	// who does not accept direct messages from the user.
+	StartConversation(userID, participantID string) (*entities.Conversation, bool, error)
+	// CreateGroup creates a group conversation of the user and the participants.
+	// It returns an error wrapping ErrInvalidConversation if there are no other participants
+	// or the name is longer than MaxConversationNameLength, ErrConversationFull for more than
+	// MaxGroupParticipants participants, and ErrBlocked or ErrDirectMessageNotAllowed
+	// as StartConversation does for each participant.
+	CreateGroup(userID, name string, participantIDs []string) (*entities.Conversation, error)
+	// GetConversations lists the user's conversations, the most recently active first.
+	GetConversations(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Conversation], error)
+	GetConversation(conversationID, userID string) (*entities.Conversation, error)
+
+	// RenameConversation, AddParticipant and LeaveConversation change group conversations,
+	// returning ErrNotGroupConversation for one-to-one conversations, and record the changes
+	// as system messages.
+	RenameConversation(conversationID, userID, name string) (*entities.Conversation, error)
+	// AddParticipant reports whether the participant was newly added, and checks the participant
+	// as CreateGroup does.
+	AddParticipant(conversationID, userID, participantID string) (bool, error)
+	LeaveConversation(conversationID, userID string) error
+
+	// SendMessage stores the message and delivers it to the live streams of the other participants.
+	// It returns an error wrapping ErrInvalidDirectMessage if the text is blank or longer than
+	// MaxDirectMessageLength, and ErrBlocked if the sender and any other participant block either of them.
+	// In a one-to-one conversation, it returns ErrDirectMessageNotAllowed if the recipient does not accept
+	// messages from the sender.
+	SendMessage(conversationID, senderID, text string) (*entities.DirectMessage, error)
+	// GetMessages lists the messages of the conversation the user has not deleted, the latest first.
+	GetMessages(conversationID, userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.DirectMessage], error)
+	// DeleteMessage deletes the message only for the user; the other participants still see it.
+	DeleteMessage(conversationID, messageID, userID string) error
+	// MarkConversationAsRead delivers the user's new read receipt to the other participants.
+	MarkConversationAsRead(conversationID, userID string) (*entities.ReadReceipt, error)
+
+	// React returns the message with its reactions and reports whether the user newly reacted with the emoji.
+	// It returns ErrInvalidReaction unless the emoji is valid.
+	React(conversationID, messageID, userID, emoji string) (*entities.DirectMessage, bool, error)
+	Unreact(conversationID, messageID, userID, emoji string) error
+
+	GetSettings(userID string) (entities.DirectMessageSettings, error)
+	// UpdateSettings returns ErrInvalidDirectMessageSettings for an unknown permission.
+	UpdateSettings(userID string, patch entities.DirectMessageSettingsPatch) (entities.DirectMessageSettings, error)
+}
+
+type directMessagesUsecase struct {
+	directMessagesRepository repositories.DirectMessagesRepositoryInterface
+	usersRepository          repositories.UsersRepositoryInterface
+	mu                       *sync.Mutex
+	messageChans             *map[string]chan *entities.DirectMessageEvent
+}
+
+func NewDirectMessagesUsecase(
+	directMessagesRepository repositories.DirectMessagesRepositoryInterface,
+	usersRepository repositories.UsersRepositoryInterface,
+	mu *sync.Mutex,
+	messageChans *map[string]chan *entities.DirectMessageEvent,
+) DirectMessagesUsecase {
+	return &directMessagesUsecase{
+		directMessagesRepository: directMessagesRepository,
+		usersRepository:          usersRepository,
+		mu:                       mu,
+		messageChans:             messageChans,
+	}
+}
+
+func (p *directMessagesUsecase) StartConversation(userID, participantID string) (*entities.Conversation, bool, error) {
+	if userID == participantID {
+		return nil, false, domainerrors.ErrSelfConversation
+	}
+	if err := p.checkNotBlocked(userID, participantID); err != nil {
+		return nil, false, err
+	}
+
+	// Existing conversations can always be opened, while sending to them is still checked.
+	conversationID, err := p.directMessagesRepository.GetDirectConversationID(userID, participantID)
+	if err == nil {
+		conversation, err := p.directMessagesRepository.GetConversation(conversationID, userID)
+		return conversation, false, err
+	}
+	if !errors.Is(err, domainerrors.ErrConversationNotFound) {
+		return nil, false, err
+	}
+
+	if err := p.checkAccepted("", userID, participantID); err != nil {
+		return nil, false, err
+	}
+	conversationID, created, err := p.directMessagesRepository.GetOrCreateDirectConversation(userID, participantID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	conversation, err := p.directMessagesRepository.GetConversation(conversationID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return conversation, created, nil
+}
+
+func (p *directMessagesUsecase) CreateGroup(userID, name string, participantIDs []string) (*entities.Conversation, error) {
+	name, err := normalizeConversationName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []string{userID}
+	seen := map[string]bool{userID: true}
+	for _, participantID := range participantIDs {
+		if seen[participantID] {
+			continue
+		}
+		seen[participantID] = true
+		members = append(members, participantID)
+	}
+	if len(members) < 2 {
+		return nil, fmt.Errorf("%w: a group needs other participants", domainerrors.ErrInvalidConversation)
+	}
+	if len(members) > entities.MaxGroupParticipants {
+		return nil, domainerrors.ErrConversationFull
+	}
+
+	for _, participantID := range members[1:] {
+		if err := p.checkNotBlocked(userID, participantID); err != nil {
+			return nil, err
+		}
+		if err := p.checkAccepted("", userID, participantID); err != nil {
+			return nil, err
+		}
+	}
+
+	conversationID, err := p.directMessagesRepository.CreateGroupConversation(name, members)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.directMessagesRepository.GetConversation(conversationID, userID)
+}
+
+func (p *directMessagesUsecase) GetConversations(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Conversation], error) {
+	return p.directMessagesRepository.GetConversations(userID, cursor, limit)
+}
+
+func (p *directMessagesUsecase) GetConversation(conversationID, userID string) (*entities.Conversation, error) {
+	return p.directMessagesRepository.GetConversation(conversationID, userID)
+}
+
+func (p *directMessagesUsecase) RenameConversation(conversationID, userID, name string) (*entities.Conversation, error) {
+	name, err := normalizeConversationName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkGroupConversation(conversationID, userID); err != nil {
+		return nil, err
+	}
+
+	err = p.directMessagesRepository.RenameConversation(conversationID, name)
+	if err != nil {
+		return nil, err
+	}
+	err = p.createSystemMessage(conversationID, userID, entities.DirectMessageConversationRenamed, name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.directMessagesRepository.GetConversation(conversationID, userID)
+}
+
+func (p *directMessagesUsecase) AddParticipant(conversationID, userID, participantID string) (bool, error) {
+	if err := p.checkGroupConversation(conversationID, userID); err != nil {
+		return false, err
+	}
+	if err := p.checkNotBlocked(userID, participantID); err != nil {
+		return false, err
+	}
+	if err := p.checkAccepted("", userID, participantID); err != nil {
+		return false, err
+	}
+
+	added, err := p.directMessagesRepository.AddParticipant(conversationID, participantID)
+	if err != nil || !added {
+		return false, err
+	}
+
+	target, err := uuid.Parse(participantID)
+	if err != nil {
+		return false, err
+	}
+	err = p.createSystemMessage(conversationID, userID, entities.DirectMessageParticipantAdded, "", &target)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (p *directMessagesUsecase) LeaveConversation(conversationID, userID string) error {
+	if err := p.checkGroupConversation(conversationID, userID); err != nil {
+		return err
+	}
+
+	err := p.directMessagesRepository.RemoveParticipant(conversationID, userID)
+	if err != nil {
+		return err
+	}
+
+	return p.createSystemMessage(conversationID, userID, entities.DirectMessageParticipantLeft, "", nil)
+}
+
+func (p *directMessagesUsecase) SendMessage(conversationID, senderID, text string) (*entities.DirectMessage, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: the text is empty", domainerrors.ErrInvalidDirectMessage)
+	}
+	if utf8.RuneCountInString(text) > entities.MaxDirectMessageLength {
+		return nil, fmt.Errorf("%w: the text is longer than %d characters", domainerrors.ErrInvalidDirectMessage, entities.MaxDirectMessageLength)
+	}
+
+	conversation, err := p.directMessagesRepository.GetConversation(conversationID, senderID)
+	if err != nil {
+		return nil, err
+	}
+	for _, participant := range conversation.Participants {
+		recipientID := participant.ID.String()
+		if recipientID == senderID {
+			continue
+		}
+		if err := p.checkNotBlocked(senderID, recipientID); err != nil {
+			return nil, err
+		}
+		// The participants of a group have accepted it when they were added.
+		if conversation.IsGroup {
+			continue
+		}
+		if err := p.checkAccepted(conversationID, senderID, recipientID); err != nil {
+			return nil, err
+		}
+	}
+
+	sender, err := uuid.Parse(senderID)
+	if err != nil {
+		return nil, err
+	}
+	message := entities.DirectMessage{
+		ConversationID: conversation.ID,
+		SenderID:       sender,
+		Type:           entities.DirectMessageText,
+		Text:           text,
+	}
+	err = p.directMessagesRepository.CreateMessage(&message)
+	if err != nil {
+		return nil, err
+	}
+
+	p.deliver(conversationID, senderID, &entities.DirectMessageEvent{
+		EventType: entities.DirectMessageCreated,
+		Message:   &message,
+	})
+
+	return &message, nil
+}
+
+func (p *directMessagesUsecase) GetMessages(conversationID, userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.DirectMessage], error) {
+	if err := p.checkParticipant(conversationID, userID); err != nil {
+		return entities.Page[*entities.DirectMessage]{}, err
+	}
+
+	return p.directMessagesRepository.GetMessages(conversationID, userID, cursor, limit)
+}
+
+func (p *directMessagesUsecase) DeleteMessage(conversationID, messageID, userID string) error {
+	return p.directMessagesRepository.DeleteMessage(conversationID, messageID, userID)
+}
+
+func (p *directMessagesUsecase) MarkConversationAsRead(conversationID, userID string) (*entities.ReadReceipt, error) {
+	receipt, err := p.directMessagesRepository.MarkConversationAsRead(conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	p.deliver(conversationID, userID, &entities.DirectMessageEvent{
+		EventType:   entities.ConversationRead,
+		ReadReceipt: receipt,
+	})
+
+	return receipt, nil
+}
+
+func (p *directMessagesUsecase) React(conversationID, messageID, userID, emoji string) (*entities.DirectMessage, bool, error) {
+	if !entities.ValidReaction(emoji) {
+		return nil, false, domainerrors.ErrInvalidReaction
+	}
+	if err := p.checkParticipant(conversationID, userID); err != nil {
+		return nil, false, err
+	}
+	if _, err := p.directMessagesRepository.GetMessage(conversationID, messageID); err != nil {
+		return nil, false, err
+	}
+
+	added, err := p.directMessagesRepository.AddReaction(messageID, userID, emoji)
+	if err != nil {
+		return nil, false, err
+	}
+
+	message, err := p.directMessagesRepository.GetMessage(conversationID, messageID)
+	if err != nil {
+		return nil, false, err
+	}
+	if added {
+		p.deliver(conversationID, userID, &entities.DirectMessageEvent{
+			EventType: entities.DirectMessageReacted,
+			Message:   message,
+		})
+	}
+
+	return message, added, nil
+}
+
+func (p *directMessagesUsecase) Unreact(conversationID, messageID, userID, emoji string) error {
+	if err := p.checkParticipant(conversationID, userID); err != nil {
+		return err
+	}
+	if _, err := p.directMessagesRepository.GetMessage(conversationID, messageID); err != nil {
+		return err
+	}
+
+	err := p.directMessagesRepository.RemoveReaction(messageID, userID, emoji)
+	if err != nil {
+		return err
+	}
+
+	message, err := p.directMessagesRepository.GetMessage(conversationID, messageID)
+	if err != nil {
+		return err
+	}
+	p.deliver(conversationID, userID, &entities.DirectMessageEvent{
+		EventType: entities.DirectMessageReacted,
+		Message:   message,
+	})
+
+	return nil
+}
+
+func (p *directMessagesUsecase) GetSettings(userID string) (entities.DirectMessageSettings, error) {
+	return p.directMessagesRepository.GetDirectMessageSettings(userID)
+}
+
+func (p *directMessagesUsecase) UpdateSettings(userID string, patch entities.DirectMessageSettingsPatch) (entities.DirectMessageSettings, error) {
+	if patch.AllowFrom != nil && !patch.AllowFrom.Valid() {
+		return entities.DirectMessageSettings{}, fmt.Errorf("%w: unknown allow_from %q", domainerrors.ErrInvalidDirectMessageSettings, *patch.AllowFrom)
+	}
+
+	return p.directMessagesRepository.UpdateDirectMessageSettings(userID, patch)
+}
+
+// checkGroupConversation returns ErrNotGroupConversation if the conversation is a one-to-one conversation,
+// or ErrConversationNotFound if the user does not participate in it.
+func (p *directMessagesUsecase) checkGroupConversation(conversationID, userID string) error {
+	conversation, err := p.directMessagesRepository.GetConversation(conversationID, userID)
+	if err != nil {
+		return err
+	}
+	if !conversation.IsGroup {
+		return domainerrors.ErrNotGroupConversation
+	}
+
+	return nil
+}
+
+// checkParticipant returns ErrConversationNotFound unless the user participates in the conversation.
+func (p *directMessagesUsecase) checkParticipant(conversationID, userID string) error {
+	participantIDs, err := p.directMessagesRepository.GetParticipantIDs(conversationID)
+	if err != nil {
+		return err
+	}
+	for _, participantID := range participantIDs {
+		if participantID.String() == userID {
+			return nil
+		}
+	}
+
+	return domainerrors.ErrConversationNotFound
+}
+
+// createSystemMessage records a membership change of a group conversation made by the user,
+// and delivers it to the other participants.
+func (p *directMessagesUsecase) createSystemMessage(conversationID, userID string, messageType entities.DirectMessageType, text string, targetUserID *uuid.UUID) error {
+	conversation, err := uuid.Parse(conversationID)
+	if err != nil {
+		return err
+	}
+	sender, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+	message := entities.DirectMessage{
+		ConversationID: conversation,
+		SenderID:       sender,
+		Type:           messageType,
+		Text:           text,
+		TargetUserID:   targetUserID,
+	}
+	err = p.directMessagesRepository.CreateMessage(&message)
+	if err != nil {
+		return err
+	}
+
+	p.deliver(conversationID, userID, &entities.DirectMessageEvent{
+		EventType: entities.DirectMessageCreated,
+		Message:   &message,
+	})
+
+	return nil
+}
+
+// checkNotBlocked returns ErrBlocked if either user blocks the other,
+// or ErrUserNotFound if the recipient does not exist.
+// Unlike posts, private users can be messaged by anyone they accept.
+func (p *directMessagesUsecase) checkNotBlocked(senderID, recipientID string) error {
+	err := p.usersRepository.CheckVisibility(nil, recipientID, senderID)
+	if err != nil && !errors.Is(err, domainerrors.ErrPrivateAccount) {
+		return err
+	}
+
+	return nil
+}
+
+// checkAccepted returns ErrDirectMessageNotAllowed unless the recipient accepts direct messages
+// from the sender, either by their settings, or by having sent a message to the conversation.
+func (p *directMessagesUsecase) checkAccepted(conversationID, senderID, recipientID string) error {
+	settings, err := p.directMessagesRepository.GetDirectMessageSettings(recipientID)
+	if err != nil {
+		return err
+	}
+	if settings.AllowFrom == entities.DirectMessagesFromEveryone {
+		return nil
+	}
+
+	relationships, err := p.usersRepository.GetRelationships(nil, senderID, []string{recipientID})
+	if err != nil {
+		return err
+	}
+	for _, relationship := range relationships {
+		if relationship.FollowedBy {
+			return nil
+		}
+	}
+
+	if conversationID != "" {
+		replied, err := p.directMessagesRepository.HasSentMessage(conversationID, recipientID)
+		if err != nil {
+			return err
+		}
+		if replied {
+			return nil
+		}
+	}
+
+	return domainerrors.ErrDirectMessageNotAllowed
+}
+
+// deliver sends the event to the live streams of the participants of the conversation
+// except the user who caused it, as notifications are delivered.
+// Failures are logged instead of being returned, since the change has already been stored.
+func (p *directMessagesUsecase) deliver(conversationID, userID string, event *entities.DirectMessageEvent) {
+	participantIDs, err := p.directMessagesRepository.GetParticipantIDs(conversationID)
+	if err != nil {
+		slog.Error("Could not get the participants to deliver a direct message event to.", "conversation_id", conversationID, "error", err)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, participantID := range participantIDs {
+		if participantID.String() == userID {
+			continue
+		}
+		if messageChan, ok := (*p.messageChans)[participantID.String()]; ok {
+			// The change can still be listed later, so a slow stream must not block the user.
+			select {
+			case messageChan <- event:
+			default:
+			}
+		}
+	}
+}
+
+// normalizeConversationName trims the name of a group conversation and checks its length.
+func normalizeConversationName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if utf8.RuneCountInString(name) > entities.MaxConversationNameLength {
+		return "", fmt.Errorf("%w: the name is longer than %d characters", domainerrors.ErrInvalidConversation, entities.MaxConversationNameLength)
+	}
+
+	return name, nil
+}
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DraftPurgingBatchSize is the maximum number of expired drafts deleted at a time.
+	DraftPurgingBatchSize = 1000
+	// DraftPurgingInterval is how often the expired drafts are deleted.
+	DraftPurgingInterval = time.Hour
+)
+
+type DraftsUsecase interface {
+	// CreateDraft saves the draft of draft.UserID if it is Savable. The text must have been
+	// normalized; the rest of the content is validated when the draft is published.
+	CreateDraft(draft *entities.Draft) error
+	GetDrafts(userID uuid.UUID, cursor *entities.Cursor, limit int) (entities.Page[*entities.Draft], error)
+	GetDraft(userID, draftID uuid.UUID) (*entities.Draft, error)
+	// UpdateDraft replaces the content of the draft in the same way as CreateDraft saves it.
+	UpdateDraft(draft *entities.Draft) error
+	DeleteDraft(userID, draftID uuid.UUID) error
+	// DeletePublishedDraft deletes the draft read by GetDraft in the transaction publishing it,
+	// so that the draft is published only once however many times it is published at a time,
+	// and is kept if it could not be published.
+	DeletePublishedDraft(tx *sql.Tx, draft *entities.Draft) error
+
+	// PurgeDrafts deletes a batch of the drafts last updated before the time,
+	// and returns the number of drafts deleted.
+	PurgeDrafts(updatedBefore time.Time) (int, error)
+}
+
+type draftsUsecase struct {
+	draftsRepository repositories.DraftsRepositoryInterface
+}
+
+func NewDraftsUsecase(draftsRepository repositories.DraftsRepositoryInterface) DraftsUsecase {
+	return &draftsUsecase{draftsRepository: draftsRepository}
+}
+
+func (p *draftsUsecase) CreateDraft(draft *entities.Draft) error {
+	if err := checkDraft(draft); err != nil {
+		return err
+	}
+
+	return p.draftsRepository.CreateDraft(draft)
+}
+
+func (p *draftsUsecase) GetDrafts(userID uuid.UUID, cursor *entities.Cursor, limit int) (entities.Page[*entities.Draft], error) {
+	return p.draftsRepository.GetDrafts(userID.String(), cursor, limit)
+}
+
+func (p *draftsUsecase) GetDraft(userID, draftID uuid.UUID) (*entities.Draft, error) {
+	return p.draftsRepository.GetDraft(userID.String(), draftID.String())
+}
+
+func (p *draftsUsecase) UpdateDraft(draft *entities.Draft) error {
+	if err := checkDraft(draft); err != nil {
+		return err
+	}
+
+	return p.draftsRepository.UpdateDraft(draft)
+}
+
+func (p *draftsUsecase) DeleteDraft(userID, draftID uuid.UUID) error {
+	return p.draftsRepository.DeleteDraft(userID.String(), draftID.String())
+}
+
+func (p *draftsUsecase) DeletePublishedDraft(tx *sql.Tx, draft *entities.Draft) error {
+	return p.draftsRepository.DeletePublishedDraft(tx, draft)
+}
+
+func (p *draftsUsecase) PurgeDrafts(updatedBefore time.Time) (int, error) {
+	return p.draftsRepository.DeleteDraftsUpdatedBefore(updatedBefore, DraftPurgingBatchSize)
+}
+
+// checkDraft returns an error wrapping ErrInvalidDraft if the draft cannot be saved.
+func checkDraft(draft *entities.Draft) error {
+	if !draft.Savable() {
+		return fmt.Errorf("%w: a draft can have at most %d media, and cannot have a poll if it quotes a post", domainerrors.ErrInvalidDraft, entities.MaxMediaPerPost)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"database/sql"
+	"time"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/text"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type EditPostUsecase interface {
+	// EditPost replaces the text of the post written by the user, if the edit policy
+	// allows it, and returns the post as seen by the user. The text must have been validated;
+	// it can be blank only if media are attached. The entities are created again from the text,
+	// and only the users who were not mentioned before are notified.
+	EditPost(userID, postID uuid.UUID, text string) (*entities.Post, error)
+	// GetPostHistory lists the versions of the post, the latest first,
+	// if the author's activities are visible to the viewer.
+	GetPostHistory(postID, viewerID string) ([]*entities.PostEdit, error)
+}
+
+type editPostUsecase struct {
+	postsRepository           repositories.PostsRepositoryInterface
+	usersRepository           repositories.UsersRepositoryInterface
+	createPostEntitiesUsecase CreatePostEntitiesUsecase
+	editPolicy                entities.PostEditPolicy
+}
+
+func NewEditPostUsecase(
+	postsRepository repositories.PostsRepositoryInterface,
+	usersRepository repositories.UsersRepositoryInterface,
+	createPostEntitiesUsecase CreatePostEntitiesUsecase,
+	editPolicy entities.PostEditPolicy,
+) EditPostUsecase {
+	return &editPostUsecase{
+		postsRepository:           postsRepository,
+		usersRepository:           usersRepository,
+		createPostEntitiesUsecase: createPostEntitiesUsecase,
+		editPolicy:                editPolicy,
+	}
+}
+
+func (p *editPostUsecase) EditPost(userID, postID uuid.UUID, postText string) (*entities.Post, error) {
+	post, err := p.postsRepository.GetPost(postID.String())
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != userID {
+		return nil, domainerrors.ErrNotPostAuthor
+	}
+	if !time.Now().Before(p.editPolicy.EditableUntil(post)) {
+		return nil, domainerrors.ErrPostEditWindowClosed
+	}
+	if p.editPolicy.EditsLeft(post) == 0 {
+		return nil, domainerrors.ErrPostEditLimitReached
+	}
+
+	if text.IsBlank(postText) {
+		if err := p.postsRepository.HydratePosts(userID.String(), []*entities.Post{post}); err != nil {
+			return nil, err
+		}
+		if len(post.Media) == 0 {
+			return nil, domainerrors.ErrEmptyPostText
+		}
+	}
+
+	// The entities are created again in the transaction of the edit, so that
+	// an edited post is never left without its hashtags and links.
+	var postEntities entities.PostEntities
+	err = p.postsRepository.WithTransaction(func(tx *sql.Tx) error {
+		if _, err := p.postsRepository.EditPost(tx, postID.String(), postText, p.editPolicy.MaxEdits); err != nil {
+			return err
+		}
+		var err error
+		postEntities, err = p.createPostEntitiesUsecase.CreatePostEntities(tx, userID, postID, false, postText)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.createPostEntitiesUsecase.NotifyMentions(userID, postID, postEntities.Mentions)
+
+	post, err = p.postsRepository.GetPost(postID.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.postsRepository.HydratePosts(userID.String(), []*entities.Post{post}); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (p *editPostUsecase) GetPostHistory(postID, viewerID string) ([]*entities.PostEdit, error) {
+	if err := checkPostVisibility(p.postsRepository, p.usersRepository, postID, viewerID); err != nil {
+		return nil, err
+	}
+
+	return p.postsRepository.GetPostHistory(postID)
+}
@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type FollowLinkUsecase interface {
+	// FollowLink counts a click on the short link of the code and returns the URL to redirect to.
+	FollowLink(code string) (string, error)
+}
+
+type followLinkUsecase struct {
+	linksRepository repositories.LinksRepositoryInterface
+}
+
+func NewFollowLinkUsecase(linksRepository repositories.LinksRepositoryInterface) FollowLinkUsecase {
+	return &followLinkUsecase{linksRepository: linksRepository}
+}
+
+func (p *followLinkUsecase) FollowLink(code string) (string, error) {
+	return p.linksRepository.FollowLink(code)
+}
@@ -1,22 +1,60 @@
 package usecases
 
 import (
+	"errors"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
 	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+// FollowResult tells what following a user resulted in.
+type FollowResult int
+
+const (
+	// Followed means a new followship was created.
+	Followed FollowResult = iota
+	// AlreadyFollowing means the followship already existed.
+	AlreadyFollowing
+	// FollowRequested means the target is a private account
+	// and a follow request is waiting for approval.
+	FollowRequested
 )
 
 type FollowUserUsecase interface {
-	FollowUser(sourceUserID, targetUserID string) error
+	// FollowUser follows the target user, or sends a follow request
+	// if the target is a private account.
+	FollowUser(sourceUserID, targetUserID string) (FollowResult, error)
 }
 
 type followUserUsecase struct {
 	usersRepository repositories.UsersRepositoryInterface
+	notifyUsecase   NotifyUsecase
 }
 
-func NewFollowUserUsecase(usersRepository repositories.UsersRepositoryInterface) FollowUserUsecase {
-	return &followUserUsecase{usersRepository: usersRepository}
+func NewFollowUserUsecase(usersRepository repositories.UsersRepositoryInterface, notifyUsecase NotifyUsecase) FollowUserUsecase {
+	return &followUserUsecase{usersRepository: usersRepository, notifyUsecase: notifyUsecase}
 }
 
-func (p *followUserUsecase) FollowUser(sourceUserID, targetUserID string) error {
-	err := p.usersRepository.FollowUser(nil, sourceUserID, targetUserID)
-	return err
+func (p *followUserUsecase) FollowUser(sourceUserID, targetUserID string) (FollowResult, error) {
+	err := p.usersRepository.CheckVisibility(nil, targetUserID, sourceUserID)
+	switch {
+	case errors.Is(err, domainerrors.ErrPrivateAccount):
+		_, err := p.usersRepository.RequestFollow(nil, sourceUserID, targetUserID)
+		return FollowRequested, err
+	case err != nil:
+		return Followed, err
+	}
+
+	created, err := p.usersRepository.FollowUser(nil, sourceUserID, targetUserID)
+	if err != nil {
+		return Followed, err
+	}
+	if !created {
+		return AlreadyFollowing, nil
+	}
+
+	p.notifyUsecase.NotifyUser(uuid.MustParse(targetUserID), uuid.MustParse(sourceUserID), entities.NotificationFollow)
+	return Followed, nil
 }
@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetBlockedUsersUsecase interface {
+	GetBlockedUsers(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimestampedUser], error)
+}
+
+type getBlockedUsersUsecase struct {
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewGetBlockedUsersUsecase(usersRepository repositories.UsersRepositoryInterface) GetBlockedUsersUsecase {
+	return &getBlockedUsersUsecase{usersRepository: usersRepository}
+}
+
+func (p *getBlockedUsersUsecase) GetBlockedUsers(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimestampedUser], error) {
+	return p.usersRepository.GetBlockedUsers(nil, userID, cursor, limit)
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetFollowersUsecase interface {
+	GetFollowers(userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.RelatedUser], error)
+}
+
+type getFollowersUsecase struct {
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewGetFollowersUsecase(usersRepository repositories.UsersRepositoryInterface) GetFollowersUsecase {
+	return &getFollowersUsecase{usersRepository: usersRepository}
+}
+
+func (p *getFollowersUsecase) GetFollowers(userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.RelatedUser], error) {
+	err := p.usersRepository.CheckVisibility(nil, userID, viewerID)
+	if err != nil {
+		return entities.Page[*entities.RelatedUser]{}, err
+	}
+
+	page, err := p.usersRepository.GetFollowers(nil, userID, viewerID, cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.RelatedUser]{}, err
+	}
+
+	return withRelationships(p.usersRepository, viewerID, page)
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetFollowingUsecase interface {
+	GetFollowing(userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.RelatedUser], error)
+}
+
+type getFollowingUsecase struct {
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewGetFollowingUsecase(usersRepository repositories.UsersRepositoryInterface) GetFollowingUsecase {
+	return &getFollowingUsecase{usersRepository: usersRepository}
+}
+
+func (p *getFollowingUsecase) GetFollowing(userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.RelatedUser], error) {
+	err := p.usersRepository.CheckVisibility(nil, userID, viewerID)
+	if err != nil {
+		return entities.Page[*entities.RelatedUser]{}, err
+	}
+
+	page, err := p.usersRepository.GetFollowing(nil, userID, viewerID, cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.RelatedUser]{}, err
+	}
+
+	return withRelationships(p.usersRepository, viewerID, page)
+}
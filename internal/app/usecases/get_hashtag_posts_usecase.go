@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetHashtagPostsUsecase interface {
+	GetHashtagPosts(tag, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error)
+}
+
+type getHashtagPostsUsecase struct {
+	postsRepository repositories.PostsRepositoryInterface
+}
+
+func NewGetHashtagPostsUsecase(postsRepository repositories.PostsRepositoryInterface) GetHashtagPostsUsecase {
+	return &getHashtagPostsUsecase{postsRepository: postsRepository}
+}
+
+func (p *getHashtagPostsUsecase) GetHashtagPosts(tag, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error) {
+	page, err := p.postsRepository.GetHashtagPosts(entities.NormalizeTag(tag), viewerID, cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+
+	err = p.postsRepository.HydratePosts(viewerID, page.Items)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+
+	return page, nil
+}
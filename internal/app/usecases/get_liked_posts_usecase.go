@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetLikedPostsUsecase interface {
+	GetLikedPosts(userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error)
+}
+
+type getLikedPostsUsecase struct {
+	postsRepository repositories.PostsRepositoryInterface
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewGetLikedPostsUsecase(postsRepository repositories.PostsRepositoryInterface, usersRepository repositories.UsersRepositoryInterface) GetLikedPostsUsecase {
+	return &getLikedPostsUsecase{postsRepository: postsRepository, usersRepository: usersRepository}
+}
+
+func (p *getLikedPostsUsecase) GetLikedPosts(userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error) {
+	err := p.usersRepository.CheckVisibility(nil, userID, viewerID)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+
+	page, err := p.postsRepository.GetLikedPosts(userID, viewerID, cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+
+	err = p.postsRepository.HydratePosts(viewerID, page.Items)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+
+	return page, nil
+}
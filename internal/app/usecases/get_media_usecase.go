@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetMediaUsecase interface {
+	GetMedia(mediaID string) (*entities.Media, error)
+}
+
+type getMediaUsecase struct {
+	mediaRepository repositories.MediaRepositoryInterface
+}
+
+func NewGetMediaUsecase(mediaRepository repositories.MediaRepositoryInterface) GetMediaUsecase {
+	return &getMediaUsecase{mediaRepository: mediaRepository}
+}
+
+func (p *getMediaUsecase) GetMedia(mediaID string) (*entities.Media, error) {
+	return p.mediaRepository.GetMedia(mediaID)
+}
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetMentionTimelineUsecase interface {
+	GetMentionTimeline(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error)
+}
+
+type getMentionTimelineUsecase struct {
+	postsRepository repositories.PostsRepositoryInterface
+}
+
+func NewGetMentionTimelineUsecase(postsRepository repositories.PostsRepositoryInterface) GetMentionTimelineUsecase {
+	return &getMentionTimelineUsecase{postsRepository: postsRepository}
+}
+
+func (p *getMentionTimelineUsecase) GetMentionTimeline(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error) {
+	page, err := p.postsRepository.GetMentionTimeline(userID, cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+
+	err = hydrateTimelineItems(p.postsRepository, userID, page.Items)
+	if err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+
+	return page, nil
+}
+
+// hydrateTimelineItems hydrates the posts, the quote reposts and the reposts of the timeline items in batch.
+func hydrateTimelineItems(postsRepository repositories.PostsRepositoryInterface, viewerID string, items []*entities.TimelineItem) error {
+	var (
+		posts  []*entities.Post
+		quotes []*entities.Repost
+	)
+	for _, item := range items {
+		if item.Post != nil {
+			posts = append(posts, item.Post)
+		} else {
+			quotes = append(quotes, item.Quote)
+		}
+		if item.Repost != nil {
+			quotes = append(quotes, item.Repost)
+		}
+	}
+
+	err := postsRepository.HydratePosts(viewerID, posts)
+	if err != nil {
+		return err
+	}
+
+	return postsRepository.HydrateQuotes(quotes)
+}
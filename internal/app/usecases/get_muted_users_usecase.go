@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetMutedUsersUsecase interface {
+	GetMutedUsers(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimestampedUser], error)
+}
+
+type getMutedUsersUsecase struct {
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewGetMutedUsersUsecase(usersRepository repositories.UsersRepositoryInterface) GetMutedUsersUsecase {
+	return &getMutedUsersUsecase{usersRepository: usersRepository}
+}
+
+func (p *getMutedUsersUsecase) GetMutedUsers(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimestampedUser], error) {
+	return p.usersRepository.GetMutedUsers(nil, userID, cursor, limit)
+}
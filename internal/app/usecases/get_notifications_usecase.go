@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetNotificationsUsecase interface {
+	// GetNotifications returns a page of grouped notifications
+	// and the number of unread ones.
+	GetNotifications(recipientID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Notification], int, error)
+}
+
+type getNotificationsUsecase struct {
+	notificationsRepository repositories.NotificationsRepositoryInterface
+}
+
+func NewGetNotificationsUsecase(notificationsRepository repositories.NotificationsRepositoryInterface) GetNotificationsUsecase {
+	return &getNotificationsUsecase{notificationsRepository: notificationsRepository}
+}
+
+func (p *getNotificationsUsecase) GetNotifications(recipientID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Notification], int, error) {
+	page, err := p.notificationsRepository.GetNotifications(recipientID, cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.Notification]{}, 0, err
+	}
+
+	unreadCount, err := p.notificationsRepository.CountUnreadNotifications(recipientID)
+	if err != nil {
+		return entities.Page[*entities.Notification]{}, 0, err
+	}
+
+	return page, unreadCount, nil
+}
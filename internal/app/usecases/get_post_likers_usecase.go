@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetPostLikersUsecase interface {
+	GetPostLikers(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error)
+}
+
+type getPostLikersUsecase struct {
+	postsRepository repositories.PostsRepositoryInterface
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewGetPostLikersUsecase(postsRepository repositories.PostsRepositoryInterface, usersRepository repositories.UsersRepositoryInterface) GetPostLikersUsecase {
+	return &getPostLikersUsecase{postsRepository: postsRepository, usersRepository: usersRepository}
+}
+
+func (p *getPostLikersUsecase) GetPostLikers(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error) {
+	err := checkPostVisibility(p.postsRepository, p.usersRepository, postID, viewerID)
+	if err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+
+	return p.postsRepository.GetPostLikers(postID, viewerID, cursor, limit)
+}
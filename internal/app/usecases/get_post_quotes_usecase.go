@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetPostQuotesUsecase interface {
+	GetPostQuotes(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Repost], error)
+}
+
+type getPostQuotesUsecase struct {
+	postsRepository repositories.PostsRepositoryInterface
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewGetPostQuotesUsecase(postsRepository repositories.PostsRepositoryInterface, usersRepository repositories.UsersRepositoryInterface) GetPostQuotesUsecase {
+	return &getPostQuotesUsecase{postsRepository: postsRepository, usersRepository: usersRepository}
+}
+
+func (p *getPostQuotesUsecase) GetPostQuotes(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Repost], error) {
+	err := checkPostVisibility(p.postsRepository, p.usersRepository, postID, viewerID)
+	if err != nil {
+		return entities.Page[*entities.Repost]{}, err
+	}
+
+	page, err := p.postsRepository.GetPostQuotes(postID, viewerID, cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.Repost]{}, err
+	}
+
+	err = p.postsRepository.HydrateQuotes(page.Items)
+	if err != nil {
+		return entities.Page[*entities.Repost]{}, err
+	}
+
+	return page, nil
+}
@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetPostRepostersUsecase interface {
+	GetPostReposters(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error)
+}
+
+type getPostRepostersUsecase struct {
+	postsRepository repositories.PostsRepositoryInterface
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewGetPostRepostersUsecase(postsRepository repositories.PostsRepositoryInterface, usersRepository repositories.UsersRepositoryInterface) GetPostRepostersUsecase {
+	return &getPostRepostersUsecase{postsRepository: postsRepository, usersRepository: usersRepository}
+}
+
+func (p *getPostRepostersUsecase) GetPostReposters(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error) {
+	err := checkPostVisibility(p.postsRepository, p.usersRepository, postID, viewerID)
+	if err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+
+	return p.postsRepository.GetPostReposters(postID, viewerID, cursor, limit)
+}
@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type GetRelationshipsUsecase interface {
+	GetRelationships(viewerID string, userIDs []string) (map[uuid.UUID]*entities.Relationship, error)
+}
+
+type getRelationshipsUsecase struct {
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewGetRelationshipsUsecase(usersRepository repositories.UsersRepositoryInterface) GetRelationshipsUsecase {
+	return &getRelationshipsUsecase{usersRepository: usersRepository}
+}
+
+func (p *getRelationshipsUsecase) GetRelationships(viewerID string, userIDs []string) (map[uuid.UUID]*entities.Relationship, error) {
+	return p.usersRepository.GetRelationships(nil, viewerID, userIDs)
+}
+
+// withRelationships annotates each user of the page with the viewer's relationship to them.
+// The relationships are left nil for anonymous viewers.
+func withRelationships(usersRepository repositories.UsersRepositoryInterface, viewerID string, page entities.Page[*entities.UserSummary]) (entities.Page[*entities.RelatedUser], error) {
+	users := make([]*entities.RelatedUser, 0, len(page.Items))
+	userIDs := make([]string, 0, len(page.Items))
+	for _, user := range page.Items {
+		users = append(users, &entities.RelatedUser{UserSummary: *user})
+		userIDs = append(userIDs, user.ID.String())
+	}
+
+	if viewerID != "" && len(userIDs) > 0 {
+		relationships, err := usersRepository.GetRelationships(nil, viewerID, userIDs)
+		if err != nil {
+			return entities.Page[*entities.RelatedUser]{}, err
+		}
+		for _, user := range users {
+			user.Relationship = relationships[user.ID]
+		}
+	}
+
+	return entities.Page[*entities.RelatedUser]{Items: users, NextCursor: page.NextCursor}, nil
+}
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetSpecificPostUsecase interface {
+	// GetSpecificPost gets the post if its author's activities are visible to the viewer.
+	GetSpecificPost(postID, viewerID string) (*entities.Post, error)
+}
+
+type getSpecificPostUsecase struct {
+	postsRepository repositories.PostsRepositoryInterface
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewGetSpecificPostUsecase(postsRepository repositories.PostsRepositoryInterface, usersRepository repositories.UsersRepositoryInterface) GetSpecificPostUsecase {
+	return &getSpecificPostUsecase{postsRepository: postsRepository, usersRepository: usersRepository}
+}
+
+func (p *getSpecificPostUsecase) GetSpecificPost(postID, viewerID string) (*entities.Post, error) {
+	err := checkPostVisibility(p.postsRepository, p.usersRepository, postID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := p.postsRepository.GetPost(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.postsRepository.HydratePosts(viewerID, []*entities.Post{post})
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
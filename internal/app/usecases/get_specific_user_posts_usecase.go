@@ -6,7 +6,7 @@ import (
 )
 
 type GetSpecificUserPostsUsecase interface {
-	GetSpecificUserPosts(userID string) ([]*entities.Post, error)
+	GetSpecificUserPosts(userID, viewerID string) ([]*entities.Post, error)
 }
 
 type getSpecificUserPostsUsecase struct {
@@ -17,11 +17,16 @@ func NewGetSpecificUserPostsUsecase(postsRepository repositories.PostsRepository
 	return &getSpecificUserPostsUsecase{postsRepository: postsRepository}
 }
 
-func (p *getSpecificUserPostsUsecase) GetSpecificUserPosts(userID string) ([]*entities.Post, error) {
+func (p *getSpecificUserPostsUsecase) GetSpecificUserPosts(userID, viewerID string) ([]*entities.Post, error) {
 	posts, err := p.postsRepository.GetSpecificUserPosts(userID)
 	if err != nil {
 		return nil, err
 	}
 
+	err = p.postsRepository.HydratePosts(viewerID, posts)
+	if err != nil {
+		return nil, err
+	}
+
 	return posts, nil
 }
@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type GetTrendsUsecase interface {
+	GetTrends(viewerID string, limit int) ([]*entities.Trend, error)
+}
+
+type getTrendsUsecase struct {
+	trendsRepository repositories.TrendsRepositoryInterface
+}
+
+func NewGetTrendsUsecase(trendsRepository repositories.TrendsRepositoryInterface) GetTrendsUsecase {
+	return &getTrendsUsecase{trendsRepository: trendsRepository}
+}
+
+func (p *getTrendsUsecase) GetTrends(viewerID string, limit int) ([]*entities.Trend, error) {
+	return p.trendsRepository.GetTrends(viewerID, limit)
+}
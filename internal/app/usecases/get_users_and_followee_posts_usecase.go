@@ -23,5 +23,10 @@ func (p *getUserAndFolloweePostsUsecase) GetUserAndFolloweePosts(userID string)
 		return nil, err
 	}
 
+	err = p.postsRepository.HydratePosts(userID, posts)
+	if err != nil {
+		return nil, err
+	}
+
 	return posts, nil
 }
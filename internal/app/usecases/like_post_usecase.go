@@ -1,24 +1,38 @@
 package usecases
 
 import (
+	"x-clone-backend/internal/domain/entities"
 	"x-clone-backend/internal/domain/repositories"
 
 	"github.com/google/uuid"
 )
 
 type LikePostUsecase interface {
-	LikePost(userID string, postID uuid.UUID) error
+	// LikePost likes the post and reports whether a new like was created.
+	// It returns ErrBlocked or ErrPrivateAccount if the user is not allowed to see the post.
+	LikePost(userID string, postID uuid.UUID) (bool, error)
 }
 
 type likePostUsecase struct {
 	usersRepository repositories.UsersRepositoryInterface
+	postsRepository repositories.PostsRepositoryInterface
+	notifyUsecase   NotifyUsecase
 }
 
-func NewLikePostUsecase(usersRepository repositories.UsersRepositoryInterface) LikePostUsecase {
-	return &likePostUsecase{usersRepository: usersRepository}
+func NewLikePostUsecase(usersRepository repositories.UsersRepositoryInterface, postsRepository repositories.PostsRepositoryInterface, notifyUsecase NotifyUsecase) LikePostUsecase {
+	return &likePostUsecase{usersRepository: usersRepository, postsRepository: postsRepository, notifyUsecase: notifyUsecase}
 }
 
-func (p *likePostUsecase) LikePost(userID string, postID uuid.UUID) error {
-	err := p.usersRepository.LikePost(nil, userID, postID)
-	return err
+func (p *likePostUsecase) LikePost(userID string, postID uuid.UUID) (bool, error) {
+	if err := checkPostVisibility(p.postsRepository, p.usersRepository, postID.String(), userID); err != nil {
+		return false, err
+	}
+
+	created, err := p.usersRepository.LikePost(nil, userID, postID)
+	if err != nil || !created {
+		return created, err
+	}
+
+	p.notifyUsecase.NotifyPostAuthor(postID, uuid.MustParse(userID), entities.NotificationLike, nil)
+	return true, nil
 }
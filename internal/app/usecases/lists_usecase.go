@@ -0,0 +1,290 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type ListsUsecase interface {
+	// CreateList and UpdateList return an error wrapping ErrInvalidList if the name is blank
+	// or longer than MaxListNameLength, or the description is longer than MaxListDescriptionLength.
+	CreateList(ownerID, name, description string, isPrivate bool) (*entities.List, error)
+	// GetList returns ErrListNotFound for the private lists of other users,
+	// and ErrBlocked if the owner and the viewer block either of them.
+	GetList(listID, viewerID string) (*entities.List, error)
+	// UpdateList, DeleteList, AddMember and RemoveMember return ErrNotListOwner
+	// unless the viewer owns the list.
+	UpdateList(listID, viewerID string, patch entities.ListPatch) (*entities.List, error)
+	DeleteList(listID, viewerID string) error
+	GetOwnedLists(ownerID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.List], error)
+	GetSubscribedLists(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.List], error)
+
+	// AddMember reports whether the user was newly added, and returns ErrBlocked
+	// if the owner and the user block either of them.
+	AddMember(listID, viewerID, userID string) (bool, error)
+	RemoveMember(listID, viewerID, userID string) error
+	GetMembers(listID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error)
+	// Subscribe reports whether the viewer newly subscribed to the list,
+	// and returns ErrOwnListSubscription for the viewer's own list.
+	Subscribe(listID, viewerID string) (bool, error)
+	Unsubscribe(listID, viewerID string) error
+
+	// GetTimeline lists the posts and reposts by the members of the list in reverse chronological order.
+	GetTimeline(listID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error)
+	// FilterTimelineEvent leaves out the posts and reposts of the event which are by
+	// the users the viewer cannot see or has muted, as the timeline of a list does.
+	FilterTimelineEvent(viewerID string, event entities.TimelineEvent) (entities.TimelineEvent, error)
+}
+
+type listsUsecase struct {
+	listsRepository repositories.ListsRepositoryInterface
+	postsRepository repositories.PostsRepositoryInterface
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewListsUsecase(
+	listsRepository repositories.ListsRepositoryInterface,
+	postsRepository repositories.PostsRepositoryInterface,
+	usersRepository repositories.UsersRepositoryInterface,
+) ListsUsecase {
+	return &listsUsecase{
+		listsRepository: listsRepository,
+		postsRepository: postsRepository,
+		usersRepository: usersRepository,
+	}
+}
+
+func (p *listsUsecase) CreateList(ownerID, name, description string, isPrivate bool) (*entities.List, error) {
+	name, err := normalizeListName(name)
+	if err != nil {
+		return nil, err
+	}
+	description, err = normalizeListDescription(description)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	list := entities.List{
+		OwnerID:     owner,
+		Name:        name,
+		Description: description,
+		IsPrivate:   isPrivate,
+	}
+	err = p.listsRepository.CreateList(&list)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.listsRepository.GetList(list.ID.String(), ownerID)
+}
+
+func (p *listsUsecase) GetList(listID, viewerID string) (*entities.List, error) {
+	list, err := p.listsRepository.GetList(listID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !list.VisibleTo(viewerID) {
+		return nil, domainerrors.ErrListNotFound
+	}
+
+	// Unlike their posts, the public lists of private users can be seen by anyone.
+	err = p.usersRepository.CheckVisibility(nil, list.OwnerID.String(), viewerID)
+	if err != nil && !errors.Is(err, domainerrors.ErrPrivateAccount) {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (p *listsUsecase) UpdateList(listID, viewerID string, patch entities.ListPatch) (*entities.List, error) {
+	if patch.Name != nil {
+		name, err := normalizeListName(*patch.Name)
+		if err != nil {
+			return nil, err
+		}
+		patch.Name = &name
+	}
+	if patch.Description != nil {
+		description, err := normalizeListDescription(*patch.Description)
+		if err != nil {
+			return nil, err
+		}
+		patch.Description = &description
+	}
+
+	if _, err := p.getOwnedList(listID, viewerID); err != nil {
+		return nil, err
+	}
+	err := p.listsRepository.UpdateList(viewerID, listID, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.listsRepository.GetList(listID, viewerID)
+}
+
+func (p *listsUsecase) DeleteList(listID, viewerID string) error {
+	if _, err := p.getOwnedList(listID, viewerID); err != nil {
+		return err
+	}
+
+	return p.listsRepository.DeleteList(viewerID, listID)
+}
+
+func (p *listsUsecase) GetOwnedLists(ownerID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.List], error) {
+	err := p.usersRepository.CheckVisibility(nil, ownerID, viewerID)
+	if err != nil && !errors.Is(err, domainerrors.ErrPrivateAccount) {
+		return entities.Page[*entities.List]{}, err
+	}
+
+	return p.listsRepository.GetOwnedLists(ownerID, viewerID, cursor, limit)
+}
+
+func (p *listsUsecase) GetSubscribedLists(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.List], error) {
+	return p.listsRepository.GetSubscribedLists(userID, cursor, limit)
+}
+
+func (p *listsUsecase) AddMember(listID, viewerID, userID string) (bool, error) {
+	if _, err := p.getOwnedList(listID, viewerID); err != nil {
+		return false, err
+	}
+
+	// Owners can add private users, whose posts are still shown only to their followers.
+	err := p.usersRepository.CheckVisibility(nil, userID, viewerID)
+	if err != nil && !errors.Is(err, domainerrors.ErrPrivateAccount) {
+		return false, err
+	}
+
+	return p.listsRepository.AddListMember(listID, userID)
+}
+
+func (p *listsUsecase) RemoveMember(listID, viewerID, userID string) error {
+	if _, err := p.getOwnedList(listID, viewerID); err != nil {
+		return err
+	}
+
+	return p.listsRepository.RemoveListMember(listID, userID)
+}
+
+func (p *listsUsecase) GetMembers(listID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error) {
+	if _, err := p.GetList(listID, viewerID); err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+
+	return p.listsRepository.GetListMembers(listID, viewerID, cursor, limit)
+}
+
+func (p *listsUsecase) Subscribe(listID, viewerID string) (bool, error) {
+	list, err := p.GetList(listID, viewerID)
+	if err != nil {
+		return false, err
+	}
+	if list.OwnerID.String() == viewerID {
+		return false, domainerrors.ErrOwnListSubscription
+	}
+
+	return p.listsRepository.Subscribe(listID, viewerID)
+}
+
+func (p *listsUsecase) Unsubscribe(listID, viewerID string) error {
+	// Users can always unsubscribe, even from the lists they can no longer see.
+	return p.listsRepository.Unsubscribe(listID, viewerID)
+}
+
+func (p *listsUsecase) GetTimeline(listID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error) {
+	if _, err := p.GetList(listID, viewerID); err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+
+	page, err := p.listsRepository.GetListTimeline(listID, viewerID, cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+
+	err = hydrateTimelineItems(p.postsRepository, viewerID, page.Items)
+	if err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+
+	return page, nil
+}
+
+func (p *listsUsecase) FilterTimelineEvent(viewerID string, event entities.TimelineEvent) (entities.TimelineEvent, error) {
+	var userIDs []uuid.UUID
+	for _, post := range event.Posts {
+		userIDs = append(userIDs, post.UserID)
+	}
+	for _, repost := range event.Reposts {
+		userIDs = append(userIDs, repost.UserID)
+	}
+	if len(userIDs) == 0 {
+		return event, nil
+	}
+
+	hidden, err := p.listsRepository.GetHiddenUserIDs(viewerID, userIDs)
+	if err != nil {
+		return entities.TimelineEvent{}, err
+	}
+
+	filtered := entities.TimelineEvent{EventType: event.EventType}
+	for _, post := range event.Posts {
+		if !hidden[post.UserID] {
+			filtered.Posts = append(filtered.Posts, post)
+		}
+	}
+	for _, repost := range event.Reposts {
+		if !hidden[repost.UserID] {
+			filtered.Reposts = append(filtered.Reposts, repost)
+		}
+	}
+
+	return filtered, nil
+}
+
+// getOwnedList returns the list if the viewer owns it, ErrNotListOwner if the viewer
+// can see but does not own it, or the error of GetList otherwise.
+func (p *listsUsecase) getOwnedList(listID, viewerID string) (*entities.List, error) {
+	list, err := p.GetList(listID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if list.OwnerID.String() != viewerID {
+		return nil, domainerrors.ErrNotListOwner
+	}
+
+	return list, nil
+}
+
+// normalizeListName trims the name and checks its length.
+func normalizeListName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: the name is empty", domainerrors.ErrInvalidList)
+	}
+	if utf8.RuneCountInString(name) > entities.MaxListNameLength {
+		return "", fmt.Errorf("%w: the name is longer than %d characters", domainerrors.ErrInvalidList, entities.MaxListNameLength)
+	}
+
+	return name, nil
+}
+
+// normalizeListDescription trims the description and checks its length.
+func normalizeListDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > entities.MaxListDescriptionLength {
+		return "", fmt.Errorf("%w: the description is longer than %d characters", domainerrors.ErrInvalidList, entities.MaxListDescriptionLength)
+	}
+
+	return description, nil
+}
@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type MarkNotificationsAsReadUsecase interface {
+	MarkNotificationAsRead(recipientID, notificationID string) error
+	MarkAllNotificationsAsRead(recipientID string) error
+}
+
+type markNotificationsAsReadUsecase struct {
+	notificationsRepository repositories.NotificationsRepositoryInterface
+}
+
+func NewMarkNotificationsAsReadUsecase(notificationsRepository repositories.NotificationsRepositoryInterface) MarkNotificationsAsReadUsecase {
+	return &markNotificationsAsReadUsecase{notificationsRepository: notificationsRepository}
+}
+
+func (p *markNotificationsAsReadUsecase) MarkNotificationAsRead(recipientID, notificationID string) error {
+	return p.notificationsRepository.MarkNotificationAsRead(recipientID, notificationID)
+}
+
+func (p *markNotificationsAsReadUsecase) MarkAllNotificationsAsRead(recipientID string) error {
+	return p.notificationsRepository.MarkAllNotificationsAsRead(recipientID)
+}
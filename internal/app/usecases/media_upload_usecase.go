@@ -0,0 +1,336 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"time"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+// expiredMediaUploadsBatchSize is the maximum number of expired uploads deleted at a time.
+const expiredMediaUploadsBatchSize = 100
+
+var sha256Pattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+type MediaUploadUsecase interface {
+	// InitUpload starts an upload of media of the content type sent in segments.
+	// It returns ErrUnsupportedMediaType if media of the type cannot be uploaded,
+	// ErrMediaTooLarge if totalBytes exceeds MaxMediaSize of the type, and
+	// ErrMediaUploadQuotaExceeded if the user would exceed MediaUploadQuota.
+	// checksum is the hex-encoded SHA-256 checksum of the whole file.
+	InitUpload(userID uuid.UUID, contentType string, totalBytes int64, checksum string) (*entities.MediaUpload, error)
+	// AppendSegment stores the segment of the index read from r, replacing the one
+	// sent before, so that a segment whose checksum does not match can be resent.
+	AppendSegment(userID uuid.UUID, uploadID string, index int, r io.Reader) (*entities.UploadSegment, error)
+	// FinalizeUpload assembles the segments and creates pending media, which is
+	// processed by ProcessMediaUsecase later. It returns ErrIncompleteMediaUpload
+	// if any segment is missing, and ErrMediaChecksumMismatch if the assembled file
+	// does not match the checksum given at the initialization.
+	FinalizeUpload(userID uuid.UUID, uploadID string) (*entities.Media, error)
+	// GetUpload returns the upload, and its media if the upload has been finalized.
+	GetUpload(userID uuid.UUID, uploadID string) (*entities.MediaUpload, *entities.Media, error)
+	// DeleteExpiredUploads deletes a batch of the uploads which expired before now
+	// and returns the number of uploads deleted.
+	DeleteExpiredUploads(now time.Time) (int, error)
+}
+
+// mediaUploadUsecase stores the segments and the assembled originals in uploadStore,
+// which is never served, until they are processed.
+type mediaUploadUsecase struct {
+	mediaUploadRepository repositories.MediaUploadRepositoryInterface
+	mediaRepository       repositories.MediaRepositoryInterface
+	uploadStore           repositories.BlobStore
+}
+
+func NewMediaUploadUsecase(mediaUploadRepository repositories.MediaUploadRepositoryInterface, mediaRepository repositories.MediaRepositoryInterface, uploadStore repositories.BlobStore) MediaUploadUsecase {
+	return &mediaUploadUsecase{mediaUploadRepository: mediaUploadRepository, mediaRepository: mediaRepository, uploadStore: uploadStore}
+}
+
+func (p *mediaUploadUsecase) InitUpload(userID uuid.UUID, contentType string, totalBytes int64, checksum string) (*entities.MediaUpload, error) {
+	maxSize, ok := entities.MaxMediaSize(contentType)
+	if !ok {
+		return nil, errors.ErrUnsupportedMediaType
+	}
+	if totalBytes <= 0 {
+		return nil, fmt.Errorf("%w: total_bytes must be positive", errors.ErrInvalidMediaUpload)
+	}
+	if totalBytes > maxSize {
+		return nil, fmt.Errorf("%w: %s must be at most %d bytes", errors.ErrMediaTooLarge, contentType, maxSize)
+	}
+	if !sha256Pattern.MatchString(checksum) {
+		return nil, fmt.Errorf("%w: sha256 must be a hex-encoded SHA-256 checksum", errors.ErrInvalidMediaUpload)
+	}
+
+	now := time.Now()
+	uploaded, err := p.mediaUploadRepository.GetUploadedBytesSince(userID, now.Add(-entities.MediaUploadQuotaWindow))
+	if err != nil {
+		return nil, err
+	}
+	if uploaded+totalBytes > entities.MediaUploadQuota {
+		return nil, errors.ErrMediaUploadQuotaExceeded
+	}
+
+	upload := &entities.MediaUpload{
+		ID:          uuid.New(),
+		UserID:      userID,
+		ContentType: contentType,
+		TotalBytes:  totalBytes,
+		SHA256:      checksum,
+		Segments:    []*entities.UploadSegment{},
+		ExpiresAt:   now.Add(entities.MediaUploadExpiry),
+	}
+	if err := p.mediaUploadRepository.CreateMediaUpload(upload); err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func (p *mediaUploadUsecase) AppendSegment(userID uuid.UUID, uploadID string, index int, r io.Reader) (*entities.UploadSegment, error) {
+	if index < 0 || index >= entities.MaxUploadSegments {
+		return nil, fmt.Errorf("%w: the index must be between 0 and %d", errors.ErrInvalidMediaUpload, entities.MaxUploadSegments-1)
+	}
+
+	upload, err := p.getUploadInProgress(userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every attempt is stored under its own key, so that a failed attempt
+	// never destroys the segment received before.
+	key := upload.ID.String() + "/segments/" + strconv.Itoa(index) + "-" + uuid.NewString()
+	hash := sha256.New()
+	content := &countingReader{r: io.TeeReader(io.LimitReader(r, entities.MaxUploadSegmentSize+1), hash)}
+	if err := p.uploadStore.Put(key, content); err != nil {
+		return nil, err
+	}
+
+	var othersSize int64
+	for _, segment := range upload.Segments {
+		if segment.Index != index {
+			othersSize += segment.Size
+		}
+	}
+	switch {
+	case content.n == 0:
+		err = fmt.Errorf("%w: the segment is empty", errors.ErrInvalidMediaUpload)
+	case content.n > entities.MaxUploadSegmentSize:
+		err = fmt.Errorf("%w: a segment must be at most %d bytes", errors.ErrMediaTooLarge, entities.MaxUploadSegmentSize)
+	case othersSize+content.n > upload.TotalBytes:
+		err = fmt.Errorf("%w: the segments exceed total_bytes", errors.ErrMediaTooLarge)
+	}
+	if err != nil {
+		p.uploadStore.Delete(key)
+		return nil, err
+	}
+
+	segment := &entities.UploadSegment{
+		Index:      index,
+		Size:       content.n,
+		SHA256:     hex.EncodeToString(hash.Sum(nil)),
+		StorageKey: key,
+	}
+	replacedKey, err := p.mediaUploadRepository.PutUploadSegment(upload.ID, segment)
+	if err != nil {
+		p.uploadStore.Delete(key)
+		return nil, err
+	}
+	if replacedKey != "" {
+		if err := p.uploadStore.Delete(replacedKey); err != nil {
+			slog.Error("Could not delete a replaced upload segment.", "media_id", upload.ID, "index", index, "error", err)
+		}
+	}
+
+	return segment, nil
+}
+
+func (p *mediaUploadUsecase) FinalizeUpload(userID uuid.UUID, uploadID string) (*entities.Media, error) {
+	upload, err := p.getUploadInProgress(userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if !upload.IsComplete() {
+		return nil, fmt.Errorf("%w: %d of %d bytes are received", errors.ErrIncompleteMediaUpload, upload.ReceivedBytes(), upload.TotalBytes)
+	}
+
+	contentType, err := p.detectContentType(upload)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != upload.ContentType {
+		return nil, fmt.Errorf("%w: the file is %s rather than %s", errors.ErrUnsupportedMediaType, contentType, upload.ContentType)
+	}
+
+	key := upload.ID.String() + "/original"
+	segments := &segmentsReader{uploadStore: p.uploadStore, upload: upload}
+	defer segments.Close()
+	hash := sha256.New()
+	if err := p.uploadStore.Put(key, io.TeeReader(segments, hash)); err != nil {
+		p.uploadStore.Delete(key)
+		return nil, err
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != upload.SHA256 {
+		p.uploadStore.Delete(key)
+		return nil, errors.ErrMediaChecksumMismatch
+	}
+
+	media := &entities.Media{
+		ID:          upload.ID,
+		UserID:      upload.UserID,
+		ContentType: upload.ContentType,
+		Size:        upload.TotalBytes,
+		Status:      entities.MediaPending,
+		StorageKey:  key,
+	}
+	if err := p.mediaUploadRepository.FinalizeMediaUpload(upload.ID, media); err != nil {
+		p.uploadStore.Delete(key)
+		return nil, err
+	}
+
+	p.deleteSegments(upload)
+
+	return media, nil
+}
+
+func (p *mediaUploadUsecase) GetUpload(userID uuid.UUID, uploadID string) (*entities.MediaUpload, *entities.Media, error) {
+	upload, err := p.mediaUploadRepository.GetMediaUpload(uploadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if upload.UserID != userID {
+		return nil, nil, errors.ErrNotMediaOwner
+	}
+	if upload.IsExpired(time.Now()) {
+		return nil, nil, errors.ErrMediaUploadNotFound
+	}
+	if upload.FinalizedAt == nil {
+		return upload, nil, nil
+	}
+
+	media, err := p.mediaRepository.GetMedia(uploadID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return upload, media, nil
+}
+
+func (p *mediaUploadUsecase) DeleteExpiredUploads(now time.Time) (int, error) {
+	uploads, err := p.mediaUploadRepository.GetExpiredMediaUploads(now, expiredMediaUploadsBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, upload := range uploads {
+		p.deleteSegments(upload)
+		if err := p.mediaUploadRepository.DeleteMediaUpload(upload.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(uploads), nil
+}
+
+// getUploadInProgress returns the upload of the user which is neither finalized nor expired.
+func (p *mediaUploadUsecase) getUploadInProgress(userID uuid.UUID, uploadID string) (*entities.MediaUpload, error) {
+	upload, err := p.mediaUploadRepository.GetMediaUpload(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserID != userID {
+		return nil, errors.ErrNotMediaOwner
+	}
+	if upload.FinalizedAt != nil {
+		return nil, errors.ErrMediaUploadFinalized
+	}
+	if upload.IsExpired(time.Now()) {
+		return nil, errors.ErrMediaUploadNotFound
+	}
+
+	return upload, nil
+}
+
+// detectContentType detects the content type of the upload by its first segment,
+// so that the type declared at the initialization is not trusted.
+func (p *mediaUploadUsecase) detectContentType(upload *entities.MediaUpload) (string, error) {
+	first, err := p.uploadStore.Get(upload.Segments[0].StorageKey)
+	if err != nil {
+		return "", err
+	}
+	defer first.Close()
+
+	head := make([]byte, entities.MediaSniffLength)
+	n, err := io.ReadFull(first, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	contentType, ok := entities.DetectMediaType(head[:n])
+	if !ok {
+		return "", errors.ErrUnsupportedMediaType
+	}
+
+	return contentType, nil
+}
+
+func (p *mediaUploadUsecase) deleteSegments(upload *entities.MediaUpload) {
+	for _, segment := range upload.Segments {
+		if err := p.uploadStore.Delete(segment.StorageKey); err != nil {
+			slog.Error("Could not delete an upload segment.", "media_id", upload.ID, "index", segment.Index, "error", err)
+		}
+	}
+}
+
+// segmentsReader reads the segments of an upload in order, opening each
+// only when the previous one is read to the end.
+type segmentsReader struct {
+	uploadStore repositories.BlobStore
+	upload      *entities.MediaUpload
+	next        int
+	current     io.ReadCloser
+}
+
+func (s *segmentsReader) Read(b []byte) (int, error) {
+	for {
+		if s.current == nil {
+			if s.next >= len(s.upload.Segments) {
+				return 0, io.EOF
+			}
+			current, err := s.uploadStore.Get(s.upload.Segments[s.next].StorageKey)
+			if err != nil {
+				return 0, err
+			}
+			s.current = current
+			s.next++
+		}
+
+		n, err := s.current.Read(b)
+		if err == io.EOF {
+			s.current.Close()
+			s.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (s *segmentsReader) Close() error {
+	if s.current == nil {
+		return nil
+	}
+	err := s.current.Close()
+	s.current = nil
+	return err
+}
@@ -5,7 +5,8 @@ import (
 )
 
 type MuteUserUsecase interface {
-	MuteUser(sourceUserID, targetUserID string) error
+	// MuteUser mutes the target user and reports whether a new mute was created.
+	MuteUser(sourceUserID, targetUserID string) (bool, error)
 }
 
 type muteUserUsecase struct {
@@ -16,7 +17,6 @@ func NewMuteUserUsecase(usersRepository repositories.UsersRepositoryInterface) M
 	return &muteUserUsecase{usersRepository: usersRepository}
 }
 
-func (p *muteUserUsecase) MuteUser(sourceUserID, targetUserID string) error {
-	err := p.usersRepository.MuteUser(nil, sourceUserID, targetUserID)
-	return err
+func (p *muteUserUsecase) MuteUser(sourceUserID, targetUserID string) (bool, error) {
+	return p.usersRepository.MuteUser(nil, sourceUserID, targetUserID)
 }
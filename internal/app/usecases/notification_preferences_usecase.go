@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type NotificationPreferencesUsecase interface {
+	GetNotificationPreferences(userID string) (entities.NotificationPreferences, error)
+	UpdateNotificationPreferences(userID string, patch entities.NotificationPreferencesPatch) (entities.NotificationPreferences, error)
+}
+
+type notificationPreferencesUsecase struct {
+	notificationsRepository repositories.NotificationsRepositoryInterface
+}
+
+func NewNotificationPreferencesUsecase(notificationsRepository repositories.NotificationsRepositoryInterface) NotificationPreferencesUsecase {
+	return &notificationPreferencesUsecase{notificationsRepository: notificationsRepository}
+}
+
+func (p *notificationPreferencesUsecase) GetNotificationPreferences(userID string) (entities.NotificationPreferences, error) {
+	return p.notificationsRepository.GetNotificationPreferences(userID)
+}
+
+func (p *notificationPreferencesUsecase) UpdateNotificationPreferences(userID string, patch entities.NotificationPreferencesPatch) (entities.NotificationPreferences, error) {
+	return p.notificationsRepository.UpdateNotificationPreferences(userID, patch)
+}
@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+// NotifyUsecase stores notifications and delivers them
+// to the live streams of their recipients.
+//
+// Notifying is a side effect of the activity which caused it,
+// so failures are logged instead of being returned.
+type NotifyUsecase interface {
+	// NotifyUser notifies the recipient of an activity by the actor, such as a follow.
+	NotifyUser(recipientID, actorID uuid.UUID, notificationType entities.NotificationType)
+	// NotifyPostAuthor notifies the author of the post of an activity by the actor,
+	// such as a like. sourceID is the repost or post which caused the notification, if any.
+	NotifyPostAuthor(postID, actorID uuid.UUID, notificationType entities.NotificationType, sourceID *uuid.UUID)
+	// NotifyMention notifies the recipient that the actor mentioned them
+	// in the post or the quote repost identified by sourceID.
+	NotifyMention(recipientID, actorID, sourceID uuid.UUID)
+	// NotifyPollClosed notifies the author and the voters of the poll of the post that it has closed.
+	NotifyPollClosed(postID, authorID uuid.UUID, voterIDs []uuid.UUID)
+}
+
+type notifyUsecase struct {
+	notificationsRepository repositories.NotificationsRepositoryInterface
+	postsRepository         repositories.PostsRepositoryInterface
+	mu                      *sync.Mutex
+	notificationChans       *map[string]chan *entities.Notification
+}
+
+func NewNotifyUsecase(
+	notificationsRepository repositories.NotificationsRepositoryInterface,
+	postsRepository repositories.PostsRepositoryInterface,
+	mu *sync.Mutex,
+	notificationChans *map[string]chan *entities.Notification,
+) NotifyUsecase {
+	return &notifyUsecase{
+		notificationsRepository: notificationsRepository,
+		postsRepository:         postsRepository,
+		mu:                      mu,
+		notificationChans:       notificationChans,
+	}
+}
+
+func (p *notifyUsecase) NotifyUser(recipientID, actorID uuid.UUID, notificationType entities.NotificationType) {
+	p.notify(&entities.Notification{
+		RecipientID: recipientID,
+		ActorID:     actorID,
+		Type:        notificationType,
+	})
+}
+
+func (p *notifyUsecase) NotifyPostAuthor(postID, actorID uuid.UUID, notificationType entities.NotificationType, sourceID *uuid.UUID) {
+	post, err := p.postsRepository.GetPost(postID.String())
+	if err != nil {
+		// Reposts of reposts have no post to notify the author of.
+		if !errors.Is(err, domainerrors.ErrPostNotFound) {
+			slog.Error("Could not get the post to notify the author of.", "post_id", postID, "error", err)
+		}
+		return
+	}
+
+	p.notify(&entities.Notification{
+		RecipientID: post.UserID,
+		ActorID:     actorID,
+		Type:        notificationType,
+		PostID:      &postID,
+		SourceID:    sourceID,
+	})
+}
+
+func (p *notifyUsecase) NotifyMention(recipientID, actorID, sourceID uuid.UUID) {
+	p.notify(&entities.Notification{
+		RecipientID: recipientID,
+		ActorID:     actorID,
+		Type:        entities.NotificationMention,
+		SourceID:    &sourceID,
+	})
+}
+
+func (p *notifyUsecase) NotifyPollClosed(postID, authorID uuid.UUID, voterIDs []uuid.UUID) {
+	recipientIDs := []uuid.UUID{authorID}
+	for _, voterID := range voterIDs {
+		// The author may have voted in their own poll.
+		if voterID != authorID {
+			recipientIDs = append(recipientIDs, voterID)
+		}
+	}
+
+	for _, recipientID := range recipientIDs {
+		p.notify(&entities.Notification{
+			RecipientID: recipientID,
+			ActorID:     authorID,
+			Type:        entities.NotificationPollClosed,
+			PostID:      &postID,
+		})
+	}
+}
+
+func (p *notifyUsecase) notify(notification *entities.Notification) {
+	// Users are not notified of their own activities, whereas the closing
+	// of a poll is not an activity of its author, who is notified as well.
+	if notification.RecipientID == notification.ActorID && notification.Type != entities.NotificationPollClosed {
+		return
+	}
+
+	notification.CreatedAt = time.Now()
+	created, err := p.notificationsRepository.CreateNotification(notification)
+	if err != nil {
+		slog.Error("Could not create a notification.", "type", notification.Type, "error", err)
+		return
+	}
+	// The recipient's preferences, mutes or blocks filtered the notification out.
+	if !created {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if notificationChan, ok := (*p.notificationChans)[notification.RecipientID.String()]; ok {
+		// A slow stream must not block the activity which caused the notification;
+		// the notification can still be listed later.
+		select {
+		case notificationChan <- notification:
+		default:
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package usecases
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"time"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/app/services"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+const (
+	// MediaProcessingBatchSize is the maximum number of media processed at a time.
+	MediaProcessingBatchSize = 10
+	// MediaProcessingInterval is how often uploaded media are checked for processing.
+	MediaProcessingInterval = 5 * time.Second
+	// staleMediaProcessingAfter is how long media can be in progress before it is
+	// assumed that the worker processing them has died, and they are processed again.
+	staleMediaProcessingAfter = 5 * time.Minute
+)
+
+type ProcessMediaUsecase interface {
+	// ProcessPendingMedia processes a batch of uploaded media into renditions and
+	// returns the number of media processed. Media which cannot be processed, such as
+	// videos longer than MaxVideoDuration, are marked as failed with the reason
+	// rather than returned as an error.
+	ProcessPendingMedia() (int, error)
+}
+
+// processMediaUsecase reads the originals from uploadStore, which is never served,
+// and stores the renditions, which are served to clients, in mediaStore.
+type processMediaUsecase struct {
+	mediaRepository repositories.MediaRepositoryInterface
+	uploadStore     repositories.BlobStore
+	mediaStore      repositories.BlobStore
+	imageService    *services.ImageService
+	videoService    *services.VideoService
+}
+
+func NewProcessMediaUsecase(mediaRepository repositories.MediaRepositoryInterface, uploadStore, mediaStore repositories.BlobStore, imageService *services.ImageService, videoService *services.VideoService) ProcessMediaUsecase {
+	return &processMediaUsecase{mediaRepository: mediaRepository, uploadStore: uploadStore, mediaStore: mediaStore, imageService: imageService, videoService: videoService}
+}
+
+func (p *processMediaUsecase) ProcessPendingMedia() (int, error) {
+	claimed, err := p.mediaRepository.ClaimUnprocessedMedia(MediaProcessingBatchSize, staleMediaProcessingAfter)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, media := range claimed {
+		process := p.processImage
+		if entities.IsVideo(media.ContentType) {
+			process = p.processVideo
+		}
+		if err := process(media); err != nil {
+			slog.Error("Could not process media.", "media_id", media.ID, "error", err)
+			media.Status = entities.MediaFailed
+			media.ProcessingError = processingErrorMessage(err)
+		}
+		if err := p.mediaRepository.UpdateProcessedMedia(media); err != nil {
+			return 0, err
+		}
+		// The original is no longer needed whether the media succeeded or failed,
+		// and deleting it makes sure its metadata such as the GPS location is never kept.
+		if err := p.uploadStore.Delete(media.StorageKey); err != nil {
+			slog.Error("Could not delete the original media.", "media_id", media.ID, "error", err)
+		}
+	}
+
+	return len(claimed), nil
+}
+
+// processImage stores the renditions of the image and sets its fields accordingly.
+func (p *processMediaUsecase) processImage(media *entities.Media) error {
+	original, err := p.uploadStore.Get(media.StorageKey)
+	if err != nil {
+		return err
+	}
+	defer original.Close()
+
+	processed, err := p.imageService.Process(original)
+	if err != nil {
+		return err
+	}
+
+	renditions := make([]*entities.Rendition, 0, len(processed.Renditions))
+	for _, encoded := range processed.Renditions {
+		key := path.Join(media.ID.String(), encoded.Name+renditionExtension(encoded.ContentType))
+		if err := p.mediaStore.Put(key, bytes.NewReader(encoded.Data)); err != nil {
+			return err
+		}
+		rendition := encoded.Rendition
+		rendition.URL = p.mediaStore.URL(key)
+		renditions = append(renditions, &rendition)
+	}
+
+	largest := renditions[len(renditions)-1]
+	media.Status = entities.MediaSucceeded
+	media.URL = largest.URL
+	media.Width = processed.Width
+	media.Height = processed.Height
+	media.Blurhash = processed.Blurhash
+	media.DurationMs = processed.DurationMs
+	media.Renditions = renditions
+
+	return nil
+}
+
+// processVideo checks the duration of the video and stores it without its metadata
+// as its only rendition.
+func (p *processMediaUsecase) processVideo(media *entities.Media) error {
+	original, err := p.uploadStore.Get(media.StorageKey)
+	if err != nil {
+		return err
+	}
+	info, err := p.videoService.Probe(original)
+	original.Close()
+	if err != nil {
+		return err
+	}
+	if info.Duration > entities.MaxVideoDuration {
+		return fmt.Errorf("%w: the video must be at most %v long", domainerrors.ErrVideoTooLong, entities.MaxVideoDuration)
+	}
+
+	original, err = p.uploadStore.Get(media.StorageKey)
+	if err != nil {
+		return err
+	}
+	defer original.Close()
+
+	stripped, w := io.Pipe()
+	go func() {
+		w.CloseWithError(p.videoService.StripMetadata(original, w))
+	}()
+	key := path.Join(media.ID.String(), "video"+renditionExtension(media.ContentType))
+	err = p.mediaStore.Put(key, stripped)
+	// Closing the pipe stops stripping the metadata if storing the video failed.
+	stripped.Close()
+	if err != nil {
+		return err
+	}
+
+	rendition := &entities.Rendition{
+		Name:        "video",
+		ContentType: media.ContentType,
+		URL:         p.mediaStore.URL(key),
+		Width:       info.Width,
+		Height:      info.Height,
+	}
+	media.Status = entities.MediaSucceeded
+	media.URL = rendition.URL
+	media.Width = info.Width
+	media.Height = info.Height
+	media.DurationMs = int(info.Duration.Milliseconds())
+	media.Renditions = []*entities.Rendition{rendition}
+
+	return nil
+}
+
+// processingErrorMessage returns the reason of the failure shown to the user,
+// hiding the details of failures which are not caused by the media.
+func processingErrorMessage(err error) string {
+	if errors.Is(err, domainerrors.ErrInvalidMedia) || errors.Is(err, domainerrors.ErrVideoTooLong) {
+		return err.Error()
+	}
+	return "the media could not be processed"
+}
+
+func renditionExtension(contentType string) string {
+	switch contentType {
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "video/mp4":
+		return ".mp4"
+	case "video/quicktime":
+		return ".mov"
+	default:
+		return ".jpg"
+	}
+}
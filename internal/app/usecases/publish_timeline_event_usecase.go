@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"log/slog"
+	"sync"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+// PublishTimelineEventUsecase delivers the events of the posts of a user
+// to the live streams of the timelines they appear on.
+//
+// Publishing is a side effect of the activity which caused it,
+// so failures are logged instead of being returned.
+type PublishTimelineEventUsecase interface {
+	// PublishToTimelines pushes the event to the streams of the author and the followers,
+	// as well as those of the lists the author is a member of. The event is dropped for
+	// the streams which are not ready to receive it, so that a slow client cannot hold up
+	// the others; the posts are returned the next time the timeline is read anyway.
+	PublishToTimelines(authorID uuid.UUID, event entities.TimelineEvent)
+}
+
+type publishTimelineEventUsecase struct {
+	usersRepository repositories.UsersRepositoryInterface
+	listsRepository repositories.ListsRepositoryInterface
+	mu              *sync.Mutex
+	usersChan       *map[string]chan entities.TimelineEvent
+	listChans       *map[string]map[chan entities.TimelineEvent]struct{}
+}
+
+func NewPublishTimelineEventUsecase(
+	usersRepository repositories.UsersRepositoryInterface,
+	listsRepository repositories.ListsRepositoryInterface,
+	mu *sync.Mutex,
+	usersChan *map[string]chan entities.TimelineEvent,
+	listChans *map[string]map[chan entities.TimelineEvent]struct{},
+) PublishTimelineEventUsecase {
+	return &publishTimelineEventUsecase{
+		usersRepository: usersRepository,
+		listsRepository: listsRepository,
+		mu:              mu,
+		usersChan:       usersChan,
+		listChans:       listChans,
+	}
+}
+
+func (p *publishTimelineEventUsecase) PublishToTimelines(authorID uuid.UUID, event entities.TimelineEvent) {
+	followerIDs, err := p.usersRepository.GetFollowerIDs(nil, authorID.String())
+	if err != nil {
+		slog.Error("Could not get the followers of the author of a timeline event.", "user_id", authorID, "error", err)
+	}
+	listIDs, err := p.listsRepository.GetMemberListIDs(authorID.String())
+	if err != nil {
+		slog.Error("Could not get the lists of the author of a timeline event.", "user_id", authorID, "error", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, userID := range append(followerIDs, authorID.String()) {
+		if userChan, ok := (*p.usersChan)[userID]; ok {
+			select {
+			case userChan <- event:
+			default:
+			}
+		}
+	}
+	for _, listID := range listIDs {
+		for listChan := range (*p.listChans)[listID] {
+			select {
+			case listChan <- event:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package usecases
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	domainerrors "x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ScheduledPostPublishingBatchSize is the maximum number of scheduled posts published at a time.
+	ScheduledPostPublishingBatchSize = 100
+	// ScheduledPostPublishingInterval is how often due scheduled posts are checked for publishing.
+	ScheduledPostPublishingInterval = 5 * time.Second
+)
+
+type ScheduledPostsUsecase interface {
+	// SchedulePost queues the post to be published at post.ScheduledAt, which must be valid
+	// by ValidScheduledTime. The text, the media and the poll must have been validated.
+	SchedulePost(post *entities.ScheduledPost, now time.Time) error
+	GetScheduledPosts(userID uuid.UUID, cursor *entities.Cursor, limit int) (entities.Page[*entities.ScheduledPost], error)
+	ReschedulePost(userID, scheduledPostID uuid.UUID, scheduledAt, now time.Time) (*entities.ScheduledPost, error)
+	CancelScheduledPost(userID, scheduledPostID uuid.UUID) error
+
+	// PublishDuePosts publishes a batch of due scheduled posts as CreatePost does: the entities
+	// are created, the media attached, the poll opened, and the posts pushed to the timelines
+	// of the authors and their followers. Each post is published with all of them or not at all,
+	// in which case it is published again later, up to MaxPublishAttempts times.
+	// It returns the number of posts published.
+	PublishDuePosts() (int, error)
+}
+
+type scheduledPostsUsecase struct {
+	scheduledPostsRepository    repositories.ScheduledPostsRepositoryInterface
+	createPostEntitiesUsecase   CreatePostEntitiesUsecase
+	attachMediaUsecase          AttachMediaUsecase
+	createPollUsecase           CreatePollUsecase
+	publishTimelineEventUsecase PublishTimelineEventUsecase
+}
+
+func NewScheduledPostsUsecase(
+	scheduledPostsRepository repositories.ScheduledPostsRepositoryInterface,
+	createPostEntitiesUsecase CreatePostEntitiesUsecase,
+	attachMediaUsecase AttachMediaUsecase,
+	createPollUsecase CreatePollUsecase,
+	publishTimelineEventUsecase PublishTimelineEventUsecase,
+) ScheduledPostsUsecase {
+	return &scheduledPostsUsecase{
+		scheduledPostsRepository:    scheduledPostsRepository,
+		createPostEntitiesUsecase:   createPostEntitiesUsecase,
+		attachMediaUsecase:          attachMediaUsecase,
+		createPollUsecase:           createPollUsecase,
+		publishTimelineEventUsecase: publishTimelineEventUsecase,
+	}
+}
+
+func (p *scheduledPostsUsecase) SchedulePost(post *entities.ScheduledPost, now time.Time) error {
+	if err := checkScheduledTime(post.ScheduledAt, now); err != nil {
+		return err
+	}
+
+	return p.scheduledPostsRepository.CreateScheduledPost(post)
+}
+
+func (p *scheduledPostsUsecase) GetScheduledPosts(userID uuid.UUID, cursor *entities.Cursor, limit int) (entities.Page[*entities.ScheduledPost], error) {
+	return p.scheduledPostsRepository.GetScheduledPosts(userID.String(), cursor, limit)
+}
+
+func (p *scheduledPostsUsecase) ReschedulePost(userID, scheduledPostID uuid.UUID, scheduledAt, now time.Time) (*entities.ScheduledPost, error) {
+	if err := checkScheduledTime(scheduledAt, now); err != nil {
+		return nil, err
+	}
+
+	return p.scheduledPostsRepository.ReschedulePost(userID.String(), scheduledPostID.String(), scheduledAt)
+}
+
+func (p *scheduledPostsUsecase) CancelScheduledPost(userID, scheduledPostID uuid.UUID) error {
+	return p.scheduledPostsRepository.DeleteScheduledPost(userID.String(), scheduledPostID.String())
+}
+
+func (p *scheduledPostsUsecase) PublishDuePosts() (int, error) {
+	// A post completed by the callback can still be rolled back afterwards,
+	// so only the posts of the scheduled posts returned as published are pushed.
+	posts := make(map[uuid.UUID]*entities.Post)
+	published, err := p.scheduledPostsRepository.PublishDuePosts(ScheduledPostPublishingBatchSize, func(tx *sql.Tx, scheduled *entities.ScheduledPost) error {
+		post, err := p.completeScheduledPost(tx, scheduled)
+		if err != nil {
+			slog.Error("Could not publish a scheduled post.", "scheduled_post_id", scheduled.ID, "error", err)
+			return err
+		}
+		posts[scheduled.ID] = post
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, scheduled := range published {
+		post, ok := posts[scheduled.ID]
+		if !ok {
+			continue
+		}
+		p.createPostEntitiesUsecase.NotifyMentions(post.UserID, post.ID, post.Entities.Mentions)
+		p.publishTimelineEventUsecase.PublishToTimelines(post.UserID, entities.TimelineEvent{EventType: entities.PostCreated, Posts: []*entities.Post{post}})
+	}
+
+	return len(published), nil
+}
+
+// completeScheduledPost creates the entities, attaches the media and opens the poll of
+// the post created for the scheduled post in tx, and returns the post.
+func (p *scheduledPostsUsecase) completeScheduledPost(tx *sql.Tx, scheduled *entities.ScheduledPost) (*entities.Post, error) {
+	post := &entities.Post{
+		ID:        *scheduled.PostID,
+		UserID:    scheduled.UserID,
+		Text:      scheduled.Text,
+		CreatedAt: *scheduled.PublishedAt,
+	}
+
+	var err error
+	post.Entities, err = p.createPostEntitiesUsecase.CreatePostEntities(tx, post.UserID, post.ID, false, post.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	// The media may have been deleted or attached to another post since the post was scheduled,
+	// in which case the post is published without them.
+	media, err := p.attachMediaUsecase.GetAttachableMedia(post.UserID, scheduled.MediaIDs)
+	if errors.Is(err, domainerrors.ErrInvalidMediaAttachment) {
+		slog.Error("Could not attach the media to a scheduled post.", "post_id", post.ID, "error", err)
+		media = nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := p.attachMediaUsecase.AttachMedia(tx, post.ID, false, media); err != nil {
+		return nil, err
+	}
+	if len(media) > 0 {
+		post.Media = media
+	}
+
+	if scheduled.Poll != nil {
+		// The poll is open for its duration from when it is published.
+		duration := time.Duration(scheduled.Poll.DurationMinutes) * time.Minute
+		poll, err := p.createPollUsecase.NewPoll(scheduled.Poll.Choices, duration, post.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.createPollUsecase.CreatePoll(tx, post.ID, poll); err != nil {
+			return nil, err
+		}
+		post.Poll = poll
+	}
+
+	return post, nil
+}
+
+// checkScheduledTime returns an error wrapping ErrInvalidScheduledTime
+// if a post cannot be scheduled at the time.
+func checkScheduledTime(scheduledAt, now time.Time) error {
+	if !entities.ValidScheduledTime(scheduledAt, now) {
+		return fmt.Errorf("%w: posts can be scheduled in the future up to %d days ahead", domainerrors.ErrInvalidScheduledTime, int(entities.MaxSchedulingPeriod.Hours()/24))
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type SearchPostsUsecase interface {
+	SearchPosts(query *entities.SearchQuery, order entities.SearchOrder, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error)
+}
+
+type searchPostsUsecase struct {
+	postsRepository repositories.PostsRepositoryInterface
+}
+
+func NewSearchPostsUsecase(postsRepository repositories.PostsRepositoryInterface) SearchPostsUsecase {
+	return &searchPostsUsecase{postsRepository: postsRepository}
+}
+
+func (p *searchPostsUsecase) SearchPosts(query *entities.SearchQuery, order entities.SearchOrder, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error) {
+	page, err := p.postsRepository.SearchPosts(query, order, viewerID, cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+
+	err = hydrateTimelineItems(p.postsRepository, viewerID, page.Items)
+	if err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+
+	return page, nil
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type SearchUsersUsecase interface {
+	SearchUsers(query, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.RelatedUser], error)
+}
+
+type searchUsersUsecase struct {
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewSearchUsersUsecase(usersRepository repositories.UsersRepositoryInterface) SearchUsersUsecase {
+	return &searchUsersUsecase{usersRepository: usersRepository}
+}
+
+func (p *searchUsersUsecase) SearchUsers(query, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.RelatedUser], error) {
+	page, err := p.usersRepository.SearchUsers(nil, query, viewerID, cursor, limit)
+	if err != nil {
+		return entities.Page[*entities.RelatedUser]{}, err
+	}
+
+	return withRelationships(p.usersRepository, viewerID, page)
+}
@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type TypeaheadUsersUsecase interface {
+	TypeaheadUsers(prefix, viewerID string, limit int) ([]*entities.UserSummary, error)
+}
+
+type typeaheadUsersUsecase struct {
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewTypeaheadUsersUsecase(usersRepository repositories.UsersRepositoryInterface) TypeaheadUsersUsecase {
+	return &typeaheadUsersUsecase{usersRepository: usersRepository}
+}
+
+func (p *typeaheadUsersUsecase) TypeaheadUsers(prefix, viewerID string, limit int) ([]*entities.UserSummary, error) {
+	return p.usersRepository.TypeaheadUsers(nil, prefix, viewerID, limit)
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+const (
+	// LinkUnfurlingBatchSize is the maximum number of links unfurled at a time.
+	LinkUnfurlingBatchSize = 10
+	// LinkUnfurlingInterval is how often posted links are checked for unfurling.
+	LinkUnfurlingInterval = 5 * time.Second
+	// linkUnfurlingTimeout is how long unfurling a link can take.
+	linkUnfurlingTimeout = 10 * time.Second
+	// staleLinkUnfurlingAfter is how long a link can be in progress before it is
+	// assumed that the worker unfurling it has died, and it is unfurled again.
+	staleLinkUnfurlingAfter = 5 * time.Minute
+)
+
+type UnfurlLinksUsecase interface {
+	// UnfurlPendingLinks fetches the preview cards of a batch of posted links and
+	// returns the number of links unfurled. Pages which cannot be fetched are
+	// marked as failed rather than returned as an error, and are not retried.
+	UnfurlPendingLinks() (int, error)
+}
+
+type unfurlLinksUsecase struct {
+	linksRepository repositories.LinksRepositoryInterface
+	unfurlFetcher   repositories.UnfurlFetcher
+}
+
+func NewUnfurlLinksUsecase(linksRepository repositories.LinksRepositoryInterface, unfurlFetcher repositories.UnfurlFetcher) UnfurlLinksUsecase {
+	return &unfurlLinksUsecase{linksRepository: linksRepository, unfurlFetcher: unfurlFetcher}
+}
+
+func (p *unfurlLinksUsecase) UnfurlPendingLinks() (int, error) {
+	claimed, err := p.linksRepository.ClaimPendingLinks(LinkUnfurlingBatchSize, staleLinkUnfurlingAfter)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, link := range claimed {
+		ctx, cancel := context.WithTimeout(context.Background(), linkUnfurlingTimeout)
+		card, err := p.unfurlFetcher.Fetch(ctx, link.URL)
+		cancel()
+
+		if err != nil {
+			slog.Info("Could not unfurl link.", "link_id", link.ID, "error", err)
+			link.UnfurlStatus = entities.UnfurlFailed
+			link.Card = nil
+		} else {
+			if card != nil {
+				card.Normalize()
+			}
+			link.UnfurlStatus = entities.UnfurlSucceeded
+			link.Card = card
+		}
+		if err := p.linksRepository.UpdateUnfurledLink(link); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(claimed), nil
+}
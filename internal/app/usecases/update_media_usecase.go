@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"fmt"
+	"unicode/utf8"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type UpdateMediaUsecase interface {
+	// UpdateAltText sets the alt text of the media uploaded by the user. It returns
+	// ErrNotMediaOwner if the media is uploaded by another user, and ErrAltTextTooLong
+	// if the alt text is longer than MaxAltTextLength characters.
+	UpdateAltText(userID uuid.UUID, mediaID, altText string) (*entities.Media, error)
+}
+
+type updateMediaUsecase struct {
+	mediaRepository repositories.MediaRepositoryInterface
+}
+
+func NewUpdateMediaUsecase(mediaRepository repositories.MediaRepositoryInterface) UpdateMediaUsecase {
+	return &updateMediaUsecase{mediaRepository: mediaRepository}
+}
+
+func (p *updateMediaUsecase) UpdateAltText(userID uuid.UUID, mediaID, altText string) (*entities.Media, error) {
+	if utf8.RuneCountInString(altText) > entities.MaxAltTextLength {
+		return nil, fmt.Errorf("%w: at most %d characters are allowed", errors.ErrAltTextTooLong, entities.MaxAltTextLength)
+	}
+
+	media, err := p.mediaRepository.GetMedia(mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if media.UserID != userID {
+		return nil, errors.ErrNotMediaOwner
+	}
+
+	if err := p.mediaRepository.UpdateAltText(mediaID, altText); err != nil {
+		return nil, err
+	}
+	media.AltText = altText
+
+	return media, nil
+}
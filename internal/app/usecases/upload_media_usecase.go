@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"bytes"
+	"io"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type UploadMediaUsecase interface {
+	UploadMedia(userID uuid.UUID, r io.Reader) (*entities.Media, error)
+}
+
+// uploadMediaUsecase stores the originals in uploadStore, which is never served,
+// until they are processed.
+type uploadMediaUsecase struct {
+	mediaRepository repositories.MediaRepositoryInterface
+	uploadStore     repositories.BlobStore
+}
+
+func NewUploadMediaUsecase(mediaRepository repositories.MediaRepositoryInterface, uploadStore repositories.BlobStore) UploadMediaUsecase {
+	return &uploadMediaUsecase{mediaRepository: mediaRepository, uploadStore: uploadStore}
+}
+
+// UploadMedia stores the image read from r for the user as pending media,
+// which is processed by ProcessMediaUsecase later. It returns ErrUnsupportedMediaType
+// unless the content is a JPEG, PNG, GIF or WebP image, and ErrMediaTooLarge
+// if it exceeds MaxImageSize, in which case nothing is left stored.
+func (p *uploadMediaUsecase) UploadMedia(userID uuid.UUID, r io.Reader) (*entities.Media, error) {
+	head := make([]byte, entities.MediaSniffLength)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	head = head[:n]
+
+	contentType, ok := entities.DetectImageType(head)
+	if !ok {
+		return nil, errors.ErrUnsupportedMediaType
+	}
+
+	id := uuid.New()
+	key := id.String() + "/original"
+	content := &countingReader{r: io.LimitReader(io.MultiReader(bytes.NewReader(head), r), entities.MaxImageSize+1)}
+	if err := p.uploadStore.Put(key, content); err != nil {
+		return nil, err
+	}
+	if content.n > entities.MaxImageSize {
+		p.uploadStore.Delete(key)
+		return nil, errors.ErrMediaTooLarge
+	}
+
+	media := &entities.Media{
+		ID:          id,
+		UserID:      userID,
+		ContentType: contentType,
+		Size:        content.n,
+		Status:      entities.MediaPending,
+		StorageKey:  key,
+	}
+	if err := p.mediaRepository.CreateMedia(media); err != nil {
+		p.uploadStore.Delete(key)
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n += int64(n)
+	return n, err
+}
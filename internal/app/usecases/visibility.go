@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/repositories"
+)
+
+// checkPostVisibility returns an error if the post does not exist
+// or its author's activities are not visible to the viewer.
+func checkPostVisibility(postsRepository repositories.PostsRepositoryInterface, usersRepository repositories.UsersRepositoryInterface, postID, viewerID string) error {
+	post, err := postsRepository.GetPost(postID)
+	if err != nil {
+		return err
+	}
+
+	return usersRepository.CheckVisibility(nil, post.UserID.String(), viewerID)
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type VotePollUsecase interface {
+	// Vote votes for the choice at the position in the poll of the post,
+	// and returns the poll with its results, which the user can now see.
+	Vote(userID, postID uuid.UUID, position int) (*entities.Poll, error)
+}
+
+type votePollUsecase struct {
+	pollsRepository repositories.PollsRepositoryInterface
+	postsRepository repositories.PostsRepositoryInterface
+	usersRepository repositories.UsersRepositoryInterface
+}
+
+func NewVotePollUsecase(
+	pollsRepository repositories.PollsRepositoryInterface,
+	postsRepository repositories.PostsRepositoryInterface,
+	usersRepository repositories.UsersRepositoryInterface,
+) VotePollUsecase {
+	return &votePollUsecase{
+		pollsRepository: pollsRepository,
+		postsRepository: postsRepository,
+		usersRepository: usersRepository,
+	}
+}
+
+func (p *votePollUsecase) Vote(userID, postID uuid.UUID, position int) (*entities.Poll, error) {
+	// Users cannot vote in the polls of authors who blocked them or whose posts are protected.
+	if err := checkPostVisibility(p.postsRepository, p.usersRepository, postID.String(), userID.String()); err != nil {
+		return nil, err
+	}
+
+	if err := p.pollsRepository.Vote(postID.String(), userID.String(), position); err != nil {
+		return nil, err
+	}
+
+	post, err := p.postsRepository.GetPost(postID.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := p.postsRepository.HydratePosts(userID.String(), []*entities.Post{post}); err != nil {
+		return nil, err
+	}
+
+	return post.Poll, nil
+}
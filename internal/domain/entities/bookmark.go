@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBookmarkFolderNameLength is the maximum number of characters of a folder name.
+const MaxBookmarkFolderNameLength = 25
+
+// BookmarkFolder represents an entry of `bookmark_folders` table.
+// Users can sort their bookmarks into folders, whose names are unique per user.
+// Bookmarks are private, so folders are only returned to their owners.
+type BookmarkFolder struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"-"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cursor points at an item of a list ordered by (Time, ID) in descending order,
+// or by (Rank, Time, ID) if Rank is set, as search results ranked by relevance are.
+// It is handed to clients as an opaque string so that they can request
+// the items following the last one they received.
+type Cursor struct {
+	Time time.Time
+	ID   uuid.UUID
+	Rank *float32
+}
+
+var errMalformedCursor = errors.New("malformed cursor")
+
+// Encode returns the opaque string representation of the cursor.
+func (c *Cursor) Encode() string {
+	raw := c.Time.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	if c.Rank != nil {
+		raw += "|" + strconv.FormatFloat(float64(*c.Rank), 'g', -1, 32)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a string returned by Cursor.Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errMalformedCursor
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, errMalformedCursor
+	}
+	timePart, idPart := parts[0], parts[1]
+
+	t, err := time.Parse(time.RFC3339Nano, timePart)
+	if err != nil {
+		return nil, errMalformedCursor
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return nil, errMalformedCursor
+	}
+
+	cursor := &Cursor{Time: t, ID: id}
+	if len(parts) == 3 {
+		rank, err := strconv.ParseFloat(parts[2], 32)
+		if err != nil {
+			return nil, errMalformedCursor
+		}
+		r := float32(rank)
+		cursor.Rank = &r
+	}
+
+	return cursor, nil
+}
+
+// Page is a slice of a list with the cursor of its last item.
+// NextCursor is nil if there are no more items.
+type Page[T any] struct {
+	Items      []T
+	NextCursor *Cursor
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{
+		Time: time.Date(2024, 11, 12, 14, 33, 42, 978924000, time.UTC),
+		ID:   uuid.New(),
+	}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if !decoded.Time.Equal(cursor.Time) || decoded.ID != cursor.ID {
+		t.Errorf("Expected %+v, but got %+v", cursor, *decoded)
+	}
+}
+
+func TestRankedCursorRoundTrip(t *testing.T) {
+	rank := float32(0.0607927)
+	cursor := Cursor{
+		Time: time.Date(2024, 11, 12, 14, 33, 42, 0, time.UTC),
+		ID:   uuid.New(),
+		Rank: &rank,
+	}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if decoded.Rank == nil || *decoded.Rank != rank {
+		t.Errorf("Expected rank %v, but got %v", rank, decoded.Rank)
+	}
+}
+
+func TestDecodeMalformedCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "!!!"},
+		{name: "missing separator", cursor: "bm8tc2VwYXJhdG9y"},
+		{name: "truncated cursor", cursor: (&Cursor{}).Encode()[:4]},
+	}
+
+	for _, test := range tests {
+		if _, err := DecodeCursor(test.cursor); err == nil {
+			t.Errorf("%s: expected an error, but got nil", test.name)
+		}
+	}
+}
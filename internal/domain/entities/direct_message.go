@@ -0,0 +1,150 @@
+package entities
+
+import (
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxDirectMessageLength is the maximum number of characters of a direct message.
+	MaxDirectMessageLength = 10000
+	// MaxConversationNameLength is the maximum number of characters of the name of a group conversation.
+	MaxConversationNameLength = 50
+	// MaxGroupParticipants is the maximum number of participants of a group conversation.
+	MaxGroupParticipants = 50
+	// MaxReactionLength is the maximum number of characters of a reaction,
+	// which allows emoji joined by zero width joiners.
+	MaxReactionLength = 10
+)
+
+// Conversation represents an entry of `conversations` table, where its participants
+// exchange direct messages. Conversations are private to their participants.
+//
+// A group conversation can have up to MaxGroupParticipants participants and a name,
+// while a one-to-one conversation always has the same two participants.
+//
+// LastMessage and UnreadCount are relative to the user the conversation is returned to:
+// the messages the user has deleted are left out, and only the messages of the other
+// participants sent after the user last read the conversation are counted as unread.
+type Conversation struct {
+	ID        uuid.UUID `json:"id"`
+	IsGroup   bool      `json:"is_group"`
+	Name      string    `json:"name,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Participants []*UserSummary `json:"participants"`
+	ReadReceipts []*ReadReceipt `json:"read_receipts"`
+	LastMessage  *DirectMessage `json:"last_message,omitempty"`
+	UnreadCount  int            `json:"unread_count"`
+}
+
+// ReadReceipt tells until when a participant has read a conversation.
+// The messages sent at or before ReadAt have been read by the participant.
+type ReadReceipt struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	ReadAt         time.Time `json:"read_at"`
+}
+
+// DirectMessageType tells a message written by its sender from a system message,
+// which records a membership change of a group conversation.
+type DirectMessageType string
+
+const (
+	DirectMessageText DirectMessageType = "text"
+	// DirectMessageParticipantAdded is sent by the participant who added TargetUserID.
+	DirectMessageParticipantAdded DirectMessageType = "participant_added"
+	// DirectMessageParticipantLeft is sent by the participant who left.
+	DirectMessageParticipantLeft DirectMessageType = "participant_left"
+	// DirectMessageConversationRenamed is sent by the participant who renamed the conversation,
+	// and has the new name as its text.
+	DirectMessageConversationRenamed DirectMessageType = "conversation_renamed"
+)
+
+// DirectMessage represents an entry of `direct_messages` table.
+type DirectMessage struct {
+	ID             uuid.UUID         `json:"id"`
+	ConversationID uuid.UUID         `json:"conversation_id"`
+	SenderID       uuid.UUID         `json:"sender_id"`
+	Type           DirectMessageType `json:"type"`
+	Text           string            `json:"text"`
+	TargetUserID   *uuid.UUID        `json:"target_user_id,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+
+	Reactions []*Reaction `json:"reactions,omitempty"`
+}
+
+// Reaction groups the users who reacted to a message with the same emoji,
+// in chronological order of their reactions.
+type Reaction struct {
+	Emoji   string      `json:"emoji"`
+	UserIDs []uuid.UUID `json:"user_ids"`
+}
+
+// ValidReaction reports whether the reaction is a short sequence of emoji,
+// rejecting letters, digits, punctuation and whitespace.
+func ValidReaction(emoji string) bool {
+	if emoji == "" || utf8.RuneCountInString(emoji) > MaxReactionLength {
+		return false
+	}
+	for _, r := range emoji {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DirectMessageEventType is the kind of change a DirectMessageEvent tells participants about.
+type DirectMessageEventType string
+
+const (
+	// DirectMessageCreated carries a new message, including system messages.
+	DirectMessageCreated DirectMessageEventType = "message_created"
+	// DirectMessageReacted carries a message whose reactions have changed.
+	DirectMessageReacted DirectMessageEventType = "message_reacted"
+	// ConversationRead carries the read receipt of a participant who has read the conversation.
+	ConversationRead DirectMessageEventType = "conversation_read"
+)
+
+// DirectMessageEvent is delivered to the live streams of the participants of a conversation.
+type DirectMessageEvent struct {
+	EventType   DirectMessageEventType `json:"event_type"`
+	Message     *DirectMessage         `json:"message,omitempty"`
+	ReadReceipt *ReadReceipt           `json:"read_receipt,omitempty"`
+}
+
+// DirectMessagePermission tells whom a user accepts direct messages from.
+type DirectMessagePermission string
+
+const (
+	DirectMessagesFromEveryone DirectMessagePermission = "everyone"
+	// DirectMessagesFromFollowing accepts direct messages only from the users the recipient follows.
+	// The other users can still reply in the conversations the recipient has sent a message to.
+	DirectMessagesFromFollowing DirectMessagePermission = "following"
+)
+
+// Valid reports whether the permission is one of the known values.
+func (p DirectMessagePermission) Valid() bool {
+	return p == DirectMessagesFromEveryone || p == DirectMessagesFromFollowing
+}
+
+// DirectMessageSettings represents an entry of `direct_message_settings` table.
+type DirectMessageSettings struct {
+	AllowFrom DirectMessagePermission `json:"allow_from"`
+}
+
+// DefaultDirectMessageSettings returns the settings of users who have never changed them.
+func DefaultDirectMessageSettings() DirectMessageSettings {
+	return DirectMessageSettings{AllowFrom: DirectMessagesFromEveryone}
+}
+
+// DirectMessageSettingsPatch holds the settings to change.
+// Nil fields are left as they are.
+type DirectMessageSettingsPatch struct {
+	AllowFrom *DirectMessagePermission `json:"allow_from"`
+}
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidReaction(t *testing.T) {
+	tests := []struct {
+		emoji    string
+		expected bool
+	}{
+		{emoji: "", expected: false},
+		{emoji: "😂", expected: true},
+		{emoji: "❤️", expected: true},
+		{emoji: "👍🏽", expected: true},
+		{emoji: "👩‍👩‍👧", expected: true},
+		{emoji: "🔥🔥", expected: true},
+		{emoji: strings.Repeat("🔥", MaxReactionLength+1), expected: false},
+		{emoji: "a", expected: false},
+		{emoji: "1", expected: false},
+		{emoji: "!", expected: false},
+		{emoji: "😂 ", expected: false},
+		{emoji: "\n", expected: false},
+	}
+
+	for _, test := range tests {
+		if got := ValidReaction(test.emoji); got != test.expected {
+			t.Errorf("%q: expected %t, but got %t", test.emoji, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultDraftMaxAge is how long drafts are kept after they were last updated.
+const DefaultDraftMaxAge = 30 * 24 * time.Hour
+
+// Draft is a post or a quote repost being written, which is saved so that it can be continued
+// on another device. It is published as a quote repost of QuotedPostID if it is set,
+// and its content is validated in the same way as those when it is published.
+type Draft struct {
+	ID           uuid.UUID    `json:"id"`
+	UserID       uuid.UUID    `json:"user_id"`
+	Text         string       `json:"text"`
+	MediaIDs     []uuid.UUID  `json:"media_ids,omitempty"`
+	Poll         *PendingPoll `json:"poll,omitempty"`
+	QuotedPostID *uuid.UUID   `json:"quoted_post_id,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// Savable reports whether the draft can be saved: it must not have more media than a post,
+// and cannot have a poll if it quotes a post, since quote reposts have no polls.
+// The rest of the content may be incomplete until the draft is published.
+func (d *Draft) Savable() bool {
+	return len(d.MediaIDs) <= MaxMediaPerPost && (d.Poll == nil || d.QuotedPostID == nil)
+}
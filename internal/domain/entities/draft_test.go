@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDraftSavable(t *testing.T) {
+	quotedPostID := uuid.New()
+	poll := &PendingPoll{Choices: []string{"yes", "no"}, DurationMinutes: 60}
+
+	tests := []struct {
+		name     string
+		draft    Draft
+		expected bool
+	}{
+		{name: "empty", draft: Draft{}, expected: true},
+		{name: "post with a poll", draft: Draft{Text: "hello", Poll: poll}, expected: true},
+		{name: "quote with media", draft: Draft{MediaIDs: []uuid.UUID{uuid.New()}, QuotedPostID: &quotedPostID}, expected: true},
+		{name: "quote with a poll", draft: Draft{Poll: poll, QuotedPostID: &quotedPostID}, expected: false},
+		{name: "too many media", draft: Draft{MediaIDs: make([]uuid.UUID, MaxMediaPerPost+1)}, expected: false},
+	}
+
+	for _, test := range tests {
+		if got := test.draft.Savable(); got != test.expected {
+			t.Errorf("%s: expected %t, but got %t", test.name, test.expected, got)
+		}
+	}
+}
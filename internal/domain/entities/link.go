@@ -0,0 +1,308 @@
+package entities
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// URLLength is the length every URL counts as toward the length limit of posts
+	// whatever its actual length, as X counts every URL as a t.co link of 23 characters.
+	URLLength = 23
+	// LinkCodeLength is the number of characters of the code of a short link.
+	LinkCodeLength = 7
+	// LinkPathPrefix is the path short links are served under, followed by their codes.
+	LinkPathPrefix = "/l/"
+
+	// maxURLLength is the maximum number of bytes of a URL to be shortened.
+	maxURLLength = 2000
+	// maxDisplayURLLength is the number of characters a URL is truncated to for display.
+	maxDisplayURLLength = 26
+	// maxCardTitleLength and maxCardDescriptionLength are the numbers
+	// of characters the texts of a preview card are truncated to.
+	maxCardTitleLength       = 200
+	maxCardDescriptionLength = 500
+)
+
+// UnfurlStatus is the state of fetching the preview card of a link.
+type UnfurlStatus string
+
+const (
+	UnfurlPending    UnfurlStatus = "pending"
+	UnfurlInProgress UnfurlStatus = "in_progress"
+	UnfurlSucceeded  UnfurlStatus = "succeeded"
+	UnfurlFailed     UnfurlStatus = "failed"
+)
+
+// Link represents an entry of `links` table, a URL posted by users, which is
+// shortened into a code once however many posts have it. Card is nil until the page
+// is unfurled, and stays nil if the page has no preview.
+type Link struct {
+	ID           uuid.UUID
+	Code         string
+	URL          string
+	ClickCount   int64
+	UnfurlStatus UnfurlStatus
+	Card         *LinkCard
+	CreatedAt    time.Time
+}
+
+// LinkCard is the preview of a linked page taken from its Open Graph,
+// Twitter card or HTML metadata.
+type LinkCard struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+}
+
+// Normalize collapses the whitespaces in the texts of the card and truncates them,
+// so that a page cannot fill posts with its metadata.
+func (c *LinkCard) Normalize() {
+	c.Title = truncate(strings.Join(strings.Fields(c.Title), " "), maxCardTitleLength)
+	c.Description = truncate(strings.Join(strings.Fields(c.Description), " "), maxCardDescriptionLength)
+	c.SiteName = truncate(strings.Join(strings.Fields(c.SiteName), " "), maxCardTitleLength)
+}
+
+// URLEntity is a URL in the text of a post. URL is the short link clients should
+// open, ExpandedURL the URL as written, and DisplayURL the shortened form of it
+// to be shown in place of it. Start and End are counted in the same way as those of Mention.
+type URLEntity struct {
+	URL         string    `json:"url"`
+	ExpandedURL string    `json:"expanded_url"`
+	DisplayURL  string    `json:"display_url"`
+	Start       int       `json:"start"`
+	End         int       `json:"end"`
+	Card        *LinkCard `json:"card,omitempty"`
+}
+
+// linkCodeAlphabet is the characters codes of short links consist of.
+const linkCodeAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// NewLinkCode returns a random code of LinkCodeLength characters for a short link.
+// Codes are unguessable so that short links do not reveal the URLs of other posts
+// by enumeration.
+func NewLinkCode() (string, error) {
+	code := make([]byte, LinkCodeLength)
+	max := big.NewInt(int64(len(linkCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = linkCodeAlphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
+
+// ShortLinkURL returns the URL of the short link of the code.
+func ShortLinkURL(code string) string {
+	return LinkPathPrefix + code
+}
+
+// ExtractURLs finds the http and https URLs in the text. Their URL is left
+// empty until they are shortened.
+//
+// A URL must not follow a letter, a digit or one of "@$#/.-_=", and its host must
+// have a top-level domain of letters. Trailing punctuations, and closing
+// parentheses and brackets without opening ones, are left out of the URL,
+// so that "(see https://example.com/a_(b))." ends at "b)".
+func ExtractURLs(text string) []*URLEntity {
+	runes := []rune(text)
+
+	var urls []*URLEntity
+	for i := 0; i < len(runes); i++ {
+		schemeEnd := i + urlSchemeLength(runes[i:])
+		if schemeEnd == i || (i > 0 && !canPrecedeURL(runes[i-1])) {
+			continue
+		}
+
+		hostEnd := schemeEnd
+		for hostEnd < len(runes) && isHostRune(runes[hostEnd]) {
+			hostEnd++
+		}
+		// A host followed by a period ends a sentence rather than being fully qualified.
+		for hostEnd > schemeEnd && runes[hostEnd-1] == '.' {
+			hostEnd--
+		}
+		if !isValidHost(runes[schemeEnd:hostEnd]) {
+			i = hostEnd - 1
+			continue
+		}
+
+		end := hostEnd
+		if end+1 < len(runes) && runes[end] == ':' && unicode.IsDigit(runes[end+1]) {
+			end++
+			for end < len(runes) && runes[end] < utf8.RuneSelf && unicode.IsDigit(runes[end]) {
+				end++
+			}
+		}
+		if end < len(runes) && strings.ContainsRune("/?#", runes[end]) {
+			for end < len(runes) && isURLRune(runes[end]) {
+				end++
+			}
+			end = trimURLEnd(runes[hostEnd:end]) + hostEnd
+		}
+
+		expanded := string(runes[i:end])
+		if len(expanded) > maxURLLength {
+			i = end - 1
+			continue
+		}
+		urls = append(urls, &URLEntity{
+			ExpandedURL: expanded,
+			DisplayURL:  displayURL(runes[schemeEnd:end]),
+			Start:       i,
+			End:         end,
+		})
+		i = end - 1
+	}
+
+	return urls
+}
+
+// ResolveURLs extracts the URLs in the text and keeps the ones of the given links,
+// setting their short links and preview cards.
+func ResolveURLs(text string, links []*Link) []*URLEntity {
+	linksByURL := make(map[string]*Link, len(links))
+	for _, link := range links {
+		linksByURL[link.URL] = link
+	}
+
+	var urls []*URLEntity
+	for _, url := range ExtractURLs(text) {
+		link, ok := linksByURL[url.ExpandedURL]
+		if !ok {
+			continue
+		}
+		url.URL = ShortLinkURL(link.Code)
+		url.Card = link.Card
+		urls = append(urls, url)
+	}
+
+	return urls
+}
+
+// LinkedURLs returns the distinct URLs in the text.
+func LinkedURLs(text string) []string {
+	seen := make(map[string]bool)
+	var urls []string
+	for _, url := range ExtractURLs(text) {
+		if seen[url.ExpandedURL] {
+			continue
+		}
+		seen[url.ExpandedURL] = true
+		urls = append(urls, url.ExpandedURL)
+	}
+
+	return urls
+}
+
+// urlSchemeLength returns the length of "http://" or "https://" at the beginning
+// of the runes case-insensitively, or 0 if there is neither.
+func urlSchemeLength(runes []rune) int {
+	for _, scheme := range []string{"https://", "http://"} {
+		if len(runes) >= len(scheme) && strings.EqualFold(string(runes[:len(scheme)]), scheme) {
+			return len(scheme)
+		}
+	}
+
+	return 0
+}
+
+func canPrecedeURL(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("@$#/.-_=＠＃", r)
+}
+
+func isHostRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.M, r) || r == '-' || r == '.'
+}
+
+// isValidHost reports whether the host consists of at least two non-empty labels,
+// none of which starts or ends with "-", and ends with a top-level domain
+// of two or more letters.
+func isValidHost(host []rune) bool {
+	labels := strings.Split(string(host), ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+	}
+
+	tld := []rune(labels[len(labels)-1])
+	if len(tld) < 2 {
+		return false
+	}
+	for _, r := range tld {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isURLRune reports whether the rune can be a part of the path, the query or
+// the fragment of a URL. Punctuations other than ASCII ones, such as "。",
+// end the URL so that a URL in a Japanese sentence does not swallow the rest of it.
+func isURLRune(r rune) bool {
+	if r < utf8.RuneSelf {
+		return r > ' ' && r != 0x7f && !strings.ContainsRune("<>\"{}|\\^`", r)
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.M, r)
+}
+
+// trimURLEnd returns the length of the path without its trailing punctuations
+// and unbalanced closing parentheses and brackets.
+func trimURLEnd(path []rune) int {
+	end := len(path)
+	for end > 0 {
+		switch r := path[end-1]; {
+		case strings.ContainsRune(".,:;!?'*", r):
+			end--
+		case r == ')' && countRune(path[:end], '(') < countRune(path[:end], ')'):
+			end--
+		case r == ']' && countRune(path[:end], '[') < countRune(path[:end], ']'):
+			end--
+		default:
+			return end
+		}
+	}
+
+	return end
+}
+
+func countRune(runes []rune, r rune) int {
+	n := 0
+	for _, c := range runes {
+		if c == r {
+			n++
+		}
+	}
+
+	return n
+}
+
+// displayURL returns the URL without its scheme, truncated to maxDisplayURLLength characters.
+func displayURL(withoutScheme []rune) string {
+	return truncate(string(withoutScheme), maxDisplayURLLength)
+}
+
+// truncate cuts the text down to max characters, ending it with "…" if it is cut.
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
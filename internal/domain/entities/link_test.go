@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractURLs(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []URLEntity
+	}{
+		{
+			name: "url in a sentence",
+			text: "see https://example.com/path?q=1.",
+			expected: []URLEntity{
+				{ExpandedURL: "https://example.com/path?q=1", DisplayURL: "example.com/path?q=1", Start: 4, End: 32},
+			},
+		},
+		{
+			name: "host only followed by a period",
+			text: "HTTP://Example.com.",
+			expected: []URLEntity{
+				{ExpandedURL: "HTTP://Example.com", DisplayURL: "Example.com", Start: 0, End: 18},
+			},
+		},
+		{
+			name: "balanced and unbalanced parentheses",
+			text: "(https://en.wikipedia.org/wiki/Go_(language))",
+			expected: []URLEntity{
+				{ExpandedURL: "https://en.wikipedia.org/wiki/Go_(language)", DisplayURL: "en.wikipedia.org/wiki/Go_…", Start: 1, End: 44},
+			},
+		},
+		{
+			name: "port and japanese punctuation",
+			text: "これ→http://localhost.dev:8080/a。次",
+			expected: []URLEntity{
+				{ExpandedURL: "http://localhost.dev:8080/a", DisplayURL: "localhost.dev:8080/a", Start: 3, End: 30},
+			},
+		},
+		{
+			name: "several urls",
+			text: "https://a.io https://b.io/x",
+			expected: []URLEntity{
+				{ExpandedURL: "https://a.io", DisplayURL: "a.io", Start: 0, End: 12},
+				{ExpandedURL: "https://b.io/x", DisplayURL: "b.io/x", Start: 13, End: 27},
+			},
+		},
+		{
+			name: "no top-level domain",
+			text: "http://localhost/admin",
+		},
+		{
+			name: "numeric top-level domain",
+			text: "http://192.168.0.1/",
+		},
+		{
+			name: "inside a word",
+			text: "xhttps://example.com",
+		},
+		{
+			name: "unsupported scheme",
+			text: "ftp://example.com",
+		},
+	}
+
+	for _, test := range tests {
+		urls := ExtractURLs(test.text)
+		if len(urls) != len(test.expected) {
+			t.Errorf("%s: expected %d urls, but got %d", test.name, len(test.expected), len(urls))
+			continue
+		}
+		for i, url := range urls {
+			if *url != test.expected[i] {
+				t.Errorf("%s: expected %+v, but got %+v", test.name, test.expected[i], *url)
+			}
+		}
+	}
+}
+
+func TestResolveURLs(t *testing.T) {
+	card := &LinkCard{Title: "Example"}
+	links := []*Link{{Code: "abc1234", URL: "https://example.com", Card: card}}
+
+	urls := ResolveURLs("https://example.com and https://unknown.com", links)
+	if len(urls) != 1 {
+		t.Fatalf("Expected 1 url, but got %d", len(urls))
+	}
+	if urls[0].URL != "/l/abc1234" || urls[0].Card != card {
+		t.Errorf("Expected the short link with the card, but got %+v", *urls[0])
+	}
+}
+
+func TestNormalizeLinkCard(t *testing.T) {
+	card := &LinkCard{
+		Title:       "  A\n\ttitle  ",
+		Description: strings.Repeat("あ", maxCardDescriptionLength+1),
+	}
+	card.Normalize()
+
+	if card.Title != "A title" {
+		t.Errorf("Expected the whitespaces to be collapsed, but got %q", card.Title)
+	}
+	if n := len([]rune(card.Description)); n != maxCardDescriptionLength || !strings.HasSuffix(card.Description, "…") {
+		t.Errorf("Expected the description to be truncated to %d characters, but got %d", maxCardDescriptionLength, n)
+	}
+}
+
+func TestNewLinkCode(t *testing.T) {
+	code, err := NewLinkCode()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(code) != LinkCodeLength {
+		t.Errorf("Expected a code of %d characters, but got %q", LinkCodeLength, code)
+	}
+	for _, r := range code {
+		if !strings.ContainsRune(linkCodeAlphabet, r) {
+			t.Errorf("Expected the code to be alphanumeric, but got %q", code)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxListNameLength and MaxListDescriptionLength are the maximum numbers of characters
+	// of the name and the description of a list.
+	MaxListNameLength        = 25
+	MaxListDescriptionLength = 100
+)
+
+// List represents an entry of `lists` table, a curated group of users
+// whose posts and reposts make up the timeline of the list.
+//
+// A private list can only be seen by its owner, while a public list can be seen and
+// subscribed to by anyone the owner does not block. Owner, the counts and SubscribedByMe
+// are filled in by the lists repository when a list is returned to clients.
+type List struct {
+	ID          uuid.UUID `json:"id"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsPrivate   bool      `json:"is_private"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	Owner           *UserSummary `json:"owner,omitempty"`
+	MemberCount     int          `json:"member_count"`
+	SubscriberCount int          `json:"subscriber_count"`
+	SubscribedByMe  bool         `json:"subscribed_by_me"`
+}
+
+// VisibleTo reports whether the viewer can see the list, apart from blocks.
+func (l *List) VisibleTo(viewerID string) bool {
+	return !l.IsPrivate || l.OwnerID.String() == viewerID
+}
+
+// ListPatch holds the changes to a list.
+// Nil fields are left as they are.
+type ListPatch struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	IsPrivate   *bool   `json:"is_private"`
+}
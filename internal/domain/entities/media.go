@@ -0,0 +1,163 @@
+package entities
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxImageSize is the maximum size of an uploaded image in bytes.
+	MaxImageSize = 5 << 20
+	// MaxMediaPerPost is the maximum number of media attached to a post or a quote repost.
+	MaxMediaPerPost = 4
+	// MediaSniffLength is the number of leading bytes DetectImageType needs.
+	MediaSniffLength = 12
+	// MaxAltTextLength is the maximum number of characters of the alt text of media.
+	MaxAltTextLength = 1000
+	// MaxGIFSize is the maximum size of a GIF image uploaded in segments.
+	MaxGIFSize = 15 << 20
+	// MaxVideoSize is the maximum size of a video, which can only be uploaded in segments.
+	MaxVideoSize = 512 << 20
+	// MaxVideoDuration is the maximum duration of a video.
+	MaxVideoDuration = 140 * time.Second
+)
+
+// MediaStatus is the state of the processing of uploaded media,
+// named after the processing states of X.
+type MediaStatus string
+
+const (
+	MediaPending    MediaStatus = "pending"
+	MediaInProgress MediaStatus = "in_progress"
+	MediaSucceeded  MediaStatus = "succeeded"
+	MediaFailed     MediaStatus = "failed"
+)
+
+// RenditionSpec is a size media are resized to: images larger than MaxSize
+// in either dimension are scaled down to fit within a MaxSize x MaxSize box,
+// preserving the aspect ratio, and smaller ones are left as they are.
+type RenditionSpec struct {
+	Name    string
+	MaxSize int
+}
+
+// ImageRenditions are the renditions generated for every uploaded image,
+// from the smallest to the largest.
+var ImageRenditions = []RenditionSpec{
+	{Name: "thumb", MaxSize: 150},
+	{Name: "small", MaxSize: 680},
+	{Name: "large", MaxSize: 2048},
+}
+
+// Rendition is a processed version of media which clients are served.
+type Rendition struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	URL         string `json:"url"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// Media represents an entry of `media` table, a file uploaded by a user
+// which can be attached to their posts and quote reposts once processed.
+// The uploaded file itself is kept in a blob store under StorageKey and is never
+// served; clients fetch the Renditions instead, and URL is that of the last one,
+// which is the largest image, the animated GIF or the video.
+// URL, the dimensions, Blurhash and Renditions are set when Status is MediaSucceeded,
+// and DurationMs as well for animated GIFs and videos. Videos have no Blurhash.
+type Media struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	ContentType string       `json:"content_type"`
+	Size        int64        `json:"size"`
+	Status      MediaStatus  `json:"status"`
+	URL         string       `json:"url,omitempty"`
+	Width       int          `json:"width,omitempty"`
+	Height      int          `json:"height,omitempty"`
+	Blurhash    string       `json:"blurhash,omitempty"`
+	AltText     string       `json:"alt_text"`
+	Renditions  []*Rendition `json:"renditions,omitempty"`
+	DurationMs  int          `json:"duration_ms,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+
+	// ProcessingError tells the user why the processing failed when Status is MediaFailed.
+	ProcessingError string `json:"processing_error,omitempty"`
+
+	StorageKey string `json:"-"`
+}
+
+// FitWithin returns the dimensions of a width x height image scaled down
+// to fit within a maxSize x maxSize box, preserving the aspect ratio.
+// Images already fitting are left as they are, and no dimension becomes zero.
+func FitWithin(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+
+	if width >= height {
+		return maxSize, max(1, (height*maxSize+width/2)/width)
+	}
+	return max(1, (width*maxSize+height/2)/height), maxSize
+}
+
+// DetectImageType returns the content type of a JPEG, PNG, GIF or WebP image
+// by the magic bytes at the beginning of the file, so that the type declared
+// by the client is not trusted. It reports false for any other data.
+func DetectImageType(head []byte) (string, bool) {
+	switch {
+	case bytes.HasPrefix(head, []byte("\xff\xd8\xff")):
+		return "image/jpeg", true
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1a\n")):
+		return "image/png", true
+	case bytes.HasPrefix(head, []byte("GIF87a")), bytes.HasPrefix(head, []byte("GIF89a")):
+		return "image/gif", true
+	case len(head) >= 12 && bytes.Equal(head[:4], []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WEBP")):
+		return "image/webp", true
+	default:
+		return "", false
+	}
+}
+
+// DetectVideoType returns the content type of an MP4 or QuickTime video by the
+// "ftyp" box at the beginning of the file. It reports false for any other data.
+func DetectVideoType(head []byte) (string, bool) {
+	if len(head) < 12 || !bytes.Equal(head[4:8], []byte("ftyp")) {
+		return "", false
+	}
+	if bytes.Equal(head[8:12], []byte("qt  ")) {
+		return "video/quicktime", true
+	}
+	return "video/mp4", true
+}
+
+// DetectMediaType returns the content type of an image or a video
+// which can be uploaded in segments.
+func DetectMediaType(head []byte) (string, bool) {
+	if contentType, ok := DetectImageType(head); ok {
+		return contentType, true
+	}
+	return DetectVideoType(head)
+}
+
+// IsVideo reports whether the content type is that of a video.
+func IsVideo(contentType string) bool {
+	return strings.HasPrefix(contentType, "video/")
+}
+
+// MaxMediaSize returns the maximum size of media of the content type uploaded
+// in segments. It reports false if media of the type cannot be uploaded.
+func MaxMediaSize(contentType string) (int64, bool) {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/webp":
+		return MaxImageSize, true
+	case "image/gif":
+		return MaxGIFSize, true
+	case "video/mp4", "video/quicktime":
+		return MaxVideoSize, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,94 @@
+package entities
+
+import "testing"
+
+func TestDetectImageType(t *testing.T) {
+	tests := []struct {
+		name         string
+		head         string
+		expectedType string
+		expectedOK   bool
+	}{
+		{name: "jpeg", head: "\xff\xd8\xff\xe0\x00\x10JFIF", expectedType: "image/jpeg", expectedOK: true},
+		{name: "png", head: "\x89PNG\r\n\x1a\n\x00\x00\x00\x0d", expectedType: "image/png", expectedOK: true},
+		{name: "gif87a", head: "GIF87a\x01\x00", expectedType: "image/gif", expectedOK: true},
+		{name: "gif89a", head: "GIF89a\x01\x00", expectedType: "image/gif", expectedOK: true},
+		{name: "webp", head: "RIFF\x24\x00\x00\x00WEBPVP8 ", expectedType: "image/webp", expectedOK: true},
+		{name: "riff but not webp", head: "RIFF\x24\x00\x00\x00WAVEfmt ", expectedOK: false},
+		{name: "truncated webp", head: "RIFF\x24\x00", expectedOK: false},
+		{name: "text", head: "<svg xmlns=", expectedOK: false},
+		{name: "empty", head: "", expectedOK: false},
+	}
+
+	for _, test := range tests {
+		contentType, ok := DetectImageType([]byte(test.head))
+		if ok != test.expectedOK || contentType != test.expectedType {
+			t.Errorf("%s: expected (%q, %v), but got (%q, %v)", test.name, test.expectedType, test.expectedOK, contentType, ok)
+		}
+	}
+}
+
+func TestFitWithin(t *testing.T) {
+	tests := []struct {
+		name           string
+		width, height  int
+		maxSize        int
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{name: "already fitting", width: 100, height: 50, maxSize: 150, expectedWidth: 100, expectedHeight: 50},
+		{name: "landscape", width: 4000, height: 3000, maxSize: 2048, expectedWidth: 2048, expectedHeight: 1536},
+		{name: "portrait", width: 1080, height: 1920, maxSize: 680, expectedWidth: 383, expectedHeight: 680},
+		{name: "square", width: 300, height: 300, maxSize: 150, expectedWidth: 150, expectedHeight: 150},
+		{name: "extremely wide", width: 10000, height: 10, maxSize: 150, expectedWidth: 150, expectedHeight: 1},
+	}
+
+	for _, test := range tests {
+		width, height := FitWithin(test.width, test.height, test.maxSize)
+		if width != test.expectedWidth || height != test.expectedHeight {
+			t.Errorf("%s: expected %dx%d, but got %dx%d", test.name, test.expectedWidth, test.expectedHeight, width, height)
+		}
+	}
+}
+
+func TestDetectMediaType(t *testing.T) {
+	tests := []struct {
+		name         string
+		head         string
+		expectedType string
+		expectedOK   bool
+	}{
+		{name: "mp4", head: "\x00\x00\x00\x20ftypisom\x00\x00", expectedType: "video/mp4", expectedOK: true},
+		{name: "quicktime", head: "\x00\x00\x00\x14ftypqt  \x00\x00", expectedType: "video/quicktime", expectedOK: true},
+		{name: "image", head: "GIF89a\x01\x00", expectedType: "image/gif", expectedOK: true},
+		{name: "truncated ftyp", head: "\x00\x00\x00\x20ftyp", expectedOK: false},
+		{name: "text", head: "<html><body>", expectedOK: false},
+	}
+
+	for _, test := range tests {
+		contentType, ok := DetectMediaType([]byte(test.head))
+		if ok != test.expectedOK || contentType != test.expectedType {
+			t.Errorf("%s: expected (%q, %v), but got (%q, %v)", test.name, test.expectedType, test.expectedOK, contentType, ok)
+		}
+	}
+}
+
+func TestMaxMediaSize(t *testing.T) {
+	tests := []struct {
+		contentType  string
+		expectedSize int64
+		expectedOK   bool
+	}{
+		{contentType: "image/png", expectedSize: MaxImageSize, expectedOK: true},
+		{contentType: "image/gif", expectedSize: MaxGIFSize, expectedOK: true},
+		{contentType: "video/mp4", expectedSize: MaxVideoSize, expectedOK: true},
+		{contentType: "video/webm", expectedOK: false},
+	}
+
+	for _, test := range tests {
+		size, ok := MaxMediaSize(test.contentType)
+		if size != test.expectedSize || ok != test.expectedOK {
+			t.Errorf("%s: expected (%d, %v), but got (%d, %v)", test.contentType, test.expectedSize, test.expectedOK, size, ok)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxUploadSegmentSize is the maximum size of a segment of a media upload.
+	MaxUploadSegmentSize = 5 << 20
+	// MaxUploadSegments is the maximum number of segments of a media upload,
+	// whose indexes are 0 to MaxUploadSegments-1.
+	MaxUploadSegments = 1000
+	// MediaUploadExpiry is how long a media upload can take from its initialization
+	// to its finalization. The segments of expired uploads are deleted.
+	MediaUploadExpiry = 24 * time.Hour
+	// MediaUploadQuota is the maximum total size of the media uploads a user
+	// can initialize within MediaUploadQuotaWindow.
+	MediaUploadQuota = 2 << 30
+	// MediaUploadQuotaWindow is the period MediaUploadQuota applies to.
+	MediaUploadQuotaWindow = 24 * time.Hour
+)
+
+// MediaUpload represents an entry of `media_uploads` table, an upload of media
+// sent in segments, which can be resumed after a disconnection by sending
+// the segments missing from Segments. The media is created with the same ID
+// once the upload is finalized.
+type MediaUpload struct {
+	ID          uuid.UUID        `json:"media_id"`
+	UserID      uuid.UUID        `json:"user_id"`
+	ContentType string           `json:"media_type"`
+	TotalBytes  int64            `json:"total_bytes"`
+	SHA256      string           `json:"sha256"`
+	Segments    []*UploadSegment `json:"segments"`
+	FinalizedAt *time.Time       `json:"finalized_at,omitempty"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// UploadSegment represents an entry of `media_upload_segments` table, a received
+// segment of a media upload. SHA256 is the hex-encoded SHA-256 checksum of
+// the segment, by which the client can tell whether it was received intact.
+type UploadSegment struct {
+	Index  int    `json:"index"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+
+	StorageKey string `json:"-"`
+}
+
+// ReceivedBytes returns the total size of the received segments.
+func (u *MediaUpload) ReceivedBytes() int64 {
+	var received int64
+	for _, segment := range u.Segments {
+		received += segment.Size
+	}
+	return received
+}
+
+// IsComplete reports whether the segments are numbered from 0 without gaps
+// and add up to TotalBytes. Segments must be sorted by Index.
+func (u *MediaUpload) IsComplete() bool {
+	for i, segment := range u.Segments {
+		if segment.Index != i {
+			return false
+		}
+	}
+	return u.ReceivedBytes() == u.TotalBytes
+}
+
+// IsExpired reports whether the upload can no longer be continued at now.
+func (u *MediaUpload) IsExpired(now time.Time) bool {
+	return u.FinalizedAt == nil && !now.Before(u.ExpiresAt)
+}
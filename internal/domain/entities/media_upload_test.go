@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMediaUploadIsComplete(t *testing.T) {
+	tests := []struct {
+		name       string
+		totalBytes int64
+		segments   []*UploadSegment
+		expected   bool
+	}{
+		{
+			name:       "all segments received",
+			totalBytes: 30,
+			segments:   []*UploadSegment{{Index: 0, Size: 10}, {Index: 1, Size: 10}, {Index: 2, Size: 10}},
+			expected:   true,
+		},
+		{
+			name:       "a segment missing in the middle",
+			totalBytes: 20,
+			segments:   []*UploadSegment{{Index: 0, Size: 10}, {Index: 2, Size: 10}},
+			expected:   false,
+		},
+		{
+			name:       "not starting from zero",
+			totalBytes: 10,
+			segments:   []*UploadSegment{{Index: 1, Size: 10}},
+			expected:   false,
+		},
+		{
+			name:       "trailing segments missing",
+			totalBytes: 30,
+			segments:   []*UploadSegment{{Index: 0, Size: 10}, {Index: 1, Size: 10}},
+			expected:   false,
+		},
+		{
+			name:       "no segments",
+			totalBytes: 10,
+			expected:   false,
+		},
+	}
+
+	for _, test := range tests {
+		upload := &MediaUpload{TotalBytes: test.totalBytes, Segments: test.segments}
+		if upload.IsComplete() != test.expected {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, !test.expected)
+		}
+	}
+}
+
+func TestMediaUploadIsExpired(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	finalizedAt := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name     string
+		upload   MediaUpload
+		expected bool
+	}{
+		{name: "in progress", upload: MediaUpload{ExpiresAt: now.Add(time.Hour)}, expected: false},
+		{name: "expired", upload: MediaUpload{ExpiresAt: now}, expected: true},
+		{name: "finalized before the expiry", upload: MediaUpload{ExpiresAt: now.Add(-time.Hour), FinalizedAt: &finalizedAt}, expected: false},
+	}
+
+	for _, test := range tests {
+		if test.upload.IsExpired(now) != test.expected {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, !test.expected)
+		}
+	}
+}
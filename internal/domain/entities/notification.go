@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationType is the kind of activity a notification tells a user about.
+type NotificationType string
+
+const (
+	NotificationFollow  NotificationType = "follow"
+	NotificationLike    NotificationType = "like"
+	NotificationRepost  NotificationType = "repost"
+	NotificationQuote   NotificationType = "quote"
+	NotificationMention NotificationType = "mention"
+	// NotificationPollClosed tells the author and the voters of a poll that it has closed.
+	// Its actor is the author, and it is sent to the author as well.
+	NotificationPollClosed NotificationType = "poll_closed"
+)
+
+// Notification represents an entry of `notifications` table,
+// or a group of them when notifications are listed.
+//
+// Likes and reposts of the same post on the same day, and follows on the same day,
+// are grouped into one notification such as "A and 3 others liked your post".
+// Actors holds the most recent actors of the group and ActorCount the number of them.
+//
+// PostID is the post the activity was done to, and SourceID is the repost,
+// quote repost or post which caused the notification, if any.
+type Notification struct {
+	ID          uuid.UUID        `json:"id"`
+	RecipientID uuid.UUID        `json:"-"`
+	ActorID     uuid.UUID        `json:"-"`
+	Type        NotificationType `json:"type"`
+	PostID      *uuid.UUID       `json:"post_id,omitempty"`
+	SourceID    *uuid.UUID       `json:"source_id,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	Read        bool             `json:"read"`
+
+	Actors     []*UserSummary `json:"actors"`
+	ActorCount int            `json:"actor_count"`
+}
+
+// GroupKey returns the key which notifications are grouped by,
+// or an empty string if the notification is never grouped.
+func (n *Notification) GroupKey() string {
+	// Groups never span days, so that listing notifications only has to aggregate
+	// the groups on the page rather than the whole history of the recipient.
+	day := n.CreatedAt.UTC().Format(time.DateOnly)
+	switch n.Type {
+	case NotificationLike, NotificationRepost:
+		if n.PostID != nil {
+			return string(n.Type) + ":" + n.PostID.String() + ":" + day
+		}
+	case NotificationFollow:
+		return string(n.Type) + ":" + day
+	}
+
+	return ""
+}
+
+// NotificationPreferences represents an entry of `notification_preferences` table.
+// The per-type toggles turn notifications of the type on or off;
+// reposts covers quote reposts.
+// The filters drop notifications from users the recipient doesn't follow,
+// users without a bio, or accounts created within NewAccountPeriod.
+type NotificationPreferences struct {
+	Likes             bool `json:"likes"`
+	Reposts           bool `json:"reposts"`
+	Follows           bool `json:"follows"`
+	Mentions          bool `json:"mentions"`
+	OnlyFromFollowing bool `json:"only_from_following"`
+	FilterNoBio       bool `json:"filter_no_bio"`
+	FilterNewAccounts bool `json:"filter_new_accounts"`
+}
+
+// NewAccountPeriod is how long an account is regarded as new by FilterNewAccounts.
+const NewAccountPeriod = 7 * 24 * time.Hour
+
+// DefaultNotificationPreferences returns the preferences of users who have never changed them.
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{
+		Likes:    true,
+		Reposts:  true,
+		Follows:  true,
+		Mentions: true,
+	}
+}
+
+// NotificationPreferencesPatch holds the preferences to change.
+// Nil fields are left as they are.
+type NotificationPreferencesPatch struct {
+	Likes             *bool `json:"likes"`
+	Reposts           *bool `json:"reposts"`
+	Follows           *bool `json:"follows"`
+	Mentions          *bool `json:"mentions"`
+	OnlyFromFollowing *bool `json:"only_from_following"`
+	FilterNoBio       *bool `json:"filter_no_bio"`
+	FilterNewAccounts *bool `json:"filter_new_accounts"`
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNotificationGroupKey(t *testing.T) {
+	postID := uuid.MustParse("6f1c2e4a-51f0-4b53-9c2a-8d1e0f7a3b21")
+	morning := time.Date(2024, 5, 1, 1, 0, 0, 0, time.UTC)
+	night := time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC)
+	nextDay := time.Date(2024, 5, 2, 1, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		a, b     Notification
+		expected bool
+	}{
+		{
+			name:     "likes of a post on the same day",
+			a:        Notification{Type: NotificationLike, PostID: &postID, CreatedAt: morning},
+			b:        Notification{Type: NotificationLike, PostID: &postID, CreatedAt: night},
+			expected: true,
+		},
+		{
+			name:     "likes of a post on different days",
+			a:        Notification{Type: NotificationLike, PostID: &postID, CreatedAt: night},
+			b:        Notification{Type: NotificationLike, PostID: &postID, CreatedAt: nextDay},
+			expected: false,
+		},
+		{
+			name:     "a like and a repost of a post",
+			a:        Notification{Type: NotificationLike, PostID: &postID, CreatedAt: morning},
+			b:        Notification{Type: NotificationRepost, PostID: &postID, CreatedAt: morning},
+			expected: false,
+		},
+		{
+			name:     "follows on the same day",
+			a:        Notification{Type: NotificationFollow, CreatedAt: morning},
+			b:        Notification{Type: NotificationFollow, CreatedAt: night},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		if grouped := test.a.GroupKey() == test.b.GroupKey(); grouped != test.expected {
+			t.Errorf("%s: expected grouped to be %t, but got %t", test.name, test.expected, grouped)
+		}
+	}
+
+	mention := Notification{Type: NotificationMention, PostID: &postID, CreatedAt: morning}
+	if key := mention.GroupKey(); key != "" {
+		t.Errorf("Expected mentions not to be grouped, but got %q", key)
+	}
+}
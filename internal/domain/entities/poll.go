@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MinPollChoices and MaxPollChoices are the numbers of choices a poll can have.
+	MinPollChoices = 2
+	MaxPollChoices = 4
+	// MaxPollChoiceLength is the maximum number of characters of a choice.
+	MaxPollChoiceLength = 25
+	// MinPollDuration and MaxPollDuration are how long a poll can accept votes.
+	MinPollDuration = 5 * time.Minute
+	MaxPollDuration = 7 * 24 * time.Hour
+)
+
+// Poll represents an entry of `polls` table, which is attached to a post
+// and accepts a vote per user until EndsAt.
+//
+// The vote counts are counted from `poll_votes` table while the poll is open,
+// and stored in `poll_choices` table when it is closed. Closed is true once EndsAt
+// has passed, even before the final results are stored. VotedChoice is the position
+// of the choice the viewer voted for, if any.
+type Poll struct {
+	ID          uuid.UUID     `json:"id"`
+	PostID      uuid.UUID     `json:"-"`
+	Choices     []*PollChoice `json:"choices"`
+	EndsAt      time.Time     `json:"ends_at"`
+	Closed      bool          `json:"closed"`
+	TotalVotes  int           `json:"total_votes"`
+	VotedChoice *int          `json:"voted_choice,omitempty"`
+}
+
+// PollChoice is a choice of a poll. Position is its 0-based index in the poll,
+// and VoteCount is nil while the results are hidden from the viewer.
+type PollChoice struct {
+	Position  int    `json:"position"`
+	Label     string `json:"label"`
+	VoteCount *int   `json:"vote_count,omitempty"`
+}
+
+// ResultsVisible reports whether the vote counts of the poll can be shown to the viewer.
+// They are hidden from the viewers who have not voted until the poll is closed,
+// so that the results so far do not sway their votes; the author can always see them.
+func (p *Poll) ResultsVisible(viewerIsAuthor bool) bool {
+	return p.Closed || p.VotedChoice != nil || viewerIsAuthor
+}
+
+// HideResults clears the vote counts of the choices.
+func (p *Poll) HideResults() {
+	for _, choice := range p.Choices {
+		choice.VoteCount = nil
+	}
+}
+
+// ValidPollDuration reports whether a poll can be open for the duration.
+func ValidPollDuration(duration time.Duration) bool {
+	return MinPollDuration <= duration && duration <= MaxPollDuration
+}
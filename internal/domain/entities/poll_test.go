@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPollResultsVisible(t *testing.T) {
+	voted := 1
+
+	tests := []struct {
+		name           string
+		poll           Poll
+		viewerIsAuthor bool
+		expected       bool
+	}{
+		{name: "open poll the viewer has not voted in", poll: Poll{}, expected: false},
+		{name: "open poll the viewer has voted in", poll: Poll{VotedChoice: &voted}, expected: true},
+		{name: "open poll of the viewer", poll: Poll{}, viewerIsAuthor: true, expected: true},
+		{name: "closed poll", poll: Poll{Closed: true}, expected: true},
+	}
+
+	for _, test := range tests {
+		if got := test.poll.ResultsVisible(test.viewerIsAuthor); got != test.expected {
+			t.Errorf("%s: expected %t, but got %t", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestHideResults(t *testing.T) {
+	count := 3
+	poll := Poll{Choices: []*PollChoice{{Label: "a", VoteCount: &count}, {Label: "b", VoteCount: &count}}}
+	poll.HideResults()
+
+	for _, choice := range poll.Choices {
+		if choice.VoteCount != nil {
+			t.Errorf("Expected the vote count of %q to be hidden, but got %d", choice.Label, *choice.VoteCount)
+		}
+	}
+}
+
+func TestValidPollDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected bool
+	}{
+		{duration: 4 * time.Minute, expected: false},
+		{duration: 5 * time.Minute, expected: true},
+		{duration: 24 * time.Hour, expected: true},
+		{duration: 7 * 24 * time.Hour, expected: true},
+		{duration: 7*24*time.Hour + time.Minute, expected: false},
+	}
+
+	for _, test := range tests {
+		if got := ValidPollDuration(test.duration); got != test.expected {
+			t.Errorf("%v: expected %t, but got %t", test.duration, test.expected, got)
+		}
+	}
+}
@@ -7,12 +7,32 @@ import (
 )
 
 // Post represents an entry of `posts` table.
-// It contains properties such as Text.
-// Currently, we only support text as a post content, but plan to
-// support more data types like Image.
+// It contains properties such as Text, and images can be attached as Media.
+//
+// EditCount is the number of times the post has been edited, and EditedAt is
+// when it was last edited; every version is kept as PostEdit.
+//
+// Author, the engagement counts, the viewer flags, the entities, the media and the poll
+// are not stored in `posts` table; they are filled in by the posts repository
+// when a post is returned to clients. BookmarkCount is only filled in for the author,
+// since bookmarks are private.
 type Post struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Text      string    `json:"text"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Text      string     `json:"text"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditCount int        `json:"edit_count"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+
+	Author         *UserSummary `json:"author,omitempty"`
+	LikeCount      int          `json:"like_count"`
+	RepostCount    int          `json:"repost_count"`
+	QuoteCount     int          `json:"quote_count"`
+	LikedByMe      bool         `json:"liked_by_me"`
+	RepostedByMe   bool         `json:"reposted_by_me"`
+	BookmarkedByMe bool         `json:"bookmarked_by_me"`
+	BookmarkCount  *int         `json:"bookmark_count,omitempty"`
+	Entities       PostEntities `json:"entities"`
+	Media          []*Media     `json:"media,omitempty"`
+	Poll           *Poll        `json:"poll,omitempty"`
 }
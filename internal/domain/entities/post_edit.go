@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostEdit represents an entry of `post_edits` table, a version of an edited post.
+// The original text is version 0, and each edit adds the next version.
+type PostEdit struct {
+	PostID    uuid.UUID `json:"post_id"`
+	Version   int       `json:"version"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PostEditPolicy limits how many times, and for how long after they are created,
+// posts can be edited.
+type PostEditPolicy struct {
+	MaxEdits int
+	Window   time.Duration
+}
+
+// DefaultPostEditPolicy returns the policy of X: 5 edits within an hour.
+func DefaultPostEditPolicy() PostEditPolicy {
+	return PostEditPolicy{MaxEdits: 5, Window: time.Hour}
+}
+
+// EditableUntil returns when the edit window of the post closes.
+func (p PostEditPolicy) EditableUntil(post *Post) time.Time {
+	return post.CreatedAt.Add(p.Window)
+}
+
+// EditsLeft returns how many more times the post can be edited within the window.
+func (p PostEditPolicy) EditsLeft(post *Post) int {
+	return max(p.MaxEdits-post.EditCount, 0)
+}
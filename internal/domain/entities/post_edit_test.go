@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPostEditPolicyEditableUntil(t *testing.T) {
+	policy := DefaultPostEditPolicy()
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	got := policy.EditableUntil(&Post{CreatedAt: createdAt})
+	if expected := createdAt.Add(time.Hour); !got.Equal(expected) {
+		t.Errorf("Expected %v, but got %v", expected, got)
+	}
+}
+
+func TestPostEditPolicyEditsLeft(t *testing.T) {
+	policy := DefaultPostEditPolicy()
+
+	tests := []struct {
+		editCount int
+		expected  int
+	}{
+		{editCount: 0, expected: 5},
+		{editCount: 4, expected: 1},
+		{editCount: 5, expected: 0},
+		// The policy may have been tightened after the post was edited.
+		{editCount: 6, expected: 0},
+	}
+
+	for _, test := range tests {
+		if got := policy.EditsLeft(&Post{EditCount: test.editCount}); got != test.expected {
+			t.Errorf("%d edits: expected %d, but got %d", test.editCount, test.expected, got)
+		}
+	}
+}
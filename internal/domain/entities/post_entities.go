@@ -0,0 +1,297 @@
+package entities
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// maxMentionLength is the maximum length of a username which can be mentioned,
+	// following X's tokenization rules.
+	maxMentionLength = 15
+	// maxCashtagLength is the maximum number of letters of a cashtag before its suffix,
+	// as in "$BRK.A".
+	maxCashtagLength = 6
+	// maxCashtagSuffixLength is the maximum number of letters of the suffix of a cashtag.
+	maxCashtagSuffixLength = 2
+)
+
+// PostEntities holds the entities extracted from the text of a post or a quote repost.
+type PostEntities struct {
+	Mentions []*Mention   `json:"mentions,omitempty"`
+	Hashtags []*Tag       `json:"hashtags,omitempty"`
+	Cashtags []*Tag       `json:"cashtags,omitempty"`
+	URLs     []*URLEntity `json:"urls,omitempty"`
+}
+
+// Tag is a #hashtag or a $cashtag. Text is the tag without the leading "#" or "$"
+// as it appears in the text, and Start and End are its offsets including the sign,
+// counted in the same way as those of Mention.
+type Tag struct {
+	Text  string `json:"text"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// Mention is an @username mention of an existing user.
+// Start and End are the offsets of the mention including the "@",
+// counted in Unicode code points as X does; End is exclusive.
+type Mention struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Start    int       `json:"start"`
+	End      int       `json:"end"`
+}
+
+// ExtractMentions finds the @username mentions in the text.
+// The mentions are not resolved yet, so their UserID is left zero
+// and their Username is written as it appears in the text.
+//
+// A mention must not follow a letter, a digit or one of "_!#$%&*@",
+// so that e-mail addresses are not taken for mentions, and must not be
+// followed by a letter, another "@" or "://". Mentions inside URLs are ignored.
+func ExtractMentions(text string) []*Mention {
+	runes := []rune(text)
+	urls := ExtractURLs(text)
+
+	var mentions []*Mention
+	for i := 0; i < len(runes); i++ {
+		if !isAtSign(runes[i]) || (i > 0 && !canPrecedeMention(runes[i-1])) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && isUsernameRune(runes[end]) {
+			end++
+		}
+		length := end - i - 1
+		if length == 0 || length > maxMentionLength || !canFollowMention(runes[end:]) || isInsideURL(urls, i) {
+			i = end - 1
+			continue
+		}
+
+		mentions = append(mentions, &Mention{
+			Username: string(runes[i+1 : end]),
+			Start:    i,
+			End:      end,
+		})
+		i = end - 1
+	}
+
+	return mentions
+}
+
+// ResolveMentions extracts the mentions in the text and keeps the ones
+// of the given users, which are looked up by their usernames case-insensitively.
+func ResolveMentions(text string, users []*UserSummary) []*Mention {
+	usersByName := make(map[string]*UserSummary, len(users))
+	for _, user := range users {
+		usersByName[strings.ToLower(user.Username)] = user
+	}
+
+	var mentions []*Mention
+	for _, mention := range ExtractMentions(text) {
+		user, ok := usersByName[strings.ToLower(mention.Username)]
+		if !ok {
+			continue
+		}
+		mention.UserID = user.ID
+		mention.Username = user.Username
+		mentions = append(mentions, mention)
+	}
+
+	return mentions
+}
+
+// MentionedUsernames returns the distinct usernames mentioned in the text in lower case.
+func MentionedUsernames(text string) []string {
+	seen := make(map[string]bool)
+	var usernames []string
+	for _, mention := range ExtractMentions(text) {
+		username := strings.ToLower(mention.Username)
+		if seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+
+	return usernames
+}
+
+func isAtSign(r rune) bool {
+	return r == '@' || r == '＠'
+}
+
+func isUsernameRune(r rune) bool {
+	return r == '_' || (r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
+
+func canPrecedeMention(r rune) bool {
+	return !isUsernameRune(r) && !isAtSign(r) && !strings.ContainsRune("!#$%&*", r)
+}
+
+func canFollowMention(rest []rune) bool {
+	if len(rest) == 0 {
+		return true
+	}
+
+	r := rest[0]
+	if isAtSign(r) || unicode.IsLetter(r) || unicode.Is(unicode.M, r) {
+		return false
+	}
+
+	return len(rest) < 3 || string(rest[:3]) != "://"
+}
+
+// ExtractHashtags finds the #hashtags in the text following X's tokenization rules.
+//
+// A hashtag consists of letters, marks, digits and underscores, and must contain
+// at least one letter or mark, so that "#1" is not a hashtag. It must not follow
+// a letter, a mark, a digit, "_" or "&", so that HTML entities such as "&#39;"
+// are not taken for hashtags, and must not be followed by another "#" or "://".
+// Hashtags inside URLs, such as fragments, are ignored.
+func ExtractHashtags(text string) []*Tag {
+	runes := []rune(text)
+	urls := ExtractURLs(text)
+
+	var hashtags []*Tag
+	for i := 0; i < len(runes); i++ {
+		if !isHashSign(runes[i]) || (i > 0 && !canPrecedeHashtag(runes[i-1])) {
+			continue
+		}
+
+		end := i + 1
+		hasAlpha := false
+		for end < len(runes) && isHashtagRune(runes[end]) {
+			hasAlpha = hasAlpha || unicode.IsLetter(runes[end]) || unicode.Is(unicode.M, runes[end])
+			end++
+		}
+		if !hasAlpha || !canFollowHashtag(runes[end:]) || isInsideURL(urls, i) {
+			i = end - 1
+			continue
+		}
+
+		hashtags = append(hashtags, &Tag{
+			Text:  string(runes[i+1 : end]),
+			Start: i,
+			End:   end,
+		})
+		i = end - 1
+	}
+
+	return hashtags
+}
+
+// ExtractCashtags finds the $cashtags, such as "$TWTR" or "$BRK.A", in the text
+// following X's tokenization rules: a cashtag must be preceded by a whitespace or
+// be at the beginning of the text, consists of up to 6 ASCII letters optionally
+// followed by "." or "_" and up to 2 more letters, and must end at a whitespace,
+// a punctuation or the end of the text.
+func ExtractCashtags(text string) []*Tag {
+	runes := []rune(text)
+
+	var cashtags []*Tag
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '$' || (i > 0 && !unicode.IsSpace(runes[i-1])) {
+			continue
+		}
+
+		end := i + 1 + countASCIILetters(runes[i+1:], maxCashtagLength+1)
+		if end == i+1 || end-i-1 > maxCashtagLength {
+			continue
+		}
+		if end+1 < len(runes) && (runes[end] == '.' || runes[end] == '_') {
+			if n := countASCIILetters(runes[end+1:], maxCashtagSuffixLength+1); n > 0 && n <= maxCashtagSuffixLength {
+				end += 1 + n
+			}
+		}
+		if end < len(runes) && !canFollowCashtag(runes[end]) {
+			continue
+		}
+
+		cashtags = append(cashtags, &Tag{
+			Text:  string(runes[i+1 : end]),
+			Start: i,
+			End:   end,
+		})
+		i = end - 1
+	}
+
+	return cashtags
+}
+
+// NormalizeTag returns the form of a hashtag or a cashtag which tags are stored
+// and looked up by, so that "#Café" and "#cafe\u0301" are the same hashtag.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(norm.NFKC.String(tag))
+}
+
+// NormalizedHashtags returns the distinct normalized hashtags in the text.
+func NormalizedHashtags(text string) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, hashtag := range ExtractHashtags(text) {
+		tag := NormalizeTag(hashtag.Text)
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+func isHashSign(r rune) bool {
+	return r == '#' || r == '＃'
+}
+
+// isHashtagRune reports whether the rune can be a part of a hashtag.
+// Besides letters, marks, digits and "_", X allows a few joiners and
+// punctuations used inside words of some scripts, such as the katakana middle dot.
+func isHashtagRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.Is(unicode.M, r) || unicode.Is(unicode.Nd, r) || r == '_' ||
+		strings.ContainsRune("\u200c\u200d\ua67e\u05be\u05f3\u05f4\uff5e\u301c\u309b\u309c\u30a0\u30fb\u3003\u0f0b\u0f0c\u00b7", r)
+}
+
+func canPrecedeHashtag(r rune) bool {
+	return !isHashtagRune(r) && !isHashSign(r) && r != '&'
+}
+
+func canFollowHashtag(rest []rune) bool {
+	if len(rest) == 0 {
+		return true
+	}
+
+	return !isHashSign(rest[0]) && (len(rest) < 3 || string(rest[:3]) != "://")
+}
+
+func canFollowCashtag(r rune) bool {
+	return unicode.IsSpace(r) || (r < utf8.RuneSelf && (unicode.IsPunct(r) || unicode.IsSymbol(r)))
+}
+
+// isInsideURL reports whether the offset is inside any of the URLs.
+func isInsideURL(urls []*URLEntity, offset int) bool {
+	for _, url := range urls {
+		if url.Start <= offset && offset < url.End {
+			return true
+		}
+	}
+
+	return false
+}
+
+// countASCIILetters counts the ASCII letters at the beginning of the runes, up to max.
+func countASCIILetters(runes []rune, max int) int {
+	n := 0
+	for n < len(runes) && n < max && runes[n] < utf8.RuneSelf && unicode.IsLetter(runes[n]) {
+		n++
+	}
+
+	return n
+}
@@ -0,0 +1,195 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []Mention
+	}{
+		{
+			name:     "single mention",
+			text:     "hello @alice",
+			expected: []Mention{{Username: "alice", Start: 6, End: 12}},
+		},
+		{
+			name: "several mentions with punctuation",
+			text: "@bob, @carol_1!",
+			expected: []Mention{
+				{Username: "bob", Start: 0, End: 4},
+				{Username: "carol_1", Start: 6, End: 14},
+			},
+		},
+		{
+			name:     "offsets in code points",
+			text:     "こんにちは＠alice",
+			expected: []Mention{{Username: "alice", Start: 5, End: 11}},
+		},
+		{
+			name: "e-mail address",
+			text: "mail me at alice@example.com",
+		},
+		{
+			name: "followed by another at sign",
+			text: "@alice@example",
+		},
+		{
+			name: "followed by a url scheme",
+			text: "@http://example.com",
+		},
+		{
+			name: "username too long",
+			text: "@abcdefghijklmnop",
+		},
+		{
+			name: "lone at sign",
+			text: "meet @ noon",
+		},
+		{
+			name:     "inside a url",
+			text:     "https://medium.com/@alice by @bob",
+			expected: []Mention{{Username: "bob", Start: 29, End: 33}},
+		},
+	}
+
+	for _, test := range tests {
+		mentions := ExtractMentions(test.text)
+		if len(mentions) != len(test.expected) {
+			t.Errorf("%s: expected %d mentions, but got %d", test.name, len(test.expected), len(mentions))
+			continue
+		}
+		for i, mention := range mentions {
+			if *mention != test.expected[i] {
+				t.Errorf("%s: expected %+v, but got %+v", test.name, test.expected[i], *mention)
+			}
+		}
+	}
+}
+
+func TestResolveMentions(t *testing.T) {
+	alice := &UserSummary{ID: uuid.New(), Username: "Alice"}
+
+	mentions := ResolveMentions("@alice and @unknown and @ALICE", []*UserSummary{alice})
+	if len(mentions) != 2 {
+		t.Fatalf("Expected 2 mentions, but got %d", len(mentions))
+	}
+	for _, mention := range mentions {
+		if mention.UserID != alice.ID || mention.Username != "Alice" {
+			t.Errorf("Expected a mention of %+v, but got %+v", alice, *mention)
+		}
+	}
+	if mentions[1].Start != 24 {
+		t.Errorf("Expected the second mention to start at 24, but got %d", mentions[1].Start)
+	}
+}
+
+func TestExtractHashtags(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []Tag
+	}{
+		{
+			name: "several hashtags",
+			text: "#go and #日本語_2024!",
+			expected: []Tag{
+				{Text: "go", Start: 0, End: 3},
+				{Text: "日本語_2024", Start: 8, End: 17},
+			},
+		},
+		{
+			name:     "full-width hash sign",
+			text:     "＃ハッシュ・タグ",
+			expected: []Tag{{Text: "ハッシュ・タグ", Start: 0, End: 8}},
+		},
+		{
+			name: "digits only",
+			text: "#1 in the charts",
+		},
+		{
+			name: "html entity",
+			text: "it&#39;s",
+		},
+		{
+			name: "inside a word",
+			text: "c#sharp",
+		},
+		{
+			name: "followed by another hash sign",
+			text: "#tag#tag",
+		},
+		{
+			name:     "url fragment",
+			text:     "https://example.com/#section #go",
+			expected: []Tag{{Text: "go", Start: 29, End: 32}},
+		},
+	}
+
+	for _, test := range tests {
+		hashtags := ExtractHashtags(test.text)
+		if len(hashtags) != len(test.expected) {
+			t.Errorf("%s: expected %d hashtags, but got %d", test.name, len(test.expected), len(hashtags))
+			continue
+		}
+		for i, hashtag := range hashtags {
+			if *hashtag != test.expected[i] {
+				t.Errorf("%s: expected %+v, but got %+v", test.name, test.expected[i], *hashtag)
+			}
+		}
+	}
+}
+
+func TestExtractCashtags(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []Tag
+	}{
+		{
+			name: "several cashtags",
+			text: "$TWTR, $brk.a and $ABC.DEF",
+			expected: []Tag{
+				{Text: "TWTR", Start: 0, End: 5},
+				{Text: "brk.a", Start: 7, End: 13},
+				{Text: "ABC", Start: 18, End: 22},
+			},
+		},
+		{
+			name: "amount of money",
+			text: "it costs $100",
+		},
+		{
+			name: "too long",
+			text: "$ABCDEFG",
+		},
+		{
+			name: "inside a word",
+			text: "US$AB",
+		},
+	}
+
+	for _, test := range tests {
+		cashtags := ExtractCashtags(test.text)
+		if len(cashtags) != len(test.expected) {
+			t.Errorf("%s: expected %d cashtags, but got %d", test.name, len(test.expected), len(cashtags))
+			continue
+		}
+		for i, cashtag := range cashtags {
+			if *cashtag != test.expected[i] {
+				t.Errorf("%s: expected %+v, but got %+v", test.name, test.expected[i], *cashtag)
+			}
+		}
+	}
+}
+
+func TestNormalizedHashtags(t *testing.T) {
+	tags := NormalizedHashtags("#Café #café #ＧＯ #go")
+	if len(tags) != 2 || tags[0] != "café" || tags[1] != "go" {
+		t.Errorf("Expected [café go], but got %v", tags)
+	}
+}
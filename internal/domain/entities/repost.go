@@ -9,10 +9,28 @@ import (
 // Repost represents an entry of `reposts` table.
 // It contains properties such as UserID and PostID.
 // UserID is the ID of a user who reposts a post.
+// Author is filled in only when reposts are listed for a post,
+// and Entities and Media only for quote reposts.
+// QuotedVersion is the version of the post a quote repost quoted, which it keeps
+// referencing when the post is edited later.
 type Repost struct {
 	ID        uuid.UUID `json:"id"`
 	ParentID  uuid.UUID `json:"parent_id"`
 	UserID    uuid.UUID `json:"user_id"`
 	Text      string    `json:"text"`
 	CreatedAt time.Time `json:"created_at"`
+
+	QuotedVersion *int         `json:"quoted_version,omitempty"`
+	Author        *UserSummary `json:"author,omitempty"`
+	Entities      PostEntities `json:"entities"`
+	Media         []*Media     `json:"media,omitempty"`
+}
+
+// TimelineItem is an entry of a timeline mixing posts and quote reposts.
+// Exactly one of Post and Quote is set. Repost is also set if the item appears
+// because a user reposted it, as in the timelines of lists.
+type TimelineItem struct {
+	Post   *Post   `json:"post,omitempty"`
+	Quote  *Repost `json:"quote,omitempty"`
+	Repost *Repost `json:"repost,omitempty"`
 }
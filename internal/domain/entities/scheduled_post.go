@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxSchedulingPeriod is how far ahead posts can be scheduled, as on X.
+	MaxSchedulingPeriod = 18 * 30 * 24 * time.Hour
+	// MaxPublishAttempts is how many times publishing a scheduled post is tried before
+	// it is marked as failed, to be rescheduled or canceled by its author.
+	MaxPublishAttempts = 5
+	// firstPublishRetryDelay is how long publishing a scheduled post waits after its first failure.
+	firstPublishRetryDelay = time.Minute
+)
+
+// ScheduledPost represents an entry of `scheduled_posts` table, a post queued by its author
+// to be published at ScheduledAt. The media are attached and the poll is opened for its
+// duration when the post is published, which sets PostID and PublishedAt.
+// FailedAt is set once publishing has failed MaxPublishAttempts times,
+// and cleared when the post is rescheduled.
+type ScheduledPost struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	Text        string       `json:"text"`
+	MediaIDs    []uuid.UUID  `json:"media_ids,omitempty"`
+	Poll        *PendingPoll `json:"poll,omitempty"`
+	ScheduledAt time.Time    `json:"scheduled_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	FailedAt    *time.Time   `json:"failed_at,omitempty"`
+
+	PostID          *uuid.UUID `json:"-"`
+	PublishedAt     *time.Time `json:"-"`
+	PublishAttempts int        `json:"-"`
+}
+
+// PendingPoll is the poll of a post not published yet, such as a scheduled post or a draft.
+// The poll is opened when the post is published.
+type PendingPoll struct {
+	Choices         []string `json:"choices"`
+	DurationMinutes int      `json:"duration_minutes"`
+}
+
+// ValidScheduledTime reports whether a post can be scheduled at the time:
+// it must be in the future, up to MaxSchedulingPeriod ahead.
+func ValidScheduledTime(scheduledAt, now time.Time) bool {
+	return scheduledAt.After(now) && !scheduledAt.After(now.Add(MaxSchedulingPeriod))
+}
+
+// PublishRetryDelay returns how long to wait before publishing a scheduled post again
+// after it has failed attempts times, doubling from a minute at every failure.
+func PublishRetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	return firstPublishRetryDelay << (attempts - 1)
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidScheduledTime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		scheduledAt time.Time
+		expected    bool
+	}{
+		{name: "past", scheduledAt: now.Add(-time.Minute), expected: false},
+		{name: "now", scheduledAt: now, expected: false},
+		{name: "soon", scheduledAt: now.Add(time.Minute), expected: true},
+		{name: "furthest", scheduledAt: now.Add(MaxSchedulingPeriod), expected: true},
+		{name: "too far", scheduledAt: now.Add(MaxSchedulingPeriod + time.Second), expected: false},
+	}
+
+	for _, test := range tests {
+		if got := ValidScheduledTime(test.scheduledAt, now); got != test.expected {
+			t.Errorf("%s: expected %t, but got %t", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestPublishRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 0, expected: 0},
+		{attempts: 1, expected: time.Minute},
+		{attempts: 2, expected: 2 * time.Minute},
+		{attempts: MaxPublishAttempts - 1, expected: 8 * time.Minute},
+	}
+
+	for _, test := range tests {
+		if got := PublishRetryDelay(test.attempts); got != test.expected {
+			t.Errorf("%d attempts: expected %s, but got %s", test.attempts, test.expected, got)
+		}
+	}
+}
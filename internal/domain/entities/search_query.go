@@ -0,0 +1,168 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// searchDateLayout is the layout of the dates of the since: and until: operators.
+const searchDateLayout = "2006-01-02"
+
+// SearchOrder is the order search results are returned in.
+type SearchOrder string
+
+const (
+	SearchByRelevance SearchOrder = "relevance"
+	SearchByRecency   SearchOrder = "recency"
+)
+
+// ErrEmptySearchQuery is returned if a search query has nothing to search for.
+var ErrEmptySearchQuery = errors.New("search query is empty")
+
+// SearchQuery is a parsed post search query, following X's search syntax:
+//
+//	words            posts containing all the words
+//	"quoted phrase"  posts containing the exact phrase
+//	-word, -"phrase" posts not containing the word or the phrase
+//	#tag             posts with the hashtag
+//	from:username    posts by the user
+//	to:username      posts mentioning the user, as replies on X start with a mention
+//	since:YYYY-MM-DD posts created on or after the date in UTC
+//	until:YYYY-MM-DD posts created before the date in UTC
+//	filter:quotes    quote reposts only; -filter:quotes leaves them out
+//
+// Any other "name:value" term is searched for as words.
+type SearchQuery struct {
+	Terms         []string
+	Phrases       []string
+	Excluded      []string
+	Hashtags      []string
+	From          string
+	To            string
+	Since         *time.Time
+	Until         *time.Time
+	OnlyQuotes    bool
+	ExcludeQuotes bool
+}
+
+// ParseSearchQuery parses a post search query. It fails if an operator has
+// an invalid value or if the query has nothing to search for.
+func ParseSearchQuery(q string) (*SearchQuery, error) {
+	var query SearchQuery
+	for _, token := range tokenizeSearchQuery(q) {
+		excluded := strings.HasPrefix(token, "-") && len(token) > 1
+		if excluded {
+			token = token[1:]
+		}
+
+		if phrase, ok := unquote(token); ok {
+			if phrase == "" {
+				continue
+			}
+			if excluded {
+				query.Excluded = append(query.Excluded, phrase)
+			} else {
+				query.Phrases = append(query.Phrases, phrase)
+			}
+			continue
+		}
+
+		name, value, isOperator := strings.Cut(token, ":")
+		switch {
+		case isOperator && strings.EqualFold(name, "filter") && strings.EqualFold(value, "quotes"):
+			if excluded {
+				query.ExcludeQuotes = true
+			} else {
+				query.OnlyQuotes = true
+			}
+		case isOperator && !excluded && (strings.EqualFold(name, "from") || strings.EqualFold(name, "to")):
+			username := strings.TrimPrefix(value, "@")
+			if username == "" || strings.IndexFunc(username, func(r rune) bool { return !isUsernameRune(r) }) >= 0 {
+				return nil, fmt.Errorf("invalid username in %s", token)
+			}
+			if strings.EqualFold(name, "from") {
+				query.From = username
+			} else {
+				query.To = username
+			}
+		case isOperator && !excluded && (strings.EqualFold(name, "since") || strings.EqualFold(name, "until")):
+			date, err := time.Parse(searchDateLayout, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid date in %s: the date must be YYYY-MM-DD", token)
+			}
+			if strings.EqualFold(name, "since") {
+				query.Since = &date
+			} else {
+				query.Until = &date
+			}
+		case !excluded && isHashSign([]rune(token)[0]):
+			hashtags := ExtractHashtags(token)
+			if len(hashtags) == 1 && hashtags[0].End == len([]rune(token)) {
+				query.Hashtags = append(query.Hashtags, NormalizeTag(hashtags[0].Text))
+			} else {
+				query.Terms = append(query.Terms, token)
+			}
+		case excluded:
+			query.Excluded = append(query.Excluded, token)
+		default:
+			query.Terms = append(query.Terms, token)
+		}
+	}
+
+	if query.OnlyQuotes && query.ExcludeQuotes {
+		return nil, errors.New("filter:quotes and -filter:quotes cannot be combined")
+	}
+	if len(query.Terms) == 0 && len(query.Phrases) == 0 && len(query.Hashtags) == 0 && query.From == "" && query.To == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	return &query, nil
+}
+
+// HasText reports whether the query searches for words or phrases,
+// by which results can be ranked by relevance.
+func (q *SearchQuery) HasText() bool {
+	return len(q.Terms) > 0 || len(q.Phrases) > 0 || len(q.Hashtags) > 0
+}
+
+// tokenizeSearchQuery splits the query at whitespaces, keeping quoted phrases,
+// including the ones following "-", as single tokens.
+func tokenizeSearchQuery(q string) []string {
+	var (
+		tokens  []string
+		current strings.Builder
+		quoted  bool
+	)
+	for _, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens
+}
+
+// unquote returns the phrase in the token if it is quoted.
+// An unterminated quote is taken to run until the end of the query.
+func unquote(token string) (string, bool) {
+	if !strings.HasPrefix(token, `"`) {
+		return "", false
+	}
+
+	return strings.TrimSpace(strings.Trim(token, `"`)), true
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		q        string
+		expected *SearchQuery
+	}{
+		{
+			name:     "words and phrases",
+			q:        `go "generic types" -java -"null pointer"`,
+			expected: &SearchQuery{Terms: []string{"go"}, Phrases: []string{"generic types"}, Excluded: []string{"java", "null pointer"}},
+		},
+		{
+			name: "operators",
+			q:    `#Golang from:@alice to:bob since:2024-01-01 until:2024-02-01 filter:quotes`,
+			expected: &SearchQuery{
+				Hashtags:   []string{"golang"},
+				From:       "alice",
+				To:         "bob",
+				Since:      &since,
+				Until:      &until,
+				OnlyQuotes: true,
+			},
+		},
+		{
+			name:     "unknown operator",
+			q:        `lang:en -filter:quotes`,
+			expected: &SearchQuery{Terms: []string{"lang:en"}, ExcludeQuotes: true},
+		},
+	}
+
+	for _, test := range tests {
+		query, err := ParseSearchQuery(test.q)
+		if err != nil {
+			t.Errorf("%s: expected no error, but got %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(query, test.expected) {
+			t.Errorf("%s: expected %+v, but got %+v", test.name, test.expected, query)
+		}
+	}
+}
+
+func TestParseInvalidSearchQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		q    string
+	}{
+		{name: "empty", q: "  "},
+		{name: "only exclusions", q: "-java since:2024-01-01"},
+		{name: "invalid date", q: "go since:yesterday"},
+		{name: "invalid username", q: "from:"},
+		{name: "contradicting filters", q: "go filter:quotes -filter:quotes"},
+	}
+
+	for _, test := range tests {
+		if _, err := ParseSearchQuery(test.q); err == nil {
+			t.Errorf("%s: expected an error, but got nil", test.name)
+		}
+	}
+}
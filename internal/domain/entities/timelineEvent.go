@@ -4,6 +4,7 @@ const (
 	TimelineAccessed   = "TimelineAccessed"
 	PostCreated        = "PostCreated"
 	PostDeleted        = "PostDeleted"
+	PostEdited         = "PostEdited"
 	RepostCreated      = "RepostCreated"
 	RepostDeleted      = "RepostDeleted"
 	QuoteRepostCreated = "QuoteRepostCreated"
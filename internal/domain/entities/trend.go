@@ -0,0 +1,147 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TrendWindow is the sliding window in which hashtags are counted to find trends.
+	TrendWindow = time.Hour
+	// TrendBaselineWindow is the window before TrendWindow which the usual activity
+	// of hashtags is averaged over, so that hashtags which are always popular do not trend.
+	TrendBaselineWindow = 24 * time.Hour
+	// MinTrendAuthors is the minimum number of distinct authors in TrendWindow
+	// for a hashtag to trend, so that a single user cannot make a trend.
+	MinTrendAuthors = 2
+	// MaxTrendCandidates is the maximum number of trends stored each time they are computed,
+	// which the trends of each viewer are chosen from.
+	MaxTrendCandidates = 50
+)
+
+// TagActivity is the activity of a hashtag around the time trends are computed.
+// Authors and Posts are counted in TrendWindow, and BaselineAuthors
+// in TrendBaselineWindow before it.
+type TagActivity struct {
+	HashtagID       uuid.UUID
+	Tag             string
+	Authors         int
+	Posts           int
+	BaselineAuthors int
+}
+
+// Trend represents an entry of `trends` table, a hashtag trending at the time
+// the trends were computed. Authors is the number of distinct authors of the Posts.
+type Trend struct {
+	HashtagID uuid.UUID `json:"-"`
+	Tag       string    `json:"tag"`
+	Score     float64   `json:"score"`
+	Posts     int       `json:"post_count"`
+	Authors   int       `json:"-"`
+}
+
+// TrendScore scores the velocity of a hashtag: how far the number of its authors
+// in TrendWindow exceeds the average in the baseline, relative to the deviation
+// expected from the baseline, as a Poisson z-score would do.
+func TrendScore(authors, baselineAuthors int) float64 {
+	expected := float64(baselineAuthors) * float64(TrendWindow) / float64(TrendBaselineWindow)
+	return (float64(authors) - expected) / math.Sqrt(expected+1)
+}
+
+// RankTrends scores the activities, leaves out the ones which are not trending
+// or are near duplicates of higher ranked ones, and returns up to limit trends
+// in descending order of the scores.
+func RankTrends(activities []*TagActivity, limit int) []*Trend {
+	var trends []*Trend
+	for _, activity := range activities {
+		score := TrendScore(activity.Authors, activity.BaselineAuthors)
+		if activity.Authors < MinTrendAuthors || score <= 0 {
+			continue
+		}
+		trends = append(trends, &Trend{
+			HashtagID: activity.HashtagID,
+			Tag:       activity.Tag,
+			Score:     score,
+			Posts:     activity.Posts,
+			Authors:   activity.Authors,
+		})
+	}
+
+	sort.SliceStable(trends, func(i, j int) bool {
+		if trends[i].Score != trends[j].Score {
+			return trends[i].Score > trends[j].Score
+		}
+		return trends[i].Tag < trends[j].Tag
+	})
+
+	ranked := make([]*Trend, 0, limit)
+	for _, trend := range trends {
+		if len(ranked) == limit {
+			break
+		}
+		duplicate := false
+		for _, kept := range ranked {
+			if areNearDuplicateTags(kept.Tag, trend.Tag) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			ranked = append(ranked, trend)
+		}
+	}
+
+	return ranked
+}
+
+// areNearDuplicateTags reports whether two normalized hashtags are most likely
+// the same topic: they are equal apart from underscores, such as "#world_cup"
+// and "#worldcup", or, if they are long enough not to be distinct words,
+// one letter is added, removed or replaced, such as "#worldcup" and "#wordcup".
+func areNearDuplicateTags(a, b string) bool {
+	a, b = strings.ReplaceAll(a, "_", ""), strings.ReplaceAll(b, "_", "")
+	if a == b {
+		return true
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < 6 || len(rb) < 6 {
+		return false
+	}
+
+	return isWithinOneEdit(ra, rb)
+}
+
+// isWithinOneEdit reports whether a can be turned into b by inserting,
+// deleting or replacing at most one rune.
+func isWithinOneEdit(a, b []rune) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(b)-len(a) > 1 {
+		return false
+	}
+
+	i, j, edited := 0, 0, false
+	for i < len(a) && j < len(b) {
+		if a[i] == b[j] {
+			i++
+			j++
+			continue
+		}
+		if edited {
+			return false
+		}
+		edited = true
+		if len(a) == len(b) {
+			i++
+		}
+		j++
+	}
+
+	return true
+}
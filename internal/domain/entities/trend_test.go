@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"testing"
+)
+
+func TestTrendScore(t *testing.T) {
+	if TrendScore(10, 0) <= TrendScore(10, 240) {
+		t.Errorf("Expected a new hashtag to score higher than a usually popular one")
+	}
+	if TrendScore(10, 240) != 0 {
+		t.Errorf("Expected a hashtag as active as usual to score 0, but got %f", TrendScore(10, 240))
+	}
+	if TrendScore(20, 0) <= TrendScore(10, 0) {
+		t.Errorf("Expected more authors to score higher")
+	}
+}
+
+func TestRankTrends(t *testing.T) {
+	activities := []*TagActivity{
+		{Tag: "worldcup", Authors: 30, Posts: 40},
+		{Tag: "world_cup", Authors: 20, Posts: 20},
+		{Tag: "wordcup", Authors: 10, Posts: 10},
+		{Tag: "monday", Authors: 24, Posts: 30, BaselineAuthors: 24 * 24},
+		{Tag: "solo", Authors: 1, Posts: 50},
+		{Tag: "golang", Authors: 5, Posts: 5},
+		{Tag: "go", Authors: 4, Posts: 4},
+		{Tag: "gopher", Authors: 3, Posts: 3},
+	}
+
+	trends := RankTrends(activities, 3)
+
+	expected := []string{"worldcup", "golang", "go"}
+	if len(trends) != len(expected) {
+		t.Fatalf("Expected %d trends, but got %d", len(expected), len(trends))
+	}
+	for i, trend := range trends {
+		if trend.Tag != expected[i] {
+			t.Errorf("Expected %s at %d, but got %s", expected[i], i, trend.Tag)
+		}
+	}
+}
+
+func TestAreNearDuplicateTags(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{a: "world_cup", b: "worldcup", expected: true},
+		{a: "worldcup", b: "wordcup", expected: true},
+		{a: "worldcup", b: "worldcups", expected: true},
+		{a: "worldcup", b: "worldcop", expected: true},
+		{a: "worldcup", b: "wordcop", expected: false},
+		{a: "cats", b: "cars", expected: false},
+		{a: "golang", b: "gopher", expected: false},
+	}
+
+	for _, test := range tests {
+		if actual := areNearDuplicateTags(test.a, test.b); actual != test.expected {
+			t.Errorf("%s and %s: expected %t, but got %t", test.a, test.b, test.expected, actual)
+		}
+	}
+}
@@ -27,3 +27,37 @@ type User struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 	Password    string    `json:"-"`
 }
+
+// UserSummary is a compact representation of a user,
+// embedded in other resources such as posts to describe their author.
+type UserSummary struct {
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	IsPrivate   bool      `json:"is_private"`
+}
+
+// Relationship describes how the viewer relates to another user.
+// FollowRequestSent is true while the viewer's request to follow
+// a private account is waiting for approval.
+type Relationship struct {
+	Following         bool `json:"following"`
+	FollowedBy        bool `json:"followed_by"`
+	Muting            bool `json:"muting"`
+	Blocking          bool `json:"blocking"`
+	FollowRequestSent bool `json:"follow_request_sent"`
+}
+
+// RelatedUser is a user summary annotated with the viewer's relationship to the user.
+// Relationship is nil for anonymous viewers.
+type RelatedUser struct {
+	UserSummary
+	Relationship *Relationship `json:"relationship,omitempty"`
+}
+
+// TimestampedUser is a user summary annotated with the time
+// the listed relationship, such as a mute or a block, was created.
+type TimestampedUser struct {
+	UserSummary
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import "io"
+
+// BlobStore stores the files of media by keys, so that the storage can be
+// switched, for example from the local filesystem to an S3-compatible one,
+// without changing the usecases.
+type BlobStore interface {
+	// Put stores the content read from r under the key, replacing any existing one.
+	Put(key string, r io.Reader) error
+	// Get opens the content stored under the key. It returns an error
+	// satisfying errors.Is(err, fs.ErrNotExist) if there is no such content.
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	// URL returns the URL which clients can fetch the content under the key from.
+	URL(key string) string
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"x-clone-backend/internal/domain/entities"
+)
+
+// BookmarksRepositoryInterface manages the bookmarks of users, which are private to them.
+// A nil folderID means the bookmark is not in any folder.
+type BookmarksRepositoryInterface interface {
+	// AddBookmark bookmarks the post into the folder, or moves the bookmark into the folder
+	// if the post is already bookmarked, and reports whether a new bookmark was created.
+	// It returns ErrBookmarkFolderNotFound if the user has no such folder.
+	AddBookmark(userID, postID string, folderID *string) (bool, error)
+	// RemoveBookmark returns ErrBookmarkNotFound if the user has not bookmarked the post.
+	RemoveBookmark(userID, postID string) error
+	// GetBookmarkedPosts lists the posts bookmarked by the user, only in the folder if given,
+	// in reverse chronological order of the bookmarks. The posts the user can no longer see
+	// are left out.
+	GetBookmarkedPosts(userID string, folderID *string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error)
+
+	// CreateBookmarkFolder returns ErrBookmarkFolderExists if the user has a folder of the name.
+	CreateBookmarkFolder(userID, name string) (*entities.BookmarkFolder, error)
+	// GetBookmarkFolders returns the folders of the user in the order they were created.
+	GetBookmarkFolders(userID string) ([]*entities.BookmarkFolder, error)
+	// GetBookmarkFolder, RenameBookmarkFolder and DeleteBookmarkFolder return
+	// ErrBookmarkFolderNotFound if the user has no such folder.
+	GetBookmarkFolder(userID, folderID string) (*entities.BookmarkFolder, error)
+	RenameBookmarkFolder(userID, folderID, name string) (*entities.BookmarkFolder, error)
+	// DeleteBookmarkFolder deletes the folder along with the bookmarks in it.
+	DeleteBookmarkFolder(userID, folderID string) error
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type DirectMessagesRepositoryInterface interface {
+	// GetOrCreateDirectConversation returns the one-to-one conversation of the two users,
+	// creating it if they have none, and reports whether it was created.
+	// It returns ErrUserNotFound for nonexistent users.
+	GetOrCreateDirectConversation(userID, otherUserID string) (string, bool, error)
+	// CreateGroupConversation creates a group conversation of the participants,
+	// or returns ErrUserNotFound for nonexistent users.
+	CreateGroupConversation(name string, participantIDs []string) (string, error)
+	// GetDirectConversationID returns the one-to-one conversation of the two users,
+	// or ErrConversationNotFound if they have none.
+	GetDirectConversationID(userID, otherUserID string) (string, error)
+	// GetConversation returns the conversation as seen by the participant,
+	// or ErrConversationNotFound if the user does not participate in it.
+	GetConversation(conversationID, userID string) (*entities.Conversation, error)
+	// GetConversations lists the conversations of the user in reverse chronological order
+	// of their last messages, or of their creations if they have no messages yet.
+	GetConversations(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Conversation], error)
+	// GetParticipantIDs returns the participants of the conversation.
+	GetParticipantIDs(conversationID string) ([]uuid.UUID, error)
+	RenameConversation(conversationID, name string) error
+	// AddParticipant adds the user to the conversation and reports whether the user was newly added.
+	// It returns ErrConversationFull if the conversation already has MaxGroupParticipants participants
+	// and the user is not one of them, and ErrUserNotFound for a nonexistent user.
+	AddParticipant(conversationID, userID string) (bool, error)
+	// RemoveParticipant returns ErrConversationNotFound if the user does not participate in the conversation.
+	RemoveParticipant(conversationID, userID string) error
+
+	// CreateMessage stores the message, fills in its ID and CreatedAt,
+	// and marks the conversation as read by the sender.
+	CreateMessage(message *entities.DirectMessage) error
+	// GetMessage returns the message with its reactions,
+	// or ErrDirectMessageNotFound if it is not in the conversation.
+	GetMessage(conversationID, messageID string) (*entities.DirectMessage, error)
+	// GetMessages lists the messages of the conversation in reverse chronological order,
+	// leaving out the ones the user has deleted.
+	GetMessages(conversationID, userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.DirectMessage], error)
+	// DeleteMessage hides the message from the user, or returns ErrDirectMessageNotFound
+	// if the message is not in the conversation or already deleted by the user.
+	DeleteMessage(conversationID, messageID, userID string) error
+	// HasSentMessage reports whether the user has sent a message in the conversation.
+	HasSentMessage(conversationID, userID string) (bool, error)
+	// MarkConversationAsRead returns the read receipt of the user after reading the conversation.
+	MarkConversationAsRead(conversationID, userID string) (*entities.ReadReceipt, error)
+
+	// AddReaction reports whether the user newly reacted to the message with the emoji.
+	AddReaction(messageID, userID, emoji string) (bool, error)
+	// RemoveReaction returns ErrReactionNotFound if the user has not reacted to the message with the emoji.
+	RemoveReaction(messageID, userID, emoji string) error
+
+	GetDirectMessageSettings(userID string) (entities.DirectMessageSettings, error)
+	UpdateDirectMessageSettings(userID string, patch entities.DirectMessageSettingsPatch) (entities.DirectMessageSettings, error)
+}
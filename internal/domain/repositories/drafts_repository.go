@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+	"x-clone-backend/internal/domain/entities"
+)
+
+type DraftsRepositoryInterface interface {
+	// CreateDraft stores the draft, and sets its ID and timestamps.
+	CreateDraft(draft *entities.Draft) error
+	// GetDrafts lists the drafts of the user, the most recently updated first.
+	GetDrafts(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Draft], error)
+	// GetDraft returns ErrDraftNotFound unless the draft belongs to the user.
+	GetDraft(userID, draftID string) (*entities.Draft, error)
+	// UpdateDraft replaces the content of the draft of draft.UserID, and sets its timestamps.
+	// It returns ErrDraftNotFound unless the draft belongs to the user.
+	UpdateDraft(draft *entities.Draft) error
+	// DeleteDraft returns ErrDraftNotFound unless the draft belongs to the user.
+	DeleteDraft(userID, draftID string) error
+	// DeletePublishedDraft deletes the draft of draft.UserID in the transaction publishing it,
+	// locking it until the transaction ends. It returns ErrDraftNotFound unless the draft
+	// belongs to the user, which is the case once it has been published in another transaction,
+	// and ErrDraftChanged if the draft has been updated since it was read.
+	DeletePublishedDraft(tx *sql.Tx, draft *entities.Draft) error
+
+	// DeleteDraftsUpdatedBefore deletes up to limit drafts last updated before the time,
+	// and returns the number of drafts deleted.
+	DeleteDraftsUpdatedBefore(before time.Time, limit int) (int, error)
+}
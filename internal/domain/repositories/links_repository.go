@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"time"
+	"x-clone-backend/internal/domain/entities"
+)
+
+type LinksRepositoryInterface interface {
+	// FollowLink counts a click on the short link of the code and returns its URL.
+	FollowLink(code string) (string, error)
+
+	// ClaimPendingLinks marks up to limit links which are not unfurled yet, and links
+	// whose unfurling has been in progress for longer than staleAfter, as in progress
+	// and returns them, so that concurrent workers never fetch the same page at the same time.
+	ClaimPendingLinks(limit int, staleAfter time.Duration) ([]*entities.Link, error)
+	// UpdateUnfurledLink stores the unfurl status and the preview card of the link.
+	UpdateUnfurledLink(link *entities.Link) error
+}
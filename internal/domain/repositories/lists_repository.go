@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type ListsRepositoryInterface interface {
+	// CreateList stores the list and sets its ID and CreatedAt.
+	CreateList(list *entities.List) error
+	// GetList returns the list with its owner, its counts and whether the viewer
+	// subscribes to it, or ErrListNotFound. Whether the viewer can see it is not checked.
+	GetList(listID, viewerID string) (*entities.List, error)
+	// UpdateList and DeleteList return ErrListNotFound unless the owner has the list.
+	UpdateList(ownerID, listID string, patch entities.ListPatch) error
+	DeleteList(ownerID, listID string) error
+
+	// GetOwnedLists lists the lists owned by the user in reverse chronological order,
+	// leaving out the private ones unless the viewer is the owner.
+	GetOwnedLists(ownerID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.List], error)
+	// GetSubscribedLists lists the lists the user subscribes to in reverse chronological order
+	// of the subscriptions, leaving out the ones turned private or owned by the users
+	// blocked by or blocking the user.
+	GetSubscribedLists(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.List], error)
+
+	// AddListMember and Subscribe are idempotent. They report whether a row was created,
+	// and return ErrUserNotFound for nonexistent users.
+	AddListMember(listID, userID string) (bool, error)
+	// RemoveListMember returns ErrListMemberNotFound if the user is not a member of the list.
+	RemoveListMember(listID, userID string) error
+	// GetListMembers lists the members of the list in reverse chronological order of the additions,
+	// leaving out the users blocked by or blocking the viewer.
+	GetListMembers(listID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error)
+	Subscribe(listID, userID string) (bool, error)
+	// Unsubscribe returns ErrListSubscriptionNotFound if the user does not subscribe to the list.
+	Unsubscribe(listID, userID string) error
+
+	// GetListTimeline lists the posts, quote reposts and reposts by the members of the list
+	// in reverse chronological order, leaving out the ones by or of the users the viewer
+	// cannot see or has muted.
+	GetListTimeline(listID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error)
+	// GetMemberListIDs returns the IDs of the lists the user is a member of.
+	GetMemberListIDs(userID string) ([]string, error)
+	// GetHiddenUserIDs returns which of the users the viewer cannot see or has muted,
+	// whose activities are left out of the timelines of lists.
+	GetHiddenUserIDs(viewerID string, userIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type MediaRepositoryInterface interface {
+	CreateMedia(media *entities.Media) error
+	GetMedia(mediaID string) (*entities.Media, error)
+	// GetMediaByIDs returns the existing media of the IDs; nonexistent ones are left out.
+	GetMediaByIDs(mediaIDs []uuid.UUID) ([]*entities.Media, error)
+	// AttachMedia attaches the media to a post, or to a quote repost if isQuote is true,
+	// in the order of the IDs.
+	AttachMedia(tx *sql.Tx, sourceID uuid.UUID, isQuote bool, mediaIDs []uuid.UUID) error
+	UpdateAltText(mediaID, altText string) error
+
+	// ClaimUnprocessedMedia marks up to limit pending media, and media whose processing
+	// has been in progress for longer than staleAfter, as in progress and returns them,
+	// so that concurrent workers never process the same media at the same time.
+	ClaimUnprocessedMedia(limit int, staleAfter time.Duration) ([]*entities.Media, error)
+	// UpdateProcessedMedia stores the status, the URL, the dimensions, the blurhash,
+	// the renditions, the duration and the processing error of processed media.
+	UpdateProcessedMedia(media *entities.Media) error
+}
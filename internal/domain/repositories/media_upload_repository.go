@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"time"
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type MediaUploadRepositoryInterface interface {
+	CreateMediaUpload(upload *entities.MediaUpload) error
+	// GetMediaUpload returns the upload with its segments sorted by index.
+	GetMediaUpload(uploadID string) (*entities.MediaUpload, error)
+	// GetUploadedBytesSince returns the total size of the uploads the user initialized since then.
+	GetUploadedBytesSince(userID uuid.UUID, since time.Time) (int64, error)
+	// PutUploadSegment stores the segment, replacing the one of the same index,
+	// and returns the storage key of the replaced one if any.
+	// It returns ErrMediaUploadFinalized if the upload has been finalized.
+	PutUploadSegment(uploadID uuid.UUID, segment *entities.UploadSegment) (string, error)
+	// FinalizeMediaUpload marks the upload as finalized and creates its media at once.
+	// It returns ErrMediaUploadFinalized if the upload has been finalized.
+	FinalizeMediaUpload(uploadID uuid.UUID, media *entities.Media) error
+	// GetExpiredMediaUploads returns up to limit uploads which expired before now
+	// without being finalized, with their segments.
+	GetExpiredMediaUploads(now time.Time, limit int) ([]*entities.MediaUpload, error)
+	DeleteMediaUpload(uploadID uuid.UUID) error
+}
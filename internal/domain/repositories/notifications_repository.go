@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"x-clone-backend/internal/domain/entities"
+)
+
+type NotificationsRepositoryInterface interface {
+	// CreateNotification stores the notification and fills in its ID and actor,
+	// unless the recipient's preferences filter it out, the recipient mutes the actor,
+	// or either of them blocks the other. It reports whether the notification was stored.
+	CreateNotification(notification *entities.Notification) (bool, error)
+
+	// GetNotifications lists the grouped notifications of the recipient, most recent first.
+	GetNotifications(recipientID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Notification], error)
+	CountUnreadNotifications(recipientID string) (int, error)
+
+	// MarkNotificationAsRead marks the whole group of the specified notification as read.
+	MarkNotificationAsRead(recipientID, notificationID string) error
+	MarkAllNotificationsAsRead(recipientID string) error
+
+	GetNotificationPreferences(userID string) (entities.NotificationPreferences, error)
+	UpdateNotificationPreferences(userID string, patch entities.NotificationPreferencesPatch) (entities.NotificationPreferences, error)
+}
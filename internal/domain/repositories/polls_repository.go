@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"database/sql"
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+type PollsRepositoryInterface interface {
+	// CreatePoll stores the poll of the post and its choices, and sets the ID of the poll.
+	CreatePoll(tx *sql.Tx, poll *entities.Poll) error
+	// Vote records the vote of the user for the choice at the position in the poll of the post.
+	// It returns ErrPollNotFound if the post has no poll, ErrPollClosed if the poll has ended,
+	// ErrInvalidPollChoice if the poll has no such choice, and ErrAlreadyVoted if the user
+	// has voted in the poll.
+	Vote(postID, userID string, position int) error
+
+	// CloseEndedPolls closes up to limit polls which have ended, storing their final results,
+	// and returns them. Polls are closed only once however many workers close them at a time.
+	CloseEndedPolls(limit int) ([]*entities.Poll, error)
+	// GetPollVoters returns the IDs of the users who voted in the poll.
+	GetPollVoters(pollID uuid.UUID) ([]uuid.UUID, error)
+}
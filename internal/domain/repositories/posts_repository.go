@@ -1,10 +1,60 @@
 package repositories
 
 import (
+	"database/sql"
 	"x-clone-backend/internal/domain/entities"
 )
 
 type PostsRepositoryInterface interface {
+	WithTransaction(fn func(tx *sql.Tx) error) error
+
+	GetPost(postID string) (*entities.Post, error)
 	GetSpecificUserPosts(userID string) ([]*entities.Post, error)
 	GetUserAndFolloweePosts(userID string) ([]*entities.Post, error)
+
+	// EditPost replaces the text of the post unless it has been edited maxEdits times,
+	// in which case it returns ErrPostEditLimitReached, and records the new version,
+	// as well as the original text at the first edit. The mentions of users no longer
+	// mentioned, the hashtags and the links are removed, so that they can be created
+	// again from the new text in tx; the mentions left are kept so as not to notify the users again.
+	EditPost(tx *sql.Tx, postID, text string, maxEdits int) (*entities.PostEdit, error)
+	// GetPostHistory lists the versions of the post, the latest first.
+	GetPostHistory(postID string) ([]*entities.PostEdit, error)
+
+	// HydratePosts fills in the author, the engagement counts and
+	// the viewer flags of the given posts in batch.
+	// The viewer flags are left false if viewerID is empty, the bookmark counts are
+	// only filled in for the viewer's own posts, and the vote counts of polls
+	// are hidden unless Poll.ResultsVisible to the viewer.
+	HydratePosts(viewerID string, posts []*entities.Post) error
+	// HydrateQuotes fills in the authors and the entities of the given quote reposts in batch.
+	HydrateQuotes(quotes []*entities.Repost) error
+
+	// CreateMentions resolves the usernames mentioned in the text of the post,
+	// or the quote repost if isQuote is true, and records the mentioned users.
+	// It returns the mentions of existing users.
+	CreateMentions(tx *sql.Tx, sourceID string, isQuote bool, text string) ([]*entities.Mention, error)
+	// CreateLinks shortens the URLs in the text of the post, or the quote repost
+	// if isQuote is true, reusing the short links of URLs posted before, and records
+	// the links of the post. It returns the URLs with their short links.
+	// The short links are created outside tx, as they are shared by all posts.
+	CreateLinks(tx *sql.Tx, sourceID string, isQuote bool, text string) ([]*entities.URLEntity, error)
+	// CreateHashtags records the hashtags in the text of the post.
+	CreateHashtags(tx *sql.Tx, postID, text string) error
+	// GetMentionTimeline lists the posts and the quote reposts mentioning the user,
+	// most recent first, leaving out the ones by authors the user cannot see.
+	GetMentionTimeline(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error)
+	// SearchPosts lists the posts and the quote reposts matching the query in the order,
+	// leaving out the ones by authors the viewer cannot see or mutes.
+	// The cursor must have a rank if, and only if, the results are ordered by relevance.
+	SearchPosts(query *entities.SearchQuery, order entities.SearchOrder, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error)
+
+	// The following methods list the engagements visible to the viewer,
+	// in reverse chronological order of the engagements.
+	GetPostLikers(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error)
+	GetPostReposters(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error)
+	GetPostQuotes(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Repost], error)
+	GetLikedPosts(userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error)
+	// GetHashtagPosts lists the posts with the normalized hashtag, most recent first.
+	GetHashtagPosts(tag, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error)
 }
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+	"x-clone-backend/internal/domain/entities"
+)
+
+type ScheduledPostsRepositoryInterface interface {
+	// CreateScheduledPost stores the scheduled post, and sets its ID and creation time.
+	CreateScheduledPost(post *entities.ScheduledPost) error
+	// GetScheduledPosts lists the posts of the user waiting to be published, the soonest first.
+	GetScheduledPosts(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.ScheduledPost], error)
+	// ReschedulePost changes when the post of the user is published and returns it,
+	// giving a post which failed to be published another MaxPublishAttempts attempts.
+	// It returns ErrScheduledPostNotFound unless the post is waiting to be published.
+	ReschedulePost(userID, scheduledPostID string, scheduledAt time.Time) (*entities.ScheduledPost, error)
+	// DeleteScheduledPost cancels the post of the user waiting to be published.
+	// It returns ErrScheduledPostNotFound unless the post is waiting to be published.
+	DeleteScheduledPost(userID, scheduledPostID string) error
+
+	// PublishDuePosts creates the posts of up to limit scheduled posts which are due,
+	// and returns the scheduled posts with PostID and PublishedAt set. Scheduled posts are
+	// published only once however many workers publish them at a time.
+	// publish is called with each scheduled post once its post is created, to write the rest
+	// of the post in the same transaction. If it returns an error, the post is left unpublished
+	// to be published again after PublishRetryDelay, and the other posts are published.
+	// Once a post has failed MaxPublishAttempts times, its FailedAt is set and it is no
+	// longer published unless it is rescheduled.
+	PublishDuePosts(limit int, publish func(tx *sql.Tx, post *entities.ScheduledPost) error) ([]*entities.ScheduledPost, error)
+}
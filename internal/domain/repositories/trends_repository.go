@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"time"
+	"x-clone-backend/internal/domain/entities"
+)
+
+type TrendsRepositoryInterface interface {
+	// GetTagActivities counts the public posts of the hashtags used in the TrendWindow
+	// ending at now by at least MinTrendAuthors authors, along with their baselines.
+	GetTagActivities(now time.Time) ([]*entities.TagActivity, error)
+	// ReplaceTrends replaces the stored trends with the ones computed at now.
+	ReplaceTrends(trends []*entities.Trend, now time.Time) error
+
+	// GetTrends returns up to limit stored trends as seen by the viewer, in descending
+	// order of the scores. The posts by users the viewer mutes or blocks, or is blocked by,
+	// are not counted, and the scores are scaled down by the share of the authors left,
+	// so that trends made by such users fall behind or disappear.
+	GetTrends(viewerID string, limit int) ([]*entities.Trend, error)
+}
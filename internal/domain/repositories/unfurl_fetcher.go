@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"x-clone-backend/internal/domain/entities"
+)
+
+// UnfurlFetcher fetches the pages of links to build their preview cards,
+// so that the usecases do not depend on how pages are fetched and parsed.
+type UnfurlFetcher interface {
+	// Fetch returns the preview card of the page at the URL,
+	// or nil if the page has no metadata to preview.
+	Fetch(ctx context.Context, url string) (*entities.LinkCard, error)
+}
@@ -14,12 +14,41 @@ type UsersRepositoryInterface interface {
 	DeleteUser(tx *sql.Tx, userID string) error
 	GetSpecificUser(tx *sql.Tx, userID string) (entities.User, error)
 	UserByUsername(tx *sql.Tx, userName string) (entities.User, error)
-	LikePost(tx *sql.Tx, userID string, postID uuid.UUID) error
+
+	// LikePost, FollowUser, MuteUser, BlockUser and RequestFollow are idempotent.
+	// They report whether a row was created, and return ErrUserNotFound or ErrPostNotFound
+	// for nonexistent users or posts and ErrSelfAction for the user themself.
+	LikePost(tx *sql.Tx, userID string, postID uuid.UUID) (bool, error)
 	UnlikePost(tx *sql.Tx, userID string, postID string) error
-	FollowUser(tx *sql.Tx, sourceUserID, targetUserID string) error
+	FollowUser(tx *sql.Tx, sourceUserID, targetUserID string) (bool, error)
 	UnfollowUser(tx *sql.Tx, sourceUserID, targetUserID string) error
-	MuteUser(tx *sql.Tx, sourceUserID, targetUserID string) error
+	MuteUser(tx *sql.Tx, sourceUserID, targetUserID string) (bool, error)
 	UnmuteUser(tx *sql.Tx, sourceUserID, targetUserID string) error
-	BlockUser(tx *sql.Tx, sourceUserID, targetUserID string) error
+	BlockUser(tx *sql.Tx, sourceUserID, targetUserID string) (bool, error)
 	UnblockUser(tx *sql.Tx, sourceUserID, targetUserID string) error
+
+	// CheckVisibility returns ErrBlocked or ErrPrivateAccount
+	// if the viewer is not allowed to see the target user's activities.
+	CheckVisibility(tx *sql.Tx, targetUserID, viewerID string) error
+
+	GetFollowers(tx *sql.Tx, userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error)
+	GetFollowing(tx *sql.Tx, userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error)
+	// GetFollowerIDs returns the IDs of all the followers of the user,
+	// to whose timelines the user's new posts are pushed.
+	GetFollowerIDs(tx *sql.Tx, userID string) ([]string, error)
+	GetRelationships(tx *sql.Tx, viewerID string, userIDs []string) (map[uuid.UUID]*entities.Relationship, error)
+	RequestFollow(tx *sql.Tx, sourceUserID, targetUserID string) (bool, error)
+	DeleteFollowRequest(tx *sql.Tx, sourceUserID, targetUserID string) error
+
+	// GetMutedUsers and GetBlockedUsers list the users muted or blocked by the specified user,
+	// in reverse chronological order of the mutes or blocks.
+	GetMutedUsers(tx *sql.Tx, userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimestampedUser], error)
+	GetBlockedUsers(tx *sql.Tx, userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimestampedUser], error)
+
+	// SearchUsers finds the users whose usernames or display names contain words similar
+	// to the query, and TypeaheadUsers the ones whose usernames or display names start
+	// with the prefix. Users followed by or following the viewer are ranked higher,
+	// and users blocked by or blocking the viewer are left out.
+	SearchUsers(tx *sql.Tx, query, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error)
+	TypeaheadUsers(tx *sql.Tx, prefix, viewerID string, limit int) ([]*entities.UserSummary, error)
 }
@@ -0,0 +1,196 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type BookmarksRepository struct {
+	DB *sql.DB
+}
+
+func NewBookmarksRepository(db *sql.DB) repositories.BookmarksRepositoryInterface {
+	return &BookmarksRepository{db}
+}
+
+func (r *BookmarksRepository) AddBookmark(userID, postID string, folderID *string) (bool, error) {
+	// xmax is zero only for the rows inserted, not for the ones updated on conflict.
+	query := `
+		INSERT INTO bookmarks (user_id, post_id, folder_id) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, post_id) DO UPDATE SET folder_id = EXCLUDED.folder_id
+		RETURNING xmax = 0
+	`
+	var created bool
+	err := r.DB.QueryRow(query, userID, postID, folderID).Scan(&created)
+	if err != nil {
+		if isForeignKeyViolation(err, "bookmarks_folder_id_user_id_fkey") {
+			return false, errors.ErrBookmarkFolderNotFound
+		}
+		return false, translateConstraintError(err)
+	}
+
+	return created, nil
+}
+
+func (r *BookmarksRepository) RemoveBookmark(userID, postID string) error {
+	query := `DELETE FROM bookmarks WHERE user_id = $1 AND post_id = $2`
+	res, err := r.DB.Exec(query, userID, postID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrBookmarkNotFound
+	}
+
+	return nil
+}
+
+func (r *BookmarksRepository) GetBookmarkedPosts(userID string, folderID *string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error) {
+	query := `
+		SELECT posts.id, posts.user_id, posts.text, posts.created_at, bookmarks.created_at
+		FROM bookmarks
+		JOIN posts ON posts.id = bookmarks.post_id
+		JOIN users u ON u.id = posts.user_id
+		WHERE bookmarks.user_id = $1
+		AND ($2::uuid IS NULL OR bookmarks.folder_id = $2::uuid)
+		AND ($3::timestamptz IS NULL OR (bookmarks.created_at, bookmarks.post_id) < ($3::timestamptz, $4::uuid))
+		AND ` + visibleUserCondition("u", "$1::uuid") + `
+		ORDER BY bookmarks.created_at DESC, bookmarks.post_id DESC
+		LIMIT $5
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, userID, folderID, cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		posts   []*entities.Post
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		var (
+			post         entities.Post
+			bookmarkedAt time.Time
+		)
+		if err := rows.Scan(&post.ID, &post.UserID, &post.Text, &post.CreatedAt, &bookmarkedAt); err != nil {
+			return entities.Page[*entities.Post]{}, err
+		}
+		posts = append(posts, &post)
+		cursors = append(cursors, entities.Cursor{Time: bookmarkedAt, ID: post.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+
+	return newPage(posts, cursors, limit), nil
+}
+
+func (r *BookmarksRepository) CreateBookmarkFolder(userID, name string) (*entities.BookmarkFolder, error) {
+	query := `
+		INSERT INTO bookmark_folders (user_id, name) VALUES ($1, $2)
+		RETURNING ` + bookmarkFolderColumns
+	folder, err := scanBookmarkFolder(r.DB.QueryRow(query, userID, name))
+	if err != nil {
+		if isUniqueViolation(err, "bookmark_folders_user_id_name_key") {
+			return nil, errors.ErrBookmarkFolderExists
+		}
+		return nil, translateConstraintError(err)
+	}
+
+	return folder, nil
+}
+
+func (r *BookmarksRepository) GetBookmarkFolders(userID string) ([]*entities.BookmarkFolder, error) {
+	query := `
+		SELECT ` + bookmarkFolderColumns + `
+		FROM bookmark_folders
+		WHERE user_id = $1
+		ORDER BY created_at, id
+	`
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	folders := []*entities.BookmarkFolder{}
+	for rows.Next() {
+		folder, err := scanBookmarkFolder(rows)
+		if err != nil {
+			return nil, err
+		}
+		folders = append(folders, folder)
+	}
+
+	return folders, rows.Err()
+}
+
+func (r *BookmarksRepository) GetBookmarkFolder(userID, folderID string) (*entities.BookmarkFolder, error) {
+	query := `SELECT ` + bookmarkFolderColumns + ` FROM bookmark_folders WHERE id = $1 AND user_id = $2`
+
+	folder, err := scanBookmarkFolder(r.DB.QueryRow(query, folderID, userID))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrBookmarkFolderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+func (r *BookmarksRepository) RenameBookmarkFolder(userID, folderID, name string) (*entities.BookmarkFolder, error) {
+	query := `
+		UPDATE bookmark_folders SET name = $3
+		WHERE id = $1 AND user_id = $2
+		RETURNING ` + bookmarkFolderColumns
+	folder, err := scanBookmarkFolder(r.DB.QueryRow(query, folderID, userID, name))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrBookmarkFolderNotFound
+	}
+	if err != nil {
+		if isUniqueViolation(err, "bookmark_folders_user_id_name_key") {
+			return nil, errors.ErrBookmarkFolderExists
+		}
+		return nil, err
+	}
+
+	return folder, nil
+}
+
+func (r *BookmarksRepository) DeleteBookmarkFolder(userID, folderID string) error {
+	query := `DELETE FROM bookmark_folders WHERE id = $1 AND user_id = $2`
+	res, err := r.DB.Exec(query, folderID, userID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrBookmarkFolderNotFound
+	}
+
+	return nil
+}
+
+const bookmarkFolderColumns = `id, user_id, name, created_at`
+
+func scanBookmarkFolder(row interface{ Scan(...any) error }) (*entities.BookmarkFolder, error) {
+	var folder entities.BookmarkFolder
+	if err := row.Scan(&folder.ID, &folder.UserID, &folder.Name, &folder.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &folder, nil
+}
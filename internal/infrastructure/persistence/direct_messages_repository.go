@@ -0,0 +1,688 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type DirectMessagesRepository struct {
+	DB *sql.DB
+}
+
+func NewDirectMessagesRepository(db *sql.DB) repositories.DirectMessagesRepositoryInterface {
+	return &DirectMessagesRepository{db}
+}
+
+func (r *DirectMessagesRepository) GetOrCreateDirectConversation(userID, otherUserID string) (string, bool, error) {
+	userIDs := []string{userID, otherUserID}
+	sort.Strings(userIDs)
+
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return "", false, err
+	}
+	defer tx.Rollback()
+
+	// Updating the conflicting conversation makes RETURNING yield the existing one as well,
+	// and xmax is zero only for the rows inserted.
+	query := `
+		INSERT INTO conversations (direct_key) VALUES ($1)
+		ON CONFLICT (direct_key) DO UPDATE SET direct_key = EXCLUDED.direct_key
+		RETURNING id, xmax = 0
+	`
+	var (
+		conversationID string
+		created        bool
+	)
+	err = tx.QueryRow(query, directKey(userID, otherUserID)).Scan(&conversationID, &created)
+	if err != nil {
+		return "", false, err
+	}
+
+	if created {
+		query = `
+			INSERT INTO conversation_participants (conversation_id, user_id)
+			SELECT $1, UNNEST($2::uuid[])
+		`
+		if _, err := tx.Exec(query, conversationID, userIDs); err != nil {
+			return "", false, translateConstraintError(err)
+		}
+	}
+
+	return conversationID, created, tx.Commit()
+}
+
+func (r *DirectMessagesRepository) CreateGroupConversation(name string, participantIDs []string) (string, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var conversationID string
+	err = tx.QueryRow(`INSERT INTO conversations (name) VALUES ($1) RETURNING id`, name).Scan(&conversationID)
+	if err != nil {
+		return "", err
+	}
+
+	query := `
+		INSERT INTO conversation_participants (conversation_id, user_id)
+		SELECT $1, UNNEST($2::uuid[])
+	`
+	if _, err := tx.Exec(query, conversationID, participantIDs); err != nil {
+		return "", translateConstraintError(err)
+	}
+
+	return conversationID, tx.Commit()
+}
+
+func (r *DirectMessagesRepository) GetDirectConversationID(userID, otherUserID string) (string, error) {
+	query := `SELECT id FROM conversations WHERE direct_key = $1`
+
+	var conversationID string
+	err := r.DB.QueryRow(query, directKey(userID, otherUserID)).Scan(&conversationID)
+	if err == sql.ErrNoRows {
+		return "", errors.ErrConversationNotFound
+	}
+
+	return conversationID, err
+}
+
+func (r *DirectMessagesRepository) GetConversation(conversationID, userID string) (*entities.Conversation, error) {
+	query := `
+		SELECT ` + conversationColumns + `
+		FROM conversation_participants cp
+		JOIN conversations c ON c.id = cp.conversation_id
+		WHERE cp.conversation_id = $1 AND cp.user_id = $2
+	`
+	rows, err := r.DB.Query(query, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conversations, _, err := r.scanConversations(rows, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(conversations) == 0 {
+		return nil, errors.ErrConversationNotFound
+	}
+
+	return conversations[0], nil
+}
+
+func (r *DirectMessagesRepository) GetConversations(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Conversation], error) {
+	query := `
+		SELECT ` + conversationColumns + `
+		FROM conversation_participants cp
+		JOIN conversations c ON c.id = cp.conversation_id
+		WHERE cp.user_id = $1
+		AND ($2::timestamptz IS NULL OR (COALESCE(c.last_message_at, c.created_at), c.id) < ($2::timestamptz, $3::uuid))
+		ORDER BY COALESCE(c.last_message_at, c.created_at) DESC, c.id DESC
+		LIMIT $4
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, userID, cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.Conversation]{}, err
+	}
+	defer rows.Close()
+
+	conversations, cursors, err := r.scanConversations(rows, userID)
+	if err != nil {
+		return entities.Page[*entities.Conversation]{}, err
+	}
+
+	return newPage(conversations, cursors, limit), nil
+}
+
+func (r *DirectMessagesRepository) GetParticipantIDs(conversationID string) ([]uuid.UUID, error) {
+	query := `SELECT user_id FROM conversation_participants WHERE conversation_id = $1 ORDER BY created_at, user_id`
+
+	rows, err := r.DB.Query(query, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+func (r *DirectMessagesRepository) RenameConversation(conversationID, name string) error {
+	res, err := r.DB.Exec(`UPDATE conversations SET name = $2 WHERE id = $1`, conversationID, name)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrConversationNotFound
+	}
+
+	return nil
+}
+
+func (r *DirectMessagesRepository) AddParticipant(conversationID, userID string) (bool, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Locking the conversation keeps concurrent additions from exceeding the limit.
+	// Adding an existing participant succeeds even to a full conversation.
+	query := `
+		SELECT COUNT(*), COALESCE(BOOL_OR(user_id = $2), FALSE) FROM conversation_participants
+		WHERE conversation_id = (SELECT id FROM conversations WHERE id = $1 FOR UPDATE)
+	`
+	var (
+		count     int
+		isPresent bool
+	)
+	if err := tx.QueryRow(query, conversationID, userID).Scan(&count, &isPresent); err != nil {
+		return false, err
+	}
+	if isPresent {
+		return false, nil
+	}
+	if count >= entities.MaxGroupParticipants {
+		return false, errors.ErrConversationFull
+	}
+
+	query = `
+		INSERT INTO conversation_participants (conversation_id, user_id) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	res, err := tx.Exec(query, conversationID, userID)
+	if err != nil {
+		return false, translateConstraintError(err)
+	}
+	added, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return added == 1, tx.Commit()
+}
+
+func (r *DirectMessagesRepository) RemoveParticipant(conversationID, userID string) error {
+	query := `DELETE FROM conversation_participants WHERE conversation_id = $1 AND user_id = $2`
+
+	res, err := r.DB.Exec(query, conversationID, userID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrConversationNotFound
+	}
+
+	return nil
+}
+
+func (r *DirectMessagesRepository) CreateMessage(message *entities.DirectMessage) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO direct_messages (conversation_id, sender_id, type, text, target_user_id) VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(
+		query,
+		message.ConversationID, message.SenderID, message.Type, message.Text, message.TargetUserID,
+	).Scan(&message.ID, &message.CreatedAt)
+	if err != nil {
+		return translateConstraintError(err)
+	}
+
+	query = `UPDATE conversations SET last_message_at = $2 WHERE id = $1`
+	if _, err := tx.Exec(query, message.ConversationID, message.CreatedAt); err != nil {
+		return err
+	}
+
+	// Replying to a conversation implies having read it.
+	query = `
+		UPDATE conversation_participants SET last_read_at = GREATEST(last_read_at, $3)
+		WHERE conversation_id = $1 AND user_id = $2
+	`
+	if _, err := tx.Exec(query, message.ConversationID, message.SenderID, message.CreatedAt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *DirectMessagesRepository) GetMessage(conversationID, messageID string) (*entities.DirectMessage, error) {
+	query := `
+		SELECT ` + directMessageColumns + `
+		FROM direct_messages m
+		WHERE m.id = $2 AND m.conversation_id = $1
+	`
+	message, err := scanDirectMessage(r.DB.QueryRow(query, conversationID, messageID))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrDirectMessageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.hydrateReactions([]*entities.DirectMessage{message})
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func (r *DirectMessagesRepository) GetMessages(conversationID, userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.DirectMessage], error) {
+	query := `
+		SELECT ` + directMessageColumns + `
+		FROM direct_messages m
+		WHERE m.conversation_id = $1
+		AND NOT EXISTS (SELECT 1 FROM direct_message_deletions WHERE message_id = m.id AND user_id = $2)
+		AND ($3::timestamptz IS NULL OR (m.created_at, m.id) < ($3::timestamptz, $4::uuid))
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT $5
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, conversationID, userID, cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.DirectMessage]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		messages []*entities.DirectMessage
+		cursors  []entities.Cursor
+	)
+	for rows.Next() {
+		message, err := scanDirectMessage(rows)
+		if err != nil {
+			return entities.Page[*entities.DirectMessage]{}, err
+		}
+		messages = append(messages, message)
+		cursors = append(cursors, entities.Cursor{Time: message.CreatedAt, ID: message.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.DirectMessage]{}, err
+	}
+
+	err = r.hydrateReactions(messages)
+	if err != nil {
+		return entities.Page[*entities.DirectMessage]{}, err
+	}
+
+	return newPage(messages, cursors, limit), nil
+}
+
+func (r *DirectMessagesRepository) DeleteMessage(conversationID, messageID, userID string) error {
+	query := `
+		INSERT INTO direct_message_deletions (message_id, user_id)
+		SELECT m.id, cp.user_id
+		FROM direct_messages m
+		JOIN conversation_participants cp ON cp.conversation_id = m.conversation_id AND cp.user_id = $3
+		WHERE m.id = $2 AND m.conversation_id = $1
+		ON CONFLICT DO NOTHING
+	`
+	res, err := r.DB.Exec(query, conversationID, messageID, userID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrDirectMessageNotFound
+	}
+
+	return nil
+}
+
+func (r *DirectMessagesRepository) HasSentMessage(conversationID, userID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM direct_messages WHERE conversation_id = $1 AND sender_id = $2)`
+
+	var sent bool
+	err := r.DB.QueryRow(query, conversationID, userID).Scan(&sent)
+	return sent, err
+}
+
+func (r *DirectMessagesRepository) MarkConversationAsRead(conversationID, userID string) (*entities.ReadReceipt, error) {
+	query := `
+		UPDATE conversation_participants SET last_read_at = CURRENT_TIMESTAMP
+		WHERE conversation_id = $1 AND user_id = $2
+		RETURNING conversation_id, user_id, last_read_at
+	`
+	var receipt entities.ReadReceipt
+	err := r.DB.QueryRow(query, conversationID, userID).Scan(&receipt.ConversationID, &receipt.UserID, &receipt.ReadAt)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrConversationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &receipt, nil
+}
+
+func (r *DirectMessagesRepository) AddReaction(messageID, userID, emoji string) (bool, error) {
+	query := `
+		INSERT INTO direct_message_reactions (message_id, user_id, emoji) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+	res, err := r.DB.Exec(query, messageID, userID, emoji)
+	if err != nil {
+		if isForeignKeyViolation(err, "direct_message_reactions_message_id_fkey") {
+			return false, errors.ErrDirectMessageNotFound
+		}
+		return false, translateConstraintError(err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
+
+func (r *DirectMessagesRepository) RemoveReaction(messageID, userID, emoji string) error {
+	query := `DELETE FROM direct_message_reactions WHERE message_id = $1 AND user_id = $2 AND emoji = $3`
+
+	res, err := r.DB.Exec(query, messageID, userID, emoji)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrReactionNotFound
+	}
+
+	return nil
+}
+
+func (r *DirectMessagesRepository) GetDirectMessageSettings(userID string) (entities.DirectMessageSettings, error) {
+	query := `SELECT allow_from FROM direct_message_settings WHERE user_id = $1`
+
+	var settings entities.DirectMessageSettings
+	err := r.DB.QueryRow(query, userID).Scan(&settings.AllowFrom)
+	if err == sql.ErrNoRows {
+		return entities.DefaultDirectMessageSettings(), nil
+	}
+
+	return settings, err
+}
+
+func (r *DirectMessagesRepository) UpdateDirectMessageSettings(userID string, patch entities.DirectMessageSettingsPatch) (entities.DirectMessageSettings, error) {
+	defaults := entities.DefaultDirectMessageSettings()
+	query := `
+		INSERT INTO direct_message_settings AS s (user_id, allow_from)
+		VALUES ($1, COALESCE($2::text, $3::text))
+		ON CONFLICT (user_id) DO UPDATE SET
+			allow_from = COALESCE($2::text, s.allow_from),
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING allow_from
+	`
+	var settings entities.DirectMessageSettings
+	err := r.DB.QueryRow(query, userID, patch.AllowFrom, defaults.AllowFrom).Scan(&settings.AllowFrom)
+	if err != nil {
+		return entities.DirectMessageSettings{}, translateConstraintError(err)
+	}
+
+	return settings, nil
+}
+
+// conversationColumns are the columns scanned by scanConversations, which are relative
+// to the participant cp: the unread messages are the ones by the other participants
+// sent after cp last read the conversation, except the ones cp has deleted.
+const conversationColumns = `
+	c.id, c.direct_key IS NULL, c.name, c.created_at, COALESCE(c.last_message_at, c.created_at),
+	(
+		SELECT COUNT(*) FROM direct_messages m
+		WHERE m.conversation_id = c.id
+		AND m.sender_id <> cp.user_id
+		AND m.created_at > cp.last_read_at
+		AND NOT EXISTS (SELECT 1 FROM direct_message_deletions WHERE message_id = m.id AND user_id = cp.user_id)
+	)`
+
+// scanConversations scans rows consisting of conversationColumns, and fills in the participants,
+// their read receipts and the last messages of the conversations seen by the user.
+func (r *DirectMessagesRepository) scanConversations(rows *sql.Rows, userID string) ([]*entities.Conversation, []entities.Cursor, error) {
+	var (
+		conversations   []*entities.Conversation
+		cursors         []entities.Cursor
+		conversationIDs []string
+	)
+	for rows.Next() {
+		var (
+			conversation entities.Conversation
+			orderedAt    time.Time
+		)
+		if err := rows.Scan(
+			&conversation.ID,
+			&conversation.IsGroup,
+			&conversation.Name,
+			&conversation.CreatedAt,
+			&orderedAt,
+			&conversation.UnreadCount,
+		); err != nil {
+			return nil, nil, err
+		}
+		conversations = append(conversations, &conversation)
+		cursors = append(cursors, entities.Cursor{Time: orderedAt, ID: conversation.ID})
+		conversationIDs = append(conversationIDs, conversation.ID.String())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(conversations) == 0 {
+		return conversations, cursors, nil
+	}
+
+	participants, receipts, err := r.getParticipants(conversationIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	lastMessages, err := r.getLastMessages(conversationIDs, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, conversation := range conversations {
+		conversation.Participants = participants[conversation.ID]
+		conversation.ReadReceipts = receipts[conversation.ID]
+		conversation.LastMessage = lastMessages[conversation.ID]
+	}
+
+	return conversations, cursors, nil
+}
+
+// getParticipants loads the participants of the specified conversations
+// and their read receipts in the order they joined.
+func (r *DirectMessagesRepository) getParticipants(conversationIDs []string) (map[uuid.UUID][]*entities.UserSummary, map[uuid.UUID][]*entities.ReadReceipt, error) {
+	query := `
+		SELECT cp.conversation_id, cp.last_read_at, u.id, u.username, u.display_name, u.is_private
+		FROM conversation_participants cp
+		JOIN users u ON u.id = cp.user_id
+		WHERE cp.conversation_id = ANY($1::uuid[])
+		ORDER BY cp.created_at, u.id
+	`
+	rows, err := r.DB.Query(query, conversationIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	participants := make(map[uuid.UUID][]*entities.UserSummary)
+	receipts := make(map[uuid.UUID][]*entities.ReadReceipt)
+	for rows.Next() {
+		var (
+			receipt     entities.ReadReceipt
+			user        entities.UserSummary
+			displayName sql.NullString
+			isPrivate   sql.NullBool
+		)
+		if err := rows.Scan(&receipt.ConversationID, &receipt.ReadAt, &user.ID, &user.Username, &displayName, &isPrivate); err != nil {
+			return nil, nil, err
+		}
+		user.DisplayName = displayName.String
+		user.IsPrivate = isPrivate.Bool
+		receipt.UserID = user.ID
+		participants[receipt.ConversationID] = append(participants[receipt.ConversationID], &user)
+		receipts[receipt.ConversationID] = append(receipts[receipt.ConversationID], &receipt)
+	}
+
+	return participants, receipts, rows.Err()
+}
+
+// getLastMessages loads the last messages of the specified conversations
+// which the user has not deleted.
+func (r *DirectMessagesRepository) getLastMessages(conversationIDs []string, userID string) (map[uuid.UUID]*entities.DirectMessage, error) {
+	query := `
+		SELECT DISTINCT ON (m.conversation_id) ` + directMessageColumns + `
+		FROM direct_messages m
+		WHERE m.conversation_id = ANY($1::uuid[])
+		AND NOT EXISTS (SELECT 1 FROM direct_message_deletions WHERE message_id = m.id AND user_id = $2)
+		ORDER BY m.conversation_id, m.created_at DESC, m.id DESC
+	`
+	rows, err := r.DB.Query(query, conversationIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*entities.DirectMessage
+	for rows.Next() {
+		message, err := scanDirectMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	err = r.hydrateReactions(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	lastMessages := make(map[uuid.UUID]*entities.DirectMessage)
+	for _, message := range messages {
+		lastMessages[message.ConversationID] = message
+	}
+
+	return lastMessages, nil
+}
+
+// hydrateReactions fills in the reactions of the messages,
+// ordered by the first reaction with each emoji.
+func (r *DirectMessagesRepository) hydrateReactions(messages []*entities.DirectMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	messagesByID := make(map[uuid.UUID]*entities.DirectMessage)
+	var messageIDs []string
+	for _, message := range messages {
+		messagesByID[message.ID] = message
+		messageIDs = append(messageIDs, message.ID.String())
+	}
+
+	query := `
+		SELECT message_id, emoji, STRING_AGG(user_id::text, ',' ORDER BY created_at, user_id)
+		FROM direct_message_reactions
+		WHERE message_id = ANY($1::uuid[])
+		GROUP BY message_id, emoji
+		ORDER BY MIN(created_at), emoji
+	`
+	rows, err := r.DB.Query(query, messageIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			messageID uuid.UUID
+			reaction  entities.Reaction
+			userIDs   string
+		)
+		if err := rows.Scan(&messageID, &reaction.Emoji, &userIDs); err != nil {
+			return err
+		}
+		for _, id := range strings.Split(userIDs, ",") {
+			userID, err := uuid.Parse(id)
+			if err != nil {
+				return err
+			}
+			reaction.UserIDs = append(reaction.UserIDs, userID)
+		}
+
+		message := messagesByID[messageID]
+		message.Reactions = append(message.Reactions, &reaction)
+	}
+
+	return rows.Err()
+}
+
+// directKey identifies the one-to-one conversation of the two users regardless of their order.
+func directKey(userID, otherUserID string) string {
+	userIDs := []string{userID, otherUserID}
+	sort.Strings(userIDs)
+	return strings.Join(userIDs, ":")
+}
+
+const directMessageColumns = `m.id, m.conversation_id, m.sender_id, m.type, m.text, m.target_user_id, m.created_at`
+
+func scanDirectMessage(row interface{ Scan(...any) error }) (*entities.DirectMessage, error) {
+	var (
+		message      entities.DirectMessage
+		targetUserID uuid.NullUUID
+	)
+	err := row.Scan(
+		&message.ID,
+		&message.ConversationID,
+		&message.SenderID,
+		&message.Type,
+		&message.Text,
+		&targetUserID,
+		&message.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if targetUserID.Valid {
+		message.TargetUserID = &targetUserID.UUID
+	}
+
+	return &message, nil
+}
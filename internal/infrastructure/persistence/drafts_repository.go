@@ -0,0 +1,193 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type DraftsRepository struct {
+	DB *sql.DB
+}
+
+func NewDraftsRepository(db *sql.DB) repositories.DraftsRepositoryInterface {
+	return &DraftsRepository{db}
+}
+
+// draftColumns lists the columns scanned by scanDraft.
+const draftColumns = `id, user_id, text, ARRAY_TO_STRING(media_ids, ','), poll, quoted_post_id, created_at, updated_at`
+
+func (r *DraftsRepository) CreateDraft(draft *entities.Draft) error {
+	poll, err := marshalPendingPoll(draft.Poll)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO drafts (user_id, text, media_ids, poll, quoted_post_id)
+		VALUES ($1, $2, $3::uuid[], $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	err = r.DB.QueryRow(query, draft.UserID, draft.Text, mediaIDStrings(draft.MediaIDs), poll, draft.QuotedPostID).
+		Scan(&draft.ID, &draft.CreatedAt, &draft.UpdatedAt)
+	if err != nil {
+		return translateConstraintError(err)
+	}
+
+	return nil
+}
+
+func (r *DraftsRepository) GetDrafts(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Draft], error) {
+	query := `
+		SELECT ` + draftColumns + `
+		FROM drafts
+		WHERE user_id = $1
+		AND ($2::timestamptz IS NULL OR (updated_at, id) < ($2::timestamptz, $3::uuid))
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $4
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, userID, cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.Draft]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		drafts  []*entities.Draft
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		draft, err := scanDraft(rows)
+		if err != nil {
+			return entities.Page[*entities.Draft]{}, err
+		}
+		drafts = append(drafts, draft)
+		cursors = append(cursors, entities.Cursor{Time: draft.UpdatedAt, ID: draft.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.Draft]{}, err
+	}
+
+	return newPage(drafts, cursors, limit), nil
+}
+
+func (r *DraftsRepository) GetDraft(userID, draftID string) (*entities.Draft, error) {
+	query := `SELECT ` + draftColumns + ` FROM drafts WHERE id = $1 AND user_id = $2`
+
+	draft, err := scanDraft(r.DB.QueryRow(query, draftID, userID))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrDraftNotFound
+	}
+
+	return draft, err
+}
+
+func (r *DraftsRepository) UpdateDraft(draft *entities.Draft) error {
+	poll, err := marshalPendingPoll(draft.Poll)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE drafts
+		SET text = $3, media_ids = $4::uuid[], poll = $5, quoted_post_id = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2
+		RETURNING created_at, updated_at
+	`
+	err = r.DB.QueryRow(query, draft.ID, draft.UserID, draft.Text, mediaIDStrings(draft.MediaIDs), poll, draft.QuotedPostID).
+		Scan(&draft.CreatedAt, &draft.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return errors.ErrDraftNotFound
+	}
+
+	return err
+}
+
+func (r *DraftsRepository) DeleteDraft(userID, draftID string) error {
+	query := `DELETE FROM drafts WHERE id = $1 AND user_id = $2`
+	res, err := r.DB.Exec(query, draftID, userID)
+	if err != nil {
+		return err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.ErrDraftNotFound
+	}
+
+	return nil
+}
+
+func (r *DraftsRepository) DeletePublishedDraft(tx *sql.Tx, draft *entities.Draft) error {
+	// The draft is locked until the transaction publishing it ends, so that concurrent
+	// transactions publishing it wait, and find it deleted once it has been published.
+	var unchanged bool
+	query := `SELECT updated_at = $3 FROM drafts WHERE id = $1 AND user_id = $2 FOR UPDATE`
+	err := tx.QueryRow(query, draft.ID, draft.UserID, draft.UpdatedAt).Scan(&unchanged)
+	if err == sql.ErrNoRows {
+		return errors.ErrDraftNotFound
+	} else if err != nil {
+		return err
+	}
+	if !unchanged {
+		return errors.ErrDraftChanged
+	}
+
+	_, err = tx.Exec(`DELETE FROM drafts WHERE id = $1`, draft.ID)
+	return err
+}
+
+func (r *DraftsRepository) DeleteDraftsUpdatedBefore(before time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM drafts
+		WHERE id IN (SELECT id FROM drafts WHERE updated_at < $1 LIMIT $2)
+	`
+	res, err := r.DB.Exec(query, before, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+func scanDraft(row interface{ Scan(...any) error }) (*entities.Draft, error) {
+	var (
+		draft        entities.Draft
+		mediaIDs     string
+		poll         []byte
+		quotedPostID uuid.NullUUID
+	)
+	err := row.Scan(&draft.ID, &draft.UserID, &draft.Text, &mediaIDs, &poll, &quotedPostID, &draft.CreatedAt, &draft.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	draft.MediaIDs, err = parseMediaIDs(mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	if poll != nil {
+		if err := json.Unmarshal(poll, &draft.Poll); err != nil {
+			return nil, err
+		}
+	}
+	if quotedPostID.Valid {
+		draft.QuotedPostID = &quotedPostID.UUID
+	}
+
+	return &draft, nil
+}
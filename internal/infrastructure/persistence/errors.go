@@ -0,0 +1,47 @@
+package infrastructure
+
+import (
+	stderrors "errors"
+	"strings"
+	"x-clone-backend/internal/app/errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// translateConstraintError converts constraint violations reported by PostgreSQL
+// into domain errors. Other errors are returned as they are.
+//
+// A foreign key violation means the referenced user or post does not exist,
+// which is told apart by the name of the violated constraint.
+// A check violation means a user tried to follow, mute or block themselves.
+func translateConstraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if !stderrors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case pgerrcode.ForeignKeyViolation:
+		if strings.Contains(pgErr.ConstraintName, "post_id") {
+			return errors.ErrPostNotFound
+		}
+		return errors.ErrUserNotFound
+	case pgerrcode.CheckViolation:
+		return errors.ErrSelfAction
+	}
+
+	return err
+}
+
+// isUniqueViolation reports whether the error is a violation of the unique constraint.
+func isUniqueViolation(err error, constraintName string) bool {
+	var pgErr *pgconn.PgError
+	return stderrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == constraintName
+}
+
+// isForeignKeyViolation reports whether the error is a violation of the foreign key constraint.
+func isForeignKeyViolation(err error, constraintName string) bool {
+	var pgErr *pgconn.PgError
+	return stderrors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.ConstraintName == constraintName
+}
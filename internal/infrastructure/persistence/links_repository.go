@@ -0,0 +1,105 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type LinksRepository struct {
+	DB *sql.DB
+}
+
+func NewLinksRepository(db *sql.DB) repositories.LinksRepositoryInterface {
+	return &LinksRepository{db}
+}
+
+func (r *LinksRepository) FollowLink(code string) (string, error) {
+	query := `UPDATE links SET click_count = click_count + 1 WHERE code = $1 RETURNING url`
+
+	var url string
+	err := r.DB.QueryRow(query, code).Scan(&url)
+	if err == sql.ErrNoRows {
+		return "", errors.ErrLinkNotFound
+	}
+
+	return url, err
+}
+
+func (r *LinksRepository) ClaimPendingLinks(limit int, staleAfter time.Duration) ([]*entities.Link, error) {
+	query := `
+		UPDATE links SET unfurl_status = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM links
+			WHERE unfurl_status = $4
+			OR (unfurl_status = $3 AND updated_at < CURRENT_TIMESTAMP - make_interval(secs => $2))
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + linkColumns("links")
+
+	rows, err := r.DB.Query(query, limit, staleAfter.Seconds(), entities.UnfurlInProgress, entities.UnfurlPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanLinkRows(rows)
+}
+
+func (r *LinksRepository) UpdateUnfurledLink(link *entities.Link) error {
+	var card []byte
+	if link.Card != nil {
+		var err error
+		card, err = json.Marshal(link.Card)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := `UPDATE links SET unfurl_status = $2, card = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	_, err := r.DB.Exec(query, link.ID, link.UnfurlStatus, card)
+	return err
+}
+
+// linkColumns returns the columns of the links table aliased as alias, which scanLink scans.
+func linkColumns(alias string) string {
+	return alias + `.id, ` + alias + `.code, ` + alias + `.url, ` + alias + `.click_count, ` +
+		alias + `.unfurl_status, ` + alias + `.card, ` + alias + `.created_at`
+}
+
+// scanLink scans a row consisting of linkColumns, preceded by dest if any.
+func scanLink(row interface{ Scan(...any) error }, dest ...any) (*entities.Link, error) {
+	var (
+		link entities.Link
+		card []byte
+	)
+	dest = append(dest, &link.ID, &link.Code, &link.URL, &link.ClickCount, &link.UnfurlStatus, &card, &link.CreatedAt)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if card != nil {
+		if err := json.Unmarshal(card, &link.Card); err != nil {
+			return nil, err
+		}
+	}
+
+	return &link, nil
+}
+
+func scanLinkRows(rows *sql.Rows) ([]*entities.Link, error) {
+	var links []*entities.Link
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
@@ -0,0 +1,452 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type ListsRepository struct {
+	DB *sql.DB
+}
+
+func NewListsRepository(db *sql.DB) repositories.ListsRepositoryInterface {
+	return &ListsRepository{db}
+}
+
+func (r *ListsRepository) CreateList(list *entities.List) error {
+	query := `
+		INSERT INTO lists (owner_id, name, description, is_private) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err := r.DB.QueryRow(query, list.OwnerID, list.Name, list.Description, list.IsPrivate).Scan(&list.ID, &list.CreatedAt)
+	if err != nil {
+		return translateConstraintError(err)
+	}
+
+	return nil
+}
+
+func (r *ListsRepository) GetList(listID, viewerID string) (*entities.List, error) {
+	query := `SELECT ` + listColumns("$2::uuid") + ` FROM lists l WHERE l.id = $1`
+
+	rows, err := r.DB.Query(query, listID, nullableUUID(viewerID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists, _, err := r.scanLists(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(lists) == 0 {
+		return nil, errors.ErrListNotFound
+	}
+
+	return lists[0], nil
+}
+
+func (r *ListsRepository) UpdateList(ownerID, listID string, patch entities.ListPatch) error {
+	query := `
+		UPDATE lists SET
+			name = COALESCE($3::text, name),
+			description = COALESCE($4::text, description),
+			is_private = COALESCE($5::boolean, is_private),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND owner_id = $2
+	`
+	res, err := r.DB.Exec(query, listID, ownerID, patch.Name, patch.Description, patch.IsPrivate)
+	if err != nil {
+		return err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrListNotFound
+	}
+
+	return nil
+}
+
+func (r *ListsRepository) DeleteList(ownerID, listID string) error {
+	query := `DELETE FROM lists WHERE id = $1 AND owner_id = $2`
+	res, err := r.DB.Exec(query, listID, ownerID)
+	if err != nil {
+		return err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrListNotFound
+	}
+
+	return nil
+}
+
+func (r *ListsRepository) GetOwnedLists(ownerID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.List], error) {
+	query := `
+		SELECT ` + listColumns("$2::uuid") + `, l.created_at
+		FROM lists l
+		WHERE l.owner_id = $1
+		AND (NOT l.is_private OR l.owner_id = $2::uuid)
+		AND ($3::timestamptz IS NULL OR (l.created_at, l.id) < ($3::timestamptz, $4::uuid))
+		ORDER BY l.created_at DESC, l.id DESC
+		LIMIT $5
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, ownerID, nullableUUID(viewerID), cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.List]{}, err
+	}
+	defer rows.Close()
+
+	lists, cursors, err := r.scanLists(rows)
+	if err != nil {
+		return entities.Page[*entities.List]{}, err
+	}
+
+	return newPage(lists, cursors, limit), nil
+}
+
+func (r *ListsRepository) GetSubscribedLists(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.List], error) {
+	query := `
+		SELECT ` + listColumns("$1::uuid") + `, ls.created_at
+		FROM list_subscriptions ls
+		JOIN lists l ON l.id = ls.list_id
+		JOIN users o ON o.id = l.owner_id
+		WHERE ls.user_id = $1
+		AND NOT l.is_private
+		AND ` + notBlockedCondition("o", "$1::uuid") + `
+		AND ($2::timestamptz IS NULL OR (ls.created_at, l.id) < ($2::timestamptz, $3::uuid))
+		ORDER BY ls.created_at DESC, l.id DESC
+		LIMIT $4
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, userID, cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.List]{}, err
+	}
+	defer rows.Close()
+
+	lists, cursors, err := r.scanLists(rows)
+	if err != nil {
+		return entities.Page[*entities.List]{}, err
+	}
+
+	return newPage(lists, cursors, limit), nil
+}
+
+func (r *ListsRepository) AddListMember(listID, userID string) (bool, error) {
+	query := `INSERT INTO list_members (list_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	res, err := r.DB.Exec(query, listID, userID)
+	if err != nil {
+		if isForeignKeyViolation(err, "list_members_list_id_fkey") {
+			return false, errors.ErrListNotFound
+		}
+		return false, translateConstraintError(err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
+
+func (r *ListsRepository) RemoveListMember(listID, userID string) error {
+	query := `DELETE FROM list_members WHERE list_id = $1 AND user_id = $2`
+	res, err := r.DB.Exec(query, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrListMemberNotFound
+	}
+
+	return nil
+}
+
+func (r *ListsRepository) GetListMembers(listID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error) {
+	query := `
+		SELECT u.id, u.username, u.display_name, u.is_private, lm.created_at
+		FROM list_members lm
+		JOIN users u ON u.id = lm.user_id
+		WHERE lm.list_id = $1
+		AND ` + notBlockedCondition("u", "$2::uuid") + `
+		AND ($3::timestamptz IS NULL OR (lm.created_at, u.id) < ($3::timestamptz, $4::uuid))
+		ORDER BY lm.created_at DESC, u.id DESC
+		LIMIT $5
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, listID, nullableUUID(viewerID), cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+	defer rows.Close()
+
+	return scanUserSummaryPage(rows, limit)
+}
+
+func (r *ListsRepository) Subscribe(listID, userID string) (bool, error) {
+	query := `INSERT INTO list_subscriptions (list_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	res, err := r.DB.Exec(query, listID, userID)
+	if err != nil {
+		if isForeignKeyViolation(err, "list_subscriptions_list_id_fkey") {
+			return false, errors.ErrListNotFound
+		}
+		return false, translateConstraintError(err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
+
+func (r *ListsRepository) Unsubscribe(listID, userID string) error {
+	query := `DELETE FROM list_subscriptions WHERE list_id = $1 AND user_id = $2`
+	res, err := r.DB.Exec(query, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrListSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (r *ListsRepository) GetListTimeline(listID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error) {
+	// A quote repost by a member appears as is, while a plain repost by a member
+	// appears as the reposted post or quote repost along with the repost.
+	query := `
+		WITH activities AS (
+			SELECT p.id, p.created_at, p.user_id AS actor_id,
+				p.id AS post_id, NULL::uuid AS quote_id, NULL::uuid AS repost_id
+			FROM list_members lm
+			JOIN posts p ON p.user_id = lm.user_id
+			WHERE lm.list_id = $1
+			UNION ALL
+			SELECT r.id, r.created_at, r.user_id,
+				CASE WHEN r.is_quote THEN NULL ELSE r.parent_post_id END,
+				CASE WHEN r.is_quote THEN r.id ELSE r.parent_repost_id END,
+				CASE WHEN r.is_quote THEN NULL ELSE r.id END
+			FROM list_members lm
+			JOIN reposts r ON r.user_id = lm.user_id
+			WHERE lm.list_id = $1
+		)
+		SELECT
+			a.id, a.created_at,
+			p.id, p.user_id, p.text, p.created_at,
+			q.id, COALESCE(q.parent_post_id, q.parent_repost_id), q.user_id, q.text, q.created_at,
+			rp.id, COALESCE(rp.parent_post_id, rp.parent_repost_id), rp.user_id, rp.created_at
+		FROM activities a
+		LEFT JOIN posts p ON p.id = a.post_id
+		LEFT JOIN reposts q ON q.id = a.quote_id AND q.is_quote
+		LEFT JOIN reposts rp ON rp.id = a.repost_id
+		JOIN users m ON m.id = a.actor_id
+		JOIN users u ON u.id = COALESCE(p.user_id, q.user_id)
+		WHERE ($3::timestamptz IS NULL OR (a.created_at, a.id) < ($3::timestamptz, $4::uuid))
+		AND ` + visibleUserCondition("m", "$2::uuid") + `
+		AND ` + visibleUserCondition("u", "$2::uuid") + `
+		AND NOT EXISTS (SELECT 1 FROM mutes WHERE source_user_id = $2::uuid AND target_user_id IN (m.id, u.id))
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT $5
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, listID, nullableUUID(viewerID), cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		items   []*entities.TimelineItem
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		var (
+			c                                      entities.Cursor
+			postID, postUserID                     uuid.NullUUID
+			quoteID, quoteParentID, quoteUserID    uuid.NullUUID
+			repostID, repostParentID, repostUserID uuid.NullUUID
+			postText, quoteText                    sql.NullString
+			postCreatedAt, quoteCreatedAt          sql.NullTime
+			repostCreatedAt                        sql.NullTime
+		)
+		err := rows.Scan(
+			&c.ID, &c.Time,
+			&postID, &postUserID, &postText, &postCreatedAt,
+			&quoteID, &quoteParentID, &quoteUserID, &quoteText, &quoteCreatedAt,
+			&repostID, &repostParentID, &repostUserID, &repostCreatedAt,
+		)
+		if err != nil {
+			return entities.Page[*entities.TimelineItem]{}, err
+		}
+
+		var item entities.TimelineItem
+		if postID.Valid {
+			item.Post = &entities.Post{
+				ID:        postID.UUID,
+				UserID:    postUserID.UUID,
+				Text:      postText.String,
+				CreatedAt: postCreatedAt.Time,
+			}
+		} else {
+			item.Quote = &entities.Repost{
+				ID:        quoteID.UUID,
+				ParentID:  quoteParentID.UUID,
+				UserID:    quoteUserID.UUID,
+				Text:      quoteText.String,
+				CreatedAt: quoteCreatedAt.Time,
+			}
+		}
+		if repostID.Valid {
+			item.Repost = &entities.Repost{
+				ID:        repostID.UUID,
+				ParentID:  repostParentID.UUID,
+				UserID:    repostUserID.UUID,
+				CreatedAt: repostCreatedAt.Time,
+			}
+		}
+		items = append(items, &item)
+		cursors = append(cursors, c)
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+
+	return newPage(items, cursors, limit), nil
+}
+
+func (r *ListsRepository) GetMemberListIDs(userID string) ([]string, error) {
+	query := `SELECT list_id FROM list_members WHERE user_id = $1`
+
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var listIDs []string
+	for rows.Next() {
+		var listID string
+		if err := rows.Scan(&listID); err != nil {
+			return nil, err
+		}
+		listIDs = append(listIDs, listID)
+	}
+
+	return listIDs, rows.Err()
+}
+
+func (r *ListsRepository) GetHiddenUserIDs(viewerID string, userIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	query := `
+		SELECT u.id
+		FROM users u
+		WHERE u.id = ANY($2::uuid[])
+		AND (
+			NOT ` + visibleUserCondition("u", "$1::uuid") + `
+			OR EXISTS (SELECT 1 FROM mutes WHERE source_user_id = $1::uuid AND target_user_id = u.id)
+		)
+	`
+	rows, err := r.DB.Query(query, nullableUUID(viewerID), userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hidden := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		hidden[userID] = true
+	}
+
+	return hidden, rows.Err()
+}
+
+// listColumns returns the columns scanned by scanLists, where viewerParam is
+// the viewer whose subscription is checked.
+func listColumns(viewerParam string) string {
+	return fmt.Sprintf(`
+		l.id, l.owner_id, l.name, l.description, l.is_private, l.created_at,
+		(SELECT COUNT(*) FROM list_members WHERE list_id = l.id),
+		(SELECT COUNT(*) FROM list_subscriptions WHERE list_id = l.id),
+		EXISTS (SELECT 1 FROM list_subscriptions WHERE list_id = l.id AND user_id = %s)`, viewerParam)
+}
+
+// scanLists scans rows consisting of listColumns, optionally followed by the time
+// they are ordered by, and fills in the owners of the lists.
+func (r *ListsRepository) scanLists(rows *sql.Rows) ([]*entities.List, []entities.Cursor, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		lists    []*entities.List
+		cursors  []entities.Cursor
+		ownerIDs []string
+	)
+	for rows.Next() {
+		var (
+			list      entities.List
+			orderedAt time.Time
+		)
+		dest := []any{
+			&list.ID, &list.OwnerID, &list.Name, &list.Description, &list.IsPrivate, &list.CreatedAt,
+			&list.MemberCount, &list.SubscriberCount, &list.SubscribedByMe,
+		}
+		if len(columns) > len(dest) {
+			dest = append(dest, &orderedAt)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, err
+		}
+		lists = append(lists, &list)
+		cursors = append(cursors, entities.Cursor{Time: orderedAt, ID: list.ID})
+		ownerIDs = append(ownerIDs, list.OwnerID.String())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(lists) == 0 {
+		return lists, cursors, nil
+	}
+
+	owners, err := getUserSummaries(r.DB, ownerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, list := range lists {
+		list.Owner = owners[list.OwnerID]
+	}
+
+	return lists, cursors, nil
+}
@@ -0,0 +1,210 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type MediaRepository struct {
+	DB *sql.DB
+}
+
+func NewMediaRepository(db *sql.DB) repositories.MediaRepositoryInterface {
+	return &MediaRepository{db}
+}
+
+func (r *MediaRepository) CreateMedia(media *entities.Media) error {
+	query := `
+		INSERT INTO media (id, user_id, content_type, size, storage_key, url, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`
+	return r.DB.QueryRow(query, media.ID, media.UserID, media.ContentType, media.Size, media.StorageKey, media.URL, media.Status).Scan(&media.CreatedAt)
+}
+
+func (r *MediaRepository) GetMedia(mediaID string) (*entities.Media, error) {
+	query := `SELECT ` + mediaColumns("media") + ` FROM media WHERE id = $1`
+
+	media, err := scanMedia(r.DB.QueryRow(query, mediaID))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+func (r *MediaRepository) GetMediaByIDs(mediaIDs []uuid.UUID) ([]*entities.Media, error) {
+	query := `SELECT ` + mediaColumns("media") + ` FROM media WHERE id = ANY($1::uuid[])`
+
+	rows, err := r.DB.Query(query, uuidStrings(mediaIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMediaRows(rows)
+}
+
+func (r *MediaRepository) AttachMedia(tx *sql.Tx, sourceID uuid.UUID, isQuote bool, mediaIDs []uuid.UUID) error {
+	if len(mediaIDs) == 0 {
+		return nil
+	}
+
+	sourceColumn := "post_id"
+	if isQuote {
+		sourceColumn = "repost_id"
+	}
+
+	query := `
+		INSERT INTO post_media (` + sourceColumn + `, media_id, position)
+		SELECT $1, media_id, position
+		FROM UNNEST($2::uuid[]) WITH ORDINALITY AS t(media_id, position)
+	`
+	var err error
+	if tx != nil {
+		_, err = tx.Exec(query, sourceID, uuidStrings(mediaIDs))
+	} else {
+		_, err = r.DB.Exec(query, sourceID, uuidStrings(mediaIDs))
+	}
+	return err
+}
+
+func (r *MediaRepository) UpdateAltText(mediaID, altText string) error {
+	query := `UPDATE media SET alt_text = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	res, err := r.DB.Exec(query, mediaID, altText)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.ErrMediaNotFound
+	}
+
+	return nil
+}
+
+func (r *MediaRepository) ClaimUnprocessedMedia(limit int, staleAfter time.Duration) ([]*entities.Media, error) {
+	query := `
+		UPDATE media SET status = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM media
+			WHERE status = $4
+			OR (status = $3 AND updated_at < CURRENT_TIMESTAMP - make_interval(secs => $2))
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + mediaColumns("media")
+
+	rows, err := r.DB.Query(query, limit, staleAfter.Seconds(), entities.MediaInProgress, entities.MediaPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMediaRows(rows)
+}
+
+func (r *MediaRepository) UpdateProcessedMedia(media *entities.Media) error {
+	renditions, err := json.Marshal(media.Renditions)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE media
+		SET status = $2, url = $3, width = $4, height = $5, blurhash = $6, renditions = $7,
+			duration_ms = $8, processing_error = $9, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+	_, err = r.DB.Exec(
+		query, media.ID, media.Status, media.URL, nullableInt(media.Width), nullableInt(media.Height), nullableString(media.Blurhash), renditions,
+		nullableInt(media.DurationMs), media.ProcessingError,
+	)
+	return err
+}
+
+// mediaColumns returns the columns of the media table aliased as alias, which scanMedia scans.
+func mediaColumns(alias string) string {
+	return alias + `.id, ` + alias + `.user_id, ` + alias + `.content_type, ` + alias + `.size, ` +
+		alias + `.status, ` + alias + `.url, ` + alias + `.width, ` + alias + `.height, ` +
+		alias + `.blurhash, ` + alias + `.alt_text, ` + alias + `.renditions, ` +
+		alias + `.duration_ms, ` + alias + `.processing_error, ` +
+		alias + `.storage_key, ` + alias + `.created_at`
+}
+
+// scanMedia scans a row consisting of mediaColumns, preceded by dest if any.
+func scanMedia(row interface{ Scan(...any) error }, dest ...any) (*entities.Media, error) {
+	var (
+		media         entities.Media
+		width, height sql.NullInt64
+		durationMs    sql.NullInt64
+		blurhash      sql.NullString
+		renditions    []byte
+	)
+	dest = append(dest,
+		&media.ID, &media.UserID, &media.ContentType, &media.Size,
+		&media.Status, &media.URL, &width, &height,
+		&blurhash, &media.AltText, &renditions,
+		&durationMs, &media.ProcessingError,
+		&media.StorageKey, &media.CreatedAt,
+	)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	media.Width = int(width.Int64)
+	media.Height = int(height.Int64)
+	media.Blurhash = blurhash.String
+	media.DurationMs = int(durationMs.Int64)
+	if err := json.Unmarshal(renditions, &media.Renditions); err != nil {
+		return nil, err
+	}
+
+	return &media, nil
+}
+
+func scanMediaRows(rows *sql.Rows) ([]*entities.Media, error) {
+	var media []*entities.Media
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return nil, err
+		}
+		media = append(media, m)
+	}
+
+	return media, rows.Err()
+}
+
+// uuidStrings converts the IDs into strings, which are passed as a uuid[] parameter.
+func uuidStrings(ids []uuid.UUID) []string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, id.String())
+	}
+
+	return strs
+}
+
+// nullableInt and nullableString convert zero values into NULL,
+// for the columns which are unset until media is processed.
+func nullableInt(n int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(n), Valid: n != 0}
+}
+
+func nullableString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
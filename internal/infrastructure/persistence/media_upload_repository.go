@@ -0,0 +1,228 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type MediaUploadRepository struct {
+	DB *sql.DB
+}
+
+func NewMediaUploadRepository(db *sql.DB) repositories.MediaUploadRepositoryInterface {
+	return &MediaUploadRepository{db}
+}
+
+const mediaUploadColumns = `id, user_id, content_type, total_bytes, sha256, finalized_at, expires_at, created_at`
+
+func (r *MediaUploadRepository) CreateMediaUpload(upload *entities.MediaUpload) error {
+	query := `
+		INSERT INTO media_uploads (id, user_id, content_type, total_bytes, sha256, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+	return r.DB.QueryRow(query, upload.ID, upload.UserID, upload.ContentType, upload.TotalBytes, upload.SHA256, upload.ExpiresAt).Scan(&upload.CreatedAt)
+}
+
+func (r *MediaUploadRepository) GetMediaUpload(uploadID string) (*entities.MediaUpload, error) {
+	query := `SELECT ` + mediaUploadColumns + ` FROM media_uploads WHERE id = $1`
+
+	upload, err := scanMediaUpload(r.DB.QueryRow(query, uploadID))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrMediaUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := r.getUploadSegments([]string{upload.ID.String()})
+	if err != nil {
+		return nil, err
+	}
+	upload.Segments = append(upload.Segments, segments[upload.ID]...)
+
+	return upload, nil
+}
+
+func (r *MediaUploadRepository) GetUploadedBytesSince(userID uuid.UUID, since time.Time) (int64, error) {
+	query := `SELECT COALESCE(SUM(total_bytes), 0) FROM media_uploads WHERE user_id = $1 AND created_at >= $2`
+
+	var total int64
+	err := r.DB.QueryRow(query, userID, since).Scan(&total)
+	return total, err
+}
+
+func (r *MediaUploadRepository) PutUploadSegment(uploadID uuid.UUID, segment *entities.UploadSegment) (string, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	// The upload is locked so that the segment is never stored after the upload
+	// is finalized, and the replaced segment is read without a race.
+	var finalizedAt sql.NullTime
+	err = tx.QueryRow(`SELECT finalized_at FROM media_uploads WHERE id = $1 FOR UPDATE`, uploadID).Scan(&finalizedAt)
+	if err == sql.ErrNoRows {
+		return "", errors.ErrMediaUploadNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if finalizedAt.Valid {
+		return "", errors.ErrMediaUploadFinalized
+	}
+
+	var replacedKey string
+	err = tx.QueryRow(
+		`SELECT storage_key FROM media_upload_segments WHERE upload_id = $1 AND segment_index = $2`,
+		uploadID, segment.Index,
+	).Scan(&replacedKey)
+	if err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+
+	query := `
+		INSERT INTO media_upload_segments (upload_id, segment_index, size, sha256, storage_key)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (upload_id, segment_index)
+		DO UPDATE SET size = EXCLUDED.size, sha256 = EXCLUDED.sha256, storage_key = EXCLUDED.storage_key, created_at = CURRENT_TIMESTAMP
+	`
+	if _, err := tx.Exec(query, uploadID, segment.Index, segment.Size, segment.SHA256, segment.StorageKey); err != nil {
+		return "", err
+	}
+
+	return replacedKey, tx.Commit()
+}
+
+func (r *MediaUploadRepository) FinalizeMediaUpload(uploadID uuid.UUID, media *entities.Media) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`UPDATE media_uploads SET finalized_at = CURRENT_TIMESTAMP WHERE id = $1 AND finalized_at IS NULL`, uploadID)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.ErrMediaUploadFinalized
+	}
+
+	query := `
+		INSERT INTO media (id, user_id, content_type, size, storage_key, url, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`
+	err = tx.QueryRow(query, media.ID, media.UserID, media.ContentType, media.Size, media.StorageKey, media.URL, media.Status).Scan(&media.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *MediaUploadRepository) GetExpiredMediaUploads(now time.Time, limit int) ([]*entities.MediaUpload, error) {
+	query := `
+		SELECT ` + mediaUploadColumns + `
+		FROM media_uploads
+		WHERE finalized_at IS NULL AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2
+	`
+	rows, err := r.DB.Query(query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		uploads   []*entities.MediaUpload
+		uploadIDs []string
+	)
+	for rows.Next() {
+		upload, err := scanMediaUpload(rows)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, upload)
+		uploadIDs = append(uploadIDs, upload.ID.String())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	segments, err := r.getUploadSegments(uploadIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, upload := range uploads {
+		upload.Segments = append(upload.Segments, segments[upload.ID]...)
+	}
+
+	return uploads, nil
+}
+
+func (r *MediaUploadRepository) DeleteMediaUpload(uploadID uuid.UUID) error {
+	_, err := r.DB.Exec(`DELETE FROM media_uploads WHERE id = $1`, uploadID)
+	return err
+}
+
+// getUploadSegments loads the segments of the uploads sorted by index.
+func (r *MediaUploadRepository) getUploadSegments(uploadIDs []string) (map[uuid.UUID][]*entities.UploadSegment, error) {
+	query := `
+		SELECT upload_id, segment_index, size, sha256, storage_key
+		FROM media_upload_segments
+		WHERE upload_id = ANY($1::uuid[])
+		ORDER BY segment_index
+	`
+	rows, err := r.DB.Query(query, uploadIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	segments := make(map[uuid.UUID][]*entities.UploadSegment)
+	for rows.Next() {
+		var (
+			uploadID uuid.UUID
+			segment  entities.UploadSegment
+		)
+		if err := rows.Scan(&uploadID, &segment.Index, &segment.Size, &segment.SHA256, &segment.StorageKey); err != nil {
+			return nil, err
+		}
+		segments[uploadID] = append(segments[uploadID], &segment)
+	}
+
+	return segments, rows.Err()
+}
+
+func scanMediaUpload(row interface{ Scan(...any) error }) (*entities.MediaUpload, error) {
+	var (
+		upload      entities.MediaUpload
+		finalizedAt sql.NullTime
+	)
+	err := row.Scan(
+		&upload.ID, &upload.UserID, &upload.ContentType, &upload.TotalBytes, &upload.SHA256,
+		&finalizedAt, &upload.ExpiresAt, &upload.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if finalizedAt.Valid {
+		upload.FinalizedAt = &finalizedAt.Time
+	}
+	upload.Segments = []*entities.UploadSegment{}
+
+	return &upload, nil
+}
@@ -0,0 +1,338 @@
+package infrastructure
+
+import (
+	"database/sql"
+	stderrors "errors"
+	"strings"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+// maxNotificationActors is the maximum number of actors returned per grouped notification.
+const maxNotificationActors = 3
+
+type NotificationsRepository struct {
+	DB *sql.DB
+}
+
+func NewNotificationsRepository(db *sql.DB) repositories.NotificationsRepositoryInterface {
+	return &NotificationsRepository{db}
+}
+
+func (r *NotificationsRepository) CreateNotification(notification *entities.Notification) (bool, error) {
+	// Preferences are applied here rather than when notifications are listed,
+	// so that changing them only affects notifications to come. Notifications of
+	// closed polls are not activities of strangers, so only mutes and blocks apply to them.
+	query := `
+		WITH inserted AS (
+			INSERT INTO notifications (recipient_id, actor_id, type, post_id, source_id, group_key, created_at)
+			SELECT $1::uuid, $2::uuid, $3::varchar, $4::uuid, $5::uuid, NULLIF($6::text, ''), $7::timestamptz
+			FROM users actor
+			LEFT JOIN notification_preferences p ON p.user_id = $1::uuid
+			WHERE actor.id = $2::uuid
+			AND COALESCE(
+				CASE $3::varchar
+					WHEN 'like' THEN p.likes
+					WHEN 'repost' THEN p.reposts
+					WHEN 'quote' THEN p.reposts
+					WHEN 'follow' THEN p.follows
+					WHEN 'poll_closed' THEN TRUE
+					ELSE p.mentions
+				END,
+				TRUE
+			)
+			AND ($3::varchar = 'poll_closed' OR (
+				(
+					NOT COALESCE(p.only_from_following, FALSE)
+					OR EXISTS (SELECT 1 FROM followships WHERE source_user_id = $1::uuid AND target_user_id = $2::uuid)
+				)
+				AND (NOT COALESCE(p.filter_no_bio, FALSE) OR COALESCE(actor.bio, '') <> '')
+				AND (NOT COALESCE(p.filter_new_accounts, FALSE) OR actor.created_at <= $7::timestamptz - make_interval(secs => $8))
+			))
+			AND NOT EXISTS (SELECT 1 FROM mutes WHERE source_user_id = $1::uuid AND target_user_id = $2::uuid)
+			AND NOT EXISTS (
+				SELECT 1 FROM blocks
+				WHERE (source_user_id = $1::uuid AND target_user_id = $2::uuid)
+				OR (source_user_id = $2::uuid AND target_user_id = $1::uuid)
+			)
+			RETURNING id, actor_id
+		)
+		SELECT inserted.id, u.id, u.username, u.display_name, u.is_private
+		FROM inserted
+		JOIN users u ON u.id = inserted.actor_id
+	`
+	var (
+		actor       entities.UserSummary
+		displayName sql.NullString
+		isPrivate   sql.NullBool
+	)
+	err := r.DB.QueryRow(
+		query,
+		notification.RecipientID,
+		notification.ActorID,
+		string(notification.Type),
+		notification.PostID,
+		notification.SourceID,
+		notification.GroupKey(),
+		notification.CreatedAt,
+		entities.NewAccountPeriod.Seconds(),
+	).Scan(&notification.ID, &actor.ID, &actor.Username, &displayName, &isPrivate)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, translateConstraintError(err)
+	}
+	actor.DisplayName = displayName.String
+	actor.IsPrivate = isPrivate.Bool
+
+	notification.Actors = []*entities.UserSummary{&actor}
+	notification.ActorCount = 1
+	return true, nil
+}
+
+func (r *NotificationsRepository) GetNotifications(recipientID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Notification], error) {
+	// The cursor and the limit are applied to the most recent notification of each group
+	// before its members are aggregated, so a page costs as much as the groups on it.
+	// Groups never span days (see Notification.GroupKey), which bounds both the lookup
+	// of more recent members and the aggregation.
+	query := `
+		WITH heads AS (
+			SELECT n.id, n.type, n.post_id, n.source_id, n.group_key, n.created_at
+			FROM notifications n
+			WHERE n.recipient_id = $1
+			AND ($2::timestamptz IS NULL OR (n.created_at, n.id) < ($2::timestamptz, $3::uuid))
+			AND NOT EXISTS (
+				SELECT 1 FROM notifications later
+				WHERE later.recipient_id = n.recipient_id
+				AND later.group_key = n.group_key
+				AND (later.created_at, later.id) > (n.created_at, n.id)
+			)
+			ORDER BY n.created_at DESC, n.id DESC
+			LIMIT $4
+		)
+		SELECT h.id, h.type, h.post_id, h.source_id, members.actor_ids, members.actor_count, members.read, h.created_at
+		FROM heads h
+		CROSS JOIN LATERAL (
+			SELECT
+				ARRAY_TO_STRING((ARRAY_AGG(m.actor_id::text ORDER BY m.created_at DESC, m.id DESC))[1:20], ',') AS actor_ids,
+				COUNT(DISTINCT m.actor_id) AS actor_count,
+				BOOL_AND(m.read_at IS NOT NULL) AS read
+			FROM notifications m
+			WHERE m.recipient_id = $1
+			AND (m.id = h.id OR m.group_key = h.group_key)
+		) members
+		ORDER BY h.created_at DESC, h.id DESC
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, recipientID, cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.Notification]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		notifications []*entities.Notification
+		actorIDs      [][]string
+		cursors       []entities.Cursor
+		allActorIDs   []string
+	)
+	for rows.Next() {
+		var (
+			notification entities.Notification
+			postID       uuid.NullUUID
+			sourceID     uuid.NullUUID
+			actorIDList  string
+		)
+		err := rows.Scan(
+			&notification.ID,
+			&notification.Type,
+			&postID,
+			&sourceID,
+			&actorIDList,
+			&notification.ActorCount,
+			&notification.Read,
+			&notification.CreatedAt,
+		)
+		if err != nil {
+			return entities.Page[*entities.Notification]{}, err
+		}
+		if postID.Valid {
+			notification.PostID = &postID.UUID
+		}
+		if sourceID.Valid {
+			notification.SourceID = &sourceID.UUID
+		}
+
+		ids := recentDistinctIDs(actorIDList, maxNotificationActors)
+		notifications = append(notifications, &notification)
+		actorIDs = append(actorIDs, ids)
+		allActorIDs = append(allActorIDs, ids...)
+		cursors = append(cursors, entities.Cursor{Time: notification.CreatedAt, ID: notification.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.Notification]{}, err
+	}
+
+	actors, err := getUserSummaries(r.DB, allActorIDs)
+	if err != nil {
+		return entities.Page[*entities.Notification]{}, err
+	}
+	for i, notification := range notifications {
+		notification.Actors = make([]*entities.UserSummary, 0, len(actorIDs[i]))
+		for _, id := range actorIDs[i] {
+			if actor, ok := actors[uuid.MustParse(id)]; ok {
+				notification.Actors = append(notification.Actors, actor)
+			}
+		}
+	}
+
+	return newPage(notifications, cursors, limit), nil
+}
+
+func (r *NotificationsRepository) CountUnreadNotifications(recipientID string) (int, error) {
+	// Only the unread notifications are scanned, through notifications_recipient_id_unread_idx,
+	// and counted by group as they are listed.
+	query := `
+		SELECT COUNT(DISTINCT COALESCE(group_key, id::text))
+		FROM notifications
+		WHERE recipient_id = $1 AND read_at IS NULL
+	`
+	var count int
+	err := r.DB.QueryRow(query, recipientID).Scan(&count)
+	return count, err
+}
+
+func (r *NotificationsRepository) MarkNotificationAsRead(recipientID, notificationID string) error {
+	query := `
+		WITH target AS (
+			SELECT COALESCE(group_key, id::text) AS grouping
+			FROM notifications
+			WHERE id = $2 AND recipient_id = $1
+		),
+		updated AS (
+			UPDATE notifications n
+			SET read_at = CURRENT_TIMESTAMP
+			FROM target
+			WHERE n.recipient_id = $1
+			AND n.read_at IS NULL
+			AND COALESCE(n.group_key, n.id::text) = target.grouping
+			RETURNING n.id
+		)
+		SELECT EXISTS (SELECT 1 FROM target)
+	`
+	var exists bool
+	err := r.DB.QueryRow(query, recipientID, notificationID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+func (r *NotificationsRepository) MarkAllNotificationsAsRead(recipientID string) error {
+	query := `UPDATE notifications SET read_at = CURRENT_TIMESTAMP WHERE recipient_id = $1 AND read_at IS NULL`
+
+	_, err := r.DB.Exec(query, recipientID)
+	return err
+}
+
+func (r *NotificationsRepository) GetNotificationPreferences(userID string) (entities.NotificationPreferences, error) {
+	query := `
+		SELECT likes, reposts, follows, mentions, only_from_following, filter_no_bio, filter_new_accounts
+		FROM notification_preferences
+		WHERE user_id = $1
+	`
+	var preferences entities.NotificationPreferences
+	err := r.DB.QueryRow(query, userID).Scan(
+		&preferences.Likes,
+		&preferences.Reposts,
+		&preferences.Follows,
+		&preferences.Mentions,
+		&preferences.OnlyFromFollowing,
+		&preferences.FilterNoBio,
+		&preferences.FilterNewAccounts,
+	)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return entities.DefaultNotificationPreferences(), nil
+	}
+
+	return preferences, err
+}
+
+func (r *NotificationsRepository) UpdateNotificationPreferences(userID string, patch entities.NotificationPreferencesPatch) (entities.NotificationPreferences, error) {
+	// The patch is applied in a single upsert so that concurrent updates
+	// of different preferences don't overwrite each other.
+	defaults := entities.DefaultNotificationPreferences()
+	query := `
+		INSERT INTO notification_preferences AS p
+			(user_id, likes, reposts, follows, mentions, only_from_following, filter_no_bio, filter_new_accounts)
+		VALUES (
+			$1,
+			COALESCE($2::boolean, $9::boolean),
+			COALESCE($3::boolean, $10::boolean),
+			COALESCE($4::boolean, $11::boolean),
+			COALESCE($5::boolean, $12::boolean),
+			COALESCE($6::boolean, $13::boolean),
+			COALESCE($7::boolean, $14::boolean),
+			COALESCE($8::boolean, $15::boolean)
+		)
+		ON CONFLICT (user_id) DO UPDATE SET
+			likes = COALESCE($2::boolean, p.likes),
+			reposts = COALESCE($3::boolean, p.reposts),
+			follows = COALESCE($4::boolean, p.follows),
+			mentions = COALESCE($5::boolean, p.mentions),
+			only_from_following = COALESCE($6::boolean, p.only_from_following),
+			filter_no_bio = COALESCE($7::boolean, p.filter_no_bio),
+			filter_new_accounts = COALESCE($8::boolean, p.filter_new_accounts),
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING likes, reposts, follows, mentions, only_from_following, filter_no_bio, filter_new_accounts
+	`
+	var preferences entities.NotificationPreferences
+	err := r.DB.QueryRow(
+		query,
+		userID,
+		patch.Likes, patch.Reposts, patch.Follows, patch.Mentions,
+		patch.OnlyFromFollowing, patch.FilterNoBio, patch.FilterNewAccounts,
+		defaults.Likes, defaults.Reposts, defaults.Follows, defaults.Mentions,
+		defaults.OnlyFromFollowing, defaults.FilterNoBio, defaults.FilterNewAccounts,
+	).Scan(
+		&preferences.Likes,
+		&preferences.Reposts,
+		&preferences.Follows,
+		&preferences.Mentions,
+		&preferences.OnlyFromFollowing,
+		&preferences.FilterNoBio,
+		&preferences.FilterNewAccounts,
+	)
+	if err != nil {
+		return entities.NotificationPreferences{}, translateConstraintError(err)
+	}
+
+	return preferences, nil
+}
+
+// recentDistinctIDs returns up to n distinct IDs from a comma separated list,
+// keeping their order.
+func recentDistinctIDs(list string, n int) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(list, ",") {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+		if len(ids) == n {
+			break
+		}
+	}
+
+	return ids
+}
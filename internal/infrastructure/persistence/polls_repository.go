@@ -0,0 +1,228 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type PollsRepository struct {
+	DB *sql.DB
+}
+
+func NewPollsRepository(db *sql.DB) repositories.PollsRepositoryInterface {
+	return &PollsRepository{db}
+}
+
+func (r *PollsRepository) CreatePoll(tx *sql.Tx, poll *entities.Poll) error {
+	// The poll and its choices are stored in a transaction of their own
+	// unless they are stored with the post.
+	if tx == nil {
+		tx, err := r.DB.Begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := r.CreatePoll(tx, poll); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	query := `INSERT INTO polls (post_id, ends_at) VALUES ($1, $2) RETURNING id`
+	if err := tx.QueryRow(query, poll.PostID, poll.EndsAt).Scan(&poll.ID); err != nil {
+		return translateConstraintError(err)
+	}
+
+	positions := make([]int, 0, len(poll.Choices))
+	labels := make([]string, 0, len(poll.Choices))
+	for _, choice := range poll.Choices {
+		positions = append(positions, choice.Position)
+		labels = append(labels, choice.Label)
+	}
+	query = `
+		INSERT INTO poll_choices (poll_id, position, label)
+		SELECT $1, * FROM UNNEST($2::smallint[], $3::text[])
+	`
+	_, err := tx.Exec(query, poll.ID, positions, labels)
+	return err
+}
+
+func (r *PollsRepository) Vote(postID, userID string, position int) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The poll is locked in share mode, so that it cannot be closed before the vote
+	// is committed and the vote is always counted in the final results.
+	query := `
+		SELECT id, closed_at IS NOT NULL OR ends_at <= CURRENT_TIMESTAMP
+		FROM polls
+		WHERE post_id = $1
+		FOR SHARE
+	`
+	var (
+		pollID uuid.UUID
+		closed bool
+	)
+	err = tx.QueryRow(query, postID).Scan(&pollID, &closed)
+	if err == sql.ErrNoRows {
+		return errors.ErrPollNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if closed {
+		return errors.ErrPollClosed
+	}
+
+	query = `
+		INSERT INTO poll_votes (poll_id, user_id, choice_id)
+		SELECT poll_id, $2, id FROM poll_choices WHERE poll_id = $1 AND position = $3
+	`
+	res, err := tx.Exec(query, pollID, userID, position)
+	if isUniqueViolation(err, "poll_votes_pkey") {
+		return errors.ErrAlreadyVoted
+	}
+	if err != nil {
+		return translateConstraintError(err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.ErrInvalidPollChoice
+	}
+
+	return tx.Commit()
+}
+
+func (r *PollsRepository) CloseEndedPolls(limit int) ([]*entities.Poll, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE polls SET closed_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM polls
+			WHERE closed_at IS NULL AND ends_at <= CURRENT_TIMESTAMP
+			ORDER BY ends_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, post_id, ends_at
+	`
+	rows, err := tx.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		polls   []*entities.Poll
+		pollIDs []string
+	)
+	for rows.Next() {
+		poll := entities.Poll{Closed: true}
+		if err := rows.Scan(&poll.ID, &poll.PostID, &poll.EndsAt); err != nil {
+			return nil, err
+		}
+		polls = append(polls, &poll)
+		pollIDs = append(pollIDs, poll.ID.String())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(polls) == 0 {
+		return nil, nil
+	}
+
+	query = `
+		UPDATE poll_choices c
+		SET vote_count = (SELECT COUNT(*) FROM poll_votes v WHERE v.choice_id = c.id)
+		WHERE c.poll_id = ANY($1::uuid[])
+	`
+	if _, err := tx.Exec(query, pollIDs); err != nil {
+		return nil, err
+	}
+
+	return polls, tx.Commit()
+}
+
+func (r *PollsRepository) GetPollVoters(pollID uuid.UUID) ([]uuid.UUID, error) {
+	query := `SELECT user_id FROM poll_votes WHERE poll_id = $1`
+	rows, err := r.DB.Query(query, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var voterIDs []uuid.UUID
+	for rows.Next() {
+		var voterID uuid.UUID
+		if err := rows.Scan(&voterID); err != nil {
+			return nil, err
+		}
+		voterIDs = append(voterIDs, voterID)
+	}
+
+	return voterIDs, rows.Err()
+}
+
+// getPolls loads the polls of the posts with the vote counts and the choices
+// the viewer voted for, keyed by the post IDs. The counts are the final results
+// for closed polls, and counted from the votes so far for open ones.
+func getPolls(db *sql.DB, postIDs []string, viewerID string) (map[uuid.UUID]*entities.Poll, error) {
+	query := `
+		SELECT
+			p.id, p.post_id, p.ends_at, p.closed_at IS NOT NULL OR p.ends_at <= CURRENT_TIMESTAMP,
+			c.position, c.label,
+			COALESCE(c.vote_count, (SELECT COUNT(*) FROM poll_votes v WHERE v.choice_id = c.id)),
+			EXISTS (SELECT 1 FROM poll_votes v WHERE v.choice_id = c.id AND v.user_id = $2::uuid)
+		FROM polls p
+		JOIN poll_choices c ON c.poll_id = p.id
+		WHERE p.post_id = ANY($1::uuid[])
+		ORDER BY c.position
+	`
+	rows, err := db.Query(query, postIDs, nullableUUID(viewerID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	polls := make(map[uuid.UUID]*entities.Poll)
+	for rows.Next() {
+		var (
+			poll      entities.Poll
+			choice    entities.PollChoice
+			voteCount int
+			votedByMe bool
+		)
+		if err := rows.Scan(&poll.ID, &poll.PostID, &poll.EndsAt, &poll.Closed, &choice.Position, &choice.Label, &voteCount, &votedByMe); err != nil {
+			return nil, err
+		}
+
+		if _, ok := polls[poll.PostID]; !ok {
+			polls[poll.PostID] = &poll
+		}
+		p := polls[poll.PostID]
+		choice.VoteCount = &voteCount
+		p.Choices = append(p.Choices, &choice)
+		p.TotalVotes += voteCount
+		if votedByMe {
+			p.VotedChoice = &choice.Position
+		}
+	}
+
+	return polls, rows.Err()
+}
@@ -2,7 +2,13 @@ package infrastructure
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
+	domainerrors "x-clone-backend/internal/app/errors"
 	"x-clone-backend/internal/domain/entities"
 	"x-clone-backend/internal/domain/repositories"
 
@@ -17,8 +23,43 @@ func NewPostsRepository(db *sql.DB) repositories.PostsRepositoryInterface {
 	return &PostsRepository{db}
 }
 
+func (r *PostsRepository) WithTransaction(fn func(tx *sql.Tx) error) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostsRepository) GetPost(postID string) (*entities.Post, error) {
+	query := `SELECT id, user_id, text, created_at, edit_count, edited_at FROM posts WHERE id = $1`
+
+	var (
+		post     entities.Post
+		editedAt sql.NullTime
+	)
+	err := r.DB.QueryRow(query, postID).Scan(&post.ID, &post.UserID, &post.Text, &post.CreatedAt, &post.EditCount, &editedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerrors.ErrPostNotFound
+		}
+		return nil, err
+	}
+	if editedAt.Valid {
+		post.EditedAt = &editedAt.Time
+	}
+
+	return &post, nil
+}
+
 func (r *PostsRepository) GetSpecificUserPosts(userID string) ([]*entities.Post, error) {
-	query := `SELECT * FROM posts WHERE user_id = $1`
+	query := `SELECT id, user_id, text, created_at FROM posts WHERE user_id = $1`
 
 	rows, err := r.DB.Query(query, userID)
 	if err != nil {
@@ -26,65 +67,1053 @@ func (r *PostsRepository) GetSpecificUserPosts(userID string) ([]*entities.Post,
 	}
 	defer rows.Close()
 
-	var posts []*entities.Post
+	return scanPosts(rows)
+}
+
+func (r *PostsRepository) GetUserAndFolloweePosts(userID string) ([]*entities.Post, error) {
+	query := `
+		SELECT posts.id, posts.user_id, posts.text, posts.created_at
+		FROM posts
+		LEFT JOIN followships ON posts.user_id = followships.target_user_id
+		WHERE followships.source_user_id = $1
+		OR posts.user_id = $1
+		ORDER BY posts.created_at DESC
+	`
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
+
+func (r *PostsRepository) EditPost(tx *sql.Tx, postID, text string, maxEdits int) (*entities.PostEdit, error) {
+	if tx == nil {
+		var edit *entities.PostEdit
+		err := r.WithTransaction(func(tx *sql.Tx) error {
+			var err error
+			edit, err = r.EditPost(tx, postID, text, maxEdits)
+			return err
+		})
+		return edit, err
+	}
+
+	// The post is locked so that concurrent edits cannot exceed the limit.
+	query := `SELECT text, created_at, edit_count FROM posts WHERE id = $1 FOR UPDATE`
+
+	var (
+		originalText string
+		createdAt    time.Time
+		editCount    int
+	)
+	err := tx.QueryRow(query, postID).Scan(&originalText, &createdAt, &editCount)
+	if err == sql.ErrNoRows {
+		return nil, domainerrors.ErrPostNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if editCount >= maxEdits {
+		return nil, domainerrors.ErrPostEditLimitReached
+	}
+
+	if editCount == 0 {
+		query = `INSERT INTO post_edits (post_id, version, text, created_at) VALUES ($1, 0, $2, $3)`
+		if _, err := tx.Exec(query, postID, originalText, createdAt); err != nil {
+			return nil, err
+		}
+	}
+
+	edit := entities.PostEdit{Version: editCount + 1, Text: text}
+	query = `INSERT INTO post_edits (post_id, version, text) VALUES ($1, $2, $3) RETURNING post_id, created_at`
+	err = tx.QueryRow(query, postID, edit.Version, text).Scan(&edit.PostID, &edit.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `UPDATE posts SET text = $2, edit_count = $3, edited_at = $4 WHERE id = $1`
+	if _, err := tx.Exec(query, postID, text, edit.Version, edit.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	query = `
+		DELETE FROM post_mentions
+		WHERE post_id = $1
+		AND user_id NOT IN (SELECT id FROM users WHERE LOWER(username) = ANY($2::text[]))
+	`
+	if _, err := tx.Exec(query, postID, entities.MentionedUsernames(text)); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`DELETE FROM post_hashtags WHERE post_id = $1`, postID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`DELETE FROM post_links WHERE post_id = $1`, postID); err != nil {
+		return nil, err
+	}
+
+	return &edit, nil
+}
+
+func (r *PostsRepository) GetPostHistory(postID string) ([]*entities.PostEdit, error) {
+	// Posts never edited have no versions recorded, so their text is the only version.
+	query := `
+		SELECT post_id, version, text, created_at FROM post_edits WHERE post_id = $1
+		UNION ALL
+		SELECT id, 0, text, created_at FROM posts WHERE id = $1 AND edit_count = 0
+		ORDER BY version DESC
+	`
+	rows, err := r.DB.Query(query, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var edits []*entities.PostEdit
+	for rows.Next() {
+		var edit entities.PostEdit
+		if err := rows.Scan(&edit.PostID, &edit.Version, &edit.Text, &edit.CreatedAt); err != nil {
+			return nil, err
+		}
+		edits = append(edits, &edit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(edits) == 0 {
+		return nil, domainerrors.ErrPostNotFound
+	}
+
+	return edits, nil
+}
+
+func (r *PostsRepository) HydratePosts(viewerID string, posts []*entities.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postsByID := make(map[uuid.UUID][]*entities.Post, len(posts))
+	var postIDs, userIDs []string
+	for _, post := range posts {
+		if _, ok := postsByID[post.ID]; !ok {
+			postIDs = append(postIDs, post.ID.String())
+		}
+		postsByID[post.ID] = append(postsByID[post.ID], post)
+		userIDs = append(userIDs, post.UserID.String())
+	}
+
+	authors, err := getUserSummaries(r.DB, userIDs)
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		post.Author = authors[post.UserID]
+	}
+
+	mentionedUsers, err := getMentionedUsers(r.DB, "post_id", postIDs)
+	if err != nil {
+		return err
+	}
+	attachedMedia, err := getAttachedMedia(r.DB, "post_id", postIDs)
+	if err != nil {
+		return err
+	}
+	links, err := getLinks(r.DB, "post_id", postIDs)
+	if err != nil {
+		return err
+	}
+	polls, err := getPolls(r.DB, postIDs, viewerID)
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		post.Entities = entities.PostEntities{
+			Mentions: entities.ResolveMentions(post.Text, mentionedUsers[post.ID]),
+			Hashtags: entities.ExtractHashtags(post.Text),
+			Cashtags: entities.ExtractCashtags(post.Text),
+			URLs:     entities.ResolveURLs(post.Text, links[post.ID]),
+		}
+		post.Media = attachedMedia[post.ID]
+
+		post.Poll = polls[post.ID]
+		if post.Poll != nil && !post.Poll.ResultsVisible(post.UserID.String() == viewerID) {
+			post.Poll.HideResults()
+		}
+	}
+
+	query := `SELECT id, edit_count, edited_at FROM posts WHERE id = ANY($1::uuid[]) AND edit_count > 0`
+	rows, err := r.DB.Query(query, postIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
 	for rows.Next() {
 		var (
-			id         uuid.UUID
-			user_id    uuid.UUID
-			text       string
-			created_at time.Time
+			postID    uuid.UUID
+			editCount int
+			editedAt  sql.NullTime
+		)
+		if err := rows.Scan(&postID, &editCount, &editedAt); err != nil {
+			return err
+		}
+		for _, post := range postsByID[postID] {
+			post.EditCount = editCount
+			if editedAt.Valid {
+				post.EditedAt = &editedAt.Time
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	query = `
+		SELECT post_id, COUNT(*), BOOL_OR(user_id = $2::uuid)
+		FROM likes
+		WHERE post_id = ANY($1::uuid[])
+		GROUP BY post_id
+	`
+	rows, err = r.DB.Query(query, postIDs, nullableUUID(viewerID))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			postID    uuid.UUID
+			count     int
+			likedByMe sql.NullBool
+		)
+		if err := rows.Scan(&postID, &count, &likedByMe); err != nil {
+			return err
+		}
+		for _, post := range postsByID[postID] {
+			post.LikeCount = count
+			post.LikedByMe = likedByMe.Bool
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	query = `
+		SELECT
+			parent_post_id,
+			COUNT(*) FILTER (WHERE NOT is_quote),
+			COUNT(*) FILTER (WHERE is_quote),
+			BOOL_OR(NOT is_quote AND user_id = $2::uuid)
+		FROM reposts
+		WHERE parent_post_id = ANY($1::uuid[])
+		GROUP BY parent_post_id
+	`
+	rows, err = r.DB.Query(query, postIDs, nullableUUID(viewerID))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			postID       uuid.UUID
+			repostCount  int
+			quoteCount   int
+			repostedByMe sql.NullBool
+		)
+		if err := rows.Scan(&postID, &repostCount, &quoteCount, &repostedByMe); err != nil {
+			return err
+		}
+		for _, post := range postsByID[postID] {
+			post.RepostCount = repostCount
+			post.QuoteCount = quoteCount
+			post.RepostedByMe = repostedByMe.Bool
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return hydrateBookmarks(r.DB, postsByID, postIDs, viewerID)
+}
+
+// hydrateBookmarks fills in whether the viewer bookmarked the posts,
+// and the bookmark counts of the posts the viewer wrote.
+func hydrateBookmarks(db *sql.DB, postsByID map[uuid.UUID][]*entities.Post, postIDs []string, viewerID string) error {
+	if viewerID == "" {
+		return nil
+	}
+
+	for _, posts := range postsByID {
+		for _, post := range posts {
+			if post.UserID.String() == viewerID {
+				bookmarkCount := 0
+				post.BookmarkCount = &bookmarkCount
+			}
+		}
+	}
+
+	query := `
+		SELECT post_id, COUNT(*), BOOL_OR(user_id = $2::uuid)
+		FROM bookmarks
+		WHERE post_id = ANY($1::uuid[])
+		GROUP BY post_id
+	`
+	rows, err := db.Query(query, postIDs, viewerID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			postID         uuid.UUID
+			count          int
+			bookmarkedByMe bool
 		)
-		if err := rows.Scan(&id, &user_id, &text, &created_at); err != nil {
+		if err := rows.Scan(&postID, &count, &bookmarkedByMe); err != nil {
+			return err
+		}
+		for _, post := range postsByID[postID] {
+			post.BookmarkedByMe = bookmarkedByMe
+			if post.BookmarkCount != nil {
+				*post.BookmarkCount = count
+			}
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *PostsRepository) HydrateQuotes(quotes []*entities.Repost) error {
+	if len(quotes) == 0 {
+		return nil
+	}
+
+	var quoteIDs, userIDs []string
+	for _, quote := range quotes {
+		quoteIDs = append(quoteIDs, quote.ID.String())
+		userIDs = append(userIDs, quote.UserID.String())
+	}
+
+	authors, err := getUserSummaries(r.DB, userIDs)
+	if err != nil {
+		return err
+	}
+	mentionedUsers, err := getMentionedUsers(r.DB, "repost_id", quoteIDs)
+	if err != nil {
+		return err
+	}
+	attachedMedia, err := getAttachedMedia(r.DB, "repost_id", quoteIDs)
+	if err != nil {
+		return err
+	}
+	links, err := getLinks(r.DB, "repost_id", quoteIDs)
+	if err != nil {
+		return err
+	}
+	for _, quote := range quotes {
+		quote.Author = authors[quote.UserID]
+		quote.Entities = entities.PostEntities{
+			Mentions: entities.ResolveMentions(quote.Text, mentionedUsers[quote.ID]),
+			Hashtags: entities.ExtractHashtags(quote.Text),
+			Cashtags: entities.ExtractCashtags(quote.Text),
+			URLs:     entities.ResolveURLs(quote.Text, links[quote.ID]),
+		}
+		quote.Media = attachedMedia[quote.ID]
+	}
+
+	query := `SELECT id, quoted_post_version FROM reposts WHERE id = ANY($1::uuid[]) AND quoted_post_version IS NOT NULL`
+	rows, err := r.DB.Query(query, quoteIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	quotedVersions := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var (
+			quoteID uuid.UUID
+			version int
+		)
+		if err := rows.Scan(&quoteID, &version); err != nil {
+			return err
+		}
+		quotedVersions[quoteID] = version
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, quote := range quotes {
+		if version, ok := quotedVersions[quote.ID]; ok {
+			quote.QuotedVersion = &version
+		}
+	}
+
+	return nil
+}
+
+func (r *PostsRepository) CreateMentions(tx *sql.Tx, sourceID string, isQuote bool, text string) ([]*entities.Mention, error) {
+	usernames := entities.MentionedUsernames(text)
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	sourceColumn := "post_id"
+	if isQuote {
+		sourceColumn = "repost_id"
+	}
+
+	// Users are recorded once per post however many times they are mentioned;
+	// the offsets are recomputed from the text when the post is returned.
+	query := `
+		INSERT INTO post_mentions (` + sourceColumn + `, user_id)
+		SELECT $1, id FROM users WHERE LOWER(username) = ANY($2::text[])
+		ON CONFLICT DO NOTHING
+		RETURNING user_id
+	`
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.Query(query, sourceID, usernames)
+	} else {
+		rows, err = r.DB.Query(query, sourceID, usernames)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
 			return nil, err
 		}
+		userIDs = append(userIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	users, err := getUserSummaries(r.DB, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	mentionedUsers := make([]*entities.UserSummary, 0, len(users))
+	for _, user := range users {
+		mentionedUsers = append(mentionedUsers, user)
+	}
+
+	return entities.ResolveMentions(text, mentionedUsers), nil
+}
+
+func (r *PostsRepository) CreateLinks(tx *sql.Tx, sourceID string, isQuote bool, text string) ([]*entities.URLEntity, error) {
+	urls := entities.LinkedURLs(text)
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	sourceColumn := "post_id"
+	if isQuote {
+		sourceColumn = "repost_id"
+	}
+
+	if err := r.createLinks(urls); err != nil {
+		return nil, err
+	}
+
+	// The links are read in a statement of their own, so that it sees
+	// the ones created by concurrent posts of the same URLs as well.
+	query := `
+		WITH inserted AS (
+			INSERT INTO post_links (` + sourceColumn + `, link_id)
+			SELECT $1, id FROM links WHERE url = ANY($2::text[])
+			ON CONFLICT DO NOTHING
+		)
+		SELECT ` + linkColumns("links") + `
+		FROM links
+		WHERE url = ANY($2::text[])
+	`
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.Query(query, sourceID, urls)
+	} else {
+		rows, err = r.DB.Query(query, sourceID, urls)
+	}
+	if err != nil {
+		return nil, translateConstraintError(err)
+	}
+	defer rows.Close()
+
+	links, err := scanLinkRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.ResolveURLs(text, links), nil
+}
+
+// createLinks creates the short links of the URLs which have none yet.
+// Random codes may collide with existing ones, in which case new codes are tried
+// a few times; collisions are unlikely enough with 62^7 codes.
+// The links are committed on their own, as a failed statement would abort
+// the transaction of the post, and unused links are harmless.
+func (r *PostsRepository) createLinks(urls []string) error {
+	const maxAttempts = 3
+
+	query := `
+		INSERT INTO links (code, url)
+		SELECT * FROM UNNEST($1::text[], $2::text[])
+		ON CONFLICT (url) DO NOTHING
+	`
+	for attempt := 1; ; attempt++ {
+		codes := make([]string, len(urls))
+		for i := range codes {
+			code, err := entities.NewLinkCode()
+			if err != nil {
+				return err
+			}
+			codes[i] = code
+		}
+
+		_, err := r.DB.Exec(query, codes, urls)
+		if err == nil || attempt == maxAttempts || !isUniqueViolation(err, "links_code_key") {
+			return err
+		}
+	}
+}
+
+func (r *PostsRepository) CreateHashtags(tx *sql.Tx, postID, text string) error {
+	tags := entities.NormalizedHashtags(text)
+	if len(tags) == 0 {
+		return nil
+	}
+	// The hashtags are locked in the same order by every post,
+	// so that concurrent transactions do not deadlock.
+	slices.Sort(tags)
+
+	// Updating conflicting hashtags makes RETURNING yield the existing ones as well.
+	query := `
+		WITH tags AS (
+			INSERT INTO hashtags (tag)
+			SELECT UNNEST($2::text[])
+			ON CONFLICT (tag) DO UPDATE SET tag = EXCLUDED.tag
+			RETURNING id
+		)
+		INSERT INTO post_hashtags (post_id, hashtag_id, created_at)
+		SELECT posts.id, tags.id, posts.created_at
+		FROM tags, posts
+		WHERE posts.id = $1
+		ON CONFLICT DO NOTHING
+	`
+	var err error
+	if tx != nil {
+		_, err = tx.Exec(query, postID, tags)
+	} else {
+		_, err = r.DB.Exec(query, postID, tags)
+	}
+	return err
+}
+
+func (r *PostsRepository) GetMentionTimeline(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error) {
+	query := `
+		SELECT
+			pm.id, pm.created_at,
+			p.id, p.user_id, p.text, p.created_at,
+			r.id, COALESCE(r.parent_post_id, r.parent_repost_id), r.user_id, r.text, r.created_at
+		FROM post_mentions pm
+		LEFT JOIN posts p ON p.id = pm.post_id
+		LEFT JOIN reposts r ON r.id = pm.repost_id
+		JOIN users u ON u.id = COALESCE(p.user_id, r.user_id)
+		WHERE pm.user_id = $1
+		AND ($2::timestamptz IS NULL OR (pm.created_at, pm.id) < ($2::timestamptz, $3::uuid))
+		AND ` + visibleUserCondition("u", "$1::uuid") + `
+		ORDER BY pm.created_at DESC, pm.id DESC
+		LIMIT $4
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, userID, cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		items   []*entities.TimelineItem
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		var (
+			c                                   entities.Cursor
+			postID, postUserID                  uuid.NullUUID
+			quoteID, quoteParentID, quoteUserID uuid.NullUUID
+			postText, quoteText                 sql.NullString
+			postCreatedAt, quoteCreatedAt       sql.NullTime
+		)
+		err := rows.Scan(
+			&c.ID, &c.Time,
+			&postID, &postUserID, &postText, &postCreatedAt,
+			&quoteID, &quoteParentID, &quoteUserID, &quoteText, &quoteCreatedAt,
+		)
+		if err != nil {
+			return entities.Page[*entities.TimelineItem]{}, err
+		}
+
+		var item entities.TimelineItem
+		if postID.Valid {
+			item.Post = &entities.Post{
+				ID:        postID.UUID,
+				UserID:    postUserID.UUID,
+				Text:      postText.String,
+				CreatedAt: postCreatedAt.Time,
+			}
+		} else {
+			item.Quote = &entities.Repost{
+				ID:        quoteID.UUID,
+				ParentID:  quoteParentID.UUID,
+				UserID:    quoteUserID.UUID,
+				Text:      quoteText.String,
+				CreatedAt: quoteCreatedAt.Time,
+			}
+		}
+		items = append(items, &item)
+		cursors = append(cursors, c)
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+
+	return newPage(items, cursors, limit), nil
+}
+
+func (r *PostsRepository) SearchPosts(query *entities.SearchQuery, order entities.SearchOrder, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimelineItem], error) {
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	viewer := arg(nullableUUID(viewerID)) + "::uuid"
+
+	// The words, the phrases and the hashtags make up the text search query,
+	// which the results are ranked by.
+	var tsqueries []string
+	if len(query.Terms) > 0 {
+		tsqueries = append(tsqueries, fmt.Sprintf("plainto_tsquery('simple', %s)", arg(strings.Join(query.Terms, " "))))
+	}
+	for _, phrase := range query.Phrases {
+		tsqueries = append(tsqueries, fmt.Sprintf("phraseto_tsquery('simple', %s)", arg(phrase)))
+	}
+	for _, hashtag := range query.Hashtags {
+		tsqueries = append(tsqueries, fmt.Sprintf("phraseto_tsquery('simple', %s)", arg(hashtag)))
+	}
+	rank := "0::real"
+	conditions := []string{
+		visibleUserCondition("u", viewer),
+		fmt.Sprintf("NOT EXISTS (SELECT 1 FROM mutes WHERE source_user_id = %s AND target_user_id = u.id)", viewer),
+	}
+	if len(tsqueries) > 0 {
+		tsquery := "(" + strings.Join(tsqueries, " && ") + ")"
+		rank = fmt.Sprintf("ts_rank_cd(items.search_vector, %s)", tsquery)
+		conditions = append(conditions, "items.search_vector @@ "+tsquery)
+	}
+
+	// The lexemes of hashtags do not tell them from plain words,
+	// so they are looked for in the text as well.
+	for _, hashtag := range query.Hashtags {
+		pattern := `(^|[^[:alnum:]_&])[#＃]` + regexp.QuoteMeta(hashtag) + `($|[^[:alnum:]_])`
+		conditions = append(conditions, "items.text ~* "+arg(pattern))
+	}
+	for _, excluded := range query.Excluded {
+		conditions = append(conditions, fmt.Sprintf("NOT items.search_vector @@ phraseto_tsquery('simple', %s)", arg(excluded)))
+	}
+	if query.From != "" {
+		conditions = append(conditions, fmt.Sprintf("LOWER(u.username) = LOWER(%s)", arg(query.From)))
+	}
+	if query.To != "" {
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM post_mentions pm
+			JOIN users mentioned ON mentioned.id = pm.user_id
+			WHERE (pm.post_id = items.id OR pm.repost_id = items.id)
+			AND LOWER(mentioned.username) = LOWER(%s)
+		)`, arg(query.To)))
+	}
+	if query.Since != nil {
+		conditions = append(conditions, "items.created_at >= "+arg(*query.Since))
+	}
+	if query.Until != nil {
+		conditions = append(conditions, "items.created_at < "+arg(*query.Until))
+	}
+
+	orderBy := "created_at DESC, id DESC"
+	if order == entities.SearchByRelevance {
+		orderBy = "rank DESC, " + orderBy
+	}
+	cursorCondition := "TRUE"
+	if cursor != nil && order == entities.SearchByRelevance {
+		cursorCondition = fmt.Sprintf("(rank, created_at, id) < (%s::real, %s::timestamptz, %s::uuid)", arg(*cursor.Rank), arg(cursor.Time), arg(cursor.ID))
+	} else if cursor != nil {
+		cursorCondition = fmt.Sprintf("(created_at, id) < (%s::timestamptz, %s::uuid)", arg(cursor.Time), arg(cursor.ID))
+	}
+
+	sqlQuery := `
+		WITH items AS (
+			SELECT p.id, p.user_id, p.text, p.created_at, p.search_vector, FALSE AS is_quote, NULL::uuid AS parent_id
+			FROM posts p
+			WHERE NOT ` + arg(query.OnlyQuotes) + `::boolean
+			UNION ALL
+			SELECT r.id, r.user_id, r.text, r.created_at::timestamptz, r.search_vector, TRUE, COALESCE(r.parent_post_id, r.parent_repost_id)
+			FROM reposts r
+			WHERE r.is_quote AND NOT ` + arg(query.ExcludeQuotes) + `::boolean
+		), matches AS (
+			SELECT items.id, items.user_id, items.text, items.created_at, items.is_quote, items.parent_id, ` + rank + ` AS rank
+			FROM items
+			JOIN users u ON u.id = items.user_id
+			WHERE ` + strings.Join(conditions, "\n\t\t\tAND ") + `
+		)
+		SELECT id, user_id, text, created_at, is_quote, parent_id, rank
+		FROM matches
+		WHERE ` + cursorCondition + `
+		ORDER BY ` + orderBy + `
+		LIMIT ` + arg(limit+1)
+
+	rows, err := r.DB.Query(sqlQuery, args...)
+	if err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		items   []*entities.TimelineItem
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		var (
+			id, userID uuid.UUID
+			text       string
+			createdAt  time.Time
+			isQuote    bool
+			parentID   uuid.NullUUID
+			itemRank   float32
+		)
+		if err := rows.Scan(&id, &userID, &text, &createdAt, &isQuote, &parentID, &itemRank); err != nil {
+			return entities.Page[*entities.TimelineItem]{}, err
+		}
+
+		var item entities.TimelineItem
+		if isQuote {
+			item.Quote = &entities.Repost{ID: id, ParentID: parentID.UUID, UserID: userID, Text: text, CreatedAt: createdAt}
+		} else {
+			item.Post = &entities.Post{ID: id, UserID: userID, Text: text, CreatedAt: createdAt}
+		}
+		items = append(items, &item)
+
+		c := entities.Cursor{Time: createdAt, ID: id}
+		if order == entities.SearchByRelevance {
+			c.Rank = &itemRank
+		}
+		cursors = append(cursors, c)
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.TimelineItem]{}, err
+	}
+
+	return newPage(items, cursors, limit), nil
+}
+
+func (r *PostsRepository) GetPostLikers(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error) {
+	query := `
+		SELECT u.id, u.username, u.display_name, u.is_private, likes.created_at
+		FROM likes
+		JOIN users u ON u.id = likes.user_id
+		WHERE likes.post_id = $1
+		AND ($3::timestamptz IS NULL OR (likes.created_at, likes.user_id) < ($3::timestamptz, $4::uuid))
+		AND ` + visibleUserCondition("u", "$2::uuid") + `
+		ORDER BY likes.created_at DESC, likes.user_id DESC
+		LIMIT $5
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, postID, nullableUUID(viewerID), cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+	defer rows.Close()
+
+	return scanUserSummaryPage(rows, limit)
+}
+
+func (r *PostsRepository) GetPostReposters(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error) {
+	query := `
+		SELECT u.id, u.username, u.display_name, u.is_private, reposts.created_at::timestamptz, reposts.id
+		FROM reposts
+		JOIN users u ON u.id = reposts.user_id
+		WHERE reposts.parent_post_id = $1
+		AND NOT reposts.is_quote
+		AND ($3::timestamptz IS NULL OR (reposts.created_at::timestamptz, reposts.id) < ($3::timestamptz, $4::uuid))
+		AND ` + visibleUserCondition("u", "$2::uuid") + `
+		ORDER BY reposts.created_at DESC, reposts.id DESC
+		LIMIT $5
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, postID, nullableUUID(viewerID), cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		users   []*entities.UserSummary
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		var (
+			user        entities.UserSummary
+			displayName sql.NullString
+			isPrivate   sql.NullBool
+			c           entities.Cursor
+		)
+		if err := rows.Scan(&user.ID, &user.Username, &displayName, &isPrivate, &c.Time, &c.ID); err != nil {
+			return entities.Page[*entities.UserSummary]{}, err
+		}
+		user.DisplayName = displayName.String
+		user.IsPrivate = isPrivate.Bool
+		users = append(users, &user)
+		cursors = append(cursors, c)
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+
+	return newPage(users, cursors, limit), nil
+}
+
+func (r *PostsRepository) GetPostQuotes(postID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Repost], error) {
+	query := `
+		SELECT
+			reposts.id, reposts.parent_post_id, reposts.user_id, reposts.text, reposts.created_at::timestamptz,
+			u.id, u.username, u.display_name, u.is_private
+		FROM reposts
+		JOIN users u ON u.id = reposts.user_id
+		WHERE reposts.parent_post_id = $1
+		AND reposts.is_quote
+		AND ($3::timestamptz IS NULL OR (reposts.created_at::timestamptz, reposts.id) < ($3::timestamptz, $4::uuid))
+		AND ` + visibleUserCondition("u", "$2::uuid") + `
+		ORDER BY reposts.created_at DESC, reposts.id DESC
+		LIMIT $5
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, postID, nullableUUID(viewerID), cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.Repost]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		quotes  []*entities.Repost
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		var (
+			quote       entities.Repost
+			author      entities.UserSummary
+			displayName sql.NullString
+			isPrivate   sql.NullBool
+		)
+		err := rows.Scan(
+			&quote.ID, &quote.ParentID, &quote.UserID, &quote.Text, &quote.CreatedAt,
+			&author.ID, &author.Username, &displayName, &isPrivate,
+		)
+		if err != nil {
+			return entities.Page[*entities.Repost]{}, err
+		}
+		author.DisplayName = displayName.String
+		author.IsPrivate = isPrivate.Bool
+		quote.Author = &author
+		quotes = append(quotes, &quote)
+		cursors = append(cursors, entities.Cursor{Time: quote.CreatedAt, ID: quote.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.Repost]{}, err
+	}
+
+	return newPage(quotes, cursors, limit), nil
+}
+
+func (r *PostsRepository) GetLikedPosts(userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error) {
+	query := `
+		SELECT posts.id, posts.user_id, posts.text, posts.created_at, likes.created_at
+		FROM likes
+		JOIN posts ON posts.id = likes.post_id
+		JOIN users u ON u.id = posts.user_id
+		WHERE likes.user_id = $1
+		AND ($3::timestamptz IS NULL OR (likes.created_at, likes.post_id) < ($3::timestamptz, $4::uuid))
+		AND ` + visibleUserCondition("u", "$2::uuid") + `
+		ORDER BY likes.created_at DESC, likes.post_id DESC
+		LIMIT $5
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, userID, nullableUUID(viewerID), cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+	defer rows.Close()
 
-		post := entities.Post{
-			ID:        id,
-			UserID:    user_id,
-			Text:      text,
-			CreatedAt: created_at,
+	var (
+		posts   []*entities.Post
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		var (
+			post    entities.Post
+			likedAt time.Time
+		)
+		if err := rows.Scan(&post.ID, &post.UserID, &post.Text, &post.CreatedAt, &likedAt); err != nil {
+			return entities.Page[*entities.Post]{}, err
 		}
 		posts = append(posts, &post)
+		cursors = append(cursors, entities.Cursor{Time: likedAt, ID: post.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.Post]{}, err
 	}
 
-	return posts, nil
+	return newPage(posts, cursors, limit), nil
 }
 
-func (r *PostsRepository) GetUserAndFolloweePosts(userID string) ([]*entities.Post, error) {
+func (r *PostsRepository) GetHashtagPosts(tag, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.Post], error) {
 	query := `
-		SELECT posts.* 
-		FROM posts
-		LEFT JOIN followships ON posts.user_id = followships.target_user_id
-		WHERE followships.source_user_id = $1
-		OR posts.user_id = $1
-		ORDER BY posts.created_at DESC
+		SELECT posts.id, posts.user_id, posts.text, ph.created_at
+		FROM hashtags h
+		JOIN post_hashtags ph ON ph.hashtag_id = h.id
+		JOIN posts ON posts.id = ph.post_id
+		JOIN users u ON u.id = posts.user_id
+		WHERE h.tag = $1
+		AND ($3::timestamptz IS NULL OR (ph.created_at, ph.post_id) < ($3::timestamptz, $4::uuid))
+		AND ` + visibleUserCondition("u", "$2::uuid") + `
+		ORDER BY ph.created_at DESC, ph.post_id DESC
+		LIMIT $5
 	`
-	rows, err := r.DB.Query(query, userID)
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, tag, nullableUUID(viewerID), cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+	defer rows.Close()
+
+	posts, err := scanPosts(rows)
+	if err != nil {
+		return entities.Page[*entities.Post]{}, err
+	}
+	cursors := make([]entities.Cursor, 0, len(posts))
+	for _, post := range posts {
+		cursors = append(cursors, entities.Cursor{Time: post.CreatedAt, ID: post.ID})
+	}
+
+	return newPage(posts, cursors, limit), nil
+}
+
+// getMentionedUsers loads the users mentioned by the posts or the quote reposts,
+// whose IDs are stored in sourceColumn of post_mentions, keyed by the source IDs.
+func getMentionedUsers(db *sql.DB, sourceColumn string, sourceIDs []string) (map[uuid.UUID][]*entities.UserSummary, error) {
+	query := `
+		SELECT pm.` + sourceColumn + `, u.id, u.username, u.display_name, u.is_private
+		FROM post_mentions pm
+		JOIN users u ON u.id = pm.user_id
+		WHERE pm.` + sourceColumn + ` = ANY($1::uuid[])
+	`
+	rows, err := db.Query(query, sourceIDs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var posts []*entities.Post
+	mentionedUsers := make(map[uuid.UUID][]*entities.UserSummary)
 	for rows.Next() {
 		var (
-			id         uuid.UUID
-			user_id    uuid.UUID
-			text       string
-			created_at time.Time
+			sourceID    uuid.UUID
+			user        entities.UserSummary
+			displayName sql.NullString
+			isPrivate   sql.NullBool
 		)
-		if err := rows.Scan(&id, &user_id, &text, &created_at); err != nil {
+		if err := rows.Scan(&sourceID, &user.ID, &user.Username, &displayName, &isPrivate); err != nil {
 			return nil, err
 		}
+		user.DisplayName = displayName.String
+		user.IsPrivate = isPrivate.Bool
+		mentionedUsers[sourceID] = append(mentionedUsers[sourceID], &user)
+	}
+
+	return mentionedUsers, rows.Err()
+}
 
-		post := entities.Post{
-			ID:        id,
-			UserID:    user_id,
-			Text:      text,
-			CreatedAt: created_at,
+// getAttachedMedia loads the media attached to the posts, or to the quote reposts
+// if sourceColumn is "repost_id", in the order they were attached.
+func getAttachedMedia(db *sql.DB, sourceColumn string, sourceIDs []string) (map[uuid.UUID][]*entities.Media, error) {
+	query := `
+		SELECT pm.` + sourceColumn + `, ` + mediaColumns("m") + `
+		FROM post_media pm
+		JOIN media m ON m.id = pm.media_id
+		WHERE pm.` + sourceColumn + ` = ANY($1::uuid[])
+		ORDER BY pm.position
+	`
+	rows, err := db.Query(query, sourceIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachedMedia := make(map[uuid.UUID][]*entities.Media)
+	for rows.Next() {
+		var sourceID uuid.UUID
+		media, err := scanMedia(rows, &sourceID)
+		if err != nil {
+			return nil, err
+		}
+		attachedMedia[sourceID] = append(attachedMedia[sourceID], media)
+	}
+
+	return attachedMedia, rows.Err()
+}
+
+// getLinks loads the links of the posts or the quote reposts,
+// whose IDs are stored in sourceColumn of post_links, keyed by the source IDs.
+func getLinks(db *sql.DB, sourceColumn string, sourceIDs []string) (map[uuid.UUID][]*entities.Link, error) {
+	query := `
+		SELECT pl.` + sourceColumn + `, ` + linkColumns("l") + `
+		FROM post_links pl
+		JOIN links l ON l.id = pl.link_id
+		WHERE pl.` + sourceColumn + ` = ANY($1::uuid[])
+	`
+	rows, err := db.Query(query, sourceIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make(map[uuid.UUID][]*entities.Link)
+	for rows.Next() {
+		var sourceID uuid.UUID
+		link, err := scanLink(rows, &sourceID)
+		if err != nil {
+			return nil, err
+		}
+		links[sourceID] = append(links[sourceID], link)
+	}
+
+	return links, rows.Err()
+}
+
+// scanPosts scans rows consisting of id, user_id, text and created_at into posts.
+func scanPosts(rows *sql.Rows) ([]*entities.Post, error) {
+	var posts []*entities.Post
+	for rows.Next() {
+		var post entities.Post
+		if err := rows.Scan(&post.ID, &post.UserID, &post.Text, &post.CreatedAt); err != nil {
+			return nil, err
 		}
 		posts = append(posts, &post)
 	}
 
-	return posts, nil
+	return posts, rows.Err()
 }
@@ -0,0 +1,162 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"x-clone-backend/internal/domain/entities"
+
+	"github.com/google/uuid"
+)
+
+// notBlockedCondition returns a SQL condition which holds if neither
+// the user of the users table aliased as usersAlias nor the viewer
+// bound to viewerParam blocks the other.
+func notBlockedCondition(usersAlias, viewerParam string) string {
+	return fmt.Sprintf(`NOT EXISTS (
+		SELECT 1 FROM blocks
+		WHERE (blocks.source_user_id = %[2]s AND blocks.target_user_id = %[1]s.id)
+		OR (blocks.source_user_id = %[1]s.id AND blocks.target_user_id = %[2]s)
+	)`, usersAlias, viewerParam)
+}
+
+// visibleUserCondition returns a SQL condition which holds if the user
+// of the users table aliased as usersAlias can be seen by the viewer
+// bound to viewerParam: neither of them blocks the other, and the user
+// is public, the viewer themself, or followed by the viewer.
+// A NULL viewer, i.e. an anonymous request, can see public users only.
+func visibleUserCondition(usersAlias, viewerParam string) string {
+	return fmt.Sprintf(`(
+		%[3]s
+		AND (
+			NOT COALESCE(%[1]s.is_private, FALSE)
+			OR %[1]s.id = %[2]s
+			OR EXISTS (
+				SELECT 1 FROM followships
+				WHERE followships.source_user_id = %[2]s AND followships.target_user_id = %[1]s.id
+			)
+		)
+	)`, usersAlias, viewerParam, notBlockedCondition(usersAlias, viewerParam))
+}
+
+// relationshipBoost returns a SQL expression which ranks the user of the users
+// table aliased as usersAlias higher if the viewer bound to viewerParam follows
+// the user, and a little higher if the user follows the viewer.
+func relationshipBoost(usersAlias, viewerParam string) string {
+	return fmt.Sprintf(`(
+		CASE WHEN EXISTS (
+			SELECT 1 FROM followships
+			WHERE followships.source_user_id = %[2]s AND followships.target_user_id = %[1]s.id
+		) THEN 0.5 ELSE 0 END
+		+ CASE WHEN EXISTS (
+			SELECT 1 FROM followships
+			WHERE followships.source_user_id = %[1]s.id AND followships.target_user_id = %[2]s
+		) THEN 0.25 ELSE 0 END
+	)`, usersAlias, viewerParam)
+}
+
+// escapeLikePattern escapes the wildcards of LIKE in s, such as the "_" common in
+// usernames, so that s is matched literally.
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// cursorArgs converts an optional cursor into query arguments,
+// which are NULL if the cursor is nil.
+func cursorArgs(cursor *entities.Cursor) (sql.NullTime, uuid.NullUUID) {
+	if cursor == nil {
+		return sql.NullTime{}, uuid.NullUUID{}
+	}
+
+	return sql.NullTime{Time: cursor.Time, Valid: true}, uuid.NullUUID{UUID: cursor.ID, Valid: true}
+}
+
+// newPage trims items fetched with limit+1 down to limit,
+// and sets the cursor of the last item if more items remain.
+func newPage[T any](items []T, cursors []entities.Cursor, limit int) entities.Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	if len(items) <= limit {
+		return entities.Page[T]{Items: items}
+	}
+
+	return entities.Page[T]{
+		Items:      items[:limit],
+		NextCursor: &cursors[limit-1],
+	}
+}
+
+// scanUserSummary scans a row consisting of id, username, display_name and is_private.
+func scanUserSummary(rows *sql.Rows) (*entities.UserSummary, error) {
+	var (
+		summary     entities.UserSummary
+		displayName sql.NullString
+		isPrivate   sql.NullBool
+	)
+	if err := rows.Scan(&summary.ID, &summary.Username, &displayName, &isPrivate); err != nil {
+		return nil, err
+	}
+	summary.DisplayName = displayName.String
+	summary.IsPrivate = isPrivate.Bool
+
+	return &summary, nil
+}
+
+// nullableUUID converts an empty ID into NULL so that
+// comparisons against it never match.
+func nullableUUID(id string) sql.NullString {
+	return sql.NullString{String: id, Valid: id != ""}
+}
+
+// scanUserSummaryPage scans rows consisting of a user summary followed by
+// the time it is ordered by, using the user ID as the tie-breaker.
+func scanUserSummaryPage(rows *sql.Rows, limit int) (entities.Page[*entities.UserSummary], error) {
+	var (
+		users   []*entities.UserSummary
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		var (
+			user        entities.UserSummary
+			displayName sql.NullString
+			isPrivate   sql.NullBool
+			orderedAt   time.Time
+		)
+		if err := rows.Scan(&user.ID, &user.Username, &displayName, &isPrivate, &orderedAt); err != nil {
+			return entities.Page[*entities.UserSummary]{}, err
+		}
+		user.DisplayName = displayName.String
+		user.IsPrivate = isPrivate.Bool
+		users = append(users, &user)
+		cursors = append(cursors, entities.Cursor{Time: orderedAt, ID: user.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+
+	return newPage(users, cursors, limit), nil
+}
+
+// getUserSummaries loads the summaries of the specified users in a single query.
+func getUserSummaries(db *sql.DB, userIDs []string) (map[uuid.UUID]*entities.UserSummary, error) {
+	query := `SELECT id, username, display_name, is_private FROM users WHERE id = ANY($1::uuid[])`
+
+	rows, err := db.Query(query, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := make(map[uuid.UUID]*entities.UserSummary)
+	for rows.Next() {
+		summary, err := scanUserSummary(rows)
+		if err != nil {
+			return nil, err
+		}
+		summaries[summary.ID] = summary
+	}
+
+	return summaries, rows.Err()
+}
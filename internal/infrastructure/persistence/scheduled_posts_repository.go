@@ -0,0 +1,285 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+	"x-clone-backend/internal/app/errors"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"github.com/google/uuid"
+)
+
+type ScheduledPostsRepository struct {
+	DB *sql.DB
+}
+
+func NewScheduledPostsRepository(db *sql.DB) repositories.ScheduledPostsRepositoryInterface {
+	return &ScheduledPostsRepository{db}
+}
+
+// scheduledPostColumns lists the columns scanned by scanScheduledPost.
+// The media IDs are joined into a string, as the driver cannot scan arrays.
+const scheduledPostColumns = `id, user_id, text, ARRAY_TO_STRING(media_ids, ','), poll, scheduled_at, created_at, failed_at, publish_attempts`
+
+func (r *ScheduledPostsRepository) CreateScheduledPost(post *entities.ScheduledPost) error {
+	poll, err := marshalPendingPoll(post.Poll)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO scheduled_posts (user_id, text, media_ids, poll, scheduled_at)
+		VALUES ($1, $2, $3::uuid[], $4, $5)
+		RETURNING id, created_at
+	`
+	err = r.DB.QueryRow(query, post.UserID, post.Text, mediaIDStrings(post.MediaIDs), poll, post.ScheduledAt).Scan(&post.ID, &post.CreatedAt)
+	if err != nil {
+		return translateConstraintError(err)
+	}
+
+	return nil
+}
+
+func (r *ScheduledPostsRepository) GetScheduledPosts(userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.ScheduledPost], error) {
+	query := `
+		SELECT ` + scheduledPostColumns + `
+		FROM scheduled_posts
+		WHERE user_id = $1
+		AND published_at IS NULL
+		AND ($2::timestamptz IS NULL OR (scheduled_at, id) > ($2::timestamptz, $3::uuid))
+		ORDER BY scheduled_at, id
+		LIMIT $4
+	`
+	cursorTime, cursorID := cursorArgs(cursor)
+	rows, err := r.DB.Query(query, userID, cursorTime, cursorID, limit+1)
+	if err != nil {
+		return entities.Page[*entities.ScheduledPost]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		posts   []*entities.ScheduledPost
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		post, err := scanScheduledPost(rows)
+		if err != nil {
+			return entities.Page[*entities.ScheduledPost]{}, err
+		}
+		posts = append(posts, post)
+		cursors = append(cursors, entities.Cursor{Time: post.ScheduledAt, ID: post.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.ScheduledPost]{}, err
+	}
+
+	return newPage(posts, cursors, limit), nil
+}
+
+func (r *ScheduledPostsRepository) ReschedulePost(userID, scheduledPostID string, scheduledAt time.Time) (*entities.ScheduledPost, error) {
+	query := `
+		UPDATE scheduled_posts
+		SET scheduled_at = $3, publish_attempts = 0, next_attempt_at = NULL, failed_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND published_at IS NULL
+		RETURNING ` + scheduledPostColumns
+
+	post, err := scanScheduledPost(r.DB.QueryRow(query, scheduledPostID, userID, scheduledAt))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrScheduledPostNotFound
+	}
+
+	return post, err
+}
+
+func (r *ScheduledPostsRepository) DeleteScheduledPost(userID, scheduledPostID string) error {
+	query := `DELETE FROM scheduled_posts WHERE id = $1 AND user_id = $2 AND published_at IS NULL`
+	res, err := r.DB.Exec(query, scheduledPostID, userID)
+	if err != nil {
+		return err
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.ErrScheduledPostNotFound
+	}
+
+	return nil
+}
+
+func (r *ScheduledPostsRepository) PublishDuePosts(limit int, publish func(tx *sql.Tx, post *entities.ScheduledPost) error) ([]*entities.ScheduledPost, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// The scheduled posts stay locked until they are marked as published,
+	// and the ones locked by other workers are skipped. The ones failing to be
+	// published wait for next_attempt_at, so that they do not hold up the others.
+	query := `
+		SELECT ` + scheduledPostColumns + `
+		FROM scheduled_posts
+		WHERE published_at IS NULL AND failed_at IS NULL
+		AND COALESCE(next_attempt_at, scheduled_at) <= CURRENT_TIMESTAMP
+		ORDER BY COALESCE(next_attempt_at, scheduled_at)
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*entities.ScheduledPost
+	for rows.Next() {
+		post, err := scanScheduledPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	published := make([]*entities.ScheduledPost, 0, len(posts))
+	for _, post := range posts {
+		// Each post is published under a savepoint, so that a post which fails
+		// to be published is rolled back without the others.
+		if _, err := tx.Exec(`SAVEPOINT publish_post`); err != nil {
+			return nil, err
+		}
+
+		if err := publishScheduledPost(tx, post, publish); err != nil {
+			if _, err := tx.Exec(`ROLLBACK TO SAVEPOINT publish_post`); err != nil {
+				return nil, err
+			}
+			post.PostID = nil
+			post.PublishedAt = nil
+			if err := recordPublishFailure(tx, post); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if _, err := tx.Exec(`RELEASE SAVEPOINT publish_post`); err != nil {
+			return nil, err
+		}
+		published = append(published, post)
+	}
+
+	return published, tx.Commit()
+}
+
+// publishScheduledPost creates the post of the scheduled post in tx, lets publish
+// write the rest of it, and marks the scheduled post as published.
+func publishScheduledPost(tx *sql.Tx, post *entities.ScheduledPost, publish func(tx *sql.Tx, post *entities.ScheduledPost) error) error {
+	var (
+		postID      uuid.UUID
+		publishedAt time.Time
+	)
+	query := `INSERT INTO posts (user_id, text) VALUES ($1, $2) RETURNING id, created_at`
+	if err := tx.QueryRow(query, post.UserID, post.Text).Scan(&postID, &publishedAt); err != nil {
+		return err
+	}
+	post.PostID = &postID
+	post.PublishedAt = &publishedAt
+
+	if err := publish(tx, post); err != nil {
+		return err
+	}
+
+	query = `UPDATE scheduled_posts SET post_id = $2, published_at = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	_, err := tx.Exec(query, post.ID, postID, publishedAt)
+	return err
+}
+
+// recordPublishFailure counts a failed attempt to publish the scheduled post, and either
+// puts the next attempt off by PublishRetryDelay or, after MaxPublishAttempts, gives up.
+func recordPublishFailure(tx *sql.Tx, post *entities.ScheduledPost) error {
+	post.PublishAttempts++
+	query := `
+		UPDATE scheduled_posts
+		SET publish_attempts = $2,
+			next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $3),
+			failed_at = CASE WHEN $4::boolean THEN CURRENT_TIMESTAMP END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING failed_at
+	`
+	return tx.QueryRow(
+		query,
+		post.ID,
+		post.PublishAttempts,
+		entities.PublishRetryDelay(post.PublishAttempts).Seconds(),
+		post.PublishAttempts >= entities.MaxPublishAttempts,
+	).Scan(&post.FailedAt)
+}
+
+func scanScheduledPost(row interface{ Scan(...any) error }) (*entities.ScheduledPost, error) {
+	var (
+		post     entities.ScheduledPost
+		mediaIDs string
+		poll     []byte
+	)
+	err := row.Scan(&post.ID, &post.UserID, &post.Text, &mediaIDs, &poll, &post.ScheduledAt, &post.CreatedAt, &post.FailedAt, &post.PublishAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	post.MediaIDs, err = parseMediaIDs(mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	if poll != nil {
+		if err := json.Unmarshal(poll, &post.Poll); err != nil {
+			return nil, err
+		}
+	}
+
+	return &post, nil
+}
+
+// mediaIDStrings returns the media IDs as strings, to be passed as $n::uuid[].
+func mediaIDStrings(mediaIDs []uuid.UUID) []string {
+	ids := make([]string, 0, len(mediaIDs))
+	for _, mediaID := range mediaIDs {
+		ids = append(ids, mediaID.String())
+	}
+
+	return ids
+}
+
+// parseMediaIDs parses the media IDs read by ARRAY_TO_STRING(media_ids, ',').
+func parseMediaIDs(s string) ([]uuid.UUID, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var mediaIDs []uuid.UUID
+	for _, id := range strings.Split(s, ",") {
+		mediaID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		mediaIDs = append(mediaIDs, mediaID)
+	}
+
+	return mediaIDs, nil
+}
+
+// marshalPendingPoll returns the JSON of the poll, or nil to store NULL if there is no poll.
+func marshalPendingPoll(poll *entities.PendingPoll) ([]byte, error) {
+	if poll == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(poll)
+}
@@ -0,0 +1,125 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+)
+
+type TrendsRepository struct {
+	DB *sql.DB
+}
+
+func NewTrendsRepository(db *sql.DB) repositories.TrendsRepositoryInterface {
+	return &TrendsRepository{db}
+}
+
+func (r *TrendsRepository) GetTagActivities(now time.Time) ([]*entities.TagActivity, error) {
+	query := `
+		SELECT
+			h.id,
+			h.tag,
+			COUNT(DISTINCT posts.user_id) FILTER (WHERE ph.created_at >= $2),
+			COUNT(*) FILTER (WHERE ph.created_at >= $2),
+			COUNT(DISTINCT posts.user_id) FILTER (WHERE ph.created_at < $2)
+		FROM post_hashtags ph
+		JOIN hashtags h ON h.id = ph.hashtag_id
+		JOIN posts ON posts.id = ph.post_id
+		JOIN users u ON u.id = posts.user_id
+		WHERE ph.created_at >= $1 AND ph.created_at < $3
+		AND NOT COALESCE(u.is_private, FALSE)
+		GROUP BY h.id, h.tag
+		HAVING COUNT(DISTINCT posts.user_id) FILTER (WHERE ph.created_at >= $2) >= $4
+	`
+	windowStart := now.Add(-entities.TrendWindow)
+	baselineStart := windowStart.Add(-entities.TrendBaselineWindow)
+	rows, err := r.DB.Query(query, baselineStart, windowStart, now, entities.MinTrendAuthors)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var activities []*entities.TagActivity
+	for rows.Next() {
+		var activity entities.TagActivity
+		err := rows.Scan(&activity.HashtagID, &activity.Tag, &activity.Authors, &activity.Posts, &activity.BaselineAuthors)
+		if err != nil {
+			return nil, err
+		}
+		activities = append(activities, &activity)
+	}
+
+	return activities, rows.Err()
+}
+
+func (r *TrendsRepository) ReplaceTrends(trends []*entities.Trend, now time.Time) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM trends`); err != nil {
+		return err
+	}
+
+	var (
+		hashtagIDs          []string
+		scores              []float64
+		postCounts, authors []int64
+	)
+	for _, trend := range trends {
+		hashtagIDs = append(hashtagIDs, trend.HashtagID.String())
+		scores = append(scores, trend.Score)
+		postCounts = append(postCounts, int64(trend.Posts))
+		authors = append(authors, int64(trend.Authors))
+	}
+
+	query := `
+		INSERT INTO trends (hashtag_id, score, post_count, author_count, window_start, window_end)
+		SELECT t.hashtag_id, t.score, t.post_count, t.author_count, $5, $6
+		FROM UNNEST($1::uuid[], $2::float8[], $3::int[], $4::int[]) AS t(hashtag_id, score, post_count, author_count)
+	`
+	_, err = tx.Exec(query, hashtagIDs, scores, postCounts, authors, now.Add(-entities.TrendWindow), now)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *TrendsRepository) GetTrends(viewerID string, limit int) ([]*entities.Trend, error) {
+	query := `
+		SELECT h.id, h.tag, t.score * COUNT(DISTINCT posts.user_id) / t.author_count AS score, COUNT(*)
+		FROM trends t
+		JOIN hashtags h ON h.id = t.hashtag_id
+		JOIN post_hashtags ph ON ph.hashtag_id = t.hashtag_id
+			AND ph.created_at >= t.window_start AND ph.created_at < t.window_end
+		JOIN posts ON posts.id = ph.post_id
+		JOIN users u ON u.id = posts.user_id
+		WHERE NOT COALESCE(u.is_private, FALSE)
+		AND NOT EXISTS (SELECT 1 FROM mutes WHERE source_user_id = $1::uuid AND target_user_id = u.id)
+		AND ` + notBlockedCondition("u", "$1::uuid") + `
+		GROUP BY h.id, h.tag, t.score, t.author_count
+		HAVING COUNT(DISTINCT posts.user_id) >= $2
+		ORDER BY score DESC, h.tag
+		LIMIT $3
+	`
+	rows, err := r.DB.Query(query, nullableUUID(viewerID), entities.MinTrendAuthors, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trends := []*entities.Trend{}
+	for rows.Next() {
+		var trend entities.Trend
+		if err := rows.Scan(&trend.HashtagID, &trend.Tag, &trend.Score, &trend.Posts); err != nil {
+			return nil, err
+		}
+		trends = append(trends, &trend)
+	}
+
+	return trends, rows.Err()
+}
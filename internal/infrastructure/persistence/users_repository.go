@@ -2,6 +2,7 @@ package infrastructure
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 	"x-clone-backend/internal/app/errors"
 	"x-clone-backend/internal/domain/entities"
@@ -142,16 +143,9 @@ func (r *UsersRepository) UserByUsername(tx *sql.Tx, username string) (entities.
 	return user, err
 }
 
-func (r *UsersRepository) LikePost(tx *sql.Tx, userID string, postID uuid.UUID) error {
-	query := "INSERT INTO likes (user_id, post_id) VALUES ($1, $2)"
-
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query, userID, postID)
-	} else {
-		_, err = r.DB.Exec(query, userID, postID)
-	}
-	return err
+func (r *UsersRepository) LikePost(tx *sql.Tx, userID string, postID uuid.UUID) (bool, error) {
+	query := "INSERT INTO likes (user_id, post_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+	return r.execInsert(tx, query, userID, postID)
 }
 
 func (r *UsersRepository) UnlikePost(tx *sql.Tx, userID string, postID string) error {
@@ -177,16 +171,9 @@ func (r *UsersRepository) UnlikePost(tx *sql.Tx, userID string, postID string) e
 	return nil
 }
 
-func (r *UsersRepository) FollowUser(tx *sql.Tx, sourceUserID, targetUserID string) error {
-	query := `INSERT INTO followships (source_user_id, target_user_id) VALUES ($1, $2)`
-
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query, sourceUserID, targetUserID)
-	} else {
-		_, err = r.DB.Exec(query, sourceUserID, targetUserID)
-	}
-	return err
+func (r *UsersRepository) FollowUser(tx *sql.Tx, sourceUserID, targetUserID string) (bool, error) {
+	query := `INSERT INTO followships (source_user_id, target_user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	return r.execInsert(tx, query, sourceUserID, targetUserID)
 }
 
 func (r *UsersRepository) UnfollowUser(tx *sql.Tx, sourceUserID, targetUserID string) error {
@@ -212,20 +199,41 @@ func (r *UsersRepository) UnfollowUser(tx *sql.Tx, sourceUserID, targetUserID st
 	return nil
 }
 
-func (r *UsersRepository) MuteUser(tx *sql.Tx, sourceUserID, targetUserID string) error {
-	query := `INSERT INTO mutes (source_user_id, target_user_id) VALUES ($1, $2)`
+func (r *UsersRepository) MuteUser(tx *sql.Tx, sourceUserID, targetUserID string) (bool, error) {
+	query := `INSERT INTO mutes (source_user_id, target_user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	return r.execInsert(tx, query, sourceUserID, targetUserID)
+}
 
+func (r *UsersRepository) UnmuteUser(tx *sql.Tx, sourceUserID, targetUserID string) error {
+	query := `DELETE FROM mutes WHERE source_user_id = $1 AND target_user_id = $2`
+	var res sql.Result
 	var err error
 	if tx != nil {
-		_, err = tx.Exec(query, sourceUserID, targetUserID)
+		res, err = tx.Exec(query, sourceUserID, targetUserID)
 	} else {
-		_, err = r.DB.Exec(query, sourceUserID, targetUserID)
+		res, err = r.DB.Exec(query, sourceUserID, targetUserID)
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.ErrMuteNotFound
+	}
+
+	return nil
 }
 
-func (r *UsersRepository) UnmuteUser(tx *sql.Tx, sourceUserID, targetUserID string) error {
-	query := `DELETE FROM mutes WHERE source_user_id = $1 AND target_user_id = $2`
+func (r *UsersRepository) BlockUser(tx *sql.Tx, sourceUserID, targetUserID string) (bool, error) {
+	query := `INSERT INTO blocks (source_user_id, target_user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	return r.execInsert(tx, query, sourceUserID, targetUserID)
+}
+
+func (r *UsersRepository) UnblockUser(tx *sql.Tx, sourceUserID, targetUserID string) error {
+	query := `DELETE FROM blocks WHERE source_user_id = $1 AND target_user_id = $2`
 	var res sql.Result
 	var err error
 	if tx != nil {
@@ -241,29 +249,164 @@ func (r *UsersRepository) UnmuteUser(tx *sql.Tx, sourceUserID, targetUserID stri
 		return err
 	}
 	if count != 1 {
-		return errors.ErrMuteNotFound
+		return errors.ErrBlockNotFound
 	}
 
 	return nil
 }
 
-func (r *UsersRepository) BlockUser(tx *sql.Tx, sourceUserID, targetUserID string) error {
-	query := `INSERT INTO blocks (source_user_id, target_user_id) VALUES ($1, $2)`
-	var err error
+func (r *UsersRepository) CheckVisibility(tx *sql.Tx, targetUserID, viewerID string) error {
+	query := `
+		SELECT
+			COALESCE(u.is_private, FALSE),
+			EXISTS (
+				SELECT 1 FROM blocks
+				WHERE (source_user_id = u.id AND target_user_id = $2::uuid)
+				OR (source_user_id = $2::uuid AND target_user_id = u.id)
+			),
+			EXISTS (
+				SELECT 1 FROM followships
+				WHERE source_user_id = $2::uuid AND target_user_id = u.id
+			)
+		FROM users u
+		WHERE u.id = $1
+	`
+	var row *sql.Row
 	if tx != nil {
-		_, err = tx.Exec(query, sourceUserID, targetUserID)
+		row = tx.QueryRow(query, targetUserID, nullableUUID(viewerID))
 	} else {
-		_, err = r.DB.Exec(query, sourceUserID, targetUserID)
+		row = r.DB.QueryRow(query, targetUserID, nullableUUID(viewerID))
 	}
+
+	var isPrivate, isBlocked, isFollowing bool
+	err := row.Scan(&isPrivate, &isBlocked, &isFollowing)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ErrUserNotFound
+		}
 		return err
 	}
 
+	switch {
+	case isBlocked:
+		return errors.ErrBlocked
+	case isPrivate && targetUserID != viewerID && !isFollowing:
+		return errors.ErrPrivateAccount
+	}
+
 	return nil
 }
 
-func (r *UsersRepository) UnblockUser(tx *sql.Tx, sourceUserID, targetUserID string) error {
-	query := `DELETE FROM blocks WHERE source_user_id = $1 AND target_user_id = $2`
+func (r *UsersRepository) GetFollowers(tx *sql.Tx, userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error) {
+	query := `
+		SELECT u.id, u.username, u.display_name, u.is_private, followships.created_at
+		FROM followships
+		JOIN users u ON u.id = followships.source_user_id
+		WHERE followships.target_user_id = $1
+		AND ($3::timestamptz IS NULL OR (followships.created_at, followships.source_user_id) < ($3::timestamptz, $4::uuid))
+		AND ` + notBlockedCondition("u", "$2::uuid") + `
+		ORDER BY followships.created_at DESC, followships.source_user_id DESC
+		LIMIT $5
+	`
+	return r.queryUserSummaryPage(tx, query, userID, viewerID, cursor, limit)
+}
+
+func (r *UsersRepository) GetFollowing(tx *sql.Tx, userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error) {
+	query := `
+		SELECT u.id, u.username, u.display_name, u.is_private, followships.created_at
+		FROM followships
+		JOIN users u ON u.id = followships.target_user_id
+		WHERE followships.source_user_id = $1
+		AND ($3::timestamptz IS NULL OR (followships.created_at, followships.target_user_id) < ($3::timestamptz, $4::uuid))
+		AND ` + notBlockedCondition("u", "$2::uuid") + `
+		ORDER BY followships.created_at DESC, followships.target_user_id DESC
+		LIMIT $5
+	`
+	return r.queryUserSummaryPage(tx, query, userID, viewerID, cursor, limit)
+}
+
+func (r *UsersRepository) GetFollowerIDs(tx *sql.Tx, userID string) ([]string, error) {
+	query := `SELECT source_user_id FROM followships WHERE target_user_id = $1`
+
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if tx != nil {
+		rows, err = tx.Query(query, userID)
+	} else {
+		rows, err = r.DB.Query(query, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var followerIDs []string
+	for rows.Next() {
+		var followerID string
+		if err := rows.Scan(&followerID); err != nil {
+			return nil, err
+		}
+		followerIDs = append(followerIDs, followerID)
+	}
+
+	return followerIDs, rows.Err()
+}
+
+func (r *UsersRepository) GetRelationships(tx *sql.Tx, viewerID string, userIDs []string) (map[uuid.UUID]*entities.Relationship, error) {
+	query := `
+		SELECT
+			t.id,
+			EXISTS (SELECT 1 FROM followships WHERE source_user_id = $1 AND target_user_id = t.id),
+			EXISTS (SELECT 1 FROM followships WHERE source_user_id = t.id AND target_user_id = $1),
+			EXISTS (SELECT 1 FROM mutes WHERE source_user_id = $1 AND target_user_id = t.id),
+			EXISTS (SELECT 1 FROM blocks WHERE source_user_id = $1 AND target_user_id = t.id),
+			EXISTS (SELECT 1 FROM follow_requests WHERE source_user_id = $1 AND target_user_id = t.id)
+		FROM unnest($2::uuid[]) AS t(id)
+	`
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.Query(query, viewerID, userIDs)
+	} else {
+		rows, err = r.DB.Query(query, viewerID, userIDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	relationships := make(map[uuid.UUID]*entities.Relationship, len(userIDs))
+	for rows.Next() {
+		var (
+			id           uuid.UUID
+			relationship entities.Relationship
+		)
+		err := rows.Scan(
+			&id,
+			&relationship.Following,
+			&relationship.FollowedBy,
+			&relationship.Muting,
+			&relationship.Blocking,
+			&relationship.FollowRequestSent,
+		)
+		if err != nil {
+			return nil, err
+		}
+		relationships[id] = &relationship
+	}
+
+	return relationships, rows.Err()
+}
+
+func (r *UsersRepository) RequestFollow(tx *sql.Tx, sourceUserID, targetUserID string) (bool, error) {
+	query := `INSERT INTO follow_requests (source_user_id, target_user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	return r.execInsert(tx, query, sourceUserID, targetUserID)
+}
+
+func (r *UsersRepository) DeleteFollowRequest(tx *sql.Tx, sourceUserID, targetUserID string) error {
+	query := `DELETE FROM follow_requests WHERE source_user_id = $1 AND target_user_id = $2`
 	var res sql.Result
 	var err error
 	if tx != nil {
@@ -279,8 +422,228 @@ func (r *UsersRepository) UnblockUser(tx *sql.Tx, sourceUserID, targetUserID str
 		return err
 	}
 	if count != 1 {
-		return errors.ErrBlockNotFound
+		return errors.ErrFollowRequestNotFound
 	}
 
 	return nil
 }
+
+func (r *UsersRepository) GetMutedUsers(tx *sql.Tx, userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimestampedUser], error) {
+	query := `
+		SELECT u.id, u.username, u.display_name, u.is_private, mutes.created_at
+		FROM mutes
+		JOIN users u ON u.id = mutes.target_user_id
+		WHERE mutes.source_user_id = $1
+		AND ($2::timestamptz IS NULL OR (mutes.created_at, mutes.target_user_id) < ($2::timestamptz, $3::uuid))
+		ORDER BY mutes.created_at DESC, mutes.target_user_id DESC
+		LIMIT $4
+	`
+	return r.queryTimestampedUserPage(tx, query, userID, cursor, limit)
+}
+
+func (r *UsersRepository) GetBlockedUsers(tx *sql.Tx, userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimestampedUser], error) {
+	query := `
+		SELECT u.id, u.username, u.display_name, u.is_private, blocks.created_at
+		FROM blocks
+		JOIN users u ON u.id = blocks.target_user_id
+		WHERE blocks.source_user_id = $1
+		AND ($2::timestamptz IS NULL OR (blocks.created_at, blocks.target_user_id) < ($2::timestamptz, $3::uuid))
+		ORDER BY blocks.created_at DESC, blocks.target_user_id DESC
+		LIMIT $4
+	`
+	return r.queryTimestampedUserPage(tx, query, userID, cursor, limit)
+}
+
+func (r *UsersRepository) SearchUsers(tx *sql.Tx, query, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error) {
+	sqlQuery := `
+		WITH matches AS (
+			SELECT u.id, u.username, u.display_name, u.is_private, u.created_at,
+				(GREATEST(
+					word_similarity($1::text, LOWER(u.username)),
+					word_similarity($1, LOWER(COALESCE(u.display_name, '')))
+				) + ` + relationshipBoost("u", "$2::uuid") + `)::real AS rank
+			FROM users u
+			WHERE ($1 <% LOWER(u.username) OR $1 <% LOWER(u.display_name) OR LOWER(u.username) LIKE '%' || $3::text || '%')
+			AND ` + notBlockedCondition("u", "$2::uuid") + `
+		)
+		SELECT id, username, display_name, is_private, created_at, rank
+		FROM matches
+		WHERE $4::real IS NULL OR (rank, created_at, id) < ($4::real, $5::timestamptz, $6::uuid)
+		ORDER BY rank DESC, created_at DESC, id DESC
+		LIMIT $7
+	`
+	query = strings.ToLower(query)
+	cursorTime, cursorID := cursorArgs(cursor)
+	var cursorRank sql.NullFloat64
+	if cursor != nil && cursor.Rank != nil {
+		cursorRank = sql.NullFloat64{Float64: float64(*cursor.Rank), Valid: true}
+	}
+	args := []any{query, nullableUUID(viewerID), escapeLikePattern(query), cursorRank, cursorTime, cursorID, limit + 1}
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.Query(sqlQuery, args...)
+	} else {
+		rows, err = r.DB.Query(sqlQuery, args...)
+	}
+	if err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		users   []*entities.UserSummary
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		var (
+			user        entities.UserSummary
+			displayName sql.NullString
+			isPrivate   sql.NullBool
+			createdAt   time.Time
+			rank        float32
+		)
+		if err := rows.Scan(&user.ID, &user.Username, &displayName, &isPrivate, &createdAt, &rank); err != nil {
+			return entities.Page[*entities.UserSummary]{}, err
+		}
+		user.DisplayName = displayName.String
+		user.IsPrivate = isPrivate.Bool
+		users = append(users, &user)
+		cursors = append(cursors, entities.Cursor{Time: createdAt, ID: user.ID, Rank: &rank})
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+
+	return newPage(users, cursors, limit), nil
+}
+
+func (r *UsersRepository) TypeaheadUsers(tx *sql.Tx, prefix, viewerID string, limit int) ([]*entities.UserSummary, error) {
+	// An exact username match comes first, as it is most likely the user being mentioned.
+	query := `
+		SELECT u.id, u.username, u.display_name, u.is_private
+		FROM users u
+		WHERE (
+			LOWER(u.username) LIKE $3::text || '%'
+			OR LOWER(u.display_name) LIKE $3 || '%'
+			OR LOWER(u.display_name) LIKE '% ' || $3 || '%'
+		)
+		AND ` + notBlockedCondition("u", "$2::uuid") + `
+		ORDER BY
+			(CASE WHEN LOWER(u.username) = $1::text THEN 1 ELSE 0 END) DESC,
+			GREATEST(similarity(LOWER(u.username), $1), similarity(LOWER(COALESCE(u.display_name, '')), $1))
+				+ ` + relationshipBoost("u", "$2::uuid") + ` DESC,
+			u.username
+		LIMIT $4
+	`
+	prefix = strings.ToLower(prefix)
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.Query(query, prefix, nullableUUID(viewerID), escapeLikePattern(prefix), limit)
+	} else {
+		rows, err = r.DB.Query(query, prefix, nullableUUID(viewerID), escapeLikePattern(prefix), limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*entities.UserSummary{}
+	for rows.Next() {
+		user, err := scanUserSummary(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// execInsert runs an INSERT ... ON CONFLICT DO NOTHING query
+// and reports whether a row was actually inserted,
+// so that inserting an existing row succeeds without creating anything.
+// Constraint violations are translated into domain errors.
+func (r *UsersRepository) execInsert(tx *sql.Tx, query string, args ...any) (bool, error) {
+	var res sql.Result
+	var err error
+	if tx != nil {
+		res, err = tx.Exec(query, args...)
+	} else {
+		res, err = r.DB.Exec(query, args...)
+	}
+	if err != nil {
+		return false, translateConstraintError(err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
+
+// queryUserSummaryPage runs a query which takes a user ID, a viewer ID,
+// a cursor and a limit as its parameters, and returns a page of user summaries.
+func (r *UsersRepository) queryUserSummaryPage(tx *sql.Tx, query, userID, viewerID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.UserSummary], error) {
+	cursorTime, cursorID := cursorArgs(cursor)
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.Query(query, userID, nullableUUID(viewerID), cursorTime, cursorID, limit+1)
+	} else {
+		rows, err = r.DB.Query(query, userID, nullableUUID(viewerID), cursorTime, cursorID, limit+1)
+	}
+	if err != nil {
+		return entities.Page[*entities.UserSummary]{}, err
+	}
+	defer rows.Close()
+
+	return scanUserSummaryPage(rows, limit)
+}
+
+// queryTimestampedUserPage runs a query which takes a user ID, a cursor and a limit
+// as its parameters, and returns a page of user summaries with their timestamps.
+func (r *UsersRepository) queryTimestampedUserPage(tx *sql.Tx, query, userID string, cursor *entities.Cursor, limit int) (entities.Page[*entities.TimestampedUser], error) {
+	cursorTime, cursorID := cursorArgs(cursor)
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.Query(query, userID, cursorTime, cursorID, limit+1)
+	} else {
+		rows, err = r.DB.Query(query, userID, cursorTime, cursorID, limit+1)
+	}
+	if err != nil {
+		return entities.Page[*entities.TimestampedUser]{}, err
+	}
+	defer rows.Close()
+
+	var (
+		users   []*entities.TimestampedUser
+		cursors []entities.Cursor
+	)
+	for rows.Next() {
+		var (
+			user        entities.TimestampedUser
+			displayName sql.NullString
+			isPrivate   sql.NullBool
+		)
+		if err := rows.Scan(&user.ID, &user.Username, &displayName, &isPrivate, &user.CreatedAt); err != nil {
+			return entities.Page[*entities.TimestampedUser]{}, err
+		}
+		user.DisplayName = displayName.String
+		user.IsPrivate = isPrivate.Bool
+		users = append(users, &user)
+		cursors = append(cursors, entities.Cursor{Time: user.CreatedAt, ID: user.ID})
+	}
+	if err := rows.Err(); err != nil {
+		return entities.Page[*entities.TimestampedUser]{}, err
+	}
+
+	return newPage(users, cursors, limit), nil
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	store, err := NewLocalBlobStore(t.TempDir(), "/media/")
+	if err != nil {
+		t.Fatalf("Failed to create a store: %v", err)
+	}
+
+	if err := store.Put("abc/original", strings.NewReader("content")); err != nil {
+		t.Fatalf("Failed to put a blob: %v", err)
+	}
+
+	r, err := store.Get("abc/original")
+	if err != nil {
+		t.Fatalf("Failed to get the blob: %v", err)
+	}
+	content, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || string(content) != "content" {
+		t.Errorf("expected the content %q, but got %q (error: %v)", "content", content, err)
+	}
+
+	if url := store.URL("abc/original"); url != "/media/abc/original" {
+		t.Errorf("unexpected URL %q", url)
+	}
+
+	if err := store.Delete("abc/original"); err != nil {
+		t.Fatalf("Failed to delete the blob: %v", err)
+	}
+	if _, err := store.Get("abc/original"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected fs.ErrNotExist for a deleted blob, but got %v", err)
+	}
+	if err := store.Delete("abc/original"); err != nil {
+		t.Errorf("expected deleting a deleted blob to succeed, but got %v", err)
+	}
+
+	if err := store.Put("../outside", strings.NewReader("content")); err == nil {
+		t.Errorf("expected a key outside the directory to be rejected")
+	}
+}
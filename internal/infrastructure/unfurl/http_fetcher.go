@@ -0,0 +1,263 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"x-clone-backend/internal/domain/entities"
+	"x-clone-backend/internal/domain/repositories"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	// fetchTimeout is how long fetching a page, including redirects, can take.
+	fetchTimeout = 5 * time.Second
+	// maxRedirects is the maximum number of redirects followed.
+	maxRedirects = 5
+	// maxPageSize is the maximum number of bytes of a page read for its metadata,
+	// which is in its head and rarely far from the beginning.
+	maxPageSize = 1 << 20
+	// userAgent is sent so that sites can tell the requests of the unfurler.
+	userAgent = "x-clone-unfurler/1.0"
+)
+
+var errDisallowedAddress = errors.New("address is not allowed")
+
+// specialPurposePrefixes are the address blocks not reachable on the internet or
+// not meant to be, taken from the IANA IPv4 and IPv6 Special-Purpose Address Registries,
+// along with multicast and the reserved 240.0.0.0/4. IPv4-mapped IPv6 addresses are
+// unmapped before being checked, and NAT64 and 6to4 ones are rejected since they
+// can reach any IPv4 address including private ones.
+var specialPurposePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("192.31.196.0/24"),
+	netip.MustParsePrefix("192.52.193.0/24"),
+	netip.MustParsePrefix("192.88.99.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("192.175.48.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("::/128"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/23"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+	netip.MustParsePrefix("3fff::/20"),
+	netip.MustParsePrefix("5f00::/16"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+// HTTPUnfurlFetcher is an UnfurlFetcher fetching pages over HTTP and reading
+// their Open Graph, Twitter card and HTML metadata.
+type HTTPUnfurlFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPUnfurlFetcher returns a fetcher which refuses to connect to private,
+// loopback, link-local and other special-purpose addresses, so that posting a link cannot make
+// the server request its internal network. The addresses are checked when
+// connecting rather than when resolving, so that redirects and DNS rebinding
+// cannot get around the check.
+func NewHTTPUnfurlFetcher() repositories.UnfurlFetcher {
+	dialer := &net.Dialer{
+		Timeout: fetchTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip, err := netip.ParseAddr(host)
+			if err != nil || !isPublicIP(ip) {
+				return fmt.Errorf("%w: %s", errDisallowedAddress, address)
+			}
+			return nil
+		},
+	}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   fetchTimeout,
+		ResponseHeaderTimeout: fetchTimeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       time.Minute,
+	}
+
+	return newHTTPUnfurlFetcher(&http.Client{Transport: transport})
+}
+
+// newHTTPUnfurlFetcher returns a fetcher using the client as it is
+// apart from its timeout and redirect policy.
+func newHTTPUnfurlFetcher(client *http.Client) *HTTPUnfurlFetcher {
+	client.Timeout = fetchTimeout
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return checkScheme(req.URL)
+	}
+
+	return &HTTPUnfurlFetcher{client: client}
+}
+
+func (f *HTTPUnfurlFetcher) Fetch(ctx context.Context, rawURL string) (*entities.LinkCard, error) {
+	pageURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScheme(pageURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	contentType := res.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+
+	body, err := charset.NewReader(io.LimitReader(res.Body, maxPageSize), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	// The URL after redirects is the base of relative image URLs.
+	return parseCard(body, res.Request.URL)
+}
+
+// parseCard reads the metadata in the head of the page. Open Graph properties take
+// precedence over Twitter card ones, which take precedence over the title element
+// and the description meta tag. It returns nil if the page has no title.
+func parseCard(r io.Reader, base *url.URL) (*entities.LinkCard, error) {
+	var (
+		meta    = make(map[string]string)
+		title   string
+		inTitle bool
+	)
+
+	tokenizer := html.NewTokenizer(r)
+loop:
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return nil, err
+			}
+			break loop
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "meta":
+				var key, content string
+				for _, attr := range token.Attr {
+					switch strings.ToLower(attr.Key) {
+					case "property", "name":
+						key = strings.ToLower(attr.Val)
+					case "content":
+						content = attr.Val
+					}
+				}
+				if _, ok := meta[key]; key != "" && !ok {
+					meta[key] = content
+				}
+			case "title":
+				inTitle = title == ""
+			case "body":
+				break loop
+			}
+		case html.TextToken:
+			if inTitle {
+				title += string(tokenizer.Text())
+			}
+		case html.EndTagToken:
+			if token := tokenizer.Token(); token.Data == "title" {
+				inTitle = false
+			} else if token.Data == "head" {
+				break loop
+			}
+		}
+	}
+
+	card := &entities.LinkCard{
+		Title:       firstNonEmpty(meta["og:title"], meta["twitter:title"], title),
+		Description: firstNonEmpty(meta["og:description"], meta["twitter:description"], meta["description"]),
+		SiteName:    meta["og:site_name"],
+	}
+	if card.Title == "" {
+		return nil, nil
+	}
+	if image := firstNonEmpty(meta["og:image"], meta["og:image:url"], meta["twitter:image"]); image != "" {
+		if imageURL, err := base.Parse(image); err == nil && checkScheme(imageURL) == nil {
+			card.ImageURL = imageURL.String()
+		}
+	}
+
+	return card, nil
+}
+
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+// isPublicIP reports whether the address is reachable on the internet,
+// that is, in none of specialPurposePrefixes.
+func isPublicIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	for _, prefix := range specialPurposePrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,171 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"x-clone-backend/internal/domain/entities"
+)
+
+func TestHTTPUnfurlFetcher(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<!DOCTYPE html>
+<html><head>
+<title>Fallback title</title>
+<meta property="og:title" content="Article title">
+<meta name="twitter:title" content="Twitter title">
+<meta name="description" content="Article description">
+<meta property="og:image" content="/images/cover.png">
+<meta property="og:site_name" content="Example">
+</head><body><meta property="og:title" content="Ignored"></body></html>`))
+	})
+	mux.HandleFunc("/plain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=shift_jis")
+		// "<title>日本語</title>" in Shift_JIS.
+		w.Write([]byte("<title>\x93\xfa\x96\x7b\x8c\xea</title>"))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/article", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"title": "JSON"}`))
+	})
+	mux.HandleFunc("/untitled", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head></head><body>No title</body></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fetcher := newHTTPUnfurlFetcher(server.Client())
+
+	tests := []struct {
+		name     string
+		path     string
+		expected *entities.LinkCard
+		wantErr  bool
+	}{
+		{
+			name: "open graph",
+			path: "/article",
+			expected: &entities.LinkCard{
+				Title:       "Article title",
+				Description: "Article description",
+				ImageURL:    server.URL + "/images/cover.png",
+				SiteName:    "Example",
+			},
+		},
+		{
+			name:     "title element in another charset",
+			path:     "/plain",
+			expected: &entities.LinkCard{Title: "日本語"},
+		},
+		{
+			name: "redirect",
+			path: "/redirect",
+			expected: &entities.LinkCard{
+				Title:       "Article title",
+				Description: "Article description",
+				ImageURL:    server.URL + "/images/cover.png",
+				SiteName:    "Example",
+			},
+		},
+		{
+			name: "no title",
+			path: "/untitled",
+		},
+		{
+			name:    "too many redirects",
+			path:    "/loop",
+			wantErr: true,
+		},
+		{
+			name:    "not html",
+			path:    "/json",
+			wantErr: true,
+		},
+		{
+			name:    "not found",
+			path:    "/missing",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		card, err := fetcher.Fetch(context.Background(), server.URL+test.path)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error, but got none", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: expected no error, but got %v", test.name, err)
+			continue
+		}
+		if (card == nil) != (test.expected == nil) || (card != nil && *card != *test.expected) {
+			t.Errorf("%s: expected %+v, but got %+v", test.name, test.expected, card)
+		}
+	}
+}
+
+func TestHTTPUnfurlFetcherRejectsInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<title>Internal</title>`))
+	}))
+	defer server.Close()
+
+	fetcher := NewHTTPUnfurlFetcher()
+	for _, url := range []string{server.URL, "ftp://example.com/"} {
+		card, err := fetcher.Fetch(context.Background(), url)
+		if err == nil {
+			t.Errorf("Expected %s to be rejected, but got %+v", url, card)
+		}
+	}
+
+	_, err := fetcher.Fetch(context.Background(), server.URL)
+	if !errors.Is(err, errDisallowedAddress) {
+		t.Errorf("Expected errDisallowedAddress, but got %v", err)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip       string
+		expected bool
+	}{
+		{ip: "93.184.216.34", expected: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", expected: true},
+		{ip: "10.1.2.3", expected: false},
+		{ip: "100.64.0.1", expected: false},
+		{ip: "127.0.0.1", expected: false},
+		{ip: "169.254.169.254", expected: false},
+		{ip: "192.0.0.170", expected: false},
+		{ip: "198.18.0.1", expected: false},
+		{ip: "203.0.113.5", expected: false},
+		{ip: "240.0.0.1", expected: false},
+		{ip: "255.255.255.255", expected: false},
+		{ip: "::1", expected: false},
+		{ip: "::ffff:10.0.0.1", expected: false},
+		{ip: "64:ff9b::a00:1", expected: false},
+		{ip: "2002:a00:1::", expected: false},
+		{ip: "fd00::1", expected: false},
+		{ip: "fe80::1", expected: false},
+	}
+
+	for _, test := range tests {
+		if public := isPublicIP(netip.MustParseAddr(test.ip)); public != test.expected {
+			t.Errorf("%s: expected public to be %t, but got %t", test.ip, test.expected, public)
+		}
+	}
+}